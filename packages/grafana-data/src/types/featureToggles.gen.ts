@@ -43,6 +43,7 @@ export interface FeatureToggles {
   featureHighlights?: boolean;
   dashboardComments?: boolean;
   annotationComments?: boolean;
+  alertRuleComments?: boolean;
   migrationLocking?: boolean;
   storage?: boolean;
   alertProvisioning?: boolean;
@@ -60,4 +61,5 @@ export interface FeatureToggles {
   prometheusStreamingJSONParser?: boolean;
   validateDashboardsOnSave?: boolean;
   prometheusWideSeries?: boolean;
+  migrateDashboardsOnRead?: boolean;
 }
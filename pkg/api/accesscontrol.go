@@ -30,6 +30,8 @@ var (
 	ScopeProvisionersPlugins       = ac.Scope("provisioners", "plugins")
 	ScopeProvisionersDatasources   = ac.Scope("provisioners", "datasources")
 	ScopeProvisionersNotifications = ac.Scope("provisioners", "notifications")
+	ScopeProvisionersAlerting      = ac.Scope("provisioners", "alerting")
+	ScopeProvisionersPlaylists     = ac.Scope("provisioners", "playlists")
 )
 
 // declareFixedRoles declares to the AccessControl service fixed roles and their
@@ -39,3 +39,19 @@ func (hs *HTTPServer) AdminProvisioningReloadNotifications(c *models.ReqContext)
 	}
 	return response.Success("Notifications config reloaded")
 }
+
+func (hs *HTTPServer) AdminProvisioningReloadAlerting(c *models.ReqContext) response.Response {
+	err := hs.ProvisioningService.ProvisionAlerting(c.Req.Context())
+	if err != nil {
+		return response.Error(500, "", err)
+	}
+	return response.Success("Alerting config reloaded")
+}
+
+func (hs *HTTPServer) AdminProvisioningReloadPlaylists(c *models.ReqContext) response.Response {
+	err := hs.ProvisioningService.ProvisionPlaylists(c.Req.Context())
+	if err != nil {
+		return response.Error(500, "Failed to reload playlists config", err)
+	}
+	return response.Success("Playlists config reloaded")
+}
@@ -93,6 +93,48 @@ func TestAPI_AdminProvisioningReload_AccessControl(t *testing.T) {
 			url:          "/api/admin/provisioning/notifications/reload",
 			exit:         true,
 		},
+		{
+			desc:         "should work for alerting with specific scope",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"message":"Alerting config reloaded"}`,
+			permissions: []*accesscontrol.Permission{
+				{
+					Action: ActionProvisioningReload,
+					Scope:  ScopeProvisionersAlerting,
+				},
+			},
+			url: "/api/admin/provisioning/alerting/reload",
+			checkCall: func(mock provisioning.ProvisioningServiceMock) {
+				assert.Len(t, mock.Calls.ProvisionAlerting, 1)
+			},
+		},
+		{
+			desc:         "should fail for alerting with no permission",
+			expectedCode: http.StatusForbidden,
+			url:          "/api/admin/provisioning/alerting/reload",
+			exit:         true,
+		},
+		{
+			desc:         "should work for playlists with specific scope",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"message":"Playlists config reloaded"}`,
+			permissions: []*accesscontrol.Permission{
+				{
+					Action: ActionProvisioningReload,
+					Scope:  ScopeProvisionersPlaylists,
+				},
+			},
+			url: "/api/admin/provisioning/playlists/reload",
+			checkCall: func(mock provisioning.ProvisioningServiceMock) {
+				assert.Len(t, mock.Calls.ProvisionPlaylists, 1)
+			},
+		},
+		{
+			desc:         "should fail for playlists with no permission",
+			expectedCode: http.StatusForbidden,
+			url:          "/api/admin/provisioning/playlists/reload",
+			exit:         true,
+		},
 		{
 			desc:         "should work for datasources with specific scope",
 			expectedCode: http.StatusOK,
@@ -30,6 +30,15 @@ func ToDashboardErrorResponse(ctx context.Context, pluginStore plugins.Store, er
 		return response.Error(http.StatusBadRequest, err.Error(), nil)
 	}
 
+	var schemaErr models.DashboardSchemaValidationError
+	if ok := errors.As(err, &schemaErr); ok {
+		return response.JSON(http.StatusBadRequest, util.DynMap{
+			"status":  "invalid-schema",
+			"message": "invalid dashboard json",
+			"errors":  schemaErr.Errors,
+		})
+	}
+
 	var validationErr alerting.ValidationError
 	if ok := errors.As(err, &validationErr); ok {
 		return response.Error(http.StatusUnprocessableEntity, validationErr.Error(), err)
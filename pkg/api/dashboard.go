@@ -15,9 +15,8 @@ import (
 	"github.com/grafana/grafana/pkg/api/dtos"
 	"github.com/grafana/grafana/pkg/api/response"
 	"github.com/grafana/grafana/pkg/components/dashdiffs"
+	"github.com/grafana/grafana/pkg/components/dashmigrations"
 	"github.com/grafana/grafana/pkg/components/simplejson"
-	"github.com/grafana/grafana/pkg/coremodel/dashboard"
-	"github.com/grafana/grafana/pkg/cuectx"
 	"github.com/grafana/grafana/pkg/infra/metrics"
 	"github.com/grafana/grafana/pkg/models"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
@@ -195,6 +194,20 @@ func (hs *HTTPServer) GetDashboard(c *models.ReqContext) response.Response {
 		return response.Error(500, "Error while loading library panels", err)
 	}
 
+	if hs.Features.IsEnabled(featuremgmt.FlagMigrateDashboardsOnRead) && dashmigrations.NeedsMigration(dash.Data) {
+		if err := hs.migrateDashboardSchema(c.Req.Context(), c.OrgId, dash.Data); err != nil {
+			return response.Error(500, "Error while migrating dashboard", err)
+		}
+	}
+
+	if dash.FolderId > 0 {
+		applied, err := hs.applyFolderPreferences(c.Req.Context(), c.OrgId, dash)
+		if err != nil {
+			return response.Error(500, "Error while applying folder preferences", err)
+		}
+		meta.FolderDefaultsApplied = applied
+	}
+
 	dto := dtos.DashboardFullWithMeta{
 		Dashboard: dash.Data,
 		Meta:      meta,
@@ -235,6 +248,30 @@ func (hs *HTTPServer) getUserLogin(ctx context.Context, userID int64) string {
 	return query.Result.Login
 }
 
+// migrateDashboardSchema migrates a dashboard to the latest schema version, with the data sources of the organization
+// to replace the data sources referenced by name.
+func (hs *HTTPServer) migrateDashboardSchema(ctx context.Context, orgID int64, data *simplejson.Json) error {
+	query := models.GetDataSourcesQuery{OrgId: orgID, DataSourceLimit: hs.Cfg.DataSourceLimit}
+	if err := hs.DataSourcesService.GetDataSources(ctx, &query); err != nil {
+		return err
+	}
+	dashmigrations.Migrate(data, dashmigrations.NewDataSourceLookup(query.Result))
+	return nil
+}
+
+// applyFolderPreferences sets the time range, refresh interval and constant variable values that a dashboard leaves
+// unset to the ones of the preferences of its folder, and returns the values it set.
+func (hs *HTTPServer) applyFolderPreferences(ctx context.Context, orgID int64, dash *models.Dashboard) (*pref.DashboardDefaultsPreference, error) {
+	preference, err := hs.preferenceService.Get(ctx, &pref.GetPreferenceQuery{OrgID: orgID, FolderID: dash.FolderId})
+	if err != nil {
+		return nil, err
+	}
+	if preference.JSONData == nil || preference.JSONData.DashboardDefaults == nil {
+		return nil, nil
+	}
+	return preference.JSONData.DashboardDefaults.ApplyTo(dash.Data), nil
+}
+
 func (hs *HTTPServer) getDashboardHelper(ctx context.Context, orgID int64, id int64, uid string) (*models.Dashboard, response.Response) {
 	var query models.GetDashboardQuery
 
@@ -310,27 +347,6 @@ func (hs *HTTPServer) PostDashboard(c *models.ReqContext) response.Response {
 		return response.Error(http.StatusBadRequest, "bad request data", err)
 	}
 
-	if hs.Features.IsEnabled(featuremgmt.FlagValidateDashboardsOnSave) {
-		// Ideally, coremodel validation calls would be integrated into the web
-		// framework. But this does the job for now.
-		if cm, has := hs.CoremodelRegistry.Get("dashboard"); has {
-			schv, err := cmd.Dashboard.Get("schemaVersion").Int()
-
-			// Only try to validate if the schemaVersion is at least the handoff version
-			// (the minimum schemaVersion against which the dashboard schema is known to
-			// work), or if schemaVersion is absent (which will happen once the Thema
-			// schema becomes canonical).
-			if err != nil || schv >= dashboard.HandoffSchemaVersion {
-				// Can't fail, web.Bind() already ensured it's valid JSON
-				b, _ := cmd.Dashboard.Bytes()
-				v, _ := cuectx.JSONtoCUE("dashboard.json", b)
-				if _, err := cm.CurrentSchema().Validate(v); err != nil {
-					return response.Error(http.StatusBadRequest, "invalid dashboard json", err)
-				}
-			}
-		}
-	}
-
 	return hs.postDashboard(c, cmd)
 }
 
@@ -577,6 +593,13 @@ func (hs *HTTPServer) GetDashboardVersions(c *models.ReqContext) response.Respon
 		return response.Error(404, fmt.Sprintf("No versions found for dashboardId %d", dashID), err)
 	}
 
+	restoredVersionNames := make(map[int]string, len(query.Result))
+	for _, version := range query.Result {
+		if version.Name != "" {
+			restoredVersionNames[version.Version] = version.Name
+		}
+	}
+
 	for _, version := range query.Result {
 		if version.RestoredFrom == version.Version {
 			version.Message = "Initial save (created by migration)"
@@ -584,7 +607,7 @@ func (hs *HTTPServer) GetDashboardVersions(c *models.ReqContext) response.Respon
 		}
 
 		if version.RestoredFrom > 0 {
-			version.Message = fmt.Sprintf("Restored from version %d", version.RestoredFrom)
+			version.Message = restoredVersionMessage(version.RestoredFrom, restoredVersionNames[version.RestoredFrom])
 			continue
 		}
 
@@ -649,6 +672,8 @@ func (hs *HTTPServer) GetDashboardVersion(c *models.ReqContext) response.Respons
 		ParentVersion: res.ParentVersion,
 		RestoredFrom:  res.RestoredFrom,
 		Version:       res.Version,
+		Name:          res.Name,
+		Pinned:        res.Pinned,
 		Created:       res.Created,
 		Message:       res.Message,
 		CreatedBy:     creator,
@@ -657,6 +682,64 @@ func (hs *HTTPServer) GetDashboardVersion(c *models.ReqContext) response.Respons
 	return response.JSON(http.StatusOK, dashVersionMeta)
 }
 
+// UpdateDashboardVersion names or pins the dashboard version with the given ID.
+// Named and pinned versions are kept by the clean up of old versions.
+func (hs *HTTPServer) UpdateDashboardVersion(c *models.ReqContext) response.Response {
+	cmd := dashver.UpdateDashboardVersionCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	if cmd.Name != nil && len(*cmd.Name) > 255 {
+		return response.Error(http.StatusBadRequest, "Version name cannot be longer than 255 characters", nil)
+	}
+
+	dashUID := web.Params(c.Req)[":uid"]
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.OrgId, 0, dashUID)
+	if rsp != nil {
+		return rsp
+	}
+
+	guardian := guardian.New(c.Req.Context(), dash.Id, c.OrgId, c.SignedInUser)
+	if canSave, err := guardian.CanSave(); err != nil || !canSave {
+		return dashboardGuardianResponse(err)
+	}
+
+	version, err := strconv.ParseInt(web.Params(c.Req)[":id"], 10, 32)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "version is invalid", err)
+	}
+	cmd.DashboardID = dash.Id
+	cmd.OrgID = c.OrgId
+	cmd.Version = int(version)
+
+	res, err := hs.dashboardVersionService.Update(c.Req.Context(), &cmd)
+	if err != nil {
+		if errors.Is(err, models.ErrDashboardVersionNotFound) {
+			return response.Error(http.StatusNotFound, "Dashboard version not found", err)
+		}
+		return response.Error(http.StatusInternalServerError, "Failed to update dashboard version", err)
+	}
+
+	creator := anonString
+	if res.CreatedBy > 0 {
+		creator = hs.getUserLogin(c.Req.Context(), res.CreatedBy)
+	}
+
+	return response.JSON(http.StatusOK, &models.DashboardVersionDTO{
+		Id:            res.ID,
+		DashboardId:   res.DashboardID,
+		DashboardUID:  dashUID,
+		ParentVersion: res.ParentVersion,
+		RestoredFrom:  res.RestoredFrom,
+		Version:       res.Version,
+		Name:          res.Name,
+		Pinned:        res.Pinned,
+		Created:       res.Created,
+		CreatedBy:     creator,
+		Message:       res.Message,
+	})
+}
+
 // POST /api/dashboards/calculate-diff performs diffs on two dashboards
 func (hs *HTTPServer) CalculateDashboardDiff(c *models.ReqContext) response.Response {
 	apiOptions := dtos.CalculateDiffOptions{}
@@ -730,7 +813,7 @@ func (hs *HTTPServer) CalculateDashboardDiff(c *models.ReqContext) response.Resp
 		return response.Error(500, "Unable to compute diff", err)
 	}
 
-	if options.DiffType == dashdiffs.DiffDelta {
+	if options.DiffType == dashdiffs.DiffDelta || options.DiffType == dashdiffs.DiffSummary {
 		return response.Respond(http.StatusOK, result.Delta).SetHeader("Content-Type", "application/json")
 	}
 
@@ -775,6 +858,10 @@ func (hs *HTTPServer) RestoreDashboardVersion(c *models.ReqContext) response.Res
 		return response.Error(404, "Dashboard version not found", nil)
 	}
 
+	if apiCmd.DryRun {
+		return response.JSON(http.StatusOK, dashdiffs.CalculateSummary(dash.Data, version.Data))
+	}
+
 	saveCmd := models.SaveDashboardCommand{}
 	saveCmd.RestoredFrom = version.Version
 	saveCmd.OrgId = c.OrgId
@@ -782,12 +869,20 @@ func (hs *HTTPServer) RestoreDashboardVersion(c *models.ReqContext) response.Res
 	saveCmd.Dashboard = version.Data
 	saveCmd.Dashboard.Set("version", dash.Version)
 	saveCmd.Dashboard.Set("uid", dash.Uid)
-	saveCmd.Message = fmt.Sprintf("Restored from version %d", version.Version)
+	saveCmd.Message = restoredVersionMessage(version.Version, version.Name)
 	saveCmd.FolderId = dash.FolderId
 
 	return hs.postDashboard(c, saveCmd)
 }
 
+// restoredVersionMessage returns the message of the versions restored from another version.
+func restoredVersionMessage(restoredFrom int, name string) string {
+	if name != "" {
+		return fmt.Sprintf("Restored from version %d (%s)", restoredFrom, name)
+	}
+	return fmt.Sprintf("Restored from version %d", restoredFrom)
+}
+
 func (hs *HTTPServer) GetDashboardTags(c *models.ReqContext) {
 	query := models.GetDashboardTagsQuery{OrgId: c.OrgId}
 	err := hs.SQLStore.GetDashboardTags(c.Req.Context(), &query)
@@ -39,7 +39,7 @@ func TestDashboardPermissionAPIEndpoint(t *testing.T) {
 			SQLStore: mockSQLStore,
 			Features: features,
 			dashboardService: dashboardservice.ProvideDashboardService(
-				settings, dashboardStore, nil, features, folderPermissions, dashboardPermissions,
+				settings, dashboardStore, nil, features, folderPermissions, dashboardPermissions, nil,
 			),
 			AccessControl: accesscontrolmock.New().WithDisabled(),
 		}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/grafana/grafana/pkg/api/apierrors"
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// The queries of each public dashboard are limited to an average of publicDashboardQueryRPS requests per second,
+// with bursts of publicDashboardQueryBurst requests for the panels of the dashboard loading at once.
+const (
+	publicDashboardQueryRPS   = 10
+	publicDashboardQueryBurst = 50
+)
+
+// GetPublicDashboard returns the dashboard of a public dashboard to anonymous viewers.
+// GET /api/public/dashboards/:accessToken
+func (hs *HTTPServer) GetPublicDashboard(c *models.ReqContext) response.Response {
+	dash, err := hs.dashboardService.GetPublicDashboard(c.Req.Context(), web.Params(c.Req)[":accessToken"])
+	if err != nil {
+		return apierrors.ToDashboardErrorResponse(c.Req.Context(), hs.pluginStore, err)
+	}
+
+	// the anonymous viewers cannot star, save or edit the dashboard
+	meta := dtos.DashboardMeta{
+		Slug:     dash.Slug,
+		Type:     models.DashTypeDB,
+		Created:  dash.Created,
+		Updated:  dash.Updated,
+		Version:  dash.Version,
+		IsPublic: dash.IsPublic,
+	}
+
+	// make sure db version is in sync with json model version
+	dash.Data.Set("version", dash.Version)
+	dto := dtos.DashboardFullWithMeta{
+		Dashboard: dash.Data,
+		Meta:      meta,
+	}
+
+	return response.JSON(http.StatusOK, dto)
+}
+
+// QueryPublicDashboard runs the queries saved in a panel of a public dashboard for anonymous viewers.
+// POST /api/public/dashboards/:accessToken/panels/:panelId/query
+func (hs *HTTPServer) QueryPublicDashboard(c *models.ReqContext) response.Response {
+	accessToken := web.Params(c.Req)[":accessToken"]
+	panelId, err := strconv.ParseInt(web.Params(c.Req)[":panelId"], 10, 64)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "invalid panel ID", err)
+	}
+
+	// the queries cannot be sent by the viewers, only the data points of the panel
+	reqDTO := dtos.PublicDashboardQueryDTO{}
+	if c.Req.Body != nil {
+		decoder := json.NewDecoder(c.Req.Body)
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&reqDTO); err != nil && !errors.Is(err, io.EOF) {
+			return response.Error(http.StatusBadRequest, "bad request data", err)
+		}
+	}
+
+	dash, err := hs.dashboardService.GetPublicDashboard(c.Req.Context(), accessToken)
+	if err != nil {
+		return apierrors.ToDashboardErrorResponse(c.Req.Context(), hs.pluginStore, err)
+	}
+
+	if !hs.publicDashboardsRateLimiter.Allow(accessToken) {
+		return response.Error(http.StatusTooManyRequests, "Rate limit reached", nil)
+	}
+
+	metricReq, err := hs.dashboardService.BuildPublicDashboardMetricRequest(c.Req.Context(), dash, panelId, reqDTO)
+	if err != nil {
+		return apierrors.ToDashboardErrorResponse(c.Req.Context(), hs.pluginStore, err)
+	}
+
+	resp, err := hs.queryDataService.QueryData(c.Req.Context(), publicDashboardViewer(dash), c.SkipCache, metricReq, true)
+	if err != nil {
+		return hs.handleQueryMetricsError(err)
+	}
+	return hs.toJsonStreamingResponse(resp)
+}
+
+// publicDashboardViewer returns the anonymous user the queries of the public dashboard are run as.
+func publicDashboardViewer(dash *models.Dashboard) *models.SignedInUser {
+	return &models.SignedInUser{
+		OrgId:   dash.OrgId,
+		OrgRole: models.ROLE_VIEWER,
+		Login:   "public-dashboard",
+	}
+}
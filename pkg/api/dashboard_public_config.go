@@ -2,7 +2,6 @@ package api
 
 import (
 	"errors"
-	"fmt"
 	"net/http"
 
 	"github.com/grafana/grafana/pkg/api/response"
@@ -11,8 +10,8 @@ import (
 	"github.com/grafana/grafana/pkg/web"
 )
 
-// Sets sharing configuration for dashboard
-func (hs *HTTPServer) GetPublicDashboard(c *models.ReqContext) response.Response {
+// Gets sharing configuration for dashboard
+func (hs *HTTPServer) GetPublicDashboardConfig(c *models.ReqContext) response.Response {
 	pdc, err := hs.dashboardService.GetPublicDashboardConfig(c.Req.Context(), c.OrgId, web.Params(c.Req)[":uid"])
 
 	if errors.Is(err, models.ErrDashboardNotFound) {
@@ -27,7 +26,7 @@ func (hs *HTTPServer) GetPublicDashboard(c *models.ReqContext) response.Response
 }
 
 // Sets sharing configuration for dashboard
-func (hs *HTTPServer) SavePublicDashboard(c *models.ReqContext) response.Response {
+func (hs *HTTPServer) SavePublicDashboardConfig(c *models.ReqContext) response.Response {
 	pdc := &models.PublicDashboardConfig{}
 
 	if err := web.Bind(c.Req, pdc); err != nil {
@@ -42,8 +41,6 @@ func (hs *HTTPServer) SavePublicDashboard(c *models.ReqContext) response.Respons
 
 	pdc, err := hs.dashboardService.SavePublicDashboardConfig(c.Req.Context(), &dto)
 
-	fmt.Println("err:", err)
-
 	if errors.Is(err, models.ErrDashboardNotFound) {
 		return response.Error(http.StatusNotFound, "dashboard not found", err)
 	}
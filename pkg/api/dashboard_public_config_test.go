@@ -1,20 +1,27 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/grafana/grafana/pkg/api/dtos"
 	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/middleware"
 	"github.com/grafana/grafana/pkg/models"
 	"github.com/grafana/grafana/pkg/services/dashboards"
+	fakeDatasources "github.com/grafana/grafana/pkg/services/datasources/fakes"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/query"
 )
 
 func TestApiRetrieveConfig(t *testing.T) {
@@ -132,3 +139,97 @@ func TestApiPersistsValue(t *testing.T) {
 		})
 	}
 }
+
+func TestAPIGetPublicDashboard(t *testing.T) {
+	dashSvc := dashboards.NewFakeDashboardService(t)
+	dashSvc.On("GetPublicDashboard", mock.Anything, "token").
+		Return(&models.Dashboard{Uid: "dash", Slug: "dash", IsPublic: true, Data: simplejson.NewFromAny(map[string]interface{}{"title": "Dash"})}, nil)
+	dashSvc.On("GetPublicDashboard", mock.Anything, "unknown").
+		Return(nil, models.ErrPublicDashboardNotFound)
+
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		hs.Features = featuremgmt.WithFeatures(featuremgmt.FlagPublicDashboards)
+		hs.dashboardService = dashSvc
+	})
+
+	t.Run("returns the dashboard without signing in", func(t *testing.T) {
+		resp, err := server.Send(server.NewGetRequest("/api/public/dashboards/token"))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var dto dtos.DashboardFullWithMeta
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&dto))
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, "Dash", dto.Dashboard.Get("title").MustString())
+		assert.True(t, dto.Meta.IsPublic)
+		assert.False(t, dto.Meta.CanEdit)
+	})
+
+	t.Run("returns 404 for unknown access tokens", func(t *testing.T) {
+		resp, err := server.Send(server.NewGetRequest("/api/public/dashboards/unknown"))
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestAPIQueryPublicDashboard(t *testing.T) {
+	dash := &models.Dashboard{Uid: "dash", OrgId: 2, IsPublic: true, Data: simplejson.New()}
+	dashSvc := dashboards.NewFakeDashboardService(t)
+	dashSvc.On("GetPublicDashboard", mock.Anything, "token").Return(dash, nil)
+	dashSvc.On("BuildPublicDashboardMetricRequest", mock.Anything, dash, int64(1), mock.Anything).
+		Return(dtos.MetricRequest{
+			From:    "now-1h",
+			To:      "now",
+			Queries: []*simplejson.Json{simplejson.NewFromAny(map[string]interface{}{"refId": "A", "datasource": map[string]interface{}{"uid": "grafana"}})},
+		}, nil)
+
+	var queriedOrgID int64
+	qds := query.ProvideService(
+		nil,
+		nil,
+		nil,
+		&fakePluginRequestValidator{},
+		&fakeDatasources.FakeDataSourceService{},
+		&fakePluginClient{
+			QueryDataHandlerFunc: func(ctx context.Context, req *backend.QueryDataRequest) (*backend.QueryDataResponse, error) {
+				queriedOrgID = req.PluginContext.OrgID
+				return &backend.QueryDataResponse{Responses: backend.Responses{"A": backend.DataResponse{}}}, nil
+			},
+		},
+		&fakeOAuthTokenService{},
+	)
+
+	now := time.Now()
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		hs.Features = featuremgmt.WithFeatures(featuremgmt.FlagPublicDashboards)
+		hs.dashboardService = dashSvc
+		hs.queryDataService = qds
+		hs.publicDashboardsRateLimiter = middleware.NewKeyRateLimiter(1, 1, func() time.Time { return now })
+	})
+
+	t.Run("rejects queries sent by the viewer", func(t *testing.T) {
+		req := server.NewPostRequest("/api/public/dashboards/token/panels/1/query", strings.NewReader(queryDatasourceInput))
+		resp, err := server.SendJSON(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("runs the queries of the panel without signing in", func(t *testing.T) {
+		req := server.NewPostRequest("/api/public/dashboards/token/panels/1/query", strings.NewReader(`{"intervalMs": 1000, "maxDataPoints": 100}`))
+		resp, err := server.SendJSON(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, int64(2), queriedOrgID)
+	})
+
+	t.Run("limits the rate of the queries of the public dashboard", func(t *testing.T) {
+		req := server.NewPostRequest("/api/public/dashboards/token/panels/1/query", nil)
+		resp, err := server.SendJSON(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	})
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/infra/metrics"
 	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/dashboardsnapshots"
 	"github.com/grafana/grafana/pkg/services/guardian"
 	"github.com/grafana/grafana/pkg/setting"
 	"github.com/grafana/grafana/pkg/util"
@@ -148,6 +150,42 @@ func (hs *HTTPServer) CreateDashboardSnapshot(c *models.ReqContext) response.Res
 	return nil
 }
 
+// POST /api/dashboards/uid/:uid/snapshots
+func (hs *HTTPServer) TakeDashboardSnapshot(c *models.ReqContext) response.Response {
+	cmd := models.TakeDashboardSnapshotCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.OrgId, 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+	guardian := guardian.New(c.Req.Context(), dash.Id, c.OrgId, c.SignedInUser)
+	if canView, err := guardian.CanView(); err != nil || !canView {
+		return dashboardGuardianResponse(err)
+	}
+
+	cmd.Dashboard = dash
+	cmd.SignedInUser = c.SignedInUser
+	if err := hs.DashboardsnapshotsService.TakeDashboardSnapshot(c.Req.Context(), &cmd); err != nil {
+		if errors.Is(err, dashboardsnapshots.ErrInvalidTimeRange) {
+			return response.Error(http.StatusBadRequest, err.Error(), err)
+		}
+		return response.Error(http.StatusInternalServerError, "Failed to create snapshot", err)
+	}
+
+	metrics.MApiDashboardSnapshotCreate.Inc()
+
+	return response.JSON(http.StatusOK, util.DynMap{
+		"key":       cmd.Result.Key,
+		"deleteKey": cmd.Result.DeleteKey,
+		"url":       setting.ToAbsUrl("dashboard/snapshot/" + cmd.Result.Key),
+		"deleteUrl": setting.ToAbsUrl("api/snapshots-delete/" + cmd.Result.DeleteKey),
+		"id":        cmd.Result.Id,
+	})
+}
+
 // GET /api/snapshots/:key
 func (hs *HTTPServer) GetDashboardSnapshot(c *models.ReqContext) response.Response {
 	key := web.Params(c.Req)[":key"]
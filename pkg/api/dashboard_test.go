@@ -29,6 +29,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/dashboards/service"
 	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
 	"github.com/grafana/grafana/pkg/services/dashboardversion/dashvertest"
+	fakeDatasources "github.com/grafana/grafana/pkg/services/datasources/fakes"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/guardian"
 	"github.com/grafana/grafana/pkg/services/libraryelements"
@@ -679,6 +680,7 @@ func TestDashboardAPIEndpoint(t *testing.T) {
 				{SaveError: models.ErrDashboardUidTooLong, ExpectedStatusCode: 400},
 				{SaveError: models.ErrDashboardCannotSaveProvisionedDashboard, ExpectedStatusCode: 400},
 				{SaveError: models.UpdatePluginDashboardError{PluginId: "plug"}, ExpectedStatusCode: 412},
+				{SaveError: models.DashboardSchemaValidationError{}, ExpectedStatusCode: 400},
 			}
 
 			cmd := models.SaveDashboardCommand{
@@ -699,6 +701,31 @@ func TestDashboardAPIEndpoint(t *testing.T) {
 					})
 			}
 		})
+
+		t.Run("Given a dashboard that is not valid against the dashboard schema", func(t *testing.T) {
+			cmd := models.SaveDashboardCommand{
+				OrgId: 1,
+				Dashboard: simplejson.NewFromAny(map[string]interface{}{
+					"title": "Dash",
+					"style": "blue",
+				}),
+			}
+
+			dashboardService := dashboards.NewFakeDashboardService(t)
+			dashboardService.On("SaveDashboard", mock.Anything, mock.AnythingOfType("*dashboards.SaveDashboardDTO"), mock.AnythingOfType("bool")).
+				Return(nil, models.DashboardSchemaValidationError{Errors: []models.DashboardSchemaFieldError{
+					{Path: "style", Message: `conflicting values "dark" and "blue"`},
+				}})
+
+			postDashboardScenario(t, "When calling POST on", "/api/dashboards", "/api/dashboards", cmd, dashboardService, nil, func(sc *scenarioContext) {
+				callPostDashboard(sc)
+				assert.Equal(t, 400, sc.resp.Code)
+				result := sc.ToJSON()
+				assert.Equal(t, "invalid-schema", result.Get("status").MustString())
+				assert.Equal(t, "style", result.Get("errors").GetIndex(0).Get("path").MustString())
+				assert.Equal(t, `conflicting values "dark" and "blue"`, result.Get("errors").GetIndex(0).Get("message").MustString())
+			})
+		})
 	})
 
 	t.Run("Given two dashboards being compared", func(t *testing.T) {
@@ -902,6 +929,7 @@ func TestDashboardAPIEndpoint(t *testing.T) {
 
 			hs := &HTTPServer{
 				Cfg:                          setting.NewCfg(),
+				Features:                     featuremgmt.WithFeatures(),
 				ProvisioningService:          fakeProvisioningService,
 				LibraryPanelService:          &mockLibraryPanelService{},
 				LibraryElementService:        &mockLibraryElementService{},
@@ -910,6 +938,7 @@ func TestDashboardAPIEndpoint(t *testing.T) {
 				SQLStore:                     mockSQLStore,
 				AccessControl:                accesscontrolmock.New(),
 				dashboardService:             dashboardService,
+				preferenceService:            &preftest.FakePreferenceService{ExpectedPreference: &pref.Preference{}},
 			}
 			hs.callGetDashboard(sc)
 
@@ -924,6 +953,191 @@ func TestDashboardAPIEndpoint(t *testing.T) {
 	})
 }
 
+func TestGetDashboardMigratesSchema(t *testing.T) {
+	origNewGuardian := guardian.New
+	t.Cleanup(func() {
+		guardian.New = origNewGuardian
+	})
+	guardian.MockDashboardGuardian(&guardian.FakeDashboardGuardian{CanViewValue: true})
+
+	setUp := func(features *featuremgmt.FeatureManager) *HTTPServer {
+		dashboardService := dashboards.NewFakeDashboardService(t)
+		dashboardService.On("GetDashboard", mock.Anything, mock.AnythingOfType("*models.GetDashboardQuery")).Run(func(args mock.Arguments) {
+			q := args.Get(1).(*models.GetDashboardQuery)
+			q.Result = models.NewDashboardFromJson(simplejson.NewFromAny(map[string]interface{}{
+				"title":         "Old dashboard",
+				"uid":           "abcdefghi",
+				"schemaVersion": 32,
+				"panels": []interface{}{
+					map[string]interface{}{"id": 1, "type": "timeseries", "datasource": "Prometheus", "targets": []interface{}{
+						map[string]interface{}{"refId": "A"},
+					}},
+				},
+			}))
+			q.Result.Id = 1
+		}).Return(nil)
+		provisioningService := dashboards.NewFakeDashboardProvisioning(t)
+		provisioningService.On("GetProvisionedDashboardDataByDashboardID", mock.Anything).Return(nil, nil)
+
+		return &HTTPServer{
+			Cfg:                          setting.NewCfg(),
+			SQLStore:                     mockstore.NewSQLStoreMock(),
+			AccessControl:                accesscontrolmock.New(),
+			Features:                     features,
+			LibraryPanelService:          &mockLibraryPanelService{},
+			dashboardService:             dashboardService,
+			dashboardProvisioningService: provisioningService,
+			DataSourcesService: &fakeDatasources.FakeDataSourceService{DataSources: []*models.DataSource{
+				{OrgId: testOrgID, Uid: "prom-uid", Name: "Prometheus", Type: "prometheus", IsDefault: true},
+			}},
+		}
+	}
+
+	loggedInUserScenarioWithRole(t, "When calling GET with dashboard migrations on read enabled", "GET", "/api/dashboards/uid/abcdefghi",
+		"/api/dashboards/uid/:uid", models.ROLE_EDITOR, func(sc *scenarioContext) {
+			hs := setUp(featuremgmt.WithFeatures(featuremgmt.FlagMigrateDashboardsOnRead))
+			hs.callGetDashboard(sc)
+			require.Equal(t, 200, sc.resp.Code)
+
+			dash := dtos.DashboardFullWithMeta{}
+			require.NoError(t, json.NewDecoder(sc.resp.Body).Decode(&dash))
+			assert.Equal(t, 36, dash.Dashboard.Get("schemaVersion").MustInt())
+			assert.Equal(t, map[string]interface{}{"uid": "prom-uid", "type": "prometheus"},
+				dash.Dashboard.Get("panels").GetIndex(0).Get("datasource").MustMap())
+		}, nil)
+
+	loggedInUserScenarioWithRole(t, "When calling GET with dashboard migrations on read disabled", "GET", "/api/dashboards/uid/abcdefghi",
+		"/api/dashboards/uid/:uid", models.ROLE_EDITOR, func(sc *scenarioContext) {
+			hs := setUp(featuremgmt.WithFeatures())
+			hs.callGetDashboard(sc)
+			require.Equal(t, 200, sc.resp.Code)
+
+			dash := dtos.DashboardFullWithMeta{}
+			require.NoError(t, json.NewDecoder(sc.resp.Body).Decode(&dash))
+			assert.Equal(t, 32, dash.Dashboard.Get("schemaVersion").MustInt())
+			assert.Equal(t, "Prometheus", dash.Dashboard.Get("panels").GetIndex(0).Get("datasource").MustString())
+		}, nil)
+}
+
+func TestGetDashboardAppliesFolderPreferences(t *testing.T) {
+	origNewGuardian := guardian.New
+	t.Cleanup(func() {
+		guardian.New = origNewGuardian
+	})
+	guardian.MockDashboardGuardian(&guardian.FakeDashboardGuardian{CanViewValue: true})
+
+	var dashboardJSON func() map[string]interface{}
+	dashboardService := dashboards.NewFakeDashboardService(t)
+	dashboardService.On("GetDashboard", mock.Anything, mock.AnythingOfType("*models.GetDashboardQuery")).Run(func(args mock.Arguments) {
+		q := args.Get(1).(*models.GetDashboardQuery)
+		q.Result = models.NewDashboardFromJson(simplejson.NewFromAny(dashboardJSON()))
+		q.Result.Id = 1
+		q.Result.FolderId = 2
+	}).Return(nil)
+	provisioningService := dashboards.NewFakeDashboardProvisioning(t)
+	provisioningService.On("GetProvisionedDashboardDataByDashboardID", mock.Anything).Return(nil, nil)
+
+	defaults := &pref.DashboardDefaultsPreference{
+		Time:      &pref.TimeRangePreference{From: "now-24h", To: "now"},
+		Refresh:   "1m",
+		Variables: map[string]string{"env": "prod", "host": "c"},
+	}
+	hs := &HTTPServer{
+		Cfg:                          setting.NewCfg(),
+		SQLStore:                     mockstore.NewSQLStoreMock(),
+		AccessControl:                accesscontrolmock.New(),
+		Features:                     featuremgmt.WithFeatures(),
+		LibraryPanelService:          &mockLibraryPanelService{},
+		dashboardService:             dashboardService,
+		dashboardProvisioningService: provisioningService,
+		preferenceService: &preftest.FakePreferenceService{ExpectedPreference: &pref.Preference{
+			FolderID: 2,
+			JSONData: &pref.PreferenceJSONData{DashboardDefaults: defaults},
+		}},
+	}
+
+	loggedInUserScenarioWithRole(t, "When calling GET on a dashboard that leaves values unset in a folder with preferences", "GET", "/api/dashboards/uid/abcdefghi",
+		"/api/dashboards/uid/:uid", models.ROLE_EDITOR, func(sc *scenarioContext) {
+			dashboardJSON = func() map[string]interface{} {
+				return map[string]interface{}{
+					"title":   "Databases",
+					"uid":     "abcdefghi",
+					"refresh": false,
+					"templating": map[string]interface{}{
+						"list": []interface{}{
+							map[string]interface{}{"type": "constant", "name": "env", "query": ""},
+							map[string]interface{}{"type": "custom", "name": "host", "query": "a,b"},
+						},
+					},
+				}
+			}
+			hs.callGetDashboard(sc)
+			require.Equal(t, 200, sc.resp.Code)
+
+			dash := dtos.DashboardFullWithMeta{}
+			require.NoError(t, json.NewDecoder(sc.resp.Body).Decode(&dash))
+			assert.Equal(t, map[string]interface{}{"from": "now-24h", "to": "now"}, dash.Dashboard.Get("time").MustMap())
+			assert.Equal(t, "1m", dash.Dashboard.Get("refresh").MustString())
+
+			variables := dash.Dashboard.GetPath("templating", "list")
+			assert.Equal(t, "prod", variables.GetIndex(0).Get("query").MustString())
+			assert.Equal(t, "prod", variables.GetIndex(0).GetPath("current", "value").MustString())
+			assert.Equal(t, "a,b", variables.GetIndex(1).Get("query").MustString())
+
+			assert.Equal(t, &pref.DashboardDefaultsPreference{
+				Time:      defaults.Time,
+				Refresh:   "1m",
+				Variables: map[string]string{"env": "prod"},
+			}, dash.Meta.FolderDefaultsApplied)
+		}, nil)
+
+	loggedInUserScenarioWithRole(t, "When calling GET on a dashboard that sets its own values in a folder with preferences", "GET", "/api/dashboards/uid/abcdefghi",
+		"/api/dashboards/uid/:uid", models.ROLE_EDITOR, func(sc *scenarioContext) {
+			dashboardJSON = func() map[string]interface{} {
+				return map[string]interface{}{
+					"title":   "Databases",
+					"uid":     "abcdefghi",
+					"time":    map[string]interface{}{"from": "now-6h", "to": "now"},
+					"refresh": "5s",
+					"templating": map[string]interface{}{
+						"list": []interface{}{
+							map[string]interface{}{"type": "constant", "name": "env", "query": "dev"},
+						},
+					},
+				}
+			}
+			hs.callGetDashboard(sc)
+			require.Equal(t, 200, sc.resp.Code)
+
+			dash := dtos.DashboardFullWithMeta{}
+			require.NoError(t, json.NewDecoder(sc.resp.Body).Decode(&dash))
+			assert.Equal(t, map[string]interface{}{"from": "now-6h", "to": "now"}, dash.Dashboard.Get("time").MustMap())
+			assert.Equal(t, "5s", dash.Dashboard.Get("refresh").MustString())
+			assert.Equal(t, "dev", dash.Dashboard.GetPath("templating", "list").GetIndex(0).Get("query").MustString())
+			assert.Nil(t, dash.Meta.FolderDefaultsApplied)
+		}, nil)
+
+	loggedInUserScenarioWithRole(t, "When calling GET on a dashboard saved with the default time range of the UI in a folder with preferences", "GET", "/api/dashboards/uid/abcdefghi",
+		"/api/dashboards/uid/:uid", models.ROLE_EDITOR, func(sc *scenarioContext) {
+			dashboardJSON = func() map[string]interface{} {
+				return map[string]interface{}{
+					"title":   "Databases",
+					"uid":     "abcdefghi",
+					"time":    map[string]interface{}{"from": "now-6h", "to": "now"},
+					"refresh": "",
+				}
+			}
+			hs.callGetDashboard(sc)
+			require.Equal(t, 200, sc.resp.Code)
+
+			dash := dtos.DashboardFullWithMeta{}
+			require.NoError(t, json.NewDecoder(sc.resp.Body).Decode(&dash))
+			assert.Equal(t, map[string]interface{}{"from": "now-6h", "to": "now"}, dash.Dashboard.Get("time").MustMap())
+			assert.Equal(t, "1m", dash.Dashboard.Get("refresh").MustString())
+			assert.Equal(t, &pref.DashboardDefaultsPreference{Refresh: "1m"}, dash.Meta.FolderDefaultsApplied)
+		}, nil)
+}
+
 func getDashboardShouldReturn200WithConfig(t *testing.T, sc *scenarioContext, provisioningService provisioning.ProvisioningService, dashboardStore dashboards.Store, dashboardService dashboards.DashboardService) dtos.DashboardFullWithMeta {
 	t.Helper()
 
@@ -942,11 +1156,12 @@ func getDashboardShouldReturn200WithConfig(t *testing.T, sc *scenarioContext, pr
 	features := featuremgmt.WithFeatures()
 
 	if dashboardService == nil {
-		dashboardService = service.ProvideDashboardService(cfg, dashboardStore, nil, features, nil, accesscontrolmock.NewMockedPermissionsService())
+		dashboardService = service.ProvideDashboardService(cfg, dashboardStore, nil, features, nil, accesscontrolmock.NewMockedPermissionsService(), nil)
 	}
 
 	hs := &HTTPServer{
 		Cfg:                   cfg,
+		Features:              features,
 		LibraryPanelService:   &libraryPanelsService,
 		LibraryElementService: &libraryElementsService,
 		SQLStore:              sc.sqlStore,
@@ -955,9 +1170,10 @@ func getDashboardShouldReturn200WithConfig(t *testing.T, sc *scenarioContext, pr
 		AccessControl:         accesscontrolmock.New(),
 		dashboardProvisioningService: service.ProvideDashboardService(
 			cfg, dashboardStore, nil, features,
-			accesscontrolmock.NewMockedPermissionsService(), accesscontrolmock.NewMockedPermissionsService(),
+			accesscontrolmock.NewMockedPermissionsService(), accesscontrolmock.NewMockedPermissionsService(), nil,
 		),
-		dashboardService: dashboardService,
+		dashboardService:  dashboardService,
+		preferenceService: &preftest.FakePreferenceService{ExpectedPreference: &pref.Preference{}},
 	}
 
 	hs.callGetDashboard(sc)
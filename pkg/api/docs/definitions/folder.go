@@ -80,6 +80,31 @@ import (
 // 404: notFoundError
 // 500: internalServerError
 
+// swagger:route GET /folders/{folder_uid}/preferences folders getFolderPreferences
+//
+// Get Folder Preferences.
+//
+// Returns the time range, refresh interval and constant variable values that the dashboards of the folder are served with.
+//
+// Responses:
+// 200: getFolderPreferencesResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+
+// swagger:route PUT /folders/{folder_uid}/preferences folders updateFolderPreferences
+//
+// Update Folder Preferences.
+//
+// Responses:
+// 200: okResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+
 // swagger:parameters getFolders
 type GetFoldersParams struct {
 	// Limit the maximum number of folders to return
@@ -96,6 +121,7 @@ type GetFoldersParams struct {
 
 // swagger:parameters getFolderByUID updateFolder deleteFolder
 // swagger:parameters getFolderPermissions updateFolderPermissions
+// swagger:parameters getFolderPreferences updateFolderPreferences
 type FolderUIDParam struct {
 	// in:path
 	// required:true
@@ -137,6 +163,19 @@ type DeleteFolderParam struct {
 	ForceDeleteRules bool `json:"forceDeleteRules"`
 }
 
+// swagger:parameters updateFolderPreferences
+type UpdateFolderPreferencesParam struct {
+	// in:body
+	// required:true
+	Body dtos.UpdateFolderPrefsCmd `json:"body"`
+}
+
+// swagger:response getFolderPreferencesResponse
+type GetFolderPreferencesResponse struct {
+	// in:body
+	Body dtos.FolderPrefs `json:"body"`
+}
+
 // swagger:response getFoldersResponse
 type GetFoldersResponse struct {
 	// The response message
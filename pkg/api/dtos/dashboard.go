@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/grafana/grafana/pkg/components/simplejson"
+	pref "github.com/grafana/grafana/pkg/services/preference"
 )
 
 type DashboardMeta struct {
@@ -34,6 +35,8 @@ type DashboardMeta struct {
 	ProvisionedExternalId  string                `json:"provisionedExternalId"`
 	AnnotationsPermissions *AnnotationPermission `json:"annotationsPermissions"`
 	IsPublic               bool                  `json:"isPublic"`
+	// FolderDefaultsApplied holds the folder preferences that were used for the values the dashboard leaves unset.
+	FolderDefaultsApplied *pref.DashboardDefaultsPreference `json:"folderDefaultsApplied,omitempty"`
 }
 type AnnotationPermission struct {
 	Dashboard    AnnotationActions `json:"dashboard"`
@@ -74,4 +77,6 @@ type CalculateDiffTarget struct {
 
 type RestoreDashboardVersionCommand struct {
 	Version int `json:"version" binding:"Required"`
+	// DryRun returns the summary of the changes of the restore without restoring the version.
+	DryRun bool `json:"dryRun"`
 }
@@ -70,6 +70,13 @@ type MetricRequest struct {
 	Debug bool `json:"debug"`
 }
 
+// PublicDashboardQueryDTO is the request of the queries of a panel of a public dashboard. Only the queries saved in the
+// dashboard can be run, so it cannot contain queries.
+type PublicDashboardQueryDTO struct {
+	IntervalMs    int64 `json:"intervalMs"`
+	MaxDataPoints int64 `json:"maxDataPoints"`
+}
+
 func GetGravatarUrl(text string) string {
 	if setting.DisableGravatar {
 		return setting.AppSubUrl + "/public/img/user_profile.png"
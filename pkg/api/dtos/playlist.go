@@ -1,12 +1,16 @@
 package dtos
 
 type PlaylistDashboard struct {
-	Id    int64  `json:"id"`
-	Slug  string `json:"slug"`
-	Title string `json:"title"`
-	Uri   string `json:"uri"`
-	Url   string `json:"url"`
-	Order int    `json:"order"`
+	Id       int64  `json:"id"`
+	Uid      string `json:"uid"`
+	Slug     string `json:"slug"`
+	Title    string `json:"title"`
+	Uri      string `json:"uri"`
+	Url      string `json:"url"`
+	Order    int    `json:"order"`
+	Interval string `json:"interval"`
+	TimeFrom string `json:"timeFrom,omitempty"`
+	TimeTo   string `json:"timeTo,omitempty"`
 }
 
 type PlaylistDashboardsSlice []PlaylistDashboard
@@ -43,3 +43,19 @@ type PatchPrefsCmd struct {
 	QueryHistory     *pref.QueryHistoryPreference `json:"queryHistory,omitempty"`
 	HomeDashboardUID *string                      `json:"homeDashboardUID,omitempty"`
 }
+
+type FolderPrefs struct {
+	Time      *pref.TimeRangePreference `json:"time,omitempty"`
+	Refresh   string                    `json:"refresh,omitempty"`
+	Variables map[string]string         `json:"variables,omitempty"`
+}
+
+// swagger:model
+type UpdateFolderPrefsCmd struct {
+	// The time range of the dashboards in the folder, such as now-24h to now
+	Time *pref.TimeRangePreference `json:"time,omitempty"`
+	// The refresh interval of the dashboards in the folder, such as 1m
+	Refresh string `json:"refresh,omitempty"`
+	// The values of the constant variables of the dashboards in the folder, by variable name
+	Variables map[string]string `json:"variables,omitempty"`
+}
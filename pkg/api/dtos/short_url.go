@@ -1,10 +1,26 @@
 package dtos
 
+import "time"
+
 type ShortURL struct {
-	UID string `json:"uid"`
-	URL string `json:"url"`
+	UID        string     `json:"uid"`
+	URL        string     `json:"url"`
+	Path       string     `json:"path"`
+	CreatedBy  int64      `json:"createdBy"`
+	Created    time.Time  `json:"created"`
+	LastSeen   *time.Time `json:"lastSeen,omitempty"`
+	Expiration *time.Time `json:"expiration,omitempty"`
+	Pinned     bool       `json:"pinned"`
 }
 
 type CreateShortURLCmd struct {
-	Path string `json:"path"`
+	Path          string `json:"path"`
+	UID           string `json:"uid"`
+	SecondsToLive int64  `json:"secondsToLive"`
+	Pinned        bool   `json:"pinned"`
+}
+
+type UpdateShortURLCmd struct {
+	SecondsToLive int64 `json:"secondsToLive"`
+	Pinned        bool  `json:"pinned"`
 }
@@ -12,6 +12,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/grafana/grafana/pkg/api/avatar"
 	"github.com/grafana/grafana/pkg/api/routing"
@@ -143,6 +144,7 @@ type HTTPServer struct {
 	teamPermissionsService       accesscontrol.TeamPermissionsService
 	NotificationService          *notifications.NotificationService
 	dashboardService             dashboards.DashboardService
+	publicDashboardsRateLimiter  *middleware.KeyRateLimiter
 	dashboardProvisioningService dashboards.DashboardProvisioningService
 	folderService                dashboards.FolderService
 	DatasourcePermissionsService permissions.DatasourcePermissionsService
@@ -256,6 +258,7 @@ func ProvideHTTPServer(opts ServerOptions, cfg *setting.Cfg, routeRegister routi
 		authenticator:                authenticator,
 		NotificationService:          notificationService,
 		dashboardService:             dashboardService,
+		publicDashboardsRateLimiter:  middleware.NewKeyRateLimiter(publicDashboardQueryRPS, publicDashboardQueryBurst, time.Now),
 		dashboardProvisioningService: dashboardProvisioningService,
 		folderService:                folderService,
 		DatasourcePermissionsService: datasourcePermissionsService,
@@ -2,8 +2,8 @@ package api
 
 import (
 	"context"
+	"errors"
 	"net/http"
-	"strconv"
 
 	"github.com/grafana/grafana/pkg/api/response"
 	"github.com/grafana/grafana/pkg/models"
@@ -11,28 +11,14 @@ import (
 )
 
 func (hs *HTTPServer) ValidateOrgPlaylist(c *models.ReqContext) {
-	id, err := strconv.ParseInt(web.Params(c.Req)[":id"], 10, 64)
-	if err != nil {
-		c.JsonApiErr(http.StatusBadRequest, "id is invalid", nil)
-		return
-	}
-	query := models.GetPlaylistByIdQuery{Id: id}
-	err = hs.SQLStore.GetPlaylist(c.Req.Context(), &query)
+	uid := web.Params(c.Req)[":uid"]
+	query := models.GetPlaylistByUidQuery{UID: uid, OrgId: c.OrgId}
+	err := hs.SQLStore.GetPlaylist(c.Req.Context(), &query)
 
 	if err != nil {
 		c.JsonApiErr(404, "Playlist not found", err)
 		return
 	}
-
-	if query.Result.OrgId == 0 {
-		c.JsonApiErr(404, "Playlist not found", err)
-		return
-	}
-
-	if query.Result.OrgId != c.OrgId {
-		c.JsonApiErr(403, "You are not allowed to edit/view playlist", nil)
-		return
-	}
 }
 
 func (hs *HTTPServer) SearchPlaylists(c *models.ReqContext) response.Response {
@@ -58,20 +44,18 @@ func (hs *HTTPServer) SearchPlaylists(c *models.ReqContext) response.Response {
 }
 
 func (hs *HTTPServer) GetPlaylist(c *models.ReqContext) response.Response {
-	id, err := strconv.ParseInt(web.Params(c.Req)[":id"], 10, 64)
-	if err != nil {
-		return response.Error(http.StatusBadRequest, "id is invalid", err)
-	}
-	cmd := models.GetPlaylistByIdQuery{Id: id}
+	uid := web.Params(c.Req)[":uid"]
+	cmd := models.GetPlaylistByUidQuery{UID: uid, OrgId: c.OrgId}
 
 	if err := hs.SQLStore.GetPlaylist(c.Req.Context(), &cmd); err != nil {
-		return response.Error(500, "Playlist not found", err)
+		return playlistErrResponse(err, "Failed to get playlist")
 	}
 
-	playlistDTOs, _ := hs.LoadPlaylistItemDTOs(c.Req.Context(), id)
+	playlistDTOs, _ := hs.LoadPlaylistItemDTOs(c.Req.Context(), cmd.Result.Id)
 
 	dto := &models.PlaylistDTO{
 		Id:       cmd.Result.Id,
+		UID:      cmd.Result.UID,
 		Name:     cmd.Result.Name,
 		Interval: cmd.Result.Interval,
 		OrgId:    cmd.Result.OrgId,
@@ -98,6 +82,9 @@ func (hs *HTTPServer) LoadPlaylistItemDTOs(ctx context.Context, id int64) ([]mod
 			Value:      item.Value,
 			Order:      item.Order,
 			Title:      item.Title,
+			Interval:   item.Interval,
+			TimeFrom:   item.TimeFrom,
+			TimeTo:     item.TimeTo,
 		})
 	}
 
@@ -114,12 +101,13 @@ func (hs *HTTPServer) LoadPlaylistItems(ctx context.Context, id int64) ([]models
 }
 
 func (hs *HTTPServer) GetPlaylistItems(c *models.ReqContext) response.Response {
-	id, err := strconv.ParseInt(web.Params(c.Req)[":id"], 10, 64)
-	if err != nil {
-		return response.Error(http.StatusBadRequest, "id is invalid", err)
+	uid := web.Params(c.Req)[":uid"]
+	query := models.GetPlaylistByUidQuery{UID: uid, OrgId: c.OrgId}
+	if err := hs.SQLStore.GetPlaylist(c.Req.Context(), &query); err != nil {
+		return playlistErrResponse(err, "Failed to get playlist")
 	}
 
-	playlistDTOs, err := hs.LoadPlaylistItemDTOs(c.Req.Context(), id)
+	playlistDTOs, err := hs.LoadPlaylistItemDTOs(c.Req.Context(), query.Result.Id)
 
 	if err != nil {
 		return response.Error(500, "Could not load playlist items", err)
@@ -129,12 +117,9 @@ func (hs *HTTPServer) GetPlaylistItems(c *models.ReqContext) response.Response {
 }
 
 func (hs *HTTPServer) GetPlaylistDashboards(c *models.ReqContext) response.Response {
-	playlistID, err := strconv.ParseInt(web.Params(c.Req)[":id"], 10, 64)
-	if err != nil {
-		return response.Error(http.StatusBadRequest, "id is invalid", err)
-	}
+	playlistUID := web.Params(c.Req)[":uid"]
 
-	playlists, err := hs.LoadPlaylistDashboards(c.Req.Context(), c.OrgId, c.SignedInUser, playlistID)
+	playlists, err := hs.LoadPlaylistDashboards(c.Req.Context(), c.OrgId, c.SignedInUser, playlistUID)
 	if err != nil {
 		return response.Error(500, "Could not load dashboards", err)
 	}
@@ -143,14 +128,11 @@ func (hs *HTTPServer) GetPlaylistDashboards(c *models.ReqContext) response.Respo
 }
 
 func (hs *HTTPServer) DeletePlaylist(c *models.ReqContext) response.Response {
-	id, err := strconv.ParseInt(web.Params(c.Req)[":id"], 10, 64)
-	if err != nil {
-		return response.Error(http.StatusBadRequest, "id is invalid", err)
-	}
+	uid := web.Params(c.Req)[":uid"]
 
-	cmd := models.DeletePlaylistCommand{Id: id, OrgId: c.OrgId}
+	cmd := models.DeletePlaylistCommand{UID: uid, OrgId: c.OrgId}
 	if err := hs.SQLStore.DeletePlaylist(c.Req.Context(), &cmd); err != nil {
-		return response.Error(500, "Failed to delete playlist", err)
+		return playlistErrResponse(err, "Failed to delete playlist")
 	}
 
 	return response.JSON(http.StatusOK, "")
@@ -164,7 +146,7 @@ func (hs *HTTPServer) CreatePlaylist(c *models.ReqContext) response.Response {
 	cmd.OrgId = c.OrgId
 
 	if err := hs.SQLStore.CreatePlaylist(c.Req.Context(), &cmd); err != nil {
-		return response.Error(500, "Failed to create playlist", err)
+		return playlistErrResponse(err, "Failed to create playlist")
 	}
 
 	return response.JSON(http.StatusOK, cmd.Result)
@@ -176,17 +158,13 @@ func (hs *HTTPServer) UpdatePlaylist(c *models.ReqContext) response.Response {
 		return response.Error(http.StatusBadRequest, "bad request data", err)
 	}
 	cmd.OrgId = c.OrgId
-	var err error
-	cmd.Id, err = strconv.ParseInt(web.Params(c.Req)[":id"], 10, 64)
-	if err != nil {
-		return response.Error(http.StatusBadRequest, "id is invalid", err)
-	}
+	cmd.UID = web.Params(c.Req)[":uid"]
 
 	if err := hs.SQLStore.UpdatePlaylist(c.Req.Context(), &cmd); err != nil {
-		return response.Error(500, "Failed to save playlist", err)
+		return playlistErrResponse(err, "Failed to save playlist")
 	}
 
-	playlistDTOs, err := hs.LoadPlaylistItemDTOs(c.Req.Context(), cmd.Id)
+	playlistDTOs, err := hs.LoadPlaylistItemDTOs(c.Req.Context(), cmd.Result.Id)
 	if err != nil {
 		return response.Error(500, "Failed to save playlist", err)
 	}
@@ -194,3 +172,16 @@ func (hs *HTTPServer) UpdatePlaylist(c *models.ReqContext) response.Response {
 	cmd.Result.Items = playlistDTOs
 	return response.JSON(http.StatusOK, cmd.Result)
 }
+
+func playlistErrResponse(err error, message string) response.Response {
+	switch {
+	case errors.Is(err, models.ErrPlaylistNotFound):
+		return response.Error(http.StatusNotFound, err.Error(), err)
+	case errors.Is(err, models.ErrPlaylistWithSameUIDExists):
+		return response.Error(http.StatusConflict, err.Error(), err)
+	case errors.Is(err, models.ErrPlaylistInvalidUID), errors.Is(err, models.ErrPlaylistUIDTooLong),
+		errors.Is(err, models.ErrPlaylistInvalidItemInterval):
+		return response.Error(http.StatusBadRequest, err.Error(), err)
+	}
+	return response.Error(http.StatusInternalServerError, message, err)
+}
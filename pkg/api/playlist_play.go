@@ -11,37 +11,62 @@ import (
 	"github.com/grafana/grafana/pkg/services/search"
 )
 
-func (hs *HTTPServer) populateDashboardsByID(ctx context.Context, dashboardByIDs []int64, dashboardIDOrder map[int64]int) (dtos.PlaylistDashboardsSlice, error) {
+func (hs *HTTPServer) populateDashboardsByID(ctx context.Context, orgID int64, dashboardByIDs map[int64]models.PlaylistItem, dashboardByUIDs map[string]models.PlaylistItem, interval string) (dtos.PlaylistDashboardsSlice, error) {
 	result := make(dtos.PlaylistDashboardsSlice, 0)
 
+	queries := []*models.GetDashboardsQuery{}
 	if len(dashboardByIDs) > 0 {
-		dashboardQuery := models.GetDashboardsQuery{DashboardIds: dashboardByIDs}
-		if err := hs.dashboardService.GetDashboards(ctx, &dashboardQuery); err != nil {
+		query := &models.GetDashboardsQuery{}
+		for id := range dashboardByIDs {
+			query.DashboardIds = append(query.DashboardIds, id)
+		}
+		queries = append(queries, query)
+	}
+	if len(dashboardByUIDs) > 0 {
+		query := &models.GetDashboardsQuery{}
+		for uid := range dashboardByUIDs {
+			query.DashboardUIds = append(query.DashboardUIds, uid)
+		}
+		queries = append(queries, query)
+	}
+
+	for _, query := range queries {
+		if err := hs.dashboardService.GetDashboards(ctx, query); err != nil {
 			return result, err
 		}
 
-		for _, item := range dashboardQuery.Result {
-			result = append(result, dtos.PlaylistDashboard{
+		for _, item := range query.Result {
+			if item.OrgId != orgID {
+				continue
+			}
+			playlistItem, ok := dashboardByIDs[item.Id]
+			if len(query.DashboardUIds) > 0 {
+				playlistItem, ok = dashboardByUIDs[item.Uid]
+			}
+			if !ok {
+				continue
+			}
+			result = append(result, newPlaylistDashboard(playlistItem, interval, dtos.PlaylistDashboard{
 				Id:    item.Id,
+				Uid:   item.Uid,
 				Slug:  item.Slug,
 				Title: item.Title,
 				Uri:   "db/" + item.Slug,
 				Url:   models.GetDashboardUrl(item.Uid, item.Slug),
-				Order: dashboardIDOrder[item.Id],
-			})
+			}))
 		}
 	}
 
 	return result, nil
 }
 
-func (hs *HTTPServer) populateDashboardsByTag(ctx context.Context, orgID int64, signedInUser *models.SignedInUser, dashboardByTag []string, dashboardTagOrder map[string]int) dtos.PlaylistDashboardsSlice {
+func (hs *HTTPServer) populateDashboardsByTag(ctx context.Context, orgID int64, signedInUser *models.SignedInUser, dashboardByTag []models.PlaylistItem, interval string) dtos.PlaylistDashboardsSlice {
 	result := make(dtos.PlaylistDashboardsSlice, 0)
 
-	for _, tag := range dashboardByTag {
+	for _, playlistItem := range dashboardByTag {
 		searchQuery := search.Query{
 			Title:        "",
-			Tags:         []string{tag},
+			Tags:         []string{playlistItem.Value},
 			SignedInUser: signedInUser,
 			Limit:        100,
 			IsStarred:    false,
@@ -50,14 +75,14 @@ func (hs *HTTPServer) populateDashboardsByTag(ctx context.Context, orgID int64,
 
 		if err := hs.SearchService.SearchHandler(ctx, &searchQuery); err == nil {
 			for _, item := range searchQuery.Result {
-				result = append(result, dtos.PlaylistDashboard{
+				result = append(result, newPlaylistDashboard(playlistItem, interval, dtos.PlaylistDashboard{
 					Id:    item.ID,
+					Uid:   item.UID,
 					Slug:  item.Slug,
 					Title: item.Title,
 					Uri:   item.URI,
 					Url:   item.URL,
-					Order: dashboardTagOrder[tag],
-				})
+				}))
 			}
 		}
 	}
@@ -65,32 +90,52 @@ func (hs *HTTPServer) populateDashboardsByTag(ctx context.Context, orgID int64,
 	return result
 }
 
-func (hs *HTTPServer) LoadPlaylistDashboards(ctx context.Context, orgID int64, signedInUser *models.SignedInUser, playlistID int64) (dtos.PlaylistDashboardsSlice, error) {
-	playlistItems, _ := hs.LoadPlaylistItems(ctx, playlistID)
+// newPlaylistDashboard sets the order, the interval and the time range of the playlist item on a dashboard of the item.
+// The dashboards of the items without an interval are shown for the interval of the playlist.
+func newPlaylistDashboard(playlistItem models.PlaylistItem, interval string, dashboard dtos.PlaylistDashboard) dtos.PlaylistDashboard {
+	dashboard.Order = playlistItem.Order
+	dashboard.Interval = interval
+	if playlistItem.Interval != "" {
+		dashboard.Interval = playlistItem.Interval
+	}
+	dashboard.TimeFrom = playlistItem.TimeFrom
+	dashboard.TimeTo = playlistItem.TimeTo
+	return dashboard
+}
+
+func (hs *HTTPServer) LoadPlaylistDashboards(ctx context.Context, orgID int64, signedInUser *models.SignedInUser, playlistUID string) (dtos.PlaylistDashboardsSlice, error) {
+	playlistQuery := models.GetPlaylistByUidQuery{UID: playlistUID, OrgId: orgID}
+	if err := hs.SQLStore.GetPlaylist(ctx, &playlistQuery); err != nil {
+		return nil, err
+	}
+	playlist := playlistQuery.Result
 
-	dashboardByIDs := make([]int64, 0)
-	dashboardByTag := make([]string, 0)
-	dashboardIDOrder := make(map[int64]int)
-	dashboardTagOrder := make(map[string]int)
+	playlistItems, _ := hs.LoadPlaylistItems(ctx, playlist.Id)
+
+	dashboardByIDs := make(map[int64]models.PlaylistItem)
+	dashboardByUIDs := make(map[string]models.PlaylistItem)
+	dashboardByTag := make([]models.PlaylistItem, 0)
 
 	for _, i := range playlistItems {
 		if i.Type == "dashboard_by_id" {
 			dashboardID, _ := strconv.ParseInt(i.Value, 10, 64)
-			dashboardByIDs = append(dashboardByIDs, dashboardID)
-			dashboardIDOrder[dashboardID] = i.Order
+			dashboardByIDs[dashboardID] = i
+		}
+
+		if i.Type == "dashboard_by_uid" {
+			dashboardByUIDs[i.Value] = i
 		}
 
 		if i.Type == "dashboard_by_tag" {
-			dashboardByTag = append(dashboardByTag, i.Value)
-			dashboardTagOrder[i.Value] = i.Order
+			dashboardByTag = append(dashboardByTag, i)
 		}
 	}
 
 	result := make(dtos.PlaylistDashboardsSlice, 0)
 
-	var k, _ = hs.populateDashboardsByID(ctx, dashboardByIDs, dashboardIDOrder)
+	var k, _ = hs.populateDashboardsByID(ctx, orgID, dashboardByIDs, dashboardByUIDs, playlist.Interval)
 	result = append(result, k...)
-	result = append(result, hs.populateDashboardsByTag(ctx, orgID, signedInUser, dashboardByTag, dashboardTagOrder)...)
+	result = append(result, hs.populateDashboardsByTag(ctx, orgID, signedInUser, dashboardByTag, playlist.Interval)...)
 
 	sort.Sort(result)
 	return result, nil
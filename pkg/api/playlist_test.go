@@ -0,0 +1,36 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/sqlstore/mockstore"
+)
+
+func TestPlaylistAPIEndpoint(t *testing.T) {
+	sqlStore := mockstore.NewSQLStoreMock()
+	sqlStore.ExpectedError = models.ErrPlaylistNotFound
+	hs := &HTTPServer{SQLStore: sqlStore}
+
+	testCases := []struct {
+		method  string
+		url     string
+		handler func(c *models.ReqContext) response.Response
+	}{
+		{method: "GET", url: "/api/playlists/unknown", handler: hs.GetPlaylist},
+		{method: "GET", url: "/api/playlists/unknown/items", handler: hs.GetPlaylistItems},
+		{method: "DELETE", url: "/api/playlists/unknown", handler: hs.DeletePlaylist},
+	}
+
+	for _, tc := range testCases {
+		loggedInUserScenarioWithRole(t, "When playlist does not exist, calling "+tc.method+" on", tc.method, tc.url,
+			tc.url, models.ROLE_EDITOR, func(sc *scenarioContext) {
+				sc.handlerFunc = tc.handler
+				sc.fakeReqWithParams(tc.method, sc.url, map[string]string{}).exec()
+				assert.Equal(t, 404, sc.resp.Code)
+			}, sqlStore)
+	}
+}
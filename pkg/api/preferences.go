@@ -2,12 +2,18 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
+	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
+
+	"github.com/grafana/grafana/pkg/api/apierrors"
 	"github.com/grafana/grafana/pkg/api/dtos"
 	"github.com/grafana/grafana/pkg/api/response"
 	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/guardian"
 	pref "github.com/grafana/grafana/pkg/services/preference"
+	"github.com/grafana/grafana/pkg/setting"
 	"github.com/grafana/grafana/pkg/web"
 )
 
@@ -199,3 +205,93 @@ func (hs *HTTPServer) PatchOrgPreferences(c *models.ReqContext) response.Respons
 	}
 	return hs.patchPreferencesFor(c.Req.Context(), c.OrgId, 0, 0, &dtoCmd)
 }
+
+// GET /api/folders/:uid/preferences
+func (hs *HTTPServer) GetFolderPreferences(c *models.ReqContext) response.Response {
+	folder, err := hs.folderService.GetFolderByUID(c.Req.Context(), c.SignedInUser, c.OrgId, web.Params(c.Req)[":uid"])
+	if err != nil {
+		return apierrors.ToFolderErrorResponse(err)
+	}
+
+	preference, err := hs.preferenceService.Get(c.Req.Context(), &pref.GetPreferenceQuery{OrgID: c.OrgId, FolderID: folder.Id})
+	if err != nil {
+		return response.Error(500, "Failed to get preferences", err)
+	}
+
+	dto := dtos.FolderPrefs{}
+	if preference.JSONData != nil && preference.JSONData.DashboardDefaults != nil {
+		dto.Time = preference.JSONData.DashboardDefaults.Time
+		dto.Refresh = preference.JSONData.DashboardDefaults.Refresh
+		dto.Variables = preference.JSONData.DashboardDefaults.Variables
+	}
+
+	return response.JSON(http.StatusOK, &dto)
+}
+
+// PUT /api/folders/:uid/preferences
+func (hs *HTTPServer) UpdateFolderPreferences(c *models.ReqContext) response.Response {
+	dtoCmd := dtos.UpdateFolderPrefsCmd{}
+	if err := web.Bind(c.Req, &dtoCmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+
+	folder, err := hs.folderService.GetFolderByUID(c.Req.Context(), c.SignedInUser, c.OrgId, web.Params(c.Req)[":uid"])
+	if err != nil {
+		return apierrors.ToFolderErrorResponse(err)
+	}
+
+	g := guardian.New(c.Req.Context(), folder.Id, c.OrgId, c.SignedInUser)
+	if canSave, err := g.CanSave(); err != nil || !canSave {
+		return dashboardGuardianResponse(err)
+	}
+
+	if dtoCmd.Time != nil && (dtoCmd.Time.From == "" || dtoCmd.Time.To == "") {
+		return response.Error(400, "Time range should have a start and an end", nil)
+	}
+	if dtoCmd.Refresh != "" {
+		if err := validateFolderRefreshInterval(dtoCmd.Refresh); err != nil {
+			return response.Error(400, err.Error(), nil)
+		}
+	}
+	for name := range dtoCmd.Variables {
+		if name == "" {
+			return response.Error(400, "Variable name should not be empty", nil)
+		}
+	}
+
+	saveCmd := pref.SavePreferenceCommand{
+		OrgID:    c.OrgId,
+		FolderID: folder.Id,
+		DashboardDefaults: &pref.DashboardDefaultsPreference{
+			Time:      dtoCmd.Time,
+			Refresh:   dtoCmd.Refresh,
+			Variables: dtoCmd.Variables,
+		},
+	}
+
+	if err := hs.preferenceService.Save(c.Req.Context(), &saveCmd); err != nil {
+		return response.Error(500, "Failed to save preferences", err)
+	}
+
+	return response.Success("Preferences updated")
+}
+
+// validateFolderRefreshInterval checks that a refresh interval is a duration that is not shorter than the
+// min_refresh_interval setting.
+func validateFolderRefreshInterval(refresh string) error {
+	d, err := gtime.ParseDuration(refresh)
+	if err != nil {
+		return fmt.Errorf("invalid refresh interval %q", refresh)
+	}
+	if setting.MinRefreshInterval == "" {
+		return nil
+	}
+	minRefreshInterval, err := gtime.ParseDuration(setting.MinRefreshInterval)
+	if err != nil {
+		return fmt.Errorf("parsing min refresh interval %q failed: %w", setting.MinRefreshInterval, err)
+	}
+	if d < minRefreshInterval {
+		return fmt.Errorf("refresh interval should not be shorter than %s", setting.MinRefreshInterval)
+	}
+	return nil
+}
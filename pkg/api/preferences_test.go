@@ -11,11 +11,15 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/api/routing"
 	"github.com/grafana/grafana/pkg/models"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/guardian"
 	pref "github.com/grafana/grafana/pkg/services/preference"
 	"github.com/grafana/grafana/pkg/services/preference/preftest"
+	"github.com/grafana/grafana/pkg/setting"
 )
 
 var (
@@ -195,3 +199,71 @@ func TestAPIEndpoint_PatchOrgPreferences(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, response.Code)
 	})
 }
+
+func TestAPIEndpoint_UpdateFolderPreferences(t *testing.T) {
+	origNewGuardian := guardian.New
+	t.Cleanup(func() {
+		guardian.New = origNewGuardian
+	})
+
+	testCases := []struct {
+		desc         string
+		canSave      bool
+		body         string
+		expectedCode int
+	}{
+		{
+			desc:         "folder editor can update folder preferences",
+			canSave:      true,
+			body:         `{"time": {"from": "now-24h", "to": "now"}, "refresh": "1m", "variables": {"env": "prod"}}`,
+			expectedCode: http.StatusOK,
+		},
+		{
+			desc:         "folder viewer cannot update folder preferences",
+			canSave:      false,
+			body:         `{"refresh": "1m"}`,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			desc:         "invalid refresh interval is rejected",
+			canSave:      true,
+			body:         `{"refresh": "often"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			desc:         "time range without an end is rejected",
+			canSave:      true,
+			body:         `{"time": {"from": "now-24h"}}`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			guardian.MockDashboardGuardian(&guardian.FakeDashboardGuardian{CanViewValue: true, CanSaveValue: tc.canSave})
+
+			folderService := dashboards.NewFakeFolderService(t)
+			folderService.On("GetFolderByUID", mock.Anything, mock.Anything, testOrgID, "folder").Return(&models.Folder{Id: 2, Uid: "folder"}, nil)
+
+			hs := HTTPServer{
+				Cfg:               setting.NewCfg(),
+				folderService:     folderService,
+				preferenceService: preftest.NewPreferenceServiceFake(),
+			}
+
+			sc := setupScenarioContext(t, "/api/folders/folder/preferences")
+			sc.defaultHandler = routing.Wrap(func(c *models.ReqContext) response.Response {
+				c.Req.Body = ioutil.NopCloser(strings.NewReader(tc.body))
+				c.Req.Header.Add("Content-Type", "application/json")
+				sc.context = c
+				sc.context.SignedInUser = &models.SignedInUser{OrgId: testOrgID, UserId: testUserID}
+
+				return hs.UpdateFolderPreferences(c)
+			})
+			sc.m.Put("/api/folders/:uid/preferences", sc.defaultHandler)
+
+			sc.fakeReqWithParams("PUT", sc.url, map[string]string{}).exec()
+			assert.Equal(t, tc.expectedCode, sc.resp.Code)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/grafana/grafana/pkg/api/dtos"
 	"github.com/grafana/grafana/pkg/api/response"
@@ -33,21 +34,153 @@ func (hs *HTTPServer) createShortURL(c *models.ReqContext) response.Response {
 		hs.log.Error("Invalid short URL path", "path", cmd.Path)
 		return response.Error(400, "Invalid path", nil)
 	}
+	if errResp := validateShortURLExpiration(cmd.SecondsToLive, cmd.Pinned); errResp != nil {
+		return errResp
+	}
 
-	shortURL, err := hs.ShortURLService.CreateShortURL(c.Req.Context(), c.SignedInUser, cmd.Path)
+	shortURL, err := hs.ShortURLService.CreateShortURL(c.Req.Context(), c.SignedInUser, &models.CreateShortUrlCommand{
+		Path:      cmd.Path,
+		Uid:       strings.TrimSpace(cmd.UID),
+		ExpiresAt: shortURLExpiresAt(cmd.SecondsToLive),
+		Pinned:    cmd.Pinned,
+	})
 	if err != nil {
+		if errors.Is(err, models.ErrShortURLInvalidUID) {
+			return response.Error(400, err.Error(), nil)
+		}
+		if errors.Is(err, models.ErrShortURLAlreadyExists) {
+			return response.Error(409, err.Error(), nil)
+		}
 		return response.Error(500, "Failed to create short URL", err)
 	}
 
-	url := fmt.Sprintf("%s/goto/%s?orgId=%d", strings.TrimSuffix(setting.AppUrl, "/"), shortURL.Uid, c.OrgId)
-	c.Logger.Debug("Created short URL", "url", url)
+	dto := shortURLToDTO(shortURL, c.OrgId)
+	c.Logger.Debug("Created short URL", "url", dto.URL)
 
-	dto := dtos.ShortURL{
-		UID: shortURL.Uid,
-		URL: url,
+	return response.JSON(http.StatusOK, dto)
+}
+
+// searchShortURLs handles requests to list the short URLs of the organization.
+func (hs *HTTPServer) searchShortURLs(c *models.ReqContext) response.Response {
+	query := models.SearchShortUrlsQuery{
+		Query: c.Query("query"),
+		Limit: c.QueryInt("limit"),
+		Page:  c.QueryInt("page"),
 	}
 
-	return response.JSON(http.StatusOK, dto)
+	shortURLs, err := hs.ShortURLService.SearchShortURLs(c.Req.Context(), c.SignedInUser, &query)
+	if err != nil {
+		return response.Error(500, "Failed to search short URLs", err)
+	}
+
+	result := make([]dtos.ShortURL, 0, len(shortURLs))
+	for _, shortURL := range shortURLs {
+		result = append(result, shortURLToDTO(shortURL, c.OrgId))
+	}
+
+	return response.JSON(http.StatusOK, result)
+}
+
+// updateShortURL handles requests to pin short URLs or to change their expiry date.
+func (hs *HTTPServer) updateShortURL(c *models.ReqContext) response.Response {
+	cmd := dtos.UpdateShortURLCmd{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	if errResp := validateShortURLExpiration(cmd.SecondsToLive, cmd.Pinned); errResp != nil {
+		return errResp
+	}
+
+	uid := web.Params(c.Req)[":uid"]
+	if errResp := hs.checkCanManageShortURL(c, uid); errResp != nil {
+		return errResp
+	}
+
+	shortURL, err := hs.ShortURLService.UpdateShortURL(c.Req.Context(), c.SignedInUser, &models.UpdateShortUrlCommand{
+		Uid:       uid,
+		ExpiresAt: shortURLExpiresAt(cmd.SecondsToLive),
+		Pinned:    cmd.Pinned,
+	})
+	if err != nil {
+		if errors.Is(err, models.ErrShortURLNotFound) {
+			return response.Error(404, err.Error(), nil)
+		}
+		return response.Error(500, "Failed to update short URL", err)
+	}
+
+	return response.JSON(http.StatusOK, shortURLToDTO(shortURL, c.OrgId))
+}
+
+// deleteShortURL handles requests to delete short URLs.
+func (hs *HTTPServer) deleteShortURL(c *models.ReqContext) response.Response {
+	uid := web.Params(c.Req)[":uid"]
+	if errResp := hs.checkCanManageShortURL(c, uid); errResp != nil {
+		return errResp
+	}
+
+	if err := hs.ShortURLService.DeleteShortURL(c.Req.Context(), c.SignedInUser, uid); err != nil {
+		if errors.Is(err, models.ErrShortURLNotFound) {
+			return response.Error(404, err.Error(), nil)
+		}
+		return response.Error(500, "Failed to delete short URL", err)
+	}
+
+	return response.Success("Short URL deleted")
+}
+
+// checkCanManageShortURL returns an error response unless the short URL exists and was created by the user, or the
+// user is an admin of the organization.
+func (hs *HTTPServer) checkCanManageShortURL(c *models.ReqContext, uid string) response.Response {
+	shortURL, err := hs.ShortURLService.GetShortURLByUID(c.Req.Context(), c.SignedInUser, uid)
+	if err != nil {
+		if errors.Is(err, models.ErrShortURLNotFound) {
+			return response.Error(404, err.Error(), nil)
+		}
+		return response.Error(500, "Failed to get short URL", err)
+	}
+
+	if shortURL.CreatedBy != c.UserId && c.OrgRole != models.ROLE_ADMIN {
+		return response.Error(403, "Only the creator of a short URL or an organization admin can change it", nil)
+	}
+
+	return nil
+}
+
+func validateShortURLExpiration(secondsToLive int64, pinned bool) response.Response {
+	if secondsToLive < 0 {
+		return response.Error(400, "Number of seconds before expiration should not be negative", nil)
+	}
+	if secondsToLive > 0 && pinned {
+		return response.Error(400, "Pinned short URLs cannot expire", nil)
+	}
+	return nil
+}
+
+func shortURLExpiresAt(secondsToLive int64) int64 {
+	if secondsToLive == 0 {
+		return 0
+	}
+	return time.Now().Add(time.Second * time.Duration(secondsToLive)).Unix()
+}
+
+func shortURLToDTO(shortURL *models.ShortUrl, orgID int64) dtos.ShortURL {
+	dto := dtos.ShortURL{
+		UID:       shortURL.Uid,
+		URL:       fmt.Sprintf("%s/goto/%s?orgId=%d", strings.TrimSuffix(setting.AppUrl, "/"), shortURL.Uid, orgID),
+		Path:      shortURL.Path,
+		CreatedBy: shortURL.CreatedBy,
+		Created:   time.Unix(shortURL.CreatedAt, 0),
+		Pinned:    shortURL.Pinned,
+	}
+	if shortURL.LastSeenAt > 0 {
+		lastSeen := time.Unix(shortURL.LastSeenAt, 0)
+		dto.LastSeen = &lastSeen
+	}
+	if shortURL.ExpiresAt > 0 {
+		expiration := time.Unix(shortURL.ExpiresAt, 0)
+		dto.Expiration = &expiration
+	}
+	return dto
 }
 
 func (hs *HTTPServer) redirectFromShortURL(c *models.ReqContext) {
@@ -68,6 +201,11 @@ func (hs *HTTPServer) redirectFromShortURL(c *models.ReqContext) {
 		return
 	}
 
+	if shortURL.IsExpired(time.Now()) {
+		hs.log.Debug("Not redirecting short URL since expired", "uid", shortURL.Uid)
+		return
+	}
+
 	// Failure to update LastSeenAt should still allow to redirect
 	if err := hs.ShortURLService.UpdateLastSeenAt(c.Req.Context(), shortURL); err != nil {
 		hs.log.Error("Failed to update short URL last seen at", "error", err)
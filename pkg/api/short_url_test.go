@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/grafana/grafana/pkg/api/dtos"
 	"github.com/grafana/grafana/pkg/api/response"
@@ -29,7 +30,7 @@ func TestShortURLAPIEndpoint(t *testing.T) {
 			Path:  cmd.Path,
 		}
 		service := &fakeShortURLService{
-			createShortURLFunc: func(ctx context.Context, user *models.SignedInUser, path string) (*models.ShortUrl, error) {
+			createShortURLFunc: func(ctx context.Context, user *models.SignedInUser, cmd *models.CreateShortUrlCommand) (*models.ShortUrl, error) {
 				return createResp, nil
 			},
 		}
@@ -45,6 +46,137 @@ func TestShortURLAPIEndpoint(t *testing.T) {
 				require.Equal(t, fmt.Sprintf("/goto/%s?orgId=%d", createResp.Uid, createResp.OrgId), shortUrl.URL)
 			})
 	})
+
+	t.Run("Given a request for creating a shortUrl with a custom uid and an expiry", func(t *testing.T) {
+		cmd := dtos.CreateShortURLCmd{
+			Path:          "d/TxKARsmGz/new-dashboard",
+			UID:           "runbook-link",
+			SecondsToLive: 3600,
+		}
+
+		var created *models.CreateShortUrlCommand
+		service := &fakeShortURLService{
+			createShortURLFunc: func(ctx context.Context, user *models.SignedInUser, cmd *models.CreateShortUrlCommand) (*models.ShortUrl, error) {
+				created = cmd
+				return &models.ShortUrl{Id: 1, OrgId: user.OrgId, Uid: cmd.Uid, Path: cmd.Path, ExpiresAt: cmd.ExpiresAt}, nil
+			},
+		}
+
+		createShortURLScenario(t, "When calling POST on", "/api/short-urls", "/api/short-urls", cmd, service,
+			func(sc *scenarioContext) {
+				callCreateShortURL(sc)
+
+				require.Equal(t, 200, sc.resp.Code)
+				require.Equal(t, "runbook-link", created.Uid)
+				require.InDelta(t, time.Now().Add(time.Hour).Unix(), created.ExpiresAt, 5)
+
+				shortUrl := dtos.ShortURL{}
+				err := json.NewDecoder(sc.resp.Body).Decode(&shortUrl)
+				require.NoError(t, err)
+				require.Equal(t, "runbook-link", shortUrl.UID)
+				require.NotNil(t, shortUrl.Expiration)
+			})
+	})
+
+	t.Run("Given a request for creating a pinned shortUrl with an expiry", func(t *testing.T) {
+		cmd := dtos.CreateShortURLCmd{Path: "d/TxKARsmGz/new-dashboard", Pinned: true, SecondsToLive: 3600}
+
+		createShortURLScenario(t, "When calling POST on", "/api/short-urls", "/api/short-urls", cmd, &fakeShortURLService{},
+			func(sc *scenarioContext) {
+				callCreateShortURL(sc)
+				require.Equal(t, 400, sc.resp.Code)
+			})
+	})
+
+	t.Run("Given a request for creating a shortUrl with a uid that is taken", func(t *testing.T) {
+		cmd := dtos.CreateShortURLCmd{Path: "d/TxKARsmGz/new-dashboard", UID: "taken"}
+		service := &fakeShortURLService{
+			createShortURLFunc: func(ctx context.Context, user *models.SignedInUser, cmd *models.CreateShortUrlCommand) (*models.ShortUrl, error) {
+				return nil, models.ErrShortURLAlreadyExists
+			},
+		}
+
+		createShortURLScenario(t, "When calling POST on", "/api/short-urls", "/api/short-urls", cmd, service,
+			func(sc *scenarioContext) {
+				callCreateShortURL(sc)
+				require.Equal(t, 409, sc.resp.Code)
+			})
+	})
+}
+
+func TestDeleteShortURLAPIEndpoint(t *testing.T) {
+	shortURL := &models.ShortUrl{Id: 1, OrgId: testOrgID, Uid: "runbook-link", CreatedBy: testUserID}
+
+	testCases := []struct {
+		desc         string
+		user         *models.SignedInUser
+		expectedCode int
+	}{
+		{
+			desc:         "the creator can delete the short URL",
+			user:         &models.SignedInUser{OrgId: testOrgID, UserId: testUserID, OrgRole: models.ROLE_VIEWER},
+			expectedCode: 200,
+		},
+		{
+			desc:         "an org admin can delete the short URL",
+			user:         &models.SignedInUser{OrgId: testOrgID, UserId: testUserID + 1, OrgRole: models.ROLE_ADMIN},
+			expectedCode: 200,
+		},
+		{
+			desc:         "another editor cannot delete the short URL",
+			user:         &models.SignedInUser{OrgId: testOrgID, UserId: testUserID + 1, OrgRole: models.ROLE_EDITOR},
+			expectedCode: 403,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			deleted := false
+			service := &fakeShortURLService{
+				shortURLs: []*models.ShortUrl{shortURL},
+				deleteShortURLFunc: func(ctx context.Context, user *models.SignedInUser, uid string) error {
+					deleted = true
+					return nil
+				},
+			}
+			hs := HTTPServer{
+				Cfg:             setting.NewCfg(),
+				ShortURLService: service,
+				log:             log.New("test"),
+			}
+
+			sc := setupScenarioContext(t, "/api/short-urls/runbook-link")
+			sc.defaultHandler = routing.Wrap(func(c *models.ReqContext) response.Response {
+				sc.context = c
+				sc.context.SignedInUser = tc.user
+				return hs.deleteShortURL(c)
+			})
+			sc.m.Delete("/api/short-urls/:uid", sc.defaultHandler)
+
+			sc.fakeReqWithParams("DELETE", sc.url, map[string]string{}).exec()
+			require.Equal(t, tc.expectedCode, sc.resp.Code)
+			require.Equal(t, tc.expectedCode == 200, deleted)
+		})
+	}
+
+	t.Run("deleting a short URL that does not exist returns 404", func(t *testing.T) {
+		hs := HTTPServer{
+			Cfg:             setting.NewCfg(),
+			ShortURLService: &fakeShortURLService{},
+			log:             log.New("test"),
+		}
+
+		sc := setupScenarioContext(t, "/api/short-urls/unknown")
+		sc.defaultHandler = routing.Wrap(func(c *models.ReqContext) response.Response {
+			sc.context = c
+			sc.context.SignedInUser = &models.SignedInUser{OrgId: testOrgID, UserId: testUserID, OrgRole: models.ROLE_ADMIN}
+			return hs.deleteShortURL(c)
+		})
+		sc.m.Delete("/api/short-urls/:uid", sc.defaultHandler)
+
+		sc.fakeReqWithParams("DELETE", sc.url, map[string]string{}).exec()
+		require.Equal(t, 404, sc.resp.Code)
+	})
 }
 
 func callCreateShortURL(sc *scenarioContext) {
@@ -76,21 +208,45 @@ func createShortURLScenario(t *testing.T, desc string, url string, routePattern
 }
 
 type fakeShortURLService struct {
-	createShortURLFunc func(ctx context.Context, user *models.SignedInUser, path string) (*models.ShortUrl, error)
+	shortURLs          []*models.ShortUrl
+	createShortURLFunc func(ctx context.Context, user *models.SignedInUser, cmd *models.CreateShortUrlCommand) (*models.ShortUrl, error)
+	deleteShortURLFunc func(ctx context.Context, user *models.SignedInUser, uid string) error
 }
 
 func (s *fakeShortURLService) GetShortURLByUID(ctx context.Context, user *models.SignedInUser, uid string) (*models.ShortUrl, error) {
-	return nil, nil
+	for _, shortURL := range s.shortURLs {
+		if shortURL.OrgId == user.OrgId && shortURL.Uid == uid {
+			return shortURL, nil
+		}
+	}
+
+	return nil, models.ErrShortURLNotFound
 }
 
-func (s *fakeShortURLService) CreateShortURL(ctx context.Context, user *models.SignedInUser, path string) (*models.ShortUrl, error) {
+func (s *fakeShortURLService) CreateShortURL(ctx context.Context, user *models.SignedInUser, cmd *models.CreateShortUrlCommand) (*models.ShortUrl, error) {
 	if s.createShortURLFunc != nil {
-		return s.createShortURLFunc(ctx, user, path)
+		return s.createShortURLFunc(ctx, user, cmd)
 	}
 
 	return nil, nil
 }
 
+func (s *fakeShortURLService) UpdateShortURL(ctx context.Context, user *models.SignedInUser, cmd *models.UpdateShortUrlCommand) (*models.ShortUrl, error) {
+	return nil, nil
+}
+
+func (s *fakeShortURLService) SearchShortURLs(ctx context.Context, user *models.SignedInUser, query *models.SearchShortUrlsQuery) ([]*models.ShortUrl, error) {
+	return s.shortURLs, nil
+}
+
+func (s *fakeShortURLService) DeleteShortURL(ctx context.Context, user *models.SignedInUser, uid string) error {
+	if s.deleteShortURLFunc != nil {
+		return s.deleteShortURLFunc(ctx, user, uid)
+	}
+
+	return nil
+}
+
 func (s *fakeShortURLService) UpdateLastSeenAt(ctx context.Context, shortURL *models.ShortUrl) error {
 	return nil
 }
@@ -163,6 +163,36 @@ var adminCommands = []*cli.Command{
 			},
 		},
 	},
+	{
+		Name:        "convert-prometheus-rules",
+		Usage:       "convert-prometheus-rules <rule file>",
+		Description: "Converts the alerting rules of a Prometheus or Loki rule file into Grafana managed alert rules, written as an alerting provisioning file",
+		Action:      runCueCommand(convertPrometheusRulesCommand),
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "datasource-uid",
+				Usage: "UID of the Prometheus or Loki data source the converted rules query",
+			},
+			&cli.StringFlag{
+				Name:  "datasource-type",
+				Usage: "type of the data source the converted rules query, prometheus or loki",
+				Value: "prometheus",
+			},
+			&cli.StringFlag{
+				Name:  "folder",
+				Usage: "title of the folder of the converted rules",
+			},
+			&cli.IntFlag{
+				Name:  "org-id",
+				Usage: "ID of the organization of the converted rules",
+				Value: 1,
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "path of the provisioning file to write. If not set, the converted rules are printed without being written",
+			},
+		},
+	},
 	{
 		Name:  "data-migration",
 		Usage: "Runs a script that migrates or cleanups data in your database",
@@ -172,6 +202,17 @@ var adminCommands = []*cli.Command{
 				Usage:  "Migrates passwords from unsecured fields to secure_json_data field. Return ok unless there is an error. Safe to execute multiple times.",
 				Action: runDbCommand(datamigrations.EncryptDatasourcePasswords),
 			},
+			{
+				Name:   "migrate-dashboard-schemas",
+				Usage:  "Migrates the JSON of the dashboards to the latest schema version. Returns ok unless there is an error. Safe to execute multiple times.",
+				Action: runDbCommand(datamigrations.MigrateDashboardSchemas),
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "List the dashboards to migrate without migrating them",
+					},
+				},
+			},
 		},
 	},
 	{
@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/grafana/grafana/pkg/cmd/grafana-cli/logger"
+	"github.com/grafana/grafana/pkg/cmd/grafana-cli/utils"
+	"github.com/grafana/grafana/pkg/services/ngalert/prom"
+	"github.com/grafana/grafana/pkg/services/provisioning/alerting"
+	"github.com/grafana/grafana/pkg/services/provisioning/values"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+// defaultRuleGroupInterval is the interval of the converted rule groups that do not set one, the default evaluation
+// interval of Prometheus.
+const defaultRuleGroupInterval = time.Minute
+
+func convertPrometheusRulesCommand(c utils.CommandLine) error {
+	filename := c.Args().First()
+	if filename == "" {
+		return errors.New("must specify the path of the Prometheus rule file to convert")
+	}
+	folder := c.String("folder")
+	if folder == "" {
+		return errors.New("must specify the folder of the converted rules with --folder")
+	}
+	opts := prom.Options{
+		DatasourceUID:  c.String("datasource-uid"),
+		DatasourceType: c.String("datasource-type"),
+	}
+	if opts.DatasourceUID == "" {
+		return errors.New("must specify the UID of the data source the converted rules query with --datasource-uid")
+	}
+	orgID := int64(c.Int("org-id"))
+	if orgID <= 0 {
+		orgID = 1
+	}
+
+	b, err := os.ReadFile(filepath.Clean(filename))
+	if err != nil {
+		return err
+	}
+	out, warnings, err := convertPrometheusRules(b, opts, folder, orgID)
+	if err != nil {
+		return err
+	}
+	for _, warning := range warnings {
+		logger.Infof("Warning: %s\n", warning)
+	}
+
+	output := c.String("output")
+	if output == "" {
+		fmt.Print(string(out))
+		return nil
+	}
+	if err := os.WriteFile(filepath.Clean(output), out, 0640); err != nil {
+		return err
+	}
+	logger.Infof("Alert rules written to %s. Copy the file to the provisioning/alerting directory to create the rules.\n", output)
+	return nil
+}
+
+// convertPrometheusRules converts a Prometheus rule file into a Grafana alerting provisioning file. Each rule is given
+// a new UID, and every '$' is escaped so that the provisioning does not interpolate the rules with environment variables.
+func convertPrometheusRules(ruleFile []byte, opts prom.Options, folder string, orgID int64) ([]byte, []string, error) {
+	file, err := prom.ParseRuleFile(ruleFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	groups, warnings, err := prom.ConvertRuleFile(*file, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	provisioned := alerting.AlertingAsConfigV1{APIVersion: values.Int64Value{Raw: "1"}}
+	for _, group := range groups {
+		interval := time.Duration(group.Interval)
+		if interval == 0 {
+			interval = defaultRuleGroupInterval
+		}
+		provisionedGroup := &alerting.RuleGroupV1{
+			OrgID:    values.Int64Value{Raw: strconv.FormatInt(orgID, 10)},
+			Name:     escapeProvisioningValue(group.Name),
+			Folder:   escapeProvisioningValue(folder),
+			Interval: values.StringValue{Raw: interval.String()},
+		}
+		for _, rule := range group.Rules {
+			r := rule.GrafanaManagedAlert
+			provisionedRule := &alerting.RuleV1{
+				UID:          values.StringValue{Raw: util.GenerateShortUID()},
+				Title:        escapeProvisioningValue(r.Title),
+				Condition:    values.StringValue{Raw: r.Condition},
+				NoDataState:  values.StringValue{Raw: string(r.NoDataState)},
+				ExecErrState: values.StringValue{Raw: string(r.ExecErrState)},
+				Annotations:  escapeProvisioningMap(rule.Annotations),
+				Labels:       escapeProvisioningMap(rule.Labels),
+			}
+			if rule.For > 0 {
+				provisionedRule.For = values.StringValue{Raw: time.Duration(rule.For).String()}
+			}
+			for _, query := range r.Data {
+				model := make(map[string]interface{})
+				if err := json.Unmarshal(query.Model, &model); err != nil {
+					return nil, nil, err
+				}
+				for k, v := range model {
+					if s, ok := v.(string); ok {
+						model[k] = escapeProvisioningValue(s).Raw
+					}
+				}
+				provisionedRule.Data = append(provisionedRule.Data, &alerting.QueryV1{
+					RefID:         values.StringValue{Raw: query.RefID},
+					DatasourceUID: values.StringValue{Raw: query.DatasourceUID},
+					RelativeTimeRange: alerting.RelativeTimeRangeV1{
+						From: values.Int64Value{Raw: strconv.FormatInt(int64(time.Duration(query.RelativeTimeRange.From).Seconds()), 10)},
+						To:   values.Int64Value{Raw: strconv.FormatInt(int64(time.Duration(query.RelativeTimeRange.To).Seconds()), 10)},
+					},
+					Model: values.JSONValue{Raw: model},
+				})
+			}
+			provisionedGroup.Rules = append(provisionedGroup.Rules, provisionedRule)
+		}
+		provisioned.RuleGroups = append(provisioned.RuleGroups, provisionedGroup)
+	}
+
+	out, err := yaml.Marshal(provisioned)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, warnings, nil
+}
+
+// escapeProvisioningValue returns the raw value of a provisioning file that reads as s.
+func escapeProvisioningValue(s string) values.StringValue {
+	return values.StringValue{Raw: strings.ReplaceAll(s, "$", "$$")}
+}
+
+func escapeProvisioningMap(m map[string]string) values.StringMapValue {
+	if m == nil {
+		return values.StringMapValue{}
+	}
+	escaped := make(map[string]string, len(m))
+	for k, v := range m {
+		escaped[k] = escapeProvisioningValue(v).Raw
+	}
+	return values.StringMapValue{Raw: escaped}
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/prom"
+	"github.com/grafana/grafana/pkg/services/provisioning/alerting"
+)
+
+func TestConvertPrometheusRules(t *testing.T) {
+	ruleFile := []byte(`
+groups:
+  - name: logs
+    rules:
+      - alert: HighErrorRate
+        expr: sum(rate({app="api"} |~ "error|\\$fatal" [5m])) > 10
+        for: 10m
+        labels:
+          severity: warning
+        annotations:
+          summary: "{{ $labels.app }} logs {{ $value }} errors per second"
+      - record: app:errors:rate5m
+        expr: sum(rate({app="api"} |= "error" [5m]))
+`)
+
+	out, warnings, err := convertPrometheusRules(ruleFile, prom.Options{DatasourceUID: "loki-uid", DatasourceType: prom.DatasourceTypeLoki}, "Imported", 2)
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+
+	var provisioned alerting.AlertingAsConfigV1
+	require.NoError(t, yaml.Unmarshal(out, &provisioned))
+	require.Equal(t, int64(1), provisioned.APIVersion.Value())
+	require.Len(t, provisioned.RuleGroups, 1)
+
+	group := provisioned.RuleGroups[0]
+	require.Equal(t, int64(2), group.OrgID.Value())
+	require.Equal(t, "logs", group.Name.Value())
+	require.Equal(t, "Imported", group.Folder.Value())
+	require.Equal(t, "1m0s", group.Interval.Value())
+	require.Len(t, group.Rules, 1)
+
+	rule := group.Rules[0]
+	require.NotEmpty(t, rule.UID.Value())
+	require.Equal(t, "HighErrorRate", rule.Title.Value())
+	require.Equal(t, "C", rule.Condition.Value())
+	require.Equal(t, "10m0s", rule.For.Value())
+	require.Equal(t, "OK", rule.NoDataState.Value())
+	require.Equal(t, "Error", rule.ExecErrState.Value())
+	require.Equal(t, map[string]string{"severity": "warning"}, rule.Labels.Value())
+	require.Equal(t, "{{ $$labels.app }} logs {{ $$value }} errors per second", rule.Annotations.Raw["summary"])
+	require.Equal(t, "{{ $labels.app }} logs {{ $value }} errors per second", rule.Annotations.Value()["summary"])
+
+	require.Len(t, rule.Data, 3)
+	query := rule.Data[0]
+	require.Equal(t, "A", query.RefID.Value())
+	require.Equal(t, "loki-uid", query.DatasourceUID.Value())
+	require.Equal(t, int64(600), query.RelativeTimeRange.From.Value())
+	require.Equal(t, int64(0), query.RelativeTimeRange.To.Value())
+	require.Equal(t, `sum(rate({app="api"} |~ "error|\\$fatal" [5m])) > 10`, query.Model.Value()["expr"])
+	require.Equal(t, "instant", query.Model.Value()["queryType"])
+	require.Equal(t, "is_number($B) || is_nan($B) || is_inf($B)", rule.Data[2].Model.Value()["expression"])
+}
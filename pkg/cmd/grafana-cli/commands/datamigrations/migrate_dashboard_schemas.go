@@ -0,0 +1,96 @@
+package datamigrations
+
+import (
+	"context"
+
+	"github.com/fatih/color"
+
+	"github.com/grafana/grafana/pkg/cmd/grafana-cli/logger"
+	"github.com/grafana/grafana/pkg/cmd/grafana-cli/utils"
+	"github.com/grafana/grafana/pkg/components/dashmigrations"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+	"github.com/grafana/grafana/pkg/util/errutil"
+)
+
+type dashboardSchemaRow struct {
+	Id    int64
+	OrgId int64
+	Uid   string
+	Title string
+	Data  []byte
+}
+
+// MigrateDashboardSchemas migrates the dashboards of every organization to the latest schema version. With the
+// dry-run flag, the dashboards to migrate are only listed.
+func MigrateDashboardSchemas(c utils.CommandLine, sqlStore *sqlstore.SQLStore) error {
+	dryRun := c.Bool("dry-run")
+
+	return sqlStore.WithTransactionalDbSession(context.Background(), func(session *sqlstore.DBSession) error {
+		var rows []*dashboardSchemaRow
+		err := session.Table("dashboard").
+			Cols("id", "org_id", "uid", "title", "data").
+			Where("is_folder = ?", sqlStore.Dialect.BooleanStr(false)).
+			Asc("org_id", "id").
+			Find(&rows)
+		if err != nil {
+			return errutil.Wrap("failed to select dashboards", err)
+		}
+
+		lookups := make(map[int64]dashmigrations.DataSourceLookup)
+		migrated, failed := 0, 0
+		for _, row := range rows {
+			data, err := simplejson.NewJson(row.Data)
+			if err != nil {
+				logger.Warnf("Skipping dashboard %q (uid %s) of organization %d, its JSON is invalid: %s\n", row.Title, row.Uid, row.OrgId, err)
+				failed++
+				continue
+			}
+			if !dashmigrations.NeedsMigration(data) {
+				continue
+			}
+
+			lookup, ok := lookups[row.OrgId]
+			if !ok {
+				var dataSources []*models.DataSource
+				if err := session.Table("data_source").Where("org_id = ?", row.OrgId).Find(&dataSources); err != nil {
+					return errutil.Wrapf(err, "failed to select data sources of organization %d", row.OrgId)
+				}
+				lookup = dashmigrations.NewDataSourceLookup(dataSources)
+				lookups[row.OrgId] = lookup
+			}
+
+			schemaVersion := dashmigrations.SchemaVersion(data)
+			dashmigrations.Migrate(data, lookup)
+			migrated++
+			if dryRun {
+				logger.Infof("Would migrate dashboard %q (uid %s) of organization %d from schema version %d\n", row.Title, row.Uid, row.OrgId, schemaVersion)
+				continue
+			}
+
+			b, err := data.Encode()
+			if err != nil {
+				return errutil.Wrapf(err, "failed to encode dashboard %s", row.Uid)
+			}
+			if _, err := session.Exec("UPDATE dashboard SET data = ? WHERE id = ?", b, row.Id); err != nil {
+				return errutil.Wrapf(err, "failed to update dashboard %s", row.Uid)
+			}
+			logger.Infof("Migrated dashboard %q (uid %s) of organization %d from schema version %d\n", row.Title, row.Uid, row.OrgId, schemaVersion)
+		}
+
+		logger.Info("\n")
+		switch {
+		case migrated == 0:
+			logger.Infof("%s All dashboards are at schema version %d\n", color.GreenString("✔"), dashmigrations.LatestSchemaVersion)
+		case dryRun:
+			logger.Infof("%s %d dashboards would be migrated to schema version %d\n", color.GreenString("✔"), migrated, dashmigrations.LatestSchemaVersion)
+		default:
+			logger.Infof("%s Migrated %d dashboards to schema version %d\n", color.GreenString("✔"), migrated, dashmigrations.LatestSchemaVersion)
+		}
+		if failed > 0 {
+			logger.Warnf("%d dashboards with invalid JSON were skipped\n", failed)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,78 @@
+package datamigrations
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/cmd/grafana-cli/commands/commandstest"
+	"github.com/grafana/grafana/pkg/components/dashmigrations"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+)
+
+func TestMigrateDashboardSchemasCommand(t *testing.T) {
+	sqlStore := sqlstore.InitTestDB(t)
+	session := sqlStore.NewSession(context.Background())
+	defer session.Close()
+
+	_, err := session.Insert(&models.DataSource{
+		OrgId: 1, Uid: "prom-uid", Name: "Prometheus", Type: "prometheus", Created: time.Now(), Updated: time.Now(),
+	})
+	require.NoError(t, err)
+
+	dashboards := []*models.Dashboard{
+		models.NewDashboardFromJson(simplejson.NewFromAny(map[string]interface{}{
+			"title":         "Old",
+			"uid":           "old",
+			"schemaVersion": 32,
+			"panels": []interface{}{
+				map[string]interface{}{"id": 1, "type": "timeseries", "datasource": "Prometheus"},
+			},
+		})),
+		models.NewDashboardFromJson(simplejson.NewFromAny(map[string]interface{}{
+			"title":         "Latest",
+			"uid":           "latest",
+			"schemaVersion": dashmigrations.LatestSchemaVersion,
+		})),
+	}
+	for _, dash := range dashboards {
+		dash.OrgId = 1
+		dash.Created = time.Now()
+		dash.Updated = time.Now()
+	}
+	_, err = session.Insert(&dashboards)
+	require.NoError(t, err)
+
+	getDashboard := func(uid string) *simplejson.Json {
+		t.Helper()
+		dash := models.Dashboard{}
+		has, err := session.Table("dashboard").Where("uid = ?", uid).Get(&dash)
+		require.NoError(t, err)
+		require.True(t, has)
+		return dash.Data
+	}
+
+	t.Run("dry run does not update the dashboards", func(t *testing.T) {
+		c, err := commandstest.NewCliContext(map[string]string{"dry-run": "true"})
+		require.NoError(t, err)
+		require.NoError(t, MigrateDashboardSchemas(c, sqlStore))
+
+		require.Equal(t, 32, dashmigrations.SchemaVersion(getDashboard("old")))
+	})
+
+	t.Run("dashboards are migrated to the latest schema version", func(t *testing.T) {
+		c, err := commandstest.NewCliContext(map[string]string{})
+		require.NoError(t, err)
+		require.NoError(t, MigrateDashboardSchemas(c, sqlStore))
+
+		old := getDashboard("old")
+		require.Equal(t, dashmigrations.LatestSchemaVersion, dashmigrations.SchemaVersion(old))
+		require.Equal(t, map[string]interface{}{"uid": "prom-uid", "type": "prometheus"},
+			old.Get("panels").GetIndex(0).Get("datasource").MustMap())
+		require.Equal(t, "Latest", getDashboard("latest").Get("title").MustString())
+	})
+}
@@ -0,0 +1,129 @@
+package dashdiffs
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+type PanelChangeType string
+
+const (
+	PanelAdded    PanelChangeType = "added"
+	PanelDeleted  PanelChangeType = "deleted"
+	PanelModified PanelChangeType = "modified"
+)
+
+// Summary is the structured summary of the changes between two dashboard versions.
+type Summary struct {
+	// Changed lists the dashboard properties that changed, other than the panels.
+	Changed []string        `json:"changed"`
+	Panels  []*PanelSummary `json:"panels"`
+}
+
+// PanelSummary is the summary of the changes of a panel, matched by ID in both versions.
+type PanelSummary struct {
+	ID     int64           `json:"id"`
+	Title  string          `json:"title"`
+	Change PanelChangeType `json:"change"`
+	// Changed lists the panel properties that changed, only for modified panels.
+	Changed []string `json:"changed,omitempty"`
+}
+
+// ignoredSummaryKeys are the dashboard properties that change with every version.
+var ignoredSummaryKeys = map[string]struct{}{
+	"id":      {},
+	"version": {},
+	"panels":  {},
+}
+
+// CalculateSummary summarizes the changes between two dashboard versions, panel by panel. The panels of collapsed
+// rows are compared as any other panel.
+func CalculateSummary(baseData, newData *simplejson.Json) *Summary {
+	summary := &Summary{
+		Changed: changedKeys(baseData.MustMap(), newData.MustMap(), ignoredSummaryKeys),
+		Panels:  []*PanelSummary{},
+	}
+
+	basePanels := panelsByID(baseData.Get("panels"), map[int64]map[string]interface{}{})
+	newPanels := panelsByID(newData.Get("panels"), map[int64]map[string]interface{}{})
+
+	for id, base := range basePanels {
+		p, ok := newPanels[id]
+		switch {
+		case !ok:
+			summary.Panels = append(summary.Panels, &PanelSummary{ID: id, Title: panelTitle(base), Change: PanelDeleted})
+		case !reflect.DeepEqual(withoutNestedPanels(base), withoutNestedPanels(p)):
+			summary.Panels = append(summary.Panels, &PanelSummary{
+				ID:      id,
+				Title:   panelTitle(p),
+				Change:  PanelModified,
+				Changed: changedKeys(base, p, map[string]struct{}{"panels": {}}),
+			})
+		}
+	}
+	for id, p := range newPanels {
+		if _, ok := basePanels[id]; !ok {
+			summary.Panels = append(summary.Panels, &PanelSummary{ID: id, Title: panelTitle(p), Change: PanelAdded})
+		}
+	}
+	sort.Slice(summary.Panels, func(i, j int) bool {
+		return summary.Panels[i].ID < summary.Panels[j].ID
+	})
+	return summary
+}
+
+// panelsByID returns the panels, including the panels of collapsed rows, by ID.
+func panelsByID(panels *simplejson.Json, result map[int64]map[string]interface{}) map[int64]map[string]interface{} {
+	for _, p := range panels.MustArray() {
+		panel := simplejson.NewFromAny(p)
+		result[panel.Get("id").MustInt64()] = panel.MustMap()
+		panelsByID(panel.Get("panels"), result)
+	}
+	return result
+}
+
+// withoutNestedPanels returns the panel without the panels of a collapsed row, which are compared on their own.
+func withoutNestedPanels(panel map[string]interface{}) map[string]interface{} {
+	if _, ok := panel["panels"]; !ok {
+		return panel
+	}
+	result := make(map[string]interface{}, len(panel))
+	for k, v := range panel {
+		if k != "panels" {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+func panelTitle(panel map[string]interface{}) string {
+	if title, ok := panel["title"].(string); ok {
+		return title
+	}
+	return ""
+}
+
+// changedKeys returns the sorted keys whose values differ between the two objects.
+func changedKeys(base, new map[string]interface{}, ignored map[string]struct{}) []string {
+	changed := []string{}
+	for k, v := range base {
+		if _, ok := ignored[k]; ok {
+			continue
+		}
+		if n, ok := new[k]; !ok || !reflect.DeepEqual(v, n) {
+			changed = append(changed, k)
+		}
+	}
+	for k := range new {
+		if _, ok := ignored[k]; ok {
+			continue
+		}
+		if _, ok := base[k]; !ok {
+			changed = append(changed, k)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
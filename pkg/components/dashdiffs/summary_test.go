@@ -0,0 +1,54 @@
+package dashdiffs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+func TestCalculateSummary(t *testing.T) {
+	base, err := simplejson.NewJson([]byte(`{
+		"id": 1,
+		"version": 3,
+		"title": "Servers",
+		"refresh": "1m",
+		"panels": [
+			{"id": 1, "title": "CPU", "type": "timeseries", "targets": [{"expr": "cpu"}]},
+			{"id": 2, "title": "Memory", "type": "timeseries"},
+			{"id": 3, "title": "Disks", "type": "row", "collapsed": true, "panels": [
+				{"id": 4, "title": "IOPS", "type": "timeseries"}
+			]}
+		]
+	}`))
+	require.NoError(t, err)
+	updated, err := simplejson.NewJson([]byte(`{
+		"id": 1,
+		"version": 4,
+		"title": "Production servers",
+		"tags": ["prod"],
+		"panels": [
+			{"id": 1, "title": "CPU", "type": "stat", "targets": [{"expr": "avg(cpu)"}]},
+			{"id": 3, "title": "Disks", "type": "row", "collapsed": true, "panels": [
+				{"id": 4, "title": "IOPS", "type": "timeseries"},
+				{"id": 5, "title": "Latency", "type": "timeseries"}
+			]}
+		]
+	}`))
+	require.NoError(t, err)
+
+	summary := CalculateSummary(base, updated)
+	require.Equal(t, []string{"refresh", "tags", "title"}, summary.Changed)
+	require.Equal(t, []*PanelSummary{
+		{ID: 1, Title: "CPU", Change: PanelModified, Changed: []string{"targets", "type"}},
+		{ID: 2, Title: "Memory", Change: PanelDeleted},
+		{ID: 5, Title: "Latency", Change: PanelAdded},
+	}, summary.Panels)
+
+	t.Run("identical versions have no changes", func(t *testing.T) {
+		summary := CalculateSummary(base, base)
+		require.Empty(t, summary.Changed)
+		require.Empty(t, summary.Panels)
+	})
+}
@@ -0,0 +1,142 @@
+package dashmigrations
+
+import (
+	"github.com/grafana/grafana/pkg/models"
+)
+
+// DataSourceLookup finds a data source by UID or name. An empty UID or name finds the default data source. It
+// returns nil if there is none.
+type DataSourceLookup func(nameOrUID string) *DataSourceRef
+
+type DataSourceRef struct {
+	UID  string `json:"uid,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
+func (ref *DataSourceRef) toMap() map[string]interface{} {
+	m := map[string]interface{}{"uid": ref.UID}
+	if ref.Type != "" {
+		m["type"] = ref.Type
+	}
+	return m
+}
+
+// builtInDataSources are the data sources that have no entry in the data_source table. They have no UID, so the
+// frontend uses their name as UID, except for the Grafana data source.
+var builtInDataSources = map[string]*DataSourceRef{
+	"-- Grafana --":   {UID: "grafana", Type: "datasource"},
+	"-- Mixed --":     {UID: "-- Mixed --", Type: "datasource"},
+	"-- Dashboard --": {UID: "-- Dashboard --", Type: "datasource"},
+}
+
+// NewDataSourceLookup returns the lookup of the data sources of an organization and of the built-in data sources.
+// The default data source is the default one of the organization, or the Grafana data source when there is none.
+func NewDataSourceLookup(dataSources []*models.DataSource) DataSourceLookup {
+	byUID := make(map[string]*DataSourceRef, len(dataSources)+len(builtInDataSources))
+	byName := make(map[string]*DataSourceRef, len(dataSources)+len(builtInDataSources))
+	defaultDS := builtInDataSources["-- Grafana --"]
+
+	for name, ds := range builtInDataSources {
+		byUID[ds.UID] = ds
+		byName[name] = ds
+	}
+	for _, ds := range dataSources {
+		ref := &DataSourceRef{UID: ds.Uid, Type: ds.Type}
+		byUID[ds.Uid] = ref
+		byName[ds.Name] = ref
+		if ds.IsDefault {
+			defaultDS = ref
+		}
+	}
+
+	return func(nameOrUID string) *DataSourceRef {
+		if nameOrUID == "" || nameOrUID == "default" {
+			return defaultDS
+		}
+		if ds, ok := byUID[nameOrUID]; ok {
+			return ds
+		}
+		return byName[nameOrUID]
+	}
+}
+
+// dataSourceRef returns the reference of a data source referenced by name, as migrateDatasourceNameToRef of the
+// frontend does. With returnDefaultAsNull, the default data source is returned as nil.
+func (m *migrator) dataSourceRef(nameOrRef interface{}, present bool, returnDefaultAsNull bool) interface{} {
+	if returnDefaultAsNull && (nameOrRef == nil || nameOrRef == "default") {
+		return nil
+	}
+	if ref, ok := nameOrRef.(map[string]interface{}); ok {
+		if _, ok := ref["uid"].(string); ok {
+			return ref
+		}
+	}
+
+	if ds := m.lookupDataSource(nameOrRef); ds != nil {
+		return ds.toMap()
+	}
+	if !present {
+		return map[string]interface{}{}
+	}
+	return map[string]interface{}{"uid": nameOrRef}
+}
+
+// lookupDataSource finds a data source referenced by name, by UID or by a variable. The data sources referenced by a
+// variable are found by the current value of the variable, and keep the variable as UID.
+func (m *migrator) lookupDataSource(nameOrRef interface{}) *DataSourceRef {
+	if m.dataSources == nil {
+		return nil
+	}
+	switch ref := nameOrRef.(type) {
+	case nil:
+		return m.dataSources("")
+	case map[string]interface{}:
+		// references without UID are references to the default data source
+		return m.dataSources("")
+	case string:
+		if ref == "" || ref[0] != '$' {
+			return m.dataSources(ref)
+		}
+		value := m.variableValue(ref)
+		if value == "" {
+			return nil
+		}
+		ds := m.dataSources(value)
+		if ds == nil {
+			return nil
+		}
+		return &DataSourceRef{UID: ref, Type: ds.Type}
+	}
+	return nil
+}
+
+// variableValue returns the current value, or the first one, of a variable referenced as $name or ${name}.
+func (m *migrator) variableValue(ref string) string {
+	name := ref[1:]
+	if len(name) > 1 && name[0] == '{' && name[len(name)-1] == '}' {
+		name = name[1 : len(name)-1]
+	}
+	for _, v := range m.variables() {
+		if v["name"] != name {
+			continue
+		}
+		current, _ := v["current"].(map[string]interface{})
+		switch value := current["value"].(type) {
+		case string:
+			return value
+		case []interface{}:
+			if len(value) > 0 {
+				s, _ := value[0].(string)
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+func (m *migrator) defaultDataSource() *DataSourceRef {
+	if m.dataSources == nil {
+		return nil
+	}
+	return m.dataSources("")
+}
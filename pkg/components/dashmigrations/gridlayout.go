@@ -0,0 +1,214 @@
+package dashmigrations
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	gridColumnCount  = 24
+	defaultRowHeight = 250
+	defaultPanelSpan = 4
+	gridCellHeight   = 30
+	gridCellVMargin  = 8
+	minPanelHeight   = 90
+)
+
+// upgradeToGridLayout moves the panels of the rows of the dashboards older than schemaVersion 16 to the grid. The
+// rows that are collapsed, repeated or have a title are kept as row panels.
+func (m *migrator) upgradeToGridLayout() {
+	rows := objects(m.dash["rows"])
+	delete(m.dash, "rows")
+	if len(rows) == 0 {
+		return
+	}
+
+	var maxPanelID float64
+	showRows := false
+	for _, row := range rows {
+		for _, panel := range objects(row["panels"]) {
+			if id, ok := number(panel["id"]); ok && id > maxPanelID {
+				maxPanelID = id
+			}
+		}
+		if truthy(row["collapse"]) || truthy(row["showTitle"]) || truthy(row["repeat"]) {
+			showRows = true
+		}
+	}
+	nextRowID := int64(maxPanelID) + 1
+
+	yPos := 0
+	widthFactor := gridColumnCount / 12
+	panels := m.panels()
+
+	for _, row := range rows {
+		if truthy(row["repeatIteration"]) {
+			continue
+		}
+
+		var height interface{} = defaultRowHeight
+		if truthy(row["height"]) {
+			height = row["height"]
+		}
+		rowGridHeight := gridHeight(height)
+
+		var rowPanel map[string]interface{}
+		collapsed := false
+		if showRows {
+			rowPanel = map[string]interface{}{
+				"id":     nextRowID,
+				"type":   "row",
+				"panels": []interface{}{},
+				"gridPos": map[string]interface{}{
+					"x": 0,
+					"y": yPos,
+					"w": gridColumnCount,
+					"h": rowGridHeight,
+				},
+			}
+			copyIfPresent(rowPanel, row, map[string]string{"title": "title", "collapsed": "collapse", "repeat": "repeat"})
+			collapsed = truthy(row["collapse"])
+			nextRowID++
+			yPos++
+		}
+
+		area := newRowArea(rowGridHeight, gridColumnCount, yPos)
+
+		for _, panel := range objects(row["panels"]) {
+			span, ok := number(panel["span"])
+			if !ok || span == 0 {
+				span = defaultPanelSpan
+			}
+			if minSpan, ok := number(panel["minSpan"]); ok && minSpan != 0 {
+				panel["minSpan"] = math.Min(gridColumnCount, float64(gridColumnCount)/12*minSpan)
+			}
+			panelWidth := int(math.Floor(span)) * widthFactor
+			panelHeight := rowGridHeight
+			if truthy(panel["height"]) {
+				panelHeight = gridHeight(panel["height"])
+			}
+
+			x, y := area.panelPosition(panelHeight, panelWidth)
+			yPos = area.yPos
+			gridPos := map[string]interface{}{
+				"x": x,
+				"y": yPos + y,
+				"w": panelWidth,
+				"h": panelHeight,
+			}
+			panel["gridPos"] = gridPos
+			area.addPanel(x, yPos+y, panelWidth, panelHeight)
+
+			delete(panel, "span")
+
+			if rowPanel != nil && collapsed {
+				rowPanel["panels"] = append(rowPanel["panels"].([]interface{}), panel)
+			} else {
+				panels = append(panels, panel)
+			}
+		}
+
+		if rowPanel != nil {
+			panels = append(panels, rowPanel)
+		}
+		if !collapsed {
+			yPos += rowGridHeight
+		}
+	}
+
+	m.dash["panels"] = panels
+}
+
+// gridHeight returns the height in grid cells of a height in pixels, which may be a number or a string like 250px.
+func gridHeight(height interface{}) int {
+	h, ok := number(height)
+	if s, isString := height.(string); isString {
+		h, ok = parseIntPrefix(strings.Replace(s, "px", "", 1))
+	}
+	if !ok {
+		h = defaultRowHeight
+	}
+	if h < minPanelHeight {
+		h = minPanelHeight
+	}
+	return int(math.Ceil(h / (gridCellHeight + gridCellVMargin)))
+}
+
+// parseIntPrefix parses the integer at the start of a string, as parseInt does in JavaScript.
+func parseIntPrefix(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	end := 0
+	for end < len(s) && (s[end] >= '0' && s[end] <= '9' || end == 0 && (s[end] == '-' || s[end] == '+')) {
+		end++
+	}
+	n, err := strconv.Atoi(s[:end])
+	return float64(n), err == nil
+}
+
+// rowArea is a row of the dashboard being filled by its panels. The area has the height of the filled cells of
+// every column of the row.
+type rowArea struct {
+	area   []int
+	yPos   int
+	height int
+}
+
+func newRowArea(height int, width int, yPos int) *rowArea {
+	return &rowArea{area: make([]int, width), yPos: yPos, height: height}
+}
+
+func (a *rowArea) reset() {
+	for i := range a.area {
+		a.area[i] = 0
+	}
+}
+
+// addPanel updates the area after adding a panel.
+func (a *rowArea) addPanel(x, y, w, h int) {
+	for i := x; i < x+w && i < len(a.area); i++ {
+		if a.area[i] == 0 || y+h-a.yPos > a.area[i] {
+			a.area[i] = y + h - a.yPos
+		}
+	}
+}
+
+// panelPosition returns the position of a new panel in the row, wrapping to a new row when the panel does not fit.
+func (a *rowArea) panelPosition(panelHeight, panelWidth int) (int, int) {
+	if x, y, ok := a.place(panelWidth); ok {
+		return x, y
+	}
+	a.yPos += a.height
+	a.reset()
+	if x, y, ok := a.place(panelWidth); ok {
+		return x, y
+	}
+	return 0, 0
+}
+
+func (a *rowArea) place(panelWidth int) (int, int, bool) {
+	startPlace, endPlace := -1, -1
+	for i := len(a.area) - 1; i >= 0; i-- {
+		if a.height-a.area[i] <= 0 {
+			break
+		}
+		if endPlace == -1 {
+			endPlace = i
+		} else if i < len(a.area)-1 && a.area[i] <= a.area[i+1] {
+			startPlace = i
+		} else {
+			break
+		}
+	}
+
+	if startPlace == -1 || endPlace == -1 || endPlace-startPlace < panelWidth-1 {
+		return 0, 0, false
+	}
+	y := 0
+	for _, h := range a.area[startPlace:] {
+		if h > y {
+			y = h
+		}
+	}
+	return startPlace, y, true
+}
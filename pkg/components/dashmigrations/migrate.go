@@ -0,0 +1,227 @@
+// Package dashmigrations migrates the JSON models of dashboards to the latest schemaVersion. The migrations are the
+// ones of the DashboardMigrator of the frontend, so that the dashboards migrated in the backend are the same as the
+// dashboards migrated when the frontend loads them.
+package dashmigrations
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+// LatestSchemaVersion is the schemaVersion the dashboards are migrated to. It must be kept in line with the
+// schemaVersion of the DashboardMigrator of the frontend.
+const LatestSchemaVersion = 36
+
+// SchemaVersion returns the schemaVersion of a dashboard, 0 when it has none.
+func SchemaVersion(dash *simplejson.Json) int {
+	v, ok := dash.CheckGet("schemaVersion")
+	if !ok {
+		return 0
+	}
+	if n, err := v.Int(); err == nil {
+		return n
+	}
+	if s, err := v.String(); err == nil {
+		if n, err := strconv.Atoi(s); err == nil {
+			return n
+		}
+	}
+	return 0
+}
+
+// NeedsMigration returns whether a dashboard is older than the LatestSchemaVersion.
+func NeedsMigration(dash *simplejson.Json) bool {
+	return SchemaVersion(dash) < LatestSchemaVersion
+}
+
+// Migrate migrates a dashboard to the LatestSchemaVersion in place. The data sources referenced by name are replaced
+// with references by UID and type found with the lookup, which may be nil. It returns false when the dashboard is
+// already at the latest version.
+func Migrate(dash *simplejson.Json, dataSources DataSourceLookup) bool {
+	oldVersion := SchemaVersion(dash)
+	if oldVersion >= LatestSchemaVersion {
+		return false
+	}
+
+	m := &migrator{dash: dash.MustMap(), dataSources: dataSources}
+	if m.dash == nil {
+		return false
+	}
+	m.ensureLists()
+
+	// the migrations of the dashboard run first, in order, then the migrations of every panel
+	var panelMigrations []panelMigration
+	for _, mig := range migrations {
+		if oldVersion >= mig.version {
+			continue
+		}
+		if mig.dashboard != nil {
+			mig.dashboard(m)
+		}
+		if mig.panel != nil {
+			panelMigrations = append(panelMigrations, mig.panel)
+		}
+	}
+
+	panels := m.panels()
+	for i := range panels {
+		for _, migrate := range panelMigrations {
+			panel, ok := panels[i].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			panels[i] = migrate(m, panel)
+			if rowPanels, ok := panels[i].(map[string]interface{})["panels"].([]interface{}); ok {
+				for j := range rowPanels {
+					if rowPanel, ok := rowPanels[j].(map[string]interface{}); ok {
+						rowPanels[j] = migrate(m, rowPanel)
+					}
+				}
+			}
+		}
+	}
+
+	m.dash["schemaVersion"] = LatestSchemaVersion
+	return true
+}
+
+type panelMigration func(m *migrator, panel map[string]interface{}) map[string]interface{}
+
+type migration struct {
+	version   int
+	dashboard func(m *migrator)
+	panel     panelMigration
+}
+
+type migrator struct {
+	dash        map[string]interface{}
+	dataSources DataSourceLookup
+	nextPanelID int64
+}
+
+// ensureLists sets the lists of panels, variables and annotations that the migrations update, as the dashboard
+// model of the frontend does.
+func (m *migrator) ensureLists() {
+	if _, ok := m.dash["panels"].([]interface{}); !ok {
+		m.dash["panels"] = []interface{}{}
+	}
+	for _, key := range []string{"templating", "annotations"} {
+		obj, ok := m.dash[key].(map[string]interface{})
+		if !ok {
+			obj = map[string]interface{}{}
+			m.dash[key] = obj
+		}
+		if _, ok := obj["list"].([]interface{}); !ok {
+			obj["list"] = []interface{}{}
+		}
+	}
+}
+
+func (m *migrator) panels() []interface{} {
+	panels, _ := m.dash["panels"].([]interface{})
+	return panels
+}
+
+func (m *migrator) variables() []map[string]interface{} {
+	return objects(m.dash["templating"].(map[string]interface{})["list"])
+}
+
+func (m *migrator) annotations() []map[string]interface{} {
+	return objects(m.dash["annotations"].(map[string]interface{})["list"])
+}
+
+// objects returns the objects of a JSON array, skipping the other values.
+func objects(v interface{}) []map[string]interface{} {
+	arr, _ := v.([]interface{})
+	result := make([]map[string]interface{}, 0, len(arr))
+	for _, item := range arr {
+		if obj, ok := item.(map[string]interface{}); ok {
+			result = append(result, obj)
+		}
+	}
+	return result
+}
+
+// truthy returns whether a JSON value is truthy in JavaScript, in which the migrations were first written.
+func truthy(v interface{}) bool {
+	switch value := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return value
+	case string:
+		return value != ""
+	}
+	if n, ok := number(v); ok {
+		return n != 0
+	}
+	return true
+}
+
+// number returns the value of a JSON number.
+func number(v interface{}) (float64, bool) {
+	switch value := v.(type) {
+	case json.Number:
+		n, err := value.Float64()
+		return n, err == nil
+	case float64:
+		return value, true
+	case float32:
+		return float64(value), true
+	case int:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case int32:
+		return float64(value), true
+	}
+	return 0, false
+}
+
+// toNumber converts a JSON value to a number as JavaScript does, or returns nil for NaN.
+func toNumber(v interface{}) interface{} {
+	if n, ok := number(v); ok {
+		return n
+	}
+	switch value := v.(type) {
+	case nil:
+		return float64(0)
+	case bool:
+		if value {
+			return float64(1)
+		}
+		return float64(0)
+	case string:
+		s := strings.TrimSpace(value)
+		if s == "" {
+			return float64(0)
+		}
+		if n, err := strconv.ParseFloat(s, 64); err == nil {
+			return n
+		}
+	}
+	return nil
+}
+
+// copyIfPresent copies the keys of an object to another one, skipping the keys it does not have.
+func copyIfPresent(dst map[string]interface{}, src map[string]interface{}, keys map[string]string) {
+	for dstKey, srcKey := range keys {
+		if v, ok := src[srcKey]; ok {
+			dst[dstKey] = v
+		}
+	}
+}
+
+// sortedKeys returns the keys of an object in order, for the migrations that iterate over objects.
+func sortedKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,304 @@
+package dashmigrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/models"
+)
+
+func migrate(t *testing.T, dashJSON string, lookup DataSourceLookup) *simplejson.Json {
+	t.Helper()
+	dash, err := simplejson.NewJson([]byte(dashJSON))
+	require.NoError(t, err)
+	require.True(t, Migrate(dash, lookup))
+	require.Equal(t, LatestSchemaVersion, SchemaVersion(dash))
+
+	// the migrated dashboard is encoded and decoded again, as the API returns it
+	b, err := dash.Encode()
+	require.NoError(t, err)
+	migrated, err := simplejson.NewJson(b)
+	require.NoError(t, err)
+	return migrated
+}
+
+func requireJSON(t *testing.T, expected string, actual *simplejson.Json) {
+	t.Helper()
+	b, err := actual.Encode()
+	require.NoError(t, err)
+	require.JSONEq(t, expected, string(b))
+}
+
+var testLookup = NewDataSourceLookup([]*models.DataSource{
+	{Uid: "prom-uid", Name: "Prometheus", Type: "prometheus", IsDefault: true},
+	{Uid: "influx-uid", Name: "Influx", Type: "influxdb"},
+})
+
+func TestMigrate(t *testing.T) {
+	t.Run("dashboards at the latest version are not migrated", func(t *testing.T) {
+		dash, err := simplejson.NewJson([]byte(`{"schemaVersion": 36, "rows": []}`))
+		require.NoError(t, err)
+		require.False(t, Migrate(dash, nil))
+		requireJSON(t, `{"schemaVersion": 36, "rows": []}`, dash)
+	})
+
+	t.Run("rows are moved to the grid", func(t *testing.T) {
+		dash := migrate(t, `{
+			"schemaVersion": 15,
+			"rows": [
+				{"height": 250, "showTitle": true, "title": "First", "panels": [
+					{"id": 1, "type": "graph", "span": 6},
+					{"id": 2, "type": "graph", "span": 6}
+				]},
+				{"height": "150px", "collapse": true, "title": "Second", "panels": [
+					{"id": 3, "type": "text", "span": 12}
+				]}
+			]
+		}`, nil)
+
+		require.Nil(t, dash.Interface().(map[string]interface{})["rows"])
+		panels := dash.Get("panels").MustArray()
+		require.Len(t, panels, 4)
+		requireJSON(t, `{"x": 0, "y": 1, "w": 12, "h": 7}`, dash.Get("panels").GetIndex(0).Get("gridPos"))
+		requireJSON(t, `{"x": 12, "y": 1, "w": 12, "h": 7}`, dash.Get("panels").GetIndex(1).Get("gridPos"))
+
+		first := dash.Get("panels").GetIndex(2)
+		require.Equal(t, "row", first.Get("type").MustString())
+		require.Equal(t, "First", first.Get("title").MustString())
+		require.Equal(t, int64(4), first.Get("id").MustInt64())
+
+		second := dash.Get("panels").GetIndex(3)
+		require.True(t, second.Get("collapsed").MustBool())
+		requireJSON(t, `{"x": 0, "y": 8, "w": 24, "h": 4}`, second.Get("gridPos"))
+		require.Len(t, second.Get("panels").MustArray(), 1)
+		requireJSON(t, `{"x": 0, "y": 9, "w": 24, "h": 4}`, second.Get("panels").GetIndex(0).Get("gridPos"))
+	})
+
+	t.Run("graph axes and thresholds are migrated", func(t *testing.T) {
+		dash := migrate(t, `{
+			"schemaVersion": 11,
+			"panels": [{
+				"id": 1,
+				"type": "graph",
+				"y-axis": true,
+				"y_formats": ["ms", "short"],
+				"grid": {"leftMin": 0, "rightMax": 10, "threshold1": 5, "threshold2": 10, "threshold1Color": "red", "threshold2Color": "blue"}
+			}]
+		}`, nil)
+
+		panel := dash.Get("panels").GetIndex(0)
+		requireJSON(t, `[
+			{"show": true, "min": 0, "format": "ms"},
+			{"show": true, "max": 10, "format": "short"}
+		]`, panel.Get("yaxes"))
+		requireJSON(t, `[
+			{"value": 5, "op": "gt", "fill": true, "fillColor": "red", "colorMode": "custom"},
+			{"value": 10, "op": "gt", "fill": true, "fillColor": "blue", "colorMode": "custom"}
+		]`, panel.Get("thresholds"))
+		requireJSON(t, `{}`, panel.Get("grid"))
+	})
+
+	t.Run("singlestat panels are migrated to stat panels", func(t *testing.T) {
+		dash := migrate(t, `{
+			"schemaVersion": 27,
+			"panels": [{
+				"id": 1,
+				"type": "singlestat",
+				"title": "Uptime",
+				"gridPos": {"x": 0, "y": 0, "w": 6, "h": 4},
+				"format": "s",
+				"valueName": "current",
+				"thresholds": "10,20",
+				"colors": ["green", "orange", "red"],
+				"colorBackground": true,
+				"sparkline": {"show": true},
+				"valueMaps": [{"value": "null", "op": "=", "text": "N/A"}],
+				"mappingType": 1,
+				"targets": [{"refId": "A", "expr": "up"}]
+			}]
+		}`, testLookup)
+
+		requireJSON(t, `{
+			"id": 1,
+			"type": "stat",
+			"title": "Uptime",
+			"gridPos": {"x": 0, "y": 0, "w": 6, "h": 4},
+			"datasource": {"uid": "prom-uid", "type": "prometheus"},
+			"targets": [{"refId": "A", "expr": "up"}],
+			"options": {
+				"reduceOptions": {"calcs": ["lastNotNull"]},
+				"orientation": "horizontal",
+				"graphMode": "area",
+				"colorMode": "background"
+			},
+			"fieldConfig": {
+				"defaults": {
+					"unit": "s",
+					"thresholds": {"mode": "absolute", "steps": [
+						{"value": null, "color": "green"},
+						{"value": 10, "color": "orange"},
+						{"value": 20, "color": "red"}
+					]},
+					"mappings": [{"type": "special", "options": {"match": "null", "result": {"text": "N/A"}}}]
+				},
+				"overrides": []
+			}
+		}`, dash.Get("panels").GetIndex(0))
+	})
+
+	t.Run("singlestat panels with a gauge are migrated to gauge panels", func(t *testing.T) {
+		dash := migrate(t, `{
+			"schemaVersion": 27,
+			"panels": [{
+				"id": 1,
+				"type": "singlestat",
+				"gauge": {"show": true, "minValue": 0, "maxValue": 100, "thresholdMarkers": true, "thresholdLabels": false}
+			}]
+		}`, nil)
+
+		panel := dash.Get("panels").GetIndex(0)
+		require.Equal(t, "gauge", panel.Get("type").MustString())
+		requireJSON(t, `{"min": 0, "max": 100}`, panel.Get("fieldConfig").Get("defaults"))
+		require.True(t, panel.Get("options").Get("showThresholdMarkers").MustBool())
+		require.False(t, panel.Get("options").Get("showThresholdLabels").MustBool(true))
+	})
+
+	t.Run("value mappings are collected in a value map", func(t *testing.T) {
+		dash := migrate(t, `{
+			"schemaVersion": 29,
+			"panels": [{
+				"id": 1,
+				"type": "stat",
+				"fieldConfig": {
+					"defaults": {
+						"thresholds": {"mode": "absolute", "steps": [{"value": null, "color": "green"}, {"value": 80, "color": "red"}]},
+						"mappings": [
+							{"id": 0, "type": 1, "value": "1", "text": "90"},
+							{"id": 1, "type": 2, "from": "5", "to": "10", "text": "low"},
+							{"id": 2, "type": 1, "value": "null", "text": "none"}
+						]
+					},
+					"overrides": []
+				},
+				"options": {}
+			}]
+		}`, nil)
+
+		requireJSON(t, `[
+			{"type": "value", "options": {"1": {"text": "90", "color": "red"}}},
+			{"type": "range", "options": {"from": 5, "to": 10, "result": {"text": "low"}}},
+			{"type": "special", "options": {"match": "null", "result": {"text": "none"}}}
+		]`, dash.Get("panels").GetIndex(0).Get("fieldConfig").Get("defaults").Get("mappings"))
+	})
+
+	t.Run("data source names are replaced with references", func(t *testing.T) {
+		dash := migrate(t, `{
+			"schemaVersion": 32,
+			"annotations": {"list": [{"name": "Annotations", "datasource": "-- Grafana --"}]},
+			"templating": {"list": [
+				{"name": "ds", "type": "datasource", "current": {"value": "Influx"}},
+				{"name": "q", "type": "query", "datasource": null}
+			]},
+			"panels": [
+				{"id": 1, "type": "timeseries", "datasource": "Influx", "targets": [{"refId": "A"}]},
+				{"id": 2, "type": "timeseries", "datasource": "$ds", "targets": [{"refId": "A"}]},
+				{"id": 3, "type": "timeseries", "datasource": null, "targets": [{"refId": "A", "datasource": null}]},
+				{"id": 4, "type": "timeseries", "datasource": "missing", "targets": [{"refId": "A", "datasource": "Influx"}]}
+			]
+		}`, testLookup)
+
+		requireJSON(t, `{"uid": "grafana", "type": "datasource"}`, dash.Get("annotations").Get("list").GetIndex(0).Get("datasource"))
+		requireJSON(t, `{"uid": "prom-uid", "type": "prometheus"}`, dash.Get("templating").Get("list").GetIndex(1).Get("datasource"))
+
+		panels := dash.Get("panels")
+		requireJSON(t, `{"uid": "influx-uid", "type": "influxdb"}`, panels.GetIndex(0).Get("datasource"))
+		requireJSON(t, `{"uid": "$ds", "type": "influxdb"}`, panels.GetIndex(1).Get("datasource"))
+		requireJSON(t, `{"uid": "prom-uid", "type": "prometheus"}`, panels.GetIndex(2).Get("datasource"))
+		requireJSON(t, `{"uid": "prom-uid", "type": "prometheus"}`, panels.GetIndex(2).Get("targets").GetIndex(0).Get("datasource"))
+		requireJSON(t, `{"uid": "missing"}`, panels.GetIndex(3).Get("datasource"))
+		requireJSON(t, `{"uid": "influx-uid", "type": "influxdb"}`, panels.GetIndex(3).Get("targets").GetIndex(0).Get("datasource"))
+	})
+
+	t.Run("CloudWatch queries with many statistics are split", func(t *testing.T) {
+		dash := migrate(t, `{
+			"schemaVersion": 33,
+			"annotations": {"list": [
+				{"name": "Alarms", "dimensions": {}, "namespace": "AWS/EC2", "region": "us-east-1", "prefixMatching": false, "statistics": ["Max", "Min"]}
+			]},
+			"panels": [{
+				"id": 1,
+				"type": "timeseries",
+				"datasource": {"uid": "cw", "type": "cloudwatch"},
+				"targets": [
+					{"refId": "A", "dimensions": {}, "namespace": "AWS/EC2", "region": "us-east-1", "metricName": "CPU", "statistics": ["Average", "Maximum"], "datasource": {"uid": "cw", "type": "cloudwatch"}}
+				]
+			}]
+		}`, nil)
+
+		requireJSON(t, `[
+			{"refId": "A", "dimensions": {}, "namespace": "AWS/EC2", "region": "us-east-1", "metricName": "CPU", "statistic": "Average", "metricQueryType": 0, "metricEditorMode": 0, "datasource": {"uid": "cw", "type": "cloudwatch"}},
+			{"refId": "B", "dimensions": {}, "namespace": "AWS/EC2", "region": "us-east-1", "metricName": "CPU", "statistic": "Maximum", "metricQueryType": 0, "metricEditorMode": 0, "datasource": {"uid": "cw", "type": "cloudwatch"}}
+		]`, dash.Get("panels").GetIndex(0).Get("targets"))
+
+		annotations := dash.Get("annotations").Get("list")
+		require.Len(t, annotations.MustArray(), 2)
+		require.Equal(t, "Alarms - Max", annotations.GetIndex(0).Get("name").MustString())
+		require.Equal(t, "Max", annotations.GetIndex(0).Get("statistic").MustString())
+		require.Equal(t, "Alarms - Min", annotations.GetIndex(1).Get("name").MustString())
+		require.Equal(t, "Min", annotations.GetIndex(1).Get("statistic").MustString())
+	})
+
+	t.Run("variables are migrated", func(t *testing.T) {
+		dash := migrate(t, `{
+			"schemaVersion": 22,
+			"templating": {"list": [
+				{"name": "c", "type": "constant", "query": "prod", "hide": 0},
+				{"name": "m", "type": "custom", "multi": true, "current": {"text": "a", "value": "a"}},
+				{"name": "q", "type": "query", "refresh": 0, "options": [{"text": "a", "value": "a"}], "useTags": true, "tagsQuery": "tags"}
+			]}
+		}`, nil)
+
+		requireJSON(t, `[
+			{"name": "c", "type": "textbox", "query": "prod", "hide": 0,
+				"current": {"selected": true, "text": "prod", "value": "prod"},
+				"options": [{"selected": true, "text": "prod", "value": "prod"}]},
+			{"name": "m", "type": "custom", "multi": true, "current": {"text": ["a"], "value": ["a"]}},
+			{"name": "q", "type": "query", "refresh": 1, "options": []}
+		]`, dash.Get("templating").Get("list"))
+	})
+
+	t.Run("panels of collapsed rows are migrated", func(t *testing.T) {
+		dash := migrate(t, `{
+			"schemaVersion": 29,
+			"panels": [{
+				"id": 1,
+				"type": "row",
+				"collapsed": true,
+				"panels": [{
+					"id": 2,
+					"type": "timeseries",
+					"options": {"tooltipOptions": {"mode": "single"}},
+					"transformations": [{"id": "labelsToFields", "options": {}}]
+				}]
+			}]
+		}`, nil)
+
+		panel := dash.Get("panels").GetIndex(0).Get("panels").GetIndex(0)
+		requireJSON(t, `{"tooltip": {"mode": "single"}}`, panel.Get("options"))
+		requireJSON(t, `[{"id": "labelsToFields", "options": {}}, {"id": "merge", "options": {}}]`, panel.Get("transformations"))
+	})
+}
+
+func TestDataSourceLookup(t *testing.T) {
+	require.Equal(t, &DataSourceRef{UID: "prom-uid", Type: "prometheus"}, testLookup(""))
+	require.Equal(t, &DataSourceRef{UID: "prom-uid", Type: "prometheus"}, testLookup("default"))
+	require.Equal(t, &DataSourceRef{UID: "influx-uid", Type: "influxdb"}, testLookup("Influx"))
+	require.Equal(t, &DataSourceRef{UID: "influx-uid", Type: "influxdb"}, testLookup("influx-uid"))
+	require.Equal(t, &DataSourceRef{UID: "-- Mixed --", Type: "datasource"}, testLookup("-- Mixed --"))
+	require.Nil(t, testLookup("missing"))
+
+	noDefault := NewDataSourceLookup(nil)
+	require.Equal(t, &DataSourceRef{UID: "grafana", Type: "datasource"}, noDefault(""))
+}
@@ -0,0 +1,904 @@
+package dashmigrations
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// migrations are the migrations of every schemaVersion, in order. The migrations of a version run when the
+// dashboard is older than the version.
+var migrations = []migration{
+	{version: 2, dashboard: migrateServices, panel: migrateGraphiteGraph},
+	{version: 3, dashboard: initNextPanelID, panel: ensurePanelID},
+	{version: 4, panel: migrateAliasYAxis},
+	{version: 6, dashboard: migratePulldownsAndVariables},
+	{version: 7, dashboard: migrateNav, panel: ensureRefIDs},
+	{version: 8, panel: migrateInfluxQueries},
+	{version: 9, panel: migrateSinglestatThresholds},
+	{version: 10, panel: migrateTableStyleThresholds},
+	{version: 12, dashboard: migrateVariableRefreshAndHide, panel: migrateGraphAxes},
+	{version: 13, panel: migrateGraphThresholds},
+	{version: 14, dashboard: migrateSharedCrosshair},
+	{version: 16, dashboard: (*migrator).upgradeToGridLayout},
+	{version: 17, panel: migrateMinSpan},
+	{version: 18, panel: migrateGaugeOptions},
+	{version: 19, panel: migratePanelLinks},
+	{version: 20, panel: migrateDataLinkVariables},
+	{version: 21, panel: migrateDataLinkSeriesLabels},
+	{version: 22, panel: migrateTableStyleAlign},
+	{version: 23, dashboard: alignVariablesCurrentWithMulti},
+	{version: 24, panel: migrateAngularTable},
+	{version: 26, panel: migrateText2},
+	{version: 27, dashboard: migrateConstantVariables},
+	{version: 28, dashboard: removeVariableTags, panel: migrateSinglestat},
+	{version: 29, dashboard: migrateQueryVariableRefresh},
+	{version: 30, panel: migrateValueMappingsAndTooltip},
+	{version: 31, panel: appendMergeAfterLabelsToFields},
+	{version: 33, panel: migratePanelDataSources},
+	{version: 34, dashboard: migrateCloudWatchAnnotations, panel: migrateCloudWatchQueries},
+	{version: 35, panel: ensureXAxisVisibility},
+	{version: 36, dashboard: migrateDefaultDataSources, panel: migrateDefaultPanelDataSources},
+}
+
+func migrateServices(m *migrator) {
+	services, _ := m.dash["services"].(map[string]interface{})
+	delete(m.dash, "services")
+	filter, _ := services["filter"].(map[string]interface{})
+	if !truthy(filter) {
+		return
+	}
+	if t, ok := filter["time"]; ok {
+		m.dash["time"] = t
+	} else {
+		delete(m.dash, "time")
+	}
+	list, ok := filter["list"].([]interface{})
+	if !ok {
+		list = []interface{}{}
+	}
+	m.dash["templating"].(map[string]interface{})["list"] = list
+}
+
+func migrateGraphiteGraph(_ *migrator, panel map[string]interface{}) map[string]interface{} {
+	if panel["type"] == "graphite" {
+		panel["type"] = "graph"
+	}
+	if panel["type"] != "graph" {
+		return panel
+	}
+
+	if legend, ok := panel["legend"].(bool); ok {
+		panel["legend"] = map[string]interface{}{"show": legend}
+	}
+
+	if grid, ok := panel["grid"].(map[string]interface{}); ok {
+		if truthy(grid["min"]) {
+			grid["leftMin"] = grid["min"]
+			delete(grid, "min")
+		}
+		if truthy(grid["max"]) {
+			grid["leftMax"] = grid["max"]
+			delete(grid, "max")
+		}
+	}
+
+	for i, key := range []string{"y_format", "y2_format"} {
+		if !truthy(panel[key]) {
+			continue
+		}
+		formats, _ := panel["y_formats"].([]interface{})
+		for len(formats) <= i {
+			formats = append(formats, nil)
+		}
+		formats[i] = panel[key]
+		panel["y_formats"] = formats
+		delete(panel, key)
+	}
+
+	return panel
+}
+
+// initNextPanelID sets the ID of the panels without one to come after the IDs of the panels of the dashboard.
+func initNextPanelID(m *migrator) {
+	var maxID float64
+	for _, panel := range objects(m.panels()) {
+		if id, ok := number(panel["id"]); ok && id > maxID {
+			maxID = id
+		}
+		for _, rowPanel := range objects(panel["panels"]) {
+			if id, ok := number(rowPanel["id"]); ok && id > maxID {
+				maxID = id
+			}
+		}
+	}
+	m.nextPanelID = int64(maxID) + 1
+}
+
+func ensurePanelID(m *migrator, panel map[string]interface{}) map[string]interface{} {
+	if !truthy(panel["id"]) {
+		panel["id"] = m.nextPanelID
+		m.nextPanelID++
+	}
+	return panel
+}
+
+func migrateAliasYAxis(_ *migrator, panel map[string]interface{}) map[string]interface{} {
+	if panel["type"] != "graph" {
+		return panel
+	}
+	if aliasYAxis, ok := panel["aliasYAxis"].(map[string]interface{}); ok {
+		// only the last alias is kept
+		for _, alias := range sortedKeys(aliasYAxis) {
+			panel["seriesOverrides"] = []interface{}{
+				map[string]interface{}{"alias": alias, "yaxis": aliasYAxis[alias]},
+			}
+		}
+	}
+	delete(panel, "aliasYAxis")
+	return panel
+}
+
+func migratePulldownsAndVariables(m *migrator) {
+	for _, pulldown := range objects(m.dash["pulldowns"]) {
+		if pulldown["type"] != "annotations" {
+			continue
+		}
+		list, ok := pulldown["annotations"].([]interface{})
+		if !ok {
+			list = []interface{}{}
+		}
+		m.dash["annotations"] = map[string]interface{}{"list": list}
+		break
+	}
+	delete(m.dash, "pulldowns")
+
+	for _, variable := range m.variables() {
+		if _, ok := variable["datasource"]; !ok {
+			variable["datasource"] = nil
+		}
+		if t, ok := variable["type"]; !ok || t == "filter" {
+			variable["type"] = "query"
+		}
+		if _, ok := variable["allFormat"]; !ok {
+			variable["allFormat"] = "glob"
+		}
+	}
+}
+
+func migrateNav(m *migrator) {
+	if nav, ok := m.dash["nav"].([]interface{}); ok && len(nav) > 0 {
+		m.dash["timepicker"] = nav[0]
+	}
+	delete(m.dash, "nav")
+}
+
+func ensureRefIDs(_ *migrator, panel map[string]interface{}) map[string]interface{} {
+	targets := objects(panel["targets"])
+	for _, target := range targets {
+		if !truthy(target["refId"]) {
+			target["refId"] = nextRefID(targets)
+		}
+	}
+	return panel
+}
+
+// nextRefID returns the first refId that no query has: A to Z, then AA, AB and so on.
+func nextRefID(queries []map[string]interface{}) string {
+	for n := 0; ; n++ {
+		refID := refIDOf(n)
+		used := false
+		for _, q := range queries {
+			if q["refId"] == refID {
+				used = true
+				break
+			}
+		}
+		if !used {
+			return refID
+		}
+	}
+}
+
+func refIDOf(n int) string {
+	const letters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	if n < len(letters) {
+		return letters[n : n+1]
+	}
+	return refIDOf(n/len(letters)-1) + letters[n%len(letters):n%len(letters)+1]
+}
+
+func migrateInfluxQueries(_ *migrator, panel map[string]interface{}) map[string]interface{} {
+	for _, target := range objects(panel["targets"]) {
+		if !truthy(target["fields"]) || !truthy(target["tags"]) || !truthy(target["groupBy"]) {
+			continue
+		}
+		if truthy(target["rawQuery"]) {
+			delete(target, "fields")
+			delete(target, "fill")
+			continue
+		}
+
+		fields := objects(target["fields"])
+		selects := make([]interface{}, 0, len(fields))
+		for _, field := range fields {
+			parts := []interface{}{
+				map[string]interface{}{"type": "field", "params": []interface{}{field["name"]}},
+				map[string]interface{}{"type": field["func"], "params": []interface{}{}},
+			}
+			if truthy(field["mathExpr"]) {
+				parts = append(parts, map[string]interface{}{"type": "math", "params": []interface{}{field["mathExpr"]}})
+			}
+			if truthy(field["asExpr"]) {
+				parts = append(parts, map[string]interface{}{"type": "alias", "params": []interface{}{field["asExpr"]}})
+			}
+			selects = append(selects, parts)
+		}
+		target["select"] = selects
+		delete(target, "fields")
+
+		for _, part := range objects(target["groupBy"]) {
+			if part["type"] == "time" && truthy(part["interval"]) {
+				part["params"] = []interface{}{part["interval"]}
+				delete(part, "interval")
+			}
+			if part["type"] == "tag" && truthy(part["key"]) {
+				part["params"] = []interface{}{part["key"]}
+				delete(part, "key")
+			}
+		}
+
+		if truthy(target["fill"]) {
+			if groupBy, ok := target["groupBy"].([]interface{}); ok {
+				target["groupBy"] = append(groupBy, map[string]interface{}{
+					"type": "fill", "params": []interface{}{target["fill"]},
+				})
+			}
+			delete(target, "fill")
+		}
+	}
+	return panel
+}
+
+func migrateSinglestatThresholds(_ *migrator, panel map[string]interface{}) map[string]interface{} {
+	if panel["type"] != "singlestat" && panel["thresholds"] != "" {
+		return panel
+	}
+	if thresholds, ok := panel["thresholds"].(string); ok && thresholds != "" {
+		if k := strings.Split(thresholds, ","); len(k) >= 3 {
+			panel["thresholds"] = strings.Join(k[1:], ",")
+		}
+	}
+	return panel
+}
+
+func migrateTableStyleThresholds(_ *migrator, panel map[string]interface{}) map[string]interface{} {
+	if panel["type"] != "table" {
+		return panel
+	}
+	for _, style := range objects(panel["styles"]) {
+		if thresholds, ok := style["thresholds"].([]interface{}); ok && len(thresholds) >= 3 {
+			style["thresholds"] = thresholds[1:]
+		}
+	}
+	return panel
+}
+
+func migrateVariableRefreshAndHide(m *migrator) {
+	for _, variable := range m.variables() {
+		if truthy(variable["refresh"]) {
+			variable["refresh"] = 1
+		} else {
+			variable["refresh"] = 0
+		}
+		if truthy(variable["hideVariable"]) {
+			variable["hide"] = 2
+		} else if truthy(variable["hideLabel"]) {
+			variable["hide"] = 1
+		}
+	}
+}
+
+func migrateGraphAxes(_ *migrator, panel map[string]interface{}) map[string]interface{} {
+	if panel["type"] != "graph" {
+		return panel
+	}
+	grid, ok := panel["grid"].(map[string]interface{})
+	if !ok || truthy(panel["yaxes"]) {
+		return panel
+	}
+
+	formats, _ := panel["y_formats"].([]interface{})
+	axis := func(min, max, logBase string, format int, label string) map[string]interface{} {
+		a := map[string]interface{}{}
+		copyIfPresent(a, panel, map[string]string{"show": "y-axis", "label": label})
+		copyIfPresent(a, grid, map[string]string{"min": min, "max": max, "logBase": logBase})
+		if format < len(formats) {
+			a["format"] = formats[format]
+		}
+		return a
+	}
+	panel["yaxes"] = []interface{}{
+		axis("leftMin", "leftMax", "leftLogBase", 0, "leftYAxisLabel"),
+		axis("rightMin", "rightMax", "rightLogBase", 1, "rightYAxisLabel"),
+	}
+	xaxis := map[string]interface{}{}
+	copyIfPresent(xaxis, panel, map[string]string{"show": "x-axis"})
+	panel["xaxis"] = xaxis
+
+	for _, key := range []string{"leftMin", "leftMax", "leftLogBase", "rightMin", "rightMax", "rightLogBase"} {
+		delete(grid, key)
+	}
+	for _, key := range []string{"y_formats", "leftYAxisLabel", "rightYAxisLabel", "y-axis", "x-axis"} {
+		delete(panel, key)
+	}
+	return panel
+}
+
+func migrateGraphThresholds(_ *migrator, panel map[string]interface{}) map[string]interface{} {
+	if panel["type"] != "graph" {
+		return panel
+	}
+	grid, ok := panel["grid"].(map[string]interface{})
+	if !ok {
+		return panel
+	}
+
+	thresholds, ok := panel["thresholds"].([]interface{})
+	if !ok {
+		thresholds = []interface{}{}
+	}
+	threshold := func(value, color string) map[string]interface{} {
+		if _, ok := number(grid[value]); !ok {
+			return nil
+		}
+		t := map[string]interface{}{"value": grid[value], "colorMode": "custom"}
+		if truthy(grid["thresholdLine"]) {
+			t["line"] = true
+			t["lineColor"] = grid[color]
+		} else {
+			t["fill"] = true
+			t["fillColor"] = grid[color]
+		}
+		return t
+	}
+	t1, t2 := threshold("threshold1", "threshold1Color"), threshold("threshold2", "threshold2Color")
+	if t1 != nil {
+		switch {
+		case t2 == nil:
+			t1["op"] = "gt"
+			thresholds = append(thresholds, t1)
+		default:
+			v1, _ := number(t1["value"])
+			v2, _ := number(t2["value"])
+			op := "gt"
+			if v1 > v2 {
+				op = "lt"
+			}
+			t1["op"], t2["op"] = op, op
+			thresholds = append(thresholds, t1, t2)
+		}
+	}
+	panel["thresholds"] = thresholds
+
+	for _, key := range []string{"threshold1", "threshold1Color", "threshold2", "threshold2Color", "thresholdLine"} {
+		delete(grid, key)
+	}
+	return panel
+}
+
+func migrateSharedCrosshair(m *migrator) {
+	if truthy(m.dash["sharedCrosshair"]) {
+		m.dash["graphTooltip"] = 1
+	} else {
+		m.dash["graphTooltip"] = 0
+	}
+	delete(m.dash, "sharedCrosshair")
+}
+
+func migrateMinSpan(_ *migrator, panel map[string]interface{}) map[string]interface{} {
+	if minSpan, ok := number(panel["minSpan"]); ok && minSpan != 0 {
+		max := gridColumnCount / minSpan
+		// the factors of the column count, the best match is the last one not greater than max
+		factors := []int{1, 2, 3, 4, 6, 8, 12, 24}
+		i := sort.Search(len(factors), func(i int) bool { return float64(factors[i]) > max })
+		if i > 0 && i < len(factors) {
+			panel["maxPerRow"] = factors[i-1]
+		}
+	}
+	delete(panel, "minSpan")
+	return panel
+}
+
+func migrateGaugeOptions(_ *migrator, panel map[string]interface{}) map[string]interface{} {
+	options, ok := panel["options-gauge"].(map[string]interface{})
+	if !ok {
+		return panel
+	}
+
+	valueOptions := map[string]interface{}{}
+	copyIfPresent(valueOptions, options, map[string]string{
+		"unit": "unit", "stat": "stat", "decimals": "decimals", "prefix": "prefix", "suffix": "suffix",
+	})
+	options["valueOptions"] = valueOptions
+
+	if thresholds, ok := options["thresholds"].([]interface{}); ok {
+		for i, j := 0, len(thresholds)-1; i < j; i, j = i+1, j-1 {
+			thresholds[i], thresholds[j] = thresholds[j], thresholds[i]
+		}
+	}
+
+	for _, key := range []string{"options", "unit", "stat", "decimals", "prefix", "suffix"} {
+		delete(options, key)
+	}
+	panel["options"] = options
+	delete(panel, "options-gauge")
+	return panel
+}
+
+var slugRemoveChars = regexp.MustCompile(`[^\w ]+`)
+var slugSpaces = regexp.MustCompile(` +`)
+
+func migratePanelLinks(_ *migrator, panel map[string]interface{}) map[string]interface{} {
+	links, ok := panel["links"].([]interface{})
+	if !ok {
+		return panel
+	}
+	for i, l := range links {
+		link, ok := l.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		url, _ := link["url"].(string)
+		if dashboard, ok := link["dashboard"].(string); url == "" && ok && dashboard != "" {
+			url = "dashboard/db/" + slugSpaces.ReplaceAllString(slugRemoveChars.ReplaceAllString(strings.ToLower(dashboard), ""), "-")
+		}
+		if dashURI, ok := link["dashUri"].(string); url == "" && ok && dashURI != "" {
+			url = "dashboard/" + dashURI
+		}
+		// some models are incomplete and have no dashboard or dashUri
+		if url == "" {
+			url = "/"
+		}
+		if truthy(link["keepTime"]) {
+			url = appendQueryToURL(url, "$__url_time_range")
+		}
+		if truthy(link["includeVars"]) {
+			url = appendQueryToURL(url, "$__all_variables")
+		}
+		if params, ok := link["params"].(string); ok {
+			url = appendQueryToURL(url, params)
+		}
+
+		newLink := map[string]interface{}{"url": url}
+		copyIfPresent(newLink, link, map[string]string{"title": "title", "targetBlank": "targetBlank"})
+		links[i] = newLink
+	}
+	return panel
+}
+
+func appendQueryToURL(url string, query string) string {
+	if query == "" {
+		return url
+	}
+	if pos := strings.Index(url, "?"); pos == -1 {
+		url += "?"
+	} else if len(url)-pos > 1 {
+		url += "&"
+	}
+	return url + query
+}
+
+var legacyVariableNames = regexp.MustCompile(`__series_name|\$__series_name|__value_time|__field_name|\$__field_name`)
+
+var legacyVariableReplacements = map[string]string{
+	"__series_name":  "__series.name",
+	"$__series_name": "${__series.name}",
+	"__value_time":   "__value.time",
+	"__field_name":   "__field.name",
+	"$__field_name":  "${__field.name}",
+}
+
+func updateVariablesSyntax(text string) string {
+	return legacyVariableNames.ReplaceAllStringFunc(text, func(match string) string {
+		return legacyVariableReplacements[match]
+	})
+}
+
+var seriesLabels = regexp.MustCompile(`__series.labels`)
+
+// updateDataLinks updates the URLs of the data links of the graph panels and of the panels with field options.
+func updateDataLinks(panel map[string]interface{}, update func(string) string) {
+	options, _ := panel["options"].(map[string]interface{})
+	fieldOptions, _ := options["fieldOptions"].(map[string]interface{})
+	defaults, _ := fieldOptions["defaults"].(map[string]interface{})
+
+	for _, links := range []interface{}{options["dataLinks"], defaults["links"]} {
+		for _, link := range objects(links) {
+			if url, ok := link["url"].(string); ok {
+				link["url"] = update(url)
+			}
+		}
+	}
+}
+
+func migrateDataLinkVariables(_ *migrator, panel map[string]interface{}) map[string]interface{} {
+	updateDataLinks(panel, updateVariablesSyntax)
+
+	options, _ := panel["options"].(map[string]interface{})
+	fieldOptions, _ := options["fieldOptions"].(map[string]interface{})
+	defaults, _ := fieldOptions["defaults"].(map[string]interface{})
+	if title, ok := defaults["title"].(string); ok && title != "" {
+		defaults["title"] = updateVariablesSyntax(title)
+	}
+	return panel
+}
+
+func migrateDataLinkSeriesLabels(_ *migrator, panel map[string]interface{}) map[string]interface{} {
+	updateDataLinks(panel, func(url string) string {
+		return seriesLabels.ReplaceAllString(url, "__field.labels")
+	})
+	return panel
+}
+
+func migrateTableStyleAlign(_ *migrator, panel map[string]interface{}) map[string]interface{} {
+	if panel["type"] != "table" {
+		return panel
+	}
+	for _, style := range objects(panel["styles"]) {
+		style["align"] = "auto"
+	}
+	return panel
+}
+
+func alignVariablesCurrentWithMulti(m *migrator) {
+	for _, variable := range m.variables() {
+		multi, ok := variable["multi"].(bool)
+		if !ok {
+			continue
+		}
+		current, ok := variable["current"].(map[string]interface{})
+		if !ok || !truthy(current) {
+			continue
+		}
+
+		_, isMulti := current["value"].([]interface{})
+		if multi == isMulti {
+			continue
+		}
+		aligned := make(map[string]interface{}, len(current))
+		for k, v := range current {
+			aligned[k] = v
+		}
+		for _, key := range []string{"value", "text"} {
+			if multi {
+				if _, ok := current[key].([]interface{}); !ok {
+					aligned[key] = []interface{}{current[key]}
+				}
+				continue
+			}
+			if values, ok := current[key].([]interface{}); ok {
+				if len(values) > 0 {
+					aligned[key] = values[0]
+				} else {
+					aligned[key] = ""
+				}
+			}
+		}
+		variable["current"] = aligned
+	}
+}
+
+func migrateAngularTable(_ *migrator, panel map[string]interface{}) map[string]interface{} {
+	// tables without styles are assumed to have the default settings
+	if panel["type"] != "table" || !truthy(panel["styles"]) || panel["table"] == "table2" {
+		return panel
+	}
+	panel["type"] = "table-old"
+	return panel
+}
+
+func migrateText2(_ *migrator, panel map[string]interface{}) map[string]interface{} {
+	if panel["type"] != "text2" {
+		return panel
+	}
+	panel["type"] = "text"
+	if options, ok := panel["options"].(map[string]interface{}); ok {
+		delete(options, "angular")
+	}
+	return panel
+}
+
+func migrateConstantVariables(m *migrator) {
+	for _, variable := range m.variables() {
+		if variable["type"] != "constant" {
+			continue
+		}
+		if hide, ok := number(variable["hide"]); ok && (hide == 0 || hide == 1) {
+			variable["type"] = "textbox"
+		}
+
+		query := variable["query"]
+		if query == nil {
+			query = ""
+		}
+		current := map[string]interface{}{"selected": true, "text": query, "value": query}
+		variable["current"] = current
+		variable["options"] = []interface{}{current}
+	}
+}
+
+func removeVariableTags(m *migrator) {
+	for _, variable := range m.variables() {
+		for _, key := range []string{"tags", "tagsQuery", "tagValuesQuery", "useTags"} {
+			if truthy(variable[key]) {
+				delete(variable, key)
+			}
+		}
+	}
+}
+
+func migrateQueryVariableRefresh(m *migrator) {
+	for _, variable := range m.variables() {
+		if variable["type"] != "query" {
+			continue
+		}
+		if refresh, _ := number(variable["refresh"]); refresh != 1 && refresh != 2 {
+			variable["refresh"] = 1
+		}
+		if options, ok := variable["options"].([]interface{}); ok && len(options) > 0 {
+			variable["options"] = []interface{}{}
+		}
+	}
+}
+
+func migrateValueMappingsAndTooltip(_ *migrator, panel map[string]interface{}) map[string]interface{} {
+	if fieldConfig, ok := panel["fieldConfig"].(map[string]interface{}); ok {
+		if defaults, ok := fieldConfig["defaults"].(map[string]interface{}); ok && truthy(defaults["mappings"]) {
+			thresholds, _ := defaults["thresholds"].(map[string]interface{})
+			defaults["mappings"] = upgradeValueMappings(defaults["mappings"], thresholds)
+		}
+		for _, override := range objects(fieldConfig["overrides"]) {
+			for _, prop := range objects(override["properties"]) {
+				if prop["id"] == "mappings" {
+					prop["value"] = upgradeValueMappings(prop["value"], nil)
+				}
+			}
+		}
+	}
+
+	if panel["type"] == "timeseries" || panel["type"] == "xychart" {
+		if options, ok := panel["options"].(map[string]interface{}); ok && truthy(options["tooltipOptions"]) {
+			options["tooltip"] = options["tooltipOptions"]
+			delete(options, "tooltipOptions")
+		}
+	}
+	return panel
+}
+
+func appendMergeAfterLabelsToFields(_ *migrator, panel map[string]interface{}) map[string]interface{} {
+	transformations, ok := panel["transformations"].([]interface{})
+	if !ok {
+		return panel
+	}
+	hasLabelsToFields := false
+	for _, t := range objects(transformations) {
+		if t["id"] == "labelsToFields" {
+			hasLabelsToFields = true
+		}
+	}
+	if !hasLabelsToFields {
+		return panel
+	}
+
+	result := make([]interface{}, 0, len(transformations)+1)
+	for _, t := range transformations {
+		result = append(result, t)
+		if obj, ok := t.(map[string]interface{}); ok && obj["id"] == "labelsToFields" {
+			result = append(result, map[string]interface{}{"id": "merge", "options": map[string]interface{}{}})
+		}
+	}
+	panel["transformations"] = result
+	return panel
+}
+
+func migratePanelDataSources(m *migrator, panel map[string]interface{}) map[string]interface{} {
+	ds, present := panel["datasource"]
+	panel["datasource"] = m.dataSourceRef(ds, present, true)
+
+	for _, target := range objects(panel["targets"]) {
+		ds, present := target["datasource"]
+		if ref := m.dataSourceRef(ds, present, true); ref != nil {
+			target["datasource"] = ref
+		}
+	}
+	return panel
+}
+
+func isCloudWatchQuery(target map[string]interface{}) bool {
+	return hasKeys(target, "dimensions", "namespace", "region", "metricName")
+}
+
+func isLegacyCloudWatchAnnotation(annotation map[string]interface{}) bool {
+	return hasKeys(annotation, "dimensions", "namespace", "region", "prefixMatching", "statistics")
+}
+
+func hasKeys(obj map[string]interface{}, keys ...string) bool {
+	for _, key := range keys {
+		if _, ok := obj[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// migrateCloudWatchQueries splits the CloudWatch queries with more than one statistic into one query per statistic.
+// The new queries are put at the end of the targets.
+func migrateCloudWatchQueries(_ *migrator, panel map[string]interface{}) map[string]interface{} {
+	targets, _ := panel["targets"].([]interface{})
+	queries := objects(targets)
+	for _, target := range objects(targets) {
+		if !isCloudWatchQuery(target) {
+			continue
+		}
+
+		if _, ok := target["metricQueryType"]; !ok {
+			target["metricQueryType"] = 0 // search
+		}
+		if _, ok := target["metricEditorMode"]; !ok {
+			mode := 0 // builder
+			if queryType, _ := number(target["metricQueryType"]); queryType == 1 || truthy(target["expression"]) {
+				mode = 1 // code
+			}
+			target["metricEditorMode"] = mode
+		}
+
+		if _, ok := target["statistics"]; !ok {
+			continue
+		}
+		if statistics, ok := target["statistics"].([]interface{}); ok && len(statistics) > 0 {
+			target["statistic"] = statistics[0]
+			for _, stat := range statistics[1:] {
+				query := make(map[string]interface{}, len(target))
+				for k, v := range target {
+					query[k] = v
+				}
+				delete(query, "statistics")
+				query["statistic"] = stat
+				query["refId"] = nextRefID(queries)
+				queries = append(queries, query)
+				targets = append(targets, query)
+			}
+		}
+		delete(target, "statistics")
+	}
+	if targets != nil {
+		panel["targets"] = targets
+	}
+	return panel
+}
+
+// migrateCloudWatchAnnotations splits the CloudWatch annotations with more than one statistic into one annotation per
+// statistic, named after the statistic.
+func migrateCloudWatchAnnotations(m *migrator) {
+	annotations := m.dash["annotations"].(map[string]interface{})
+	list, _ := annotations["list"].([]interface{})
+	for _, annotation := range objects(list) {
+		if !isLegacyCloudWatchAnnotation(annotation) {
+			continue
+		}
+		statistics, ok := annotation["statistics"].([]interface{})
+		if !ok || len(statistics) == 0 {
+			continue
+		}
+
+		name := annotation["name"]
+		for _, stat := range statistics[1:] {
+			newAnnotation := make(map[string]interface{}, len(annotation))
+			for k, v := range annotation {
+				if k != "statistics" {
+					newAnnotation[k] = v
+				}
+			}
+			newAnnotation["statistic"] = stat
+			newAnnotation["name"] = jsString(name) + " - " + jsString(stat)
+			list = append(list, newAnnotation)
+		}
+		annotation["statistic"] = statistics[0]
+		if len(statistics) > 1 {
+			annotation["name"] = jsString(name) + " - " + jsString(statistics[0])
+		}
+		delete(annotation, "statistics")
+	}
+	annotations["list"] = list
+}
+
+// jsString formats a value in a template string as JavaScript does.
+func jsString(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return "undefined"
+	case string:
+		return value
+	case bool:
+		return strconv.FormatBool(value)
+	}
+	if n, ok := number(v); ok {
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	return ""
+}
+
+// ensureXAxisVisibility keeps the time axis of the time series panels with all their axes hidden visible.
+func ensureXAxisVisibility(_ *migrator, panel map[string]interface{}) map[string]interface{} {
+	if panel["type"] != "timeseries" {
+		return panel
+	}
+	fieldConfig, _ := panel["fieldConfig"].(map[string]interface{})
+	defaults, _ := fieldConfig["defaults"].(map[string]interface{})
+	custom, _ := defaults["custom"].(map[string]interface{})
+	if custom["axisPlacement"] != "hidden" {
+		return panel
+	}
+
+	overrides, ok := fieldConfig["overrides"].([]interface{})
+	if !ok {
+		overrides = []interface{}{}
+	}
+	fieldConfig["overrides"] = append(overrides, map[string]interface{}{
+		"matcher": map[string]interface{}{"id": "byType", "options": "time"},
+		"properties": []interface{}{
+			map[string]interface{}{"id": "custom.axisPlacement", "value": "auto"},
+		},
+	})
+	return panel
+}
+
+func migrateDefaultDataSources(m *migrator) {
+	for _, annotation := range m.annotations() {
+		ds, present := annotation["datasource"]
+		annotation["datasource"] = m.dataSourceRef(ds, present, false)
+	}
+
+	defaultDS := m.defaultDataSource()
+	if defaultDS == nil {
+		return
+	}
+	for _, variable := range m.variables() {
+		if ds, ok := variable["datasource"]; ok && ds == nil && variable["type"] == "query" {
+			variable["datasource"] = defaultDS.toMap()
+		}
+	}
+}
+
+// migrateDefaultPanelDataSources replaces the null data sources of the panels and their queries with the default
+// data source. The panels without data source take the data source of their queries, if any.
+func migrateDefaultPanelDataSources(m *migrator, panel map[string]interface{}) map[string]interface{} {
+	defaultDS := m.defaultDataSource()
+	if defaultDS == nil {
+		return panel
+	}
+	targets, ok := panel["targets"].([]interface{})
+	if !ok {
+		return panel
+	}
+
+	panelDataSourceWasDefault := false
+	if panel["datasource"] == nil && len(targets) > 0 {
+		panel["datasource"] = defaultDS.toMap()
+		panelDataSourceWasDefault = true
+	}
+	for _, target := range objects(targets) {
+		ds, present := target["datasource"]
+		if truthy(ds) && panelDataSourceWasDefault {
+			// the default data source may have changed, the data source of the queries is the one to keep
+			panel["datasource"] = ds
+		}
+		if present && ds == nil {
+			target["datasource"] = defaultDS.toMap()
+		}
+	}
+	return panel
+}
@@ -0,0 +1,341 @@
+package dashmigrations
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// singlestatKeptProps are the properties of the singlestat panels that the stat and gauge panels they are migrated
+// to keep. The other properties are options of the singlestat panel.
+var singlestatKeptProps = map[string]bool{
+	"id": true, "gridPos": true, "type": true, "title": true, "scopedVars": true, "repeat": true,
+	"repeatIteration": true, "repeatPanelId": true, "repeatDirection": true, "repeatedByRow": true, "minSpan": true,
+	"collapsed": true, "panels": true, "targets": true, "datasource": true, "timeFrom": true, "timeShift": true,
+	"hideTimeOverride": true, "description": true, "links": true, "cacheTimeout": true, "transparent": true,
+	"pluginVersion": true, "transformations": true, "fieldConfig": true, "maxDataPoints": true, "interval": true,
+	"libraryPanel": true,
+}
+
+// reducerIDs are the IDs of the reducers of the stat and gauge panels, and of their aliases.
+var reducerIDs = map[string]string{
+	"sum": "sum", "max": "max", "min": "min", "logmin": "logmin", "mean": "mean", "last": "last", "first": "first",
+	"count": "count", "range": "range", "diff": "diff", "diffperc": "diffperc", "delta": "delta", "step": "step",
+	"firstNotNull": "firstNotNull", "lastNotNull": "lastNotNull", "changeCount": "changeCount",
+	"distinctCount": "distinctCount", "allIsZero": "allIsZero", "allIsNull": "allIsNull", "allValues": "allValues",
+	"uniqueValues": "uniqueValues",
+	"current":      "lastNotNull", "avg": "mean", "total": "sum",
+}
+
+// migrateSinglestat migrates the singlestat panels, which have been removed, to gauge panels if they show a gauge
+// and to stat panels otherwise.
+func migrateSinglestat(_ *migrator, panel map[string]interface{}) map[string]interface{} {
+	if panel["type"] != "singlestat" {
+		return panel
+	}
+
+	old := map[string]interface{}{}
+	newPanel := map[string]interface{}{}
+	for k, v := range panel {
+		if singlestatKeptProps[k] {
+			newPanel[k] = v
+		} else {
+			old[k] = v
+		}
+	}
+
+	gauge, _ := old["gauge"].(map[string]interface{})
+	isGauge := truthy(gauge["show"])
+	if isGauge {
+		newPanel["type"] = "gauge"
+	} else {
+		newPanel["type"] = "stat"
+	}
+
+	calc := "mean"
+	if valueName, ok := old["valueName"].(string); ok && reducerIDs[valueName] != "" {
+		calc = reducerIDs[valueName]
+	}
+	reduceOptions := map[string]interface{}{"calcs": []interface{}{calc}}
+	if tableColumn, ok := old["tableColumn"].(string); ok && tableColumn != "" {
+		reduceOptions["fields"] = "/^" + tableColumn + "$/"
+	}
+	options := map[string]interface{}{
+		"reduceOptions": reduceOptions,
+		"orientation":   "horizontal",
+	}
+
+	defaults := map[string]interface{}{}
+	if truthy(old["format"]) {
+		defaults["unit"] = old["format"]
+	}
+	if truthy(old["nullPointMode"]) {
+		defaults["nullValueMode"] = old["nullPointMode"]
+	}
+	if truthy(old["nullText"]) {
+		defaults["noValue"] = old["nullText"]
+	}
+	if decimals, ok := old["decimals"]; ok && (truthy(decimals) || isZero(decimals)) {
+		defaults["decimals"] = decimals
+	}
+
+	// one more color than thresholds, the first step has no value
+	thresholds, hasThresholds := old["thresholds"].(string)
+	colors, hasColors := old["colors"].([]interface{})
+	var migratedThresholds map[string]interface{}
+	if hasThresholds && thresholds != "" && hasColors {
+		levels := strings.Split(thresholds, ",")
+		steps := make([]interface{}, 0, len(colors))
+		for i, color := range colors {
+			var value interface{}
+			if i > 0 && i-1 < len(levels) {
+				value = toNumber(strings.TrimSpace(levels[i-1]))
+			}
+			steps = append(steps, map[string]interface{}{"value": value, "color": color})
+		}
+		migratedThresholds = map[string]interface{}{"mode": "absolute", "steps": steps}
+		defaults["thresholds"] = migratedThresholds
+	}
+
+	if mappings := convertOldAngularValueMappings(old, migratedThresholds); len(mappings) > 0 {
+		defaults["mappings"] = mappings
+	}
+
+	if isGauge {
+		copyIfPresent(defaults, gauge, map[string]string{"min": "minValue", "max": "maxValue"})
+	}
+
+	fieldConfig, _ := panel["fieldConfig"].(map[string]interface{})
+	newPanel["fieldConfig"] = map[string]interface{}{
+		"defaults":  defaults,
+		"overrides": standardOverrides(fieldConfig["overrides"]),
+	}
+
+	if isGauge {
+		if gauge != nil {
+			copyIfPresent(options, gauge, map[string]string{
+				"showThresholdMarkers": "thresholdMarkers",
+				"showThresholdLabels":  "thresholdLabels",
+			})
+		}
+	} else {
+		sparkline, _ := old["sparkline"].(map[string]interface{})
+		options["graphMode"] = "none"
+		if truthy(sparkline["show"]) {
+			options["graphMode"] = "area"
+		}
+
+		switch {
+		case truthy(old["colorBackground"]):
+			options["colorMode"] = "background"
+		case truthy(old["colorValue"]):
+			options["colorMode"] = "value"
+		default:
+			options["colorMode"] = "none"
+			if truthy(sparkline["lineColor"]) && options["graphMode"] == "area" {
+				defaults["color"] = map[string]interface{}{"mode": "fixed", "fixedColor": sparkline["lineColor"]}
+			}
+		}
+
+		if old["valueName"] == "name" {
+			options["textMode"] = "name"
+		}
+	}
+	newPanel["options"] = options
+
+	return newPanel
+}
+
+func isZero(v interface{}) bool {
+	n, ok := number(v)
+	return ok && n == 0
+}
+
+// standardOverrides removes the custom properties of the previous panel type from the overrides of a panel.
+func standardOverrides(v interface{}) []interface{} {
+	result := []interface{}{}
+	for _, override := range objects(v) {
+		props := []interface{}{}
+		for _, prop := range objects(override["properties"]) {
+			if id, _ := prop["id"].(string); !strings.HasPrefix(id, "custom.") {
+				props = append(props, prop)
+			}
+		}
+		if len(props) > 0 {
+			override["properties"] = props
+			result = append(result, override)
+		}
+	}
+	return result
+}
+
+// convertOldAngularValueMappings converts the value and range maps of the singlestat panels to value mappings.
+func convertOldAngularValueMappings(panel map[string]interface{}, migratedThresholds map[string]interface{}) []interface{} {
+	valueMaps, _ := panel["valueMaps"].([]interface{})
+	rangeMaps, _ := panel["rangeMaps"].([]interface{})
+
+	mappingType, _ := number(panel["mappingType"])
+	if !truthy(panel["mappingType"]) {
+		if len(valueMaps) > 0 {
+			mappingType = 1
+		} else if len(rangeMaps) > 0 {
+			mappingType = 2
+		}
+	}
+
+	thresholds := migratedThresholds
+	if fieldConfig, ok := panel["fieldConfig"].(map[string]interface{}); ok {
+		if defaults, ok := fieldConfig["defaults"].(map[string]interface{}); ok {
+			if t, ok := defaults["thresholds"].(map[string]interface{}); ok {
+				thresholds = t
+			}
+		}
+	}
+
+	maps := valueMaps
+	if mappingType == 2 {
+		maps = rangeMaps
+	} else if mappingType != 1 {
+		return nil
+	}
+
+	mappings := []interface{}{}
+	for _, old := range objects(maps) {
+		old["type"] = mappingType
+		if mapping := upgradeValueMapping(old, thresholds, true); mapping != nil {
+			mappings = append(mappings, mapping)
+		}
+	}
+	return mappings
+}
+
+// upgradeValueMappings upgrades the value mappings of the panels older than schemaVersion 30. The value to text
+// mappings are collected in a single value map. It returns nil if there are no mappings.
+func upgradeValueMappings(oldMappings interface{}, thresholds map[string]interface{}) interface{} {
+	if !truthy(oldMappings) {
+		return nil
+	}
+
+	valueOptions := map[string]interface{}{}
+	mappings := []interface{}{}
+	for _, old := range objects(oldMappings) {
+		// the mappings that are already upgraded are kept
+		if truthy(old["type"]) && truthy(old["options"]) {
+			if old["type"] == "value" {
+				if options, ok := old["options"].(map[string]interface{}); ok {
+					for k, v := range options {
+						valueOptions[k] = v
+					}
+				}
+			} else {
+				mappings = append(mappings, old)
+			}
+			continue
+		}
+
+		mapping := upgradeValueMapping(old, thresholds, false)
+		if mapping == nil {
+			continue
+		}
+		if mapping["type"] == "value" {
+			for k, v := range mapping["options"].(map[string]interface{}) {
+				valueOptions[k] = v
+			}
+			continue
+		}
+		mappings = append(mappings, mapping)
+	}
+
+	if len(valueOptions) > 0 {
+		mappings = append([]interface{}{map[string]interface{}{"type": "value", "options": valueOptions}}, mappings...)
+	}
+	return mappings
+}
+
+// upgradeValueMapping upgrades a value to text or range to text mapping. The color of the mapping is the one of the
+// threshold of its text, if it is a number. The ranges with null bounds are special null mappings if
+// nullRanges is set.
+func upgradeValueMapping(old map[string]interface{}, thresholds map[string]interface{}, nullRanges bool) map[string]interface{} {
+	result := map[string]interface{}{}
+	copyIfPresent(result, old, map[string]string{"text": "text"})
+	if text, ok := old["text"].(string); ok && thresholds != nil {
+		if n, ok := parseFloatPrefix(text); ok {
+			if color := activeThresholdColor(n, thresholds["steps"]); color != nil {
+				result["color"] = color
+			}
+		}
+	}
+	nullMapping := map[string]interface{}{
+		"type":    "special",
+		"options": map[string]interface{}{"match": "null", "result": result},
+	}
+
+	mappingType, _ := number(old["type"])
+	switch mappingType {
+	case 1:
+		value, ok := old["value"]
+		if !ok || value == nil {
+			return nil
+		}
+		if value == "null" {
+			return nullMapping
+		}
+		return map[string]interface{}{
+			"type":    "value",
+			"options": map[string]interface{}{jsString(value): result},
+		}
+	case 2:
+		if nullRanges && (old["from"] == "null" || old["to"] == "null") {
+			return nullMapping
+		}
+		return map[string]interface{}{
+			"type": "range",
+			"options": map[string]interface{}{
+				"from":   toNumber(old["from"]),
+				"to":     toNumber(old["to"]),
+				"result": result,
+			},
+		}
+	}
+	return nil
+}
+
+// activeThresholdColor returns the color of the last threshold step that a value reaches. The value of the first
+// step, null in JSON, is -Infinity.
+func activeThresholdColor(value float64, steps interface{}) interface{} {
+	thresholds := objects(steps)
+	if len(thresholds) == 0 {
+		return nil
+	}
+	active := thresholds[0]
+	for _, t := range thresholds {
+		stepValue, ok := number(t["value"])
+		if !ok {
+			stepValue = math.Inf(-1)
+		}
+		if value < stepValue {
+			break
+		}
+		active = t
+	}
+	if !truthy(active["color"]) {
+		return nil
+	}
+	return active["color"]
+}
+
+var floatPrefix = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?`)
+
+// parseFloatPrefix parses the number at the start of a string, as parseFloat does in JavaScript.
+func parseFloatPrefix(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(strings.TrimLeft(s, "+-"), "Infinity") {
+		if strings.HasPrefix(s, "-") {
+			return math.Inf(-1), true
+		}
+		return math.Inf(1), true
+	}
+	n, err := strconv.ParseFloat(floatPrefix.FindString(s), 64)
+	return n, err == nil
+}
@@ -75,6 +75,9 @@ var (
 	// MApiDashboardInsert is a metric dashboards inserted
 	MApiDashboardInsert prometheus.Counter
 
+	// MDashboardSchemaValidationFailures is a metric counter for dashboards saved that are not valid against the dashboard schema
+	MDashboardSchemaValidationFailures *prometheus.CounterVec
+
 	// MAlertingResultState is a metric alert execution result counter
 	MAlertingResultState *prometheus.CounterVec
 
@@ -336,6 +339,12 @@ func init() {
 		Namespace: ExporterName,
 	})
 
+	MDashboardSchemaValidationFailures = newCounterVecStartingAtZero(prometheus.CounterOpts{
+		Name:      "dashboard_schema_validation_failures_total",
+		Help:      "counter for dashboards saved that are not valid against the dashboard schema",
+		Namespace: ExporterName,
+	}, []string{"mode"}, "warn", "enforce")
+
 	MAlertingResultState = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Name:      "alerting_result_total",
 		Help:      "alert execution result counter",
@@ -634,6 +643,7 @@ func initMetricVars() {
 		MApiDashboardSnapshotExternal,
 		MApiDashboardSnapshotGet,
 		MApiDashboardInsert,
+		MDashboardSchemaValidationFailures,
 		MAlertingResultState,
 		MAlertingNotificationSent,
 		MAlertingNotificationFailed,
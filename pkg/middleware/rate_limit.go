@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"sync"
 	"time"
 
 	"github.com/grafana/grafana/pkg/models"
@@ -22,3 +23,36 @@ func RateLimit(rps, burst int, getTime getTimeFn) web.Handler {
 		}
 	}
 }
+
+// KeyRateLimiter is a very basic rate limiter, which limits the requests with each key separately.
+// Will allow average of "rps" requests per second for each key over an extended period of time, with max "burst"
+// requests at the same time. A limiter is kept for every key, so the keys should be validated before being limited.
+type KeyRateLimiter struct {
+	rps      int
+	burst    int
+	getTime  getTimeFn
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// NewKeyRateLimiter returns a KeyRateLimiter. getTime should return the current time. For non-testing purposes use time.Now
+func NewKeyRateLimiter(rps, burst int, getTime getTimeFn) *KeyRateLimiter {
+	return &KeyRateLimiter{
+		rps:      rps,
+		burst:    burst,
+		getTime:  getTime,
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+// Allow reports whether a request with the key may happen now.
+func (l *KeyRateLimiter) Allow(key string) bool {
+	l.mu.Lock()
+	limiter, ok := l.limiters[key]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(l.rps), l.burst)
+		l.limiters[key] = limiter
+	}
+	l.mu.Unlock()
+	return limiter.AllowN(l.getTime(), 1)
+}
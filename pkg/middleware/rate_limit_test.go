@@ -86,3 +86,19 @@ func TestRateLimitMiddleware(t *testing.T) {
 		}
 	})
 }
+
+func TestKeyRateLimiter(t *testing.T) {
+	currentTime := time.Now()
+	l := NewKeyRateLimiter(10, 2, func() time.Time { return currentTime })
+
+	assert.True(t, l.Allow("a"))
+	assert.True(t, l.Allow("a"))
+	assert.False(t, l.Allow("a"))
+
+	// other keys are limited separately
+	assert.True(t, l.Allow("b"))
+
+	currentTime = currentTime.Add(100 * time.Millisecond)
+	assert.True(t, l.Allow("a"))
+	assert.False(t, l.Allow("a"))
+}
@@ -80,6 +80,34 @@ type CreateDashboardSnapshotCommand struct {
 	Result *DashboardSnapshot
 }
 
+// TakeDashboardSnapshotCommand creates a snapshot of a saved dashboard, with the data of its panels queried by the server.
+// swagger:model
+type TakeDashboardSnapshotCommand struct {
+	// The start of the time range, in epoch milliseconds or relative using Grafana time units. Defaults to the time range of the dashboard.
+	// required:false
+	// example: now-1h
+	From string `json:"from"`
+	// The end of the time range, in epoch milliseconds or relative using Grafana time units. Defaults to the time range of the dashboard.
+	// required:false
+	// example: now
+	To string `json:"to"`
+	// The values of the template variables by variable name. Defaults to the current values saved in the dashboard.
+	// required:false
+	Variables map[string]string `json:"variables"`
+	// Snapshot name. Defaults to the title of the dashboard.
+	// required:false
+	Name string `json:"name"`
+	// When the snapshot should expire in seconds. Default is never to expire.
+	// required:false
+	// default:0
+	Expires int64 `json:"expires"`
+
+	Dashboard    *Dashboard    `json:"-"`
+	SignedInUser *SignedInUser `json:"-"`
+
+	Result *DashboardSnapshot
+}
+
 type DeleteDashboardSnapshotCommand struct {
 	DeleteKey string `json:"-"`
 }
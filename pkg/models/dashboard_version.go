@@ -15,11 +15,13 @@ var (
 // A DashboardVersion represents the comparable data in a dashboard, allowing
 // diffs of the dashboard to be performed.
 type DashboardVersion struct {
-	Id            int64 `json:"id"`
-	DashboardId   int64 `json:"dashboardId"`
-	ParentVersion int   `json:"parentVersion"`
-	RestoredFrom  int   `json:"restoredFrom"`
-	Version       int   `json:"version"`
+	Id            int64  `json:"id"`
+	DashboardId   int64  `json:"dashboardId"`
+	ParentVersion int    `json:"parentVersion"`
+	RestoredFrom  int    `json:"restoredFrom"`
+	Version       int    `json:"version"`
+	Name          string `json:"name"`
+	Pinned        bool   `json:"pinned"`
 
 	Created   time.Time `json:"created"`
 	CreatedBy int64     `json:"createdBy"`
@@ -38,6 +40,8 @@ type DashboardVersionMeta struct {
 	ParentVersion int              `json:"parentVersion"`
 	RestoredFrom  int              `json:"restoredFrom"`
 	Version       int              `json:"version"`
+	Name          string           `json:"name"`
+	Pinned        bool             `json:"pinned"`
 	Created       time.Time        `json:"created"`
 	Message       string           `json:"message"`
 	Data          *simplejson.Json `json:"data"`
@@ -53,6 +57,8 @@ type DashboardVersionDTO struct {
 	ParentVersion int       `json:"parentVersion"`
 	RestoredFrom  int       `json:"restoredFrom"`
 	Version       int       `json:"version"`
+	Name          string    `json:"name"`
+	Pinned        bool      `json:"pinned"`
 	Created       time.Time `json:"created"`
 	CreatedBy     string    `json:"createdBy"`
 	Message       string    `json:"message"`
@@ -78,6 +78,11 @@ var (
 		Reason:     "Folder name cannot be the same as one of its dashboards",
 		StatusCode: 400,
 	}
+	ErrPublicDashboardNotFound = DashboardErr{
+		Reason:     "Public dashboard not found",
+		StatusCode: 404,
+		Status:     "not-found",
+	}
 	ErrDashboardWithSameNameAsFolder = DashboardErr{
 		Reason:     "Dashboard name cannot be the same as folder",
 		StatusCode: 400,
@@ -176,6 +181,26 @@ func (d UpdatePluginDashboardError) Error() string {
 	return "Dashboard belongs to plugin"
 }
 
+// DashboardSchemaFieldError is an error of a field of a dashboard that is not valid against the dashboard schema.
+type DashboardSchemaFieldError struct {
+	// Path is the dot separated path of the field, e.g. panels.0.gridPos
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// DashboardSchemaValidationError is returned when saving a dashboard that is not valid against the dashboard schema.
+type DashboardSchemaValidationError struct {
+	Errors []DashboardSchemaFieldError
+}
+
+func (e DashboardSchemaValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, fieldErr := range e.Errors {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", fieldErr.Path, fieldErr.Message))
+	}
+	return fmt.Sprintf("invalid dashboard json: %s", strings.Join(msgs, "; "))
+}
+
 const (
 	DashTypeDB       = "db"
 	DashTypeSnapshot = "snapshot"
@@ -206,7 +231,26 @@ type Dashboard struct {
 }
 
 type PublicDashboardConfig struct {
-	IsPublic bool `json:"isPublic"`
+	IsPublic        bool            `json:"isPublic"`
+	PublicDashboard PublicDashboard `json:"publicDashboard"`
+}
+
+// PublicDashboard gives anonymous viewers access to a dashboard with its access token. The queries of the
+// dashboard are run over the time range of the time settings when set, otherwise over the time range of the dashboard.
+type PublicDashboard struct {
+	Uid          string           `json:"uid" xorm:"pk uid"`
+	DashboardUid string           `json:"dashboardUid" xorm:"dashboard_uid"`
+	OrgId        int64            `json:"-" xorm:"org_id"`
+	AccessToken  string           `json:"accessToken" xorm:"access_token"`
+	IsEnabled    bool             `json:"isEnabled" xorm:"is_enabled"`
+	TimeSettings *simplejson.Json `json:"timeSettings" xorm:"time_settings"`
+
+	Created time.Time `json:"-"`
+	Updated time.Time `json:"-"`
+}
+
+func (pd PublicDashboard) TableName() string {
+	return "dashboard_public"
 }
 
 func (d *Dashboard) SetId(id int64) {
@@ -6,12 +6,17 @@ import (
 
 // Typed errors
 var (
-	ErrPlaylistNotFound = errors.New("Playlist not found")
+	ErrPlaylistNotFound            = errors.New("Playlist not found")
+	ErrPlaylistWithSameUIDExists   = errors.New("a playlist with the same uid already exists")
+	ErrPlaylistInvalidUID          = errors.New("playlist uid contains illegal characters")
+	ErrPlaylistUIDTooLong          = errors.New("playlist uid too long, max 40 characters")
+	ErrPlaylistInvalidItemInterval = errors.New("playlist item interval is invalid")
 )
 
 // Playlist model
 type Playlist struct {
 	Id       int64  `json:"id"`
+	UID      string `json:"uid" xorm:"uid"`
 	Name     string `json:"name"`
 	Interval string `json:"interval"`
 	OrgId    int64  `json:"-"`
@@ -19,6 +24,7 @@ type Playlist struct {
 
 type PlaylistDTO struct {
 	Id       int64             `json:"id"`
+	UID      string            `json:"uid"`
 	Name     string            `json:"name"`
 	Interval string            `json:"interval"`
 	OrgId    int64             `json:"-"`
@@ -32,6 +38,11 @@ type PlaylistItemDTO struct {
 	Title      string `json:"title"`
 	Value      string `json:"value"`
 	Order      int    `json:"order"`
+	// Interval is how long the dashboards of the item are shown, instead of the interval of the playlist.
+	Interval string `json:"interval,omitempty"`
+	// TimeFrom and TimeTo override the time range of the dashboards of the item.
+	TimeFrom string `json:"timeFrom,omitempty"`
+	TimeTo   string `json:"timeTo,omitempty"`
 }
 
 type PlaylistItem struct {
@@ -41,6 +52,9 @@ type PlaylistItem struct {
 	Value      string
 	Order      int
 	Title      string
+	Interval   string
+	TimeFrom   string
+	TimeTo     string
 }
 
 type Playlists []*Playlist
@@ -51,7 +65,7 @@ type Playlists []*Playlist
 
 type UpdatePlaylistCommand struct {
 	OrgId    int64             `json:"-"`
-	Id       int64             `json:"id"`
+	UID      string            `json:"uid"`
 	Name     string            `json:"name" binding:"Required"`
 	Interval string            `json:"interval"`
 	Items    []PlaylistItemDTO `json:"items"`
@@ -60,6 +74,7 @@ type UpdatePlaylistCommand struct {
 }
 
 type CreatePlaylistCommand struct {
+	UID      string            `json:"uid"`
 	Name     string            `json:"name" binding:"Required"`
 	Interval string            `json:"interval"`
 	Items    []PlaylistItemDTO `json:"items"`
@@ -69,7 +84,7 @@ type CreatePlaylistCommand struct {
 }
 
 type DeletePlaylistCommand struct {
-	Id    int64
+	UID   string
 	OrgId int64
 }
 
@@ -85,8 +100,9 @@ type GetPlaylistsQuery struct {
 	Result Playlists
 }
 
-type GetPlaylistByIdQuery struct {
-	Id     int64
+type GetPlaylistByUidQuery struct {
+	UID    string
+	OrgId  int64
 	Result *Playlist
 }
 
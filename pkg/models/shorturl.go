@@ -6,7 +6,9 @@ import (
 )
 
 var (
-	ErrShortURLNotFound = errors.New("short URL not found")
+	ErrShortURLNotFound      = errors.New("short URL not found")
+	ErrShortURLAlreadyExists = errors.New("a short URL with the same uid already exists")
+	ErrShortURLInvalidUID    = errors.New("short URL uid contains illegal characters or is too long")
 )
 
 type ShortUrl struct {
@@ -17,6 +19,35 @@ type ShortUrl struct {
 	CreatedBy  int64
 	CreatedAt  int64
 	LastSeenAt int64
+	ExpiresAt  int64
+	Pinned     bool
+}
+
+// IsExpired returns whether the short URL has an expiry date that has passed.
+func (s *ShortUrl) IsExpired(now time.Time) bool {
+	return s.ExpiresAt > 0 && s.ExpiresAt <= now.Unix()
+}
+
+// CreateShortUrlCommand creates a short URL. A uid is generated when Uid is empty. An ExpiresAt of zero means the
+// short URL does not expire.
+type CreateShortUrlCommand struct {
+	Path      string
+	Uid       string
+	ExpiresAt int64
+	Pinned    bool
+}
+
+// UpdateShortUrlCommand sets the expiry date and the pinned state of a short URL.
+type UpdateShortUrlCommand struct {
+	Uid       string
+	ExpiresAt int64
+	Pinned    bool
+}
+
+type SearchShortUrlsQuery struct {
+	Query string
+	Limit int
+	Page  int
 }
 
 type DeleteShortUrlCommand struct {
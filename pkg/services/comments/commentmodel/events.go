@@ -2,12 +2,25 @@ package commentmodel
 
 type EventType string
 
+// MentionsPath is the path of the channels, below the comment feature channels, where the users
+// receive the comments mentioning them.
+const MentionsPath = "mentions"
+
 const (
-	EventCommentCreated EventType = "commentCreated"
+	EventCommentCreated   EventType = "commentCreated"
+	EventCommentMentioned EventType = "commentMentioned"
 )
 
 // Event represents comment event structure.
 type Event struct {
-	Event          EventType   `json:"event"`
-	CommentCreated *CommentDto `json:"commentCreated"`
+	Event            EventType         `json:"event"`
+	CommentCreated   *CommentDto       `json:"commentCreated,omitempty"`
+	CommentMentioned *CommentMentioned `json:"commentMentioned,omitempty"`
+}
+
+// CommentMentioned is sent to the users mentioned in a comment.
+type CommentMentioned struct {
+	ObjectType string      `json:"objectType"`
+	ObjectID   string      `json:"objectId"`
+	Comment    *CommentDto `json:"comment"`
 }
@@ -5,6 +5,8 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 const (
@@ -14,12 +16,37 @@ const (
 	ObjectTypeDashboard = "dashboard"
 	// ObjectTypeAnnotation used for annotation comments.
 	ObjectTypeAnnotation = "annotation"
+	// ObjectTypeAlertRule used for alert rule comments, the object ID is the UID of the rule.
+	ObjectTypeAlertRule = "alert_rule"
+	// ObjectTypePanel used for panel comments, the object ID is the dashboard UID and
+	// the panel ID separated by a slash, see PanelObjectID.
+	ObjectTypePanel = "panel"
 )
 
 var RegisteredObjectTypes = map[string]struct{}{
 	ObjectTypeOrg:        {},
 	ObjectTypeDashboard:  {},
 	ObjectTypeAnnotation: {},
+	ObjectTypeAlertRule:  {},
+	ObjectTypePanel:      {},
+}
+
+// PanelObjectID returns the object ID of the comments of a panel.
+func PanelObjectID(dashboardUID string, panelID int64) string {
+	return dashboardUID + "/" + strconv.FormatInt(panelID, 10)
+}
+
+// ParsePanelObjectID returns the dashboard UID and the panel ID of a panel object ID.
+func ParsePanelObjectID(objectID string) (string, int64, bool) {
+	i := strings.LastIndex(objectID, "/")
+	if i <= 0 {
+		return "", 0, false
+	}
+	panelID, err := strconv.ParseInt(objectID[i+1:], 10, 64)
+	if err != nil || panelID <= 0 {
+		return "", 0, false
+	}
+	return objectID[:i], panelID, true
 }
 
 type CommentGroup struct {
@@ -23,7 +23,7 @@ type PermissionChecker struct {
 func NewPermissionChecker(sqlStore *sqlstore.SQLStore, features featuremgmt.FeatureToggles,
 	accessControl accesscontrol.AccessControl, dashboardService dashboards.DashboardService,
 ) *PermissionChecker {
-	return &PermissionChecker{sqlStore: sqlStore, features: features, accessControl: accessControl}
+	return &PermissionChecker{sqlStore: sqlStore, features: features, accessControl: accessControl, dashboardService: dashboardService}
 }
 
 func (c *PermissionChecker) getDashboardByUid(ctx context.Context, orgID int64, uid string) (*models.Dashboard, error) {
@@ -42,6 +42,44 @@ func (c *PermissionChecker) getDashboardById(ctx context.Context, orgID int64, i
 	return query.Result, nil
 }
 
+// getAlertRuleNamespaceUID returns the UID of the folder of an alert rule.
+func (c *PermissionChecker) getAlertRuleNamespaceUID(ctx context.Context, orgID int64, uid string) (string, bool, error) {
+	var namespaceUID string
+	var has bool
+	err := c.sqlStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		var err error
+		has, err = sess.Table("alert_rule").Where("org_id = ? AND uid = ?", orgID, uid).Cols("namespace_uid").Get(&namespaceUID)
+		return err
+	})
+	return namespaceUID, has, err
+}
+
+// checkAlertRulePermissions checks the permissions of the user on an alert rule, using the permissions
+// of the user on the folder of the rule when access control is disabled.
+func (c *PermissionChecker) checkAlertRulePermissions(ctx context.Context, orgId int64, signedInUser *models.SignedInUser, uid string, write bool) (bool, error) {
+	namespaceUID, has, err := c.getAlertRuleNamespaceUID(ctx, orgId, uid)
+	if err != nil || !has {
+		return false, err
+	}
+	if !c.accessControl.IsDisabled() {
+		action := accesscontrol.ActionAlertingRuleRead
+		if write {
+			action = accesscontrol.ActionAlertingRuleUpdate
+		}
+		evaluator := accesscontrol.EvalPermission(action, dashboards.ScopeFoldersProvider.GetResourceScopeUID(namespaceUID))
+		return c.accessControl.Evaluate(ctx, signedInUser, evaluator)
+	}
+	folder, err := c.getDashboardByUid(ctx, orgId, namespaceUID)
+	if err != nil {
+		return false, err
+	}
+	guard := guardian.New(ctx, folder.Id, orgId, signedInUser)
+	if write {
+		return guard.CanEdit()
+	}
+	return guard.CanView()
+}
+
 func (c *PermissionChecker) CheckReadPermissions(ctx context.Context, orgId int64, signedInUser *models.SignedInUser, objectType string, objectID string) (bool, error) {
 	switch objectType {
 	case ObjectTypeOrg:
@@ -83,6 +121,29 @@ func (c *PermissionChecker) CheckReadPermissions(ctx context.Context, orgId int6
 		if ok, err := guard.CanView(); err != nil || !ok {
 			return false, nil
 		}
+	case ObjectTypePanel:
+		if !c.features.IsEnabled(featuremgmt.FlagDashboardComments) {
+			return false, nil
+		}
+		dashboardUID, _, ok := ParsePanelObjectID(objectID)
+		if !ok {
+			return false, nil
+		}
+		dash, err := c.getDashboardByUid(ctx, orgId, dashboardUID)
+		if err != nil {
+			return false, err
+		}
+		guard := guardian.New(ctx, dash.Id, orgId, signedInUser)
+		if ok, err := guard.CanView(); err != nil || !ok {
+			return false, nil
+		}
+	case ObjectTypeAlertRule:
+		if !c.features.IsEnabled(featuremgmt.FlagAlertRuleComments) {
+			return false, nil
+		}
+		if ok, err := c.checkAlertRulePermissions(ctx, orgId, signedInUser, objectID, false); err != nil || !ok {
+			return false, err
+		}
 	default:
 		return false, nil
 	}
@@ -136,6 +197,29 @@ func (c *PermissionChecker) CheckWritePermissions(ctx context.Context, orgId int
 		if ok, err := guard.CanEdit(); err != nil || !ok {
 			return false, nil
 		}
+	case ObjectTypePanel:
+		if !c.features.IsEnabled(featuremgmt.FlagDashboardComments) {
+			return false, nil
+		}
+		dashboardUID, _, ok := ParsePanelObjectID(objectID)
+		if !ok {
+			return false, nil
+		}
+		dash, err := c.getDashboardByUid(ctx, orgId, dashboardUID)
+		if err != nil {
+			return false, err
+		}
+		guard := guardian.New(ctx, dash.Id, orgId, signedInUser)
+		if ok, err := guard.CanEdit(); err != nil || !ok {
+			return false, nil
+		}
+	case ObjectTypeAlertRule:
+		if !c.features.IsEnabled(featuremgmt.FlagAlertRuleComments) {
+			return false, nil
+		}
+		if ok, err := c.checkAlertRulePermissions(ctx, orgId, signedInUser, objectID, true); err != nil || !ok {
+			return false, err
+		}
 	default:
 		return false, nil
 	}
@@ -0,0 +1,148 @@
+package commentmodel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	accesscontrolmock "github.com/grafana/grafana/pkg/services/accesscontrol/mock"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+)
+
+func mockGuardian(t *testing.T, fake *guardian.FakeDashboardGuardian) {
+	origNew := guardian.New
+	t.Cleanup(func() {
+		guardian.New = origNew
+	})
+	guardian.MockDashboardGuardian(fake)
+}
+
+func newDashboardService(t *testing.T, dash *models.Dashboard) *dashboards.FakeDashboardService {
+	dashboardService := dashboards.NewFakeDashboardService(t)
+	dashboardService.On("GetDashboard", mock.Anything, mock.AnythingOfType("*models.GetDashboardQuery")).Run(func(args mock.Arguments) {
+		query := args.Get(1).(*models.GetDashboardQuery)
+		if query.Uid != dash.Uid && query.Id != dash.Id {
+			return
+		}
+		query.Result = dash
+	}).Return(nil).Maybe()
+	return dashboardService
+}
+
+func TestPermissionChecker_Panel(t *testing.T) {
+	user := &models.SignedInUser{UserId: 1, OrgId: 1, OrgRole: models.ROLE_VIEWER}
+	dash := &models.Dashboard{Id: 1, Uid: "dash", OrgId: 1}
+
+	t.Run("uses the permissions of the user on the dashboard of the panel", func(t *testing.T) {
+		mockGuardian(t, &guardian.FakeDashboardGuardian{CanViewValue: true})
+		checker := NewPermissionChecker(nil, featuremgmt.WithFeatures(featuremgmt.FlagDashboardComments),
+			accesscontrolmock.New().WithDisabled(), newDashboardService(t, dash))
+
+		ok, err := checker.CheckReadPermissions(context.Background(), 1, user, ObjectTypePanel, "dash/2")
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		ok, err = checker.CheckWritePermissions(context.Background(), 1, user, ObjectTypePanel, "dash/2")
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("rejects an invalid panel object id", func(t *testing.T) {
+		mockGuardian(t, &guardian.FakeDashboardGuardian{CanViewValue: true})
+		checker := NewPermissionChecker(nil, featuremgmt.WithFeatures(featuremgmt.FlagDashboardComments),
+			accesscontrolmock.New().WithDisabled(), newDashboardService(t, dash))
+
+		ok, err := checker.CheckReadPermissions(context.Background(), 1, user, ObjectTypePanel, "dash")
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("rejects panel comments when dashboard comments are disabled", func(t *testing.T) {
+		mockGuardian(t, &guardian.FakeDashboardGuardian{CanViewValue: true})
+		checker := NewPermissionChecker(nil, featuremgmt.WithFeatures(),
+			accesscontrolmock.New().WithDisabled(), newDashboardService(t, dash))
+
+		ok, err := checker.CheckReadPermissions(context.Background(), 1, user, ObjectTypePanel, "dash/2")
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+}
+
+func TestPermissionChecker_AlertRule(t *testing.T) {
+	sqlStore := sqlstore.InitTestDB(t)
+	err := sqlStore.WithDbSession(context.Background(), func(sess *sqlstore.DBSession) error {
+		_, err := sess.Insert(&ngmodels.AlertRule{
+			OrgID:           1,
+			UID:             "rule",
+			Title:           "Rule",
+			NamespaceUID:    "folder",
+			RuleGroup:       "group",
+			Condition:       "A",
+			Data:            []ngmodels.AlertQuery{},
+			IntervalSeconds: 60,
+			Updated:         time.Now(),
+			NoDataState:     ngmodels.NoData,
+			ExecErrState:    ngmodels.AlertingErrState,
+		})
+		return err
+	})
+	require.NoError(t, err)
+	user := &models.SignedInUser{UserId: 1, OrgId: 1, OrgRole: models.ROLE_VIEWER}
+	folder := &models.Dashboard{Id: 1, Uid: "folder", OrgId: 1, IsFolder: true}
+	features := featuremgmt.WithFeatures(featuremgmt.FlagAlertRuleComments)
+
+	t.Run("uses the permissions of the user on the folder of the rule without access control", func(t *testing.T) {
+		mockGuardian(t, &guardian.FakeDashboardGuardian{CanViewValue: true})
+		checker := NewPermissionChecker(sqlStore, features, accesscontrolmock.New().WithDisabled(), newDashboardService(t, folder))
+
+		ok, err := checker.CheckReadPermissions(context.Background(), 1, user, ObjectTypeAlertRule, "rule")
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		ok, err = checker.CheckWritePermissions(context.Background(), 1, user, ObjectTypeAlertRule, "rule")
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("uses the alert rule permissions of the user with access control", func(t *testing.T) {
+		ac := accesscontrolmock.New().WithPermissions([]*accesscontrol.Permission{
+			{Action: accesscontrol.ActionAlertingRuleRead, Scope: dashboards.ScopeFoldersProvider.GetResourceScopeUID("folder")},
+		})
+		checker := NewPermissionChecker(sqlStore, features, ac, newDashboardService(t, folder))
+
+		ok, err := checker.CheckReadPermissions(context.Background(), 1, user, ObjectTypeAlertRule, "rule")
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		ok, err = checker.CheckWritePermissions(context.Background(), 1, user, ObjectTypeAlertRule, "rule")
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("rejects an unknown rule", func(t *testing.T) {
+		mockGuardian(t, &guardian.FakeDashboardGuardian{CanViewValue: true})
+		checker := NewPermissionChecker(sqlStore, features, accesscontrolmock.New().WithDisabled(), newDashboardService(t, folder))
+
+		ok, err := checker.CheckReadPermissions(context.Background(), 1, user, ObjectTypeAlertRule, "unknown")
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("rejects alert rule comments when they are disabled", func(t *testing.T) {
+		mockGuardian(t, &guardian.FakeDashboardGuardian{CanViewValue: true})
+		checker := NewPermissionChecker(sqlStore, featuremgmt.WithFeatures(), accesscontrolmock.New().WithDisabled(), newDashboardService(t, folder))
+
+		ok, err := checker.CheckReadPermissions(context.Background(), 1, user, ObjectTypeAlertRule, "rule")
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+}
@@ -117,6 +117,7 @@ func (s *Service) Create(ctx context.Context, orgID int64, signedInUser *models.
 	}
 	eventJSON, _ := json.Marshal(e)
 	_ = s.live.Publish(orgID, fmt.Sprintf("grafana/comment/%s/%s", cmd.ObjectType, cmd.ObjectID), eventJSON)
+	s.notifyMentions(ctx, orgID, signedInUser, cmd.ObjectType, cmd.ObjectID, mDto)
 	return mDto, nil
 }
 
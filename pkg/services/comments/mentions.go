@@ -0,0 +1,163 @@
+package comments
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/annotations"
+	"github.com/grafana/grafana/pkg/services/comments/commentmodel"
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+const tmplCommentMention = "comment_mention"
+
+// mentionPattern matches the @login mentions of users. Logins may be email addresses, and the
+// punctuation at the end of a mention is not part of the login.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w.@])@(\w(?:[\w.\-@+]*\w)?)`)
+
+// parseMentions returns the logins mentioned in the content of a comment, without duplicates.
+func parseMentions(content string) []string {
+	var logins []string
+	seen := make(map[string]struct{})
+	for _, match := range mentionPattern.FindAllStringSubmatch(content, -1) {
+		login := match[1]
+		if _, ok := seen[login]; ok {
+			continue
+		}
+		seen[login] = struct{}{}
+		logins = append(logins, login)
+	}
+	return logins
+}
+
+// notifyMentions sends the comment to the users it mentions, with a Live event on the mentions channel
+// of each user and by email. Only the members of the organization that can read the comment are notified.
+func (s *Service) notifyMentions(ctx context.Context, orgID int64, author *models.SignedInUser, objectType string, objectID string, comment *commentmodel.CommentDto) {
+	logins := parseMentions(comment.Content)
+	if len(logins) == 0 {
+		return
+	}
+
+	e := commentmodel.Event{
+		Event: commentmodel.EventCommentMentioned,
+		CommentMentioned: &commentmodel.CommentMentioned{
+			ObjectType: objectType,
+			ObjectID:   objectID,
+			Comment:    comment,
+		},
+	}
+	eventJSON, _ := json.Marshal(e)
+
+	var title, url string
+	var linkErr error
+	if s.cfg.Smtp.Enabled {
+		if title, url, linkErr = s.getObjectLink(ctx, orgID, author, objectType, objectID); linkErr != nil {
+			s.log.Warn("Failed to get link of commented object, not sending mention emails", "objectType", objectType, "objectId", objectID, "error", linkErr)
+		}
+	}
+
+	for _, login := range logins {
+		query := models.GetSignedInUserQuery{Login: login, OrgId: orgID}
+		if err := s.sqlStore.GetSignedInUser(ctx, &query); err != nil {
+			if !errors.Is(err, models.ErrUserNotFound) {
+				s.log.Warn("Failed to get mentioned user", "login", login, "error", err)
+			}
+			continue
+		}
+		user := query.Result
+		if user.OrgId != orgID || user.UserId == author.UserId || user.IsDisabled {
+			continue
+		}
+		ok, err := s.permissions.CheckReadPermissions(ctx, orgID, user, objectType, objectID)
+		if err != nil || !ok {
+			continue
+		}
+
+		_ = s.live.Publish(orgID, fmt.Sprintf("grafana/comment/%s/%d", commentmodel.MentionsPath, user.UserId), eventJSON)
+
+		if !s.cfg.Smtp.Enabled || linkErr != nil || !util.IsEmail(user.Email) {
+			continue
+		}
+		cmd := models.SendEmailCommand{
+			To:       []string{user.Email},
+			Template: tmplCommentMention,
+			Data: map[string]interface{}{
+				"Name":        user.NameOrFallback(),
+				"MentionedBy": util.StringsFallback3(author.Name, author.Email, author.Login),
+				"Title":       title,
+				"Url":         url,
+				"Content":     comment.Content,
+			},
+		}
+		if err := s.notifications.SendEmailCommandHandler(ctx, &cmd); err != nil {
+			s.log.Warn("Failed to send comment mention email", "login", login, "error", err)
+		}
+	}
+}
+
+// getObjectLink returns the title and the absolute URL of a commented object.
+func (s *Service) getObjectLink(ctx context.Context, orgID int64, signedInUser *models.SignedInUser, objectType string, objectID string) (string, string, error) {
+	switch objectType {
+	case commentmodel.ObjectTypeDashboard:
+		dash, err := s.getDashboard(ctx, &models.GetDashboardQuery{Uid: objectID, OrgId: orgID})
+		if err != nil {
+			return "", "", err
+		}
+		return dash.Title, setting.ToAbsUrl(fmt.Sprintf("d/%s/%s", dash.Uid, dash.Slug)), nil
+	case commentmodel.ObjectTypePanel:
+		dashboardUID, panelID, ok := commentmodel.ParsePanelObjectID(objectID)
+		if !ok {
+			return "", "", errors.New("invalid panel object id")
+		}
+		dash, err := s.getDashboard(ctx, &models.GetDashboardQuery{Uid: dashboardUID, OrgId: orgID})
+		if err != nil {
+			return "", "", err
+		}
+		return dash.Title, setting.ToAbsUrl(fmt.Sprintf("d/%s/%s?viewPanel=%d", dash.Uid, dash.Slug, panelID)), nil
+	case commentmodel.ObjectTypeAnnotation:
+		annotationID, err := strconv.ParseInt(objectID, 10, 64)
+		if err != nil {
+			return "", "", err
+		}
+		items, err := annotations.GetRepository().Find(ctx, &annotations.ItemQuery{AnnotationId: annotationID, OrgId: orgID, SignedInUser: signedInUser})
+		if err != nil {
+			return "", "", err
+		}
+		if len(items) != 1 {
+			return "", "", errors.New("annotation not found")
+		}
+		dash, err := s.getDashboard(ctx, &models.GetDashboardQuery{Id: items[0].DashboardId, OrgId: orgID})
+		if err != nil {
+			return "", "", err
+		}
+		return dash.Title, setting.ToAbsUrl(fmt.Sprintf("d/%s/%s", dash.Uid, dash.Slug)), nil
+	case commentmodel.ObjectTypeAlertRule:
+		var title string
+		err := s.sqlStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+			has, err := sess.Table("alert_rule").Where("org_id = ? AND uid = ?", orgID, objectID).Cols("title").Get(&title)
+			if err == nil && !has {
+				err = errors.New("alert rule not found")
+			}
+			return err
+		})
+		if err != nil {
+			return "", "", err
+		}
+		return title, setting.ToAbsUrl(fmt.Sprintf("alerting/grafana/%s/view", objectID)), nil
+	}
+	return "", "", errUnknownObjectType
+}
+
+func (s *Service) getDashboard(ctx context.Context, query *models.GetDashboardQuery) (*models.Dashboard, error) {
+	if err := s.dashboardService.GetDashboard(ctx, query); err != nil {
+		return nil, err
+	}
+	return query.Result, nil
+}
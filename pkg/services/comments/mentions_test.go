@@ -0,0 +1,122 @@
+package comments
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	accesscontrolmock "github.com/grafana/grafana/pkg/services/accesscontrol/mock"
+	"github.com/grafana/grafana/pkg/services/comments/commentmodel"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/services/notifications"
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestParseMentions(t *testing.T) {
+	for content, expected := range map[string][]string{
+		"no mentions":                            nil,
+		"@admin can you check?":                  {"admin"},
+		"cc @alice, @bob.smith and @alice.":      {"alice", "bob.smith"},
+		"ping @jane@example.com (on call)":       {"jane@example.com"},
+		"mail me at ops@example.com, not @ here": nil,
+		"@a-b_c\n@d":                             {"a-b_c", "d"},
+	} {
+		require.Equal(t, expected, parseMentions(content), content)
+	}
+}
+
+type fakeLivePublisher struct {
+	channels []string
+}
+
+func (p *fakeLivePublisher) Publish(_ int64, channel string, _ []byte) error {
+	p.channels = append(p.channels, channel)
+	return nil
+}
+
+func mentionsChannel(user *models.User) string {
+	return "grafana/comment/mentions/" + strconv.FormatInt(user.Id, 10)
+}
+
+func TestNotifyMentions(t *testing.T) {
+	origNew := guardian.New
+	t.Cleanup(func() {
+		guardian.New = origNew
+	})
+	guardian.New = func(_ context.Context, _ int64, _ int64, user *models.SignedInUser) guardian.DashboardGuardian {
+		return &guardian.FakeDashboardGuardian{CanViewValue: user.Login != "noaccess"}
+	}
+
+	sqlStore := sqlstore.InitTestDB(t)
+	users := make(map[string]*models.User)
+	for _, login := range []string{"author", "viewer", "other", "noaccess"} {
+		user, err := sqlStore.CreateUser(context.Background(), models.CreateUserCommand{Login: login, Email: login + "@example.com"})
+		require.NoError(t, err)
+		if user.OrgId != 1 {
+			err := sqlStore.AddOrgUser(context.Background(), &models.AddOrgUserCommand{OrgId: 1, UserId: user.Id, Role: models.ROLE_VIEWER})
+			require.NoError(t, err)
+		}
+		users[login] = user
+	}
+	author := &models.SignedInUser{UserId: users["author"].Id, OrgId: 1, Login: "author"}
+	comment := &commentmodel.CommentDto{Content: "@viewer @noaccess @author @unknown @other please check"}
+	dash := &models.Dashboard{Id: 1, Uid: "dash", Slug: "dash", Title: "Dash"}
+
+	setup := func(t *testing.T, dashboardService *dashboards.FakeDashboardService) (*Service, *fakeLivePublisher, *[]string) {
+		cfg := setting.NewCfg()
+		cfg.Smtp.Enabled = true
+		publisher := &fakeLivePublisher{}
+		var emails []string
+		notificationService := notifications.MockNotificationService()
+		notificationService.EmailHandler = func(_ context.Context, cmd *models.SendEmailCommand) error {
+			emails = append(emails, cmd.To...)
+			return nil
+		}
+		return &Service{
+			cfg:              cfg,
+			live:             publisher,
+			sqlStore:         sqlStore,
+			dashboardService: dashboardService,
+			notifications:    notificationService,
+			log:              log.NewNopLogger(),
+			permissions: commentmodel.NewPermissionChecker(sqlStore, featuremgmt.WithFeatures(featuremgmt.FlagDashboardComments),
+				accesscontrolmock.New().WithDisabled(), dashboardService),
+		}, publisher, &emails
+	}
+
+	returnDashboard := func(args mock.Arguments) {
+		args.Get(1).(*models.GetDashboardQuery).Result = dash
+	}
+
+	t.Run("notifies only the other users that can read the comment", func(t *testing.T) {
+		dashboardService := dashboards.NewFakeDashboardService(t)
+		dashboardService.On("GetDashboard", mock.Anything, mock.AnythingOfType("*models.GetDashboardQuery")).Run(returnDashboard).Return(nil)
+		s, publisher, emails := setup(t, dashboardService)
+
+		s.notifyMentions(context.Background(), 1, author, commentmodel.ObjectTypeDashboard, "dash", comment)
+
+		require.Equal(t, []string{mentionsChannel(users["viewer"]), mentionsChannel(users["other"])}, publisher.channels)
+		require.Equal(t, []string{"viewer@example.com", "other@example.com"}, *emails)
+	})
+
+	t.Run("notifies the users on Live when the link of the object cannot be found", func(t *testing.T) {
+		dashboardService := dashboards.NewFakeDashboardService(t)
+		dashboardService.On("GetDashboard", mock.Anything, mock.AnythingOfType("*models.GetDashboardQuery")).Return(errors.New("boom")).Once()
+		dashboardService.On("GetDashboard", mock.Anything, mock.AnythingOfType("*models.GetDashboardQuery")).Run(returnDashboard).Return(nil)
+		s, publisher, emails := setup(t, dashboardService)
+
+		s.notifyMentions(context.Background(), 1, author, commentmodel.ObjectTypeDashboard, "dash", comment)
+
+		require.Equal(t, []string{mentionsChannel(users["viewer"]), mentionsChannel(users["other"])}, publisher.channels)
+		require.Empty(t, *emails)
+	})
+}
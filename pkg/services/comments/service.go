@@ -3,30 +3,43 @@ package comments
 import (
 	"context"
 
+	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/comments/commentmodel"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/live"
+	"github.com/grafana/grafana/pkg/services/notifications"
 	"github.com/grafana/grafana/pkg/services/sqlstore"
 	"github.com/grafana/grafana/pkg/setting"
 )
 
+// livePublisher publishes the events of comments to Live channels.
+type livePublisher interface {
+	Publish(orgID int64, channel string, data []byte) error
+}
+
 type Service struct {
-	cfg         *setting.Cfg
-	live        *live.GrafanaLive
-	sqlStore    *sqlstore.SQLStore
-	storage     Storage
-	permissions *commentmodel.PermissionChecker
+	cfg              *setting.Cfg
+	live             livePublisher
+	sqlStore         *sqlstore.SQLStore
+	storage          Storage
+	permissions      *commentmodel.PermissionChecker
+	dashboardService dashboards.DashboardService
+	notifications    notifications.EmailSender
+	log              log.Logger
 }
 
 func ProvideService(cfg *setting.Cfg, store *sqlstore.SQLStore, live *live.GrafanaLive,
 	features featuremgmt.FeatureToggles, accessControl accesscontrol.AccessControl,
-	dashboardService dashboards.DashboardService) *Service {
+	dashboardService dashboards.DashboardService, notificationService notifications.EmailSender) *Service {
 	s := &Service{
-		cfg:      cfg,
-		live:     live,
-		sqlStore: store,
+		cfg:              cfg,
+		live:             live,
+		sqlStore:         store,
+		dashboardService: dashboardService,
+		notifications:    notificationService,
+		log:              log.New("comments"),
 		storage: &sqlStorage{
 			sql: store,
 		},
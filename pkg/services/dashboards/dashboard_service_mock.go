@@ -5,6 +5,8 @@ package dashboards
 import (
 	context "context"
 
+	dtos "github.com/grafana/grafana/pkg/api/dtos"
+
 	models "github.com/grafana/grafana/pkg/models"
 	mock "github.com/stretchr/testify/mock"
 
@@ -16,6 +18,27 @@ type FakeDashboardService struct {
 	mock.Mock
 }
 
+// BuildPublicDashboardMetricRequest provides a mock function with given fields: ctx, dashboard, panelId, reqDTO
+func (_m *FakeDashboardService) BuildPublicDashboardMetricRequest(ctx context.Context, dashboard *models.Dashboard, panelId int64, reqDTO dtos.PublicDashboardQueryDTO) (dtos.MetricRequest, error) {
+	ret := _m.Called(ctx, dashboard, panelId, reqDTO)
+
+	var r0 dtos.MetricRequest
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Dashboard, int64, dtos.PublicDashboardQueryDTO) dtos.MetricRequest); ok {
+		r0 = rf(ctx, dashboard, panelId, reqDTO)
+	} else {
+		r0 = ret.Get(0).(dtos.MetricRequest)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Dashboard, int64, dtos.PublicDashboardQueryDTO) error); ok {
+		r1 = rf(ctx, dashboard, panelId, reqDTO)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BuildSaveDashboardCommand provides a mock function with given fields: ctx, dto, shouldValidateAlerts, validateProvisionedDashboard
 func (_m *FakeDashboardService) BuildSaveDashboardCommand(ctx context.Context, dto *SaveDashboardDTO, shouldValidateAlerts bool, validateProvisionedDashboard bool) (*models.SaveDashboardCommand, error) {
 	ret := _m.Called(ctx, dto, shouldValidateAlerts, validateProvisionedDashboard)
@@ -118,6 +141,29 @@ func (_m *FakeDashboardService) GetDashboards(ctx context.Context, query *models
 	return r0
 }
 
+// GetPublicDashboard provides a mock function with given fields: ctx, accessToken
+func (_m *FakeDashboardService) GetPublicDashboard(ctx context.Context, accessToken string) (*models.Dashboard, error) {
+	ret := _m.Called(ctx, accessToken)
+
+	var r0 *models.Dashboard
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Dashboard); ok {
+		r0 = rf(ctx, accessToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Dashboard)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accessToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPublicDashboardConfig provides a mock function with given fields: ctx, orgId, dashboardUid
 func (_m *FakeDashboardService) GetPublicDashboardConfig(ctx context.Context, orgId int64, dashboardUid string) (*models.PublicDashboardConfig, error) {
 	ret := _m.Called(ctx, orgId, dashboardUid)
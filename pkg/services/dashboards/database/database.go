@@ -194,25 +194,42 @@ func (d *DashboardStore) SaveDashboard(cmd models.SaveDashboardCommand) (*models
 
 // retrieves public dashboard configuration
 func (d *DashboardStore) GetPublicDashboardConfig(orgId int64, dashboardUid string) (*models.PublicDashboardConfig, error) {
+	if dashboardUid == "" {
+		return nil, models.ErrDashboardIdentifierNotSet
+	}
+
 	var result []*models.Dashboard
+	pdc := &models.PublicDashboardConfig{}
+	err := d.sqlStore.WithDbSession(context.Background(), func(sess *sqlstore.DBSession) error {
+		if err := sess.Where("org_id = ? AND uid= ?", orgId, dashboardUid).Find(&result); err != nil {
+			return err
+		}
+		if len(result) == 0 {
+			return models.ErrDashboardNotFound
+		}
+		pdc.IsPublic = result[0].IsPublic
 
-	err := d.sqlStore.WithTransactionalDbSession(context.Background(), func(sess *sqlstore.DBSession) error {
-		return sess.Where("org_id = ? AND uid= ?", orgId, dashboardUid).Find(&result)
+		_, err := sess.Where("org_id = ? AND dashboard_uid = ?", orgId, dashboardUid).Get(&pdc.PublicDashboard)
+		return err
 	})
-
-	if len(result) == 0 {
-		return nil, models.ErrDashboardNotFound
-	}
-
-	pdc := &models.PublicDashboardConfig{
-		IsPublic: result[0].IsPublic,
+	if err != nil {
+		return nil, err
 	}
 
-	return pdc, err
+	return pdc, nil
 }
 
 // stores public dashboard configuration
 func (d *DashboardStore) SavePublicDashboardConfig(cmd models.SavePublicDashboardConfigCommand) (*models.PublicDashboardConfig, error) {
+	pd := cmd.PublicDashboardConfig.PublicDashboard
+	if pd.Uid == "" || pd.AccessToken == "" {
+		return nil, models.ErrDashboardIdentifierNotSet
+	}
+	pd.DashboardUid = cmd.Uid
+	pd.OrgId = cmd.OrgId
+	pd.IsEnabled = cmd.PublicDashboardConfig.IsPublic
+	pd.Updated = time.Now()
+
 	err := d.sqlStore.WithTransactionalDbSession(context.Background(), func(sess *sqlstore.DBSession) error {
 		affectedRowCount, err := sess.Table("dashboard").Where("org_id = ? AND uid = ?", cmd.OrgId, cmd.Uid).Update(map[string]interface{}{"is_public": cmd.PublicDashboardConfig.IsPublic})
 		if err != nil {
@@ -223,14 +240,57 @@ func (d *DashboardStore) SavePublicDashboardConfig(cmd models.SavePublicDashboar
 			return models.ErrDashboardNotFound
 		}
 
-		return nil
+		exists, err := sess.Exist(&models.PublicDashboard{Uid: pd.Uid})
+		if err != nil {
+			return err
+		}
+		if exists {
+			_, err = sess.ID(pd.Uid).Cols("is_enabled", "time_settings", "updated").Update(&pd)
+			return err
+		}
+		pd.Created = pd.Updated
+		_, err = sess.Insert(&pd)
+		return err
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	return &cmd.PublicDashboardConfig, nil
+	return &models.PublicDashboardConfig{IsPublic: pd.IsEnabled, PublicDashboard: pd}, nil
+}
+
+// retrieves the public dashboard with the access token, and its dashboard
+func (d *DashboardStore) GetPublicDashboard(accessToken string) (*models.PublicDashboard, *models.Dashboard, error) {
+	if accessToken == "" {
+		return nil, nil, models.ErrPublicDashboardNotFound
+	}
+
+	pd := &models.PublicDashboard{}
+	dashboard := &models.Dashboard{}
+	err := d.sqlStore.WithDbSession(context.Background(), func(sess *sqlstore.DBSession) error {
+		has, err := sess.Where("access_token = ?", accessToken).Get(pd)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return models.ErrPublicDashboardNotFound
+		}
+
+		has, err = sess.Where("org_id = ? AND uid = ?", pd.OrgId, pd.DashboardUid).Get(dashboard)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return models.ErrPublicDashboardNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pd, dashboard, nil
 }
 
 func (d *DashboardStore) UpdateDashboardACL(ctx context.Context, dashboardID int64, items []*models.DashboardAcl) error {
@@ -764,7 +824,7 @@ func (d *DashboardStore) deleteDashboard(cmd *models.DeleteDashboardCommand, ses
 	}
 
 	if dashboard.IsFolder {
-		deletes = append(deletes, "DELETE FROM dashboard WHERE folder_id = ?")
+		deletes = append(deletes, "DELETE FROM dashboard WHERE folder_id = ?", "DELETE FROM preferences WHERE folder_id = ?")
 
 		var dashIds []struct {
 			Id  int64
@@ -586,6 +586,68 @@ func TestIntegrationDashboard_Filter(t *testing.T) {
 
 }
 
+func TestIntegrationPublicDashboards(t *testing.T) {
+	sqlStore := sqlstore.InitTestDB(t)
+	dashboardStore := ProvideDashboardStore(sqlStore)
+	savedDash := insertTestDashboard(t, dashboardStore, "test dash 23", 1, 0, false)
+
+	t.Run("returns an empty public dashboard config for new dashboards", func(t *testing.T) {
+		pdc, err := dashboardStore.GetPublicDashboardConfig(1, savedDash.Uid)
+		require.NoError(t, err)
+		require.False(t, pdc.IsPublic)
+		require.Empty(t, pdc.PublicDashboard.Uid)
+
+		_, err = dashboardStore.GetPublicDashboardConfig(1, "unknown")
+		require.ErrorIs(t, err, models.ErrDashboardNotFound)
+	})
+
+	t.Run("saves and updates the public dashboard config", func(t *testing.T) {
+		pdc, err := dashboardStore.SavePublicDashboardConfig(models.SavePublicDashboardConfigCommand{
+			Uid:   savedDash.Uid,
+			OrgId: 1,
+			PublicDashboardConfig: models.PublicDashboardConfig{
+				IsPublic: true,
+				PublicDashboard: models.PublicDashboard{
+					Uid:          "pd",
+					AccessToken:  "token",
+					TimeSettings: simplejson.NewFromAny(map[string]interface{}{"from": "now-1h", "to": "now"}),
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.True(t, pdc.PublicDashboard.IsEnabled)
+
+		pd, dash, err := dashboardStore.GetPublicDashboard("token")
+		require.NoError(t, err)
+		require.Equal(t, savedDash.Id, dash.Id)
+		require.True(t, dash.IsPublic)
+		require.Equal(t, savedDash.Uid, pd.DashboardUid)
+		require.True(t, pd.IsEnabled)
+		require.Equal(t, "now-1h", pd.TimeSettings.Get("from").MustString())
+
+		_, err = dashboardStore.SavePublicDashboardConfig(models.SavePublicDashboardConfigCommand{
+			Uid:   savedDash.Uid,
+			OrgId: 1,
+			PublicDashboardConfig: models.PublicDashboardConfig{
+				IsPublic:        false,
+				PublicDashboard: models.PublicDashboard{Uid: "pd", AccessToken: "token"},
+			},
+		})
+		require.NoError(t, err)
+
+		pdc, err = dashboardStore.GetPublicDashboardConfig(1, savedDash.Uid)
+		require.NoError(t, err)
+		require.False(t, pdc.IsPublic)
+		require.False(t, pdc.PublicDashboard.IsEnabled)
+		require.Equal(t, "token", pdc.PublicDashboard.AccessToken)
+	})
+
+	t.Run("returns not found for unknown access tokens", func(t *testing.T) {
+		_, _, err := dashboardStore.GetPublicDashboard("unknown")
+		require.ErrorIs(t, err, models.ErrPublicDashboardNotFound)
+	})
+}
+
 func insertTestRule(t *testing.T, sqlStore *sqlstore.SQLStore, foderOrgID int64, folderUID string) {
 	sqlStore.WithDbSession(context.Background(), func(sess *sqlstore.DBSession) error {
 		type alertQuery struct {
@@ -8,6 +8,9 @@ import (
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
 
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/framework/coremodel"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/models"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
@@ -39,12 +42,13 @@ type DashboardServiceImpl struct {
 	features             featuremgmt.FeatureToggles
 	folderPermissions    accesscontrol.FolderPermissionsService
 	dashboardPermissions accesscontrol.DashboardPermissionsService
+	coremodelRegistry    *coremodel.Registry
 }
 
 func ProvideDashboardService(
 	cfg *setting.Cfg, store dashboards.Store, dashAlertExtractor alerting.DashAlertExtractor,
 	features featuremgmt.FeatureToggles, folderPermissionsService accesscontrol.FolderPermissionsService,
-	dashboardPermissionsService accesscontrol.DashboardPermissionsService,
+	dashboardPermissionsService accesscontrol.DashboardPermissionsService, coremodelRegistry *coremodel.Registry,
 ) *DashboardServiceImpl {
 	return &DashboardServiceImpl{
 		cfg:                  cfg,
@@ -54,6 +58,7 @@ func ProvideDashboardService(
 		features:             features,
 		folderPermissions:    folderPermissionsService,
 		dashboardPermissions: dashboardPermissionsService,
+		coremodelRegistry:    coremodelRegistry,
 	}
 }
 
@@ -308,6 +313,11 @@ func (dr *DashboardServiceImpl) SaveDashboard(ctx context.Context, dto *dashboar
 		dto.Dashboard.Data.Set("refresh", setting.MinRefreshInterval)
 	}
 
+	// only the dashboards saved by the users are validated against the schema, not the provisioned and imported ones
+	if err := dr.validateDashboardSchema(dto.OrgId, dto.Dashboard); err != nil {
+		return nil, err
+	}
+
 	cmd, err := dr.BuildSaveDashboardCommand(ctx, dto, true, !allowUiUpdate)
 	if err != nil {
 		return nil, err
@@ -363,6 +373,22 @@ func (dr *DashboardServiceImpl) SavePublicDashboardConfig(ctx context.Context, d
 		PublicDashboardConfig: dto.PublicDashboardConfig,
 	}
 
+	// the public dashboard keeps its identifiers, so that the links to it keep working
+	existing, err := dr.dashboardStore.GetPublicDashboardConfig(dto.OrgId, dto.Uid)
+	if err != nil {
+		return nil, err
+	}
+	pd := &cmd.PublicDashboardConfig.PublicDashboard
+	pd.Uid = existing.PublicDashboard.Uid
+	pd.AccessToken = existing.PublicDashboard.AccessToken
+	if pd.Uid == "" {
+		pd.Uid = util.GenerateShortUID()
+		pd.AccessToken, err = util.GetRandomString(32)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	pdc, err := dr.dashboardStore.SavePublicDashboardConfig(cmd)
 	if err != nil {
 		return nil, err
@@ -371,6 +397,90 @@ func (dr *DashboardServiceImpl) SavePublicDashboardConfig(ctx context.Context, d
 	return pdc, nil
 }
 
+// GetPublicDashboard returns the dashboard of the public dashboard with the access token, with the time range of the
+// public dashboard. It returns ErrPublicDashboardNotFound if the public dashboard does not exist or is disabled.
+func (dr *DashboardServiceImpl) GetPublicDashboard(ctx context.Context, accessToken string) (*models.Dashboard, error) {
+	pd, dash, err := dr.getEnabledPublicDashboard(accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if from, to, ok := publicDashboardTimeSettings(pd); ok {
+		dash.Data.Set("time", map[string]interface{}{"from": from, "to": to})
+	}
+	return dash, nil
+}
+
+// BuildPublicDashboardMetricRequest returns the request of the queries of a panel of a public dashboard, as returned
+// by GetPublicDashboard. Only the queries saved in the dashboard are run, over the time range of the dashboard.
+func (dr *DashboardServiceImpl) BuildPublicDashboardMetricRequest(ctx context.Context, dash *models.Dashboard, panelId int64, reqDTO dtos.PublicDashboardQueryDTO) (dtos.MetricRequest, error) {
+	panel := findPanel(dash.Data.Get("panels"), panelId)
+	if panel == nil {
+		return dtos.MetricRequest{}, models.ErrDashboardPanelNotFound
+	}
+
+	metricReq := dtos.MetricRequest{
+		From:    dash.Data.GetPath("time", "from").MustString("now-6h"),
+		To:      dash.Data.GetPath("time", "to").MustString("now"),
+		Queries: []*simplejson.Json{},
+	}
+	for _, target := range panel.Get("targets").MustArray() {
+		query := simplejson.NewFromAny(target)
+		if query.Get("hide").MustBool() {
+			continue
+		}
+		// the queries use the data source of the panel, unless they set their own
+		if ds, ok := panel.CheckGet("datasource"); ok {
+			if _, ok := query.CheckGet("datasource"); !ok {
+				query.Set("datasource", ds.Interface())
+			}
+		}
+		if reqDTO.IntervalMs > 0 {
+			query.Set("intervalMs", reqDTO.IntervalMs)
+		}
+		if reqDTO.MaxDataPoints > 0 {
+			query.Set("maxDataPoints", reqDTO.MaxDataPoints)
+		}
+		metricReq.Queries = append(metricReq.Queries, query)
+	}
+	return metricReq, nil
+}
+
+func (dr *DashboardServiceImpl) getEnabledPublicDashboard(accessToken string) (*models.PublicDashboard, *models.Dashboard, error) {
+	pd, dash, err := dr.dashboardStore.GetPublicDashboard(accessToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !pd.IsEnabled || !dash.IsPublic {
+		return nil, nil, models.ErrPublicDashboardNotFound
+	}
+	return pd, dash, nil
+}
+
+// publicDashboardTimeSettings returns the time range of the public dashboard, if it is set.
+func publicDashboardTimeSettings(pd *models.PublicDashboard) (string, string, bool) {
+	if pd.TimeSettings == nil {
+		return "", "", false
+	}
+	from := pd.TimeSettings.Get("from").MustString()
+	to := pd.TimeSettings.Get("to").MustString()
+	return from, to, from != "" && to != ""
+}
+
+// findPanel returns the panel with the ID, including the panels of collapsed rows.
+func findPanel(panels *simplejson.Json, panelId int64) *simplejson.Json {
+	for _, p := range panels.MustArray() {
+		panel := simplejson.NewFromAny(p)
+		if panel.Get("id").MustInt64() == panelId {
+			return panel
+		}
+		if nested := findPanel(panel.Get("panels"), panelId); nested != nil {
+			return nested
+		}
+	}
+	return nil
+}
+
 // DeleteDashboard removes dashboard from the DB. Errors out if the dashboard was provisioned. Should be used for
 // operations by the user where we want to make sure user does not delete provisioned dashboard.
 func (dr *DashboardServiceImpl) DeleteDashboard(ctx context.Context, dashboardId int64, orgId int64) error {
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+)
+
+func TestSavePublicDashboardConfig(t *testing.T) {
+	t.Run("generates the identifiers of new public dashboards", func(t *testing.T) {
+		fakeStore := dashboards.NewFakeDashboardStore(t)
+		fakeStore.On("GetPublicDashboardConfig", int64(1), "dash").Return(&models.PublicDashboardConfig{}, nil)
+		fakeStore.On("SavePublicDashboardConfig", mock.Anything).Return(func(cmd models.SavePublicDashboardConfigCommand) *models.PublicDashboardConfig {
+			return &cmd.PublicDashboardConfig
+		}, nil)
+		service := &DashboardServiceImpl{log: log.New("test.logger"), dashboardStore: fakeStore}
+
+		pdc, err := service.SavePublicDashboardConfig(context.Background(), &dashboards.SavePublicDashboardConfigDTO{
+			Uid:                   "dash",
+			OrgId:                 1,
+			PublicDashboardConfig: models.PublicDashboardConfig{IsPublic: true},
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, pdc.PublicDashboard.Uid)
+		require.Len(t, pdc.PublicDashboard.AccessToken, 32)
+	})
+
+	t.Run("keeps the identifiers of existing public dashboards", func(t *testing.T) {
+		fakeStore := dashboards.NewFakeDashboardStore(t)
+		existing := models.PublicDashboard{Uid: "pd", AccessToken: "token"}
+		fakeStore.On("GetPublicDashboardConfig", int64(1), "dash").Return(&models.PublicDashboardConfig{IsPublic: true, PublicDashboard: existing}, nil)
+		fakeStore.On("SavePublicDashboardConfig", mock.Anything).Return(func(cmd models.SavePublicDashboardConfigCommand) *models.PublicDashboardConfig {
+			return &cmd.PublicDashboardConfig
+		}, nil)
+		service := &DashboardServiceImpl{log: log.New("test.logger"), dashboardStore: fakeStore}
+
+		pdc, err := service.SavePublicDashboardConfig(context.Background(), &dashboards.SavePublicDashboardConfigDTO{
+			Uid:   "dash",
+			OrgId: 1,
+			PublicDashboardConfig: models.PublicDashboardConfig{
+				PublicDashboard: models.PublicDashboard{Uid: "other", AccessToken: "other"},
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "pd", pdc.PublicDashboard.Uid)
+		require.Equal(t, "token", pdc.PublicDashboard.AccessToken)
+	})
+}
+
+func TestGetPublicDashboard(t *testing.T) {
+	newDashboard := func() *models.Dashboard {
+		data, err := simplejson.NewJson([]byte(`{"time": {"from": "now-6h", "to": "now"}}`))
+		require.NoError(t, err)
+		return &models.Dashboard{Uid: "dash", OrgId: 1, IsPublic: true, Data: data}
+	}
+
+	t.Run("returns the dashboard with the time range of the public dashboard", func(t *testing.T) {
+		fakeStore := dashboards.NewFakeDashboardStore(t)
+		pd := &models.PublicDashboard{IsEnabled: true, TimeSettings: simplejson.NewFromAny(map[string]interface{}{"from": "now-1h", "to": "now-30m"})}
+		fakeStore.On("GetPublicDashboard", "token").Return(pd, newDashboard(), nil)
+		service := &DashboardServiceImpl{log: log.New("test.logger"), dashboardStore: fakeStore}
+
+		dash, err := service.GetPublicDashboard(context.Background(), "token")
+		require.NoError(t, err)
+		require.Equal(t, "now-1h", dash.Data.GetPath("time", "from").MustString())
+		require.Equal(t, "now-30m", dash.Data.GetPath("time", "to").MustString())
+	})
+
+	t.Run("keeps the time range of the dashboard without time settings", func(t *testing.T) {
+		fakeStore := dashboards.NewFakeDashboardStore(t)
+		fakeStore.On("GetPublicDashboard", "token").Return(&models.PublicDashboard{IsEnabled: true}, newDashboard(), nil)
+		service := &DashboardServiceImpl{log: log.New("test.logger"), dashboardStore: fakeStore}
+
+		dash, err := service.GetPublicDashboard(context.Background(), "token")
+		require.NoError(t, err)
+		require.Equal(t, "now-6h", dash.Data.GetPath("time", "from").MustString())
+	})
+
+	t.Run("returns not found for disabled public dashboards", func(t *testing.T) {
+		fakeStore := dashboards.NewFakeDashboardStore(t)
+		fakeStore.On("GetPublicDashboard", "token").Return(&models.PublicDashboard{IsEnabled: false}, newDashboard(), nil)
+		service := &DashboardServiceImpl{log: log.New("test.logger"), dashboardStore: fakeStore}
+
+		_, err := service.GetPublicDashboard(context.Background(), "token")
+		require.ErrorIs(t, err, models.ErrPublicDashboardNotFound)
+	})
+}
+
+func TestBuildPublicDashboardMetricRequest(t *testing.T) {
+	data, err := simplejson.NewJson([]byte(`{
+		"time": {"from": "now-1h", "to": "now"},
+		"panels": [
+			{
+				"id": 1,
+				"datasource": {"uid": "prom", "type": "prometheus"},
+				"targets": [
+					{"refId": "A", "expr": "up"},
+					{"refId": "B", "expr": "down", "hide": true},
+					{"refId": "C", "expr": "sideways", "datasource": {"uid": "other"}}
+				]
+			},
+			{
+				"id": 2,
+				"type": "row",
+				"collapsed": true,
+				"panels": [{"id": 3, "datasource": {"uid": "loki"}, "targets": [{"refId": "A", "expr": "{app=\"api\"}"}]}]
+			}
+		]
+	}`))
+	require.NoError(t, err)
+	dash := &models.Dashboard{Uid: "dash", OrgId: 1, IsPublic: true, Data: data}
+	service := &DashboardServiceImpl{log: log.New("test.logger")}
+
+	t.Run("returns the visible queries of the panel", func(t *testing.T) {
+		req, err := service.BuildPublicDashboardMetricRequest(context.Background(), dash, 1, dtos.PublicDashboardQueryDTO{IntervalMs: 2000, MaxDataPoints: 500})
+		require.NoError(t, err)
+		require.Equal(t, "now-1h", req.From)
+		require.Equal(t, "now", req.To)
+		require.Len(t, req.Queries, 2)
+		require.Equal(t, "A", req.Queries[0].Get("refId").MustString())
+		require.Equal(t, "prom", req.Queries[0].GetPath("datasource", "uid").MustString())
+		require.Equal(t, int64(2000), req.Queries[0].Get("intervalMs").MustInt64())
+		require.Equal(t, int64(500), req.Queries[0].Get("maxDataPoints").MustInt64())
+		require.Equal(t, "C", req.Queries[1].Get("refId").MustString())
+		require.Equal(t, "other", req.Queries[1].GetPath("datasource", "uid").MustString())
+	})
+
+	t.Run("finds the panels of collapsed rows", func(t *testing.T) {
+		req, err := service.BuildPublicDashboardMetricRequest(context.Background(), dash, 3, dtos.PublicDashboardQueryDTO{})
+		require.NoError(t, err)
+		require.Len(t, req.Queries, 1)
+		require.Equal(t, "loki", req.Queries[0].GetPath("datasource", "uid").MustString())
+	})
+
+	t.Run("returns not found for unknown panels", func(t *testing.T) {
+		_, err := service.BuildPublicDashboardMetricRequest(context.Background(), dash, 4, dtos.PublicDashboardQueryDTO{})
+		require.ErrorIs(t, err, models.ErrDashboardPanelNotFound)
+	})
+}
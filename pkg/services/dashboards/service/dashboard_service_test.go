@@ -12,9 +12,13 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/coremodel/dashboard"
+	"github.com/grafana/grafana/pkg/cuectx"
+	"github.com/grafana/grafana/pkg/framework/coremodel"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/models"
 	m "github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/guardian"
 	"github.com/grafana/grafana/pkg/setting"
 )
@@ -203,6 +207,49 @@ func TestIntegrationDashboardService(t *testing.T) {
 				require.NoError(t, err)
 			})
 		})
+
+		t.Run("Dashboard schema validation", func(t *testing.T) {
+			dcm, err := dashboard.ProvideCoremodel(cuectx.ProvideThemaLibrary())
+			require.NoError(t, err)
+			registry, err := coremodel.NewRegistry(dcm)
+			require.NoError(t, err)
+			cfg := setting.NewCfg()
+			cfg.DashboardSchemaValidation.Mode = setting.DashboardSchemaValidationEnforce
+			service := &DashboardServiceImpl{
+				cfg:                cfg,
+				log:                log.New("test.logger"),
+				dashboardStore:     &fakeStore,
+				dashAlertExtractor: &dummyDashAlertExtractor{},
+				features:           featuremgmt.WithFeatures(),
+				coremodelRegistry:  registry,
+			}
+			newInvalidDashboard := func() *models.Dashboard {
+				dash := models.NewDashboardFromJson(simplejson.NewFromAny(map[string]interface{}{
+					"title":         "Dash",
+					"schemaVersion": 36,
+					"style":         "blue",
+				}))
+				dash.SetId(3)
+				return dash
+			}
+
+			t.Run("Should reject invalid dashboards saved with the API", func(t *testing.T) {
+				dto := &m.SaveDashboardDTO{OrgId: 1, Dashboard: newInvalidDashboard(), User: &models.SignedInUser{UserId: 1}}
+				_, err := service.SaveDashboard(context.Background(), dto, false)
+				var validationErr models.DashboardSchemaValidationError
+				require.ErrorAs(t, err, &validationErr)
+			})
+
+			t.Run("Should not validate provisioned dashboards", func(t *testing.T) {
+				fakeStore.On("ValidateDashboardBeforeSave", mock.Anything, mock.Anything).Return(false, nil).Once()
+				fakeStore.On("SaveProvisionedDashboard", mock.Anything, mock.Anything).Return(&models.Dashboard{Data: simplejson.New()}, nil).Once()
+				fakeStore.On("SaveAlerts", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+				dto := &m.SaveDashboardDTO{OrgId: 1, Dashboard: newInvalidDashboard()}
+				_, err := service.SaveProvisionedDashboard(context.Background(), dto, &models.DashboardProvisioning{})
+				require.NoError(t, err)
+			})
+		})
 	})
 }
 
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	cueerrors "cuelang.org/go/cue/errors"
+
+	"github.com/grafana/grafana/pkg/coremodel/dashboard"
+	"github.com/grafana/grafana/pkg/cuectx"
+	"github.com/grafana/grafana/pkg/infra/metrics"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+// schemaValidationMode returns the schema validation mode of an organization. The validateDashboardsOnSave feature
+// toggle enforces the validation in the organizations without a mode of their own.
+func (dr *DashboardServiceImpl) schemaValidationMode(orgID int64) setting.DashboardSchemaValidationMode {
+	if mode, ok := dr.cfg.DashboardSchemaValidation.OrgModes[orgID]; ok {
+		return mode
+	}
+	if dr.features.IsEnabled(featuremgmt.FlagValidateDashboardsOnSave) {
+		return setting.DashboardSchemaValidationEnforce
+	}
+	if dr.cfg.DashboardSchemaValidation.Mode == "" {
+		return setting.DashboardSchemaValidationOff
+	}
+	return dr.cfg.DashboardSchemaValidation.Mode
+}
+
+// validateDashboardSchema validates a dashboard of an organization against the dashboard coremodel. In warn mode, the
+// errors are logged and the dashboard is saved anyway.
+func (dr *DashboardServiceImpl) validateDashboardSchema(orgID int64, dash *models.Dashboard) error {
+	if dash.IsFolder || dr.coremodelRegistry == nil {
+		return nil
+	}
+	mode := dr.schemaValidationMode(orgID)
+	if mode == setting.DashboardSchemaValidationOff {
+		return nil
+	}
+	cm, has := dr.coremodelRegistry.Get("dashboard")
+	if !has {
+		return nil
+	}
+
+	// Only validate if the schemaVersion is at least the handoff version (the minimum schemaVersion against which
+	// the dashboard schema is known to work), or if schemaVersion is absent.
+	if schv, err := dash.Data.Get("schemaVersion").Int(); err == nil && schv < dashboard.HandoffSchemaVersion {
+		return nil
+	}
+
+	b, err := dash.Data.Encode()
+	if err != nil {
+		return err
+	}
+	v, err := cuectx.JSONtoCUE("dashboard.json", b)
+	if err != nil {
+		return err
+	}
+	if _, err := cm.CurrentSchema().Validate(v); err != nil {
+		metrics.MDashboardSchemaValidationFailures.WithLabelValues(string(mode)).Inc()
+		validationErr := models.DashboardSchemaValidationError{Errors: schemaFieldErrors(err)}
+		if mode == setting.DashboardSchemaValidationWarn {
+			dr.log.Warn("Saving dashboard that is not valid against the dashboard schema", "dashboardUid", dash.Uid,
+				"dashboardTitle", dash.Title, "orgId", orgID, "error", validationErr)
+			return nil
+		}
+		return validationErr
+	}
+
+	return nil
+}
+
+// schemaFieldErrors returns the errors of the fields of a dashboard that failed the validation. Thema returns them
+// as an unexported slice of errors.
+func schemaFieldErrors(err error) []models.DashboardSchemaFieldError {
+	errs := []error{err}
+	if v := reflect.ValueOf(err); v.Kind() == reflect.Slice {
+		errs = make([]error, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			if e, ok := v.Index(i).Interface().(error); ok {
+				errs = append(errs, e)
+			}
+		}
+	}
+
+	fieldErrs := make([]models.DashboardSchemaFieldError, 0, len(errs))
+	for _, e := range errs {
+		fieldErrs = append(fieldErrs, schemaFieldError(e))
+	}
+	return fieldErrs
+}
+
+func schemaFieldError(err error) models.DashboardSchemaFieldError {
+	// Thema wraps the CUE errors it has no handler for
+	var cueErr cueerrors.Error
+	if errors.As(err, &cueErr) {
+		format, args := cueErr.Msg()
+		return models.DashboardSchemaFieldError{
+			Path:    strings.Join(trimSchemaPath(cueErr.Path()), "."),
+			Message: fmt.Sprintf(format, args...),
+		}
+	}
+
+	// The other errors are formatted as "<lineage@version>.path: validation failed, data is not an instance:",
+	// followed by the reason, with the positions in the schema and the data on lines of their own.
+	var lines []string
+	for _, line := range strings.Split(err.Error(), "\n") {
+		if strings.HasPrefix(line, "\t\t") || strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines = append(lines, strings.TrimSpace(line))
+	}
+	msg := strings.Join(lines, " ")
+	i := strings.Index(msg, ": ")
+	if i < 0 {
+		return models.DashboardSchemaFieldError{Message: msg}
+	}
+	path := msg[:i]
+	if j := strings.Index(path, ">."); j >= 0 {
+		path = path[j+2:]
+	}
+	return models.DashboardSchemaFieldError{
+		Path:    path,
+		Message: strings.TrimPrefix(msg[i+2:], "validation failed, data is not an instance: "),
+	}
+}
+
+// trimSchemaPath removes the path of the schema in the lineage from the path of a field, as Thema does.
+func trimSchemaPath(parts []string) []string {
+	for i, s := range parts {
+		if s == "seqs" && len(parts) >= i+4 {
+			return parts[i+4:]
+		}
+	}
+	if len(parts) == 0 {
+		return parts
+	}
+	return parts[1:]
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/coremodel/dashboard"
+	"github.com/grafana/grafana/pkg/cuectx"
+	"github.com/grafana/grafana/pkg/framework/coremodel"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestValidateDashboardSchema(t *testing.T) {
+	dcm, err := dashboard.ProvideCoremodel(cuectx.ProvideThemaLibrary())
+	require.NoError(t, err)
+	registry, err := coremodel.NewRegistry(dcm)
+	require.NoError(t, err)
+
+	newService := func(settings setting.DashboardSchemaValidationSettings, features ...interface{}) *DashboardServiceImpl {
+		cfg := setting.NewCfg()
+		cfg.DashboardSchemaValidation = settings
+		return &DashboardServiceImpl{
+			cfg:               cfg,
+			log:               log.New("test.logger"),
+			features:          featuremgmt.WithFeatures(features...),
+			coremodelRegistry: registry,
+		}
+	}
+	newDashboard := func(t *testing.T, data string) *models.Dashboard {
+		t.Helper()
+		json, err := simplejson.NewJson([]byte(data))
+		require.NoError(t, err)
+		return models.NewDashboardFromJson(json)
+	}
+	const valid = `{"title": "Valid", "schemaVersion": 36}`
+	const invalid = `{"title": "Invalid", "schemaVersion": 36, "style": "blue", "editable": "yes"}`
+
+	t.Run("Should not validate when the validation is off", func(t *testing.T) {
+		s := newService(setting.DashboardSchemaValidationSettings{Mode: setting.DashboardSchemaValidationOff})
+		require.NoError(t, s.validateDashboardSchema(1, newDashboard(t, invalid)))
+	})
+
+	t.Run("Should reject invalid dashboards with the errors of their fields when the validation is enforced", func(t *testing.T) {
+		s := newService(setting.DashboardSchemaValidationSettings{Mode: setting.DashboardSchemaValidationEnforce})
+		require.NoError(t, s.validateDashboardSchema(1, newDashboard(t, valid)))
+
+		err := s.validateDashboardSchema(1, newDashboard(t, invalid))
+		var validationErr models.DashboardSchemaValidationError
+		require.ErrorAs(t, err, &validationErr)
+		paths := map[string]struct{}{}
+		for _, fieldErr := range validationErr.Errors {
+			require.NotEmpty(t, fieldErr.Message)
+			paths[fieldErr.Path] = struct{}{}
+		}
+		require.Equal(t, map[string]struct{}{"style": {}, "editable": {}}, paths)
+	})
+
+	t.Run("Should save invalid dashboards when the validation warns", func(t *testing.T) {
+		s := newService(setting.DashboardSchemaValidationSettings{Mode: setting.DashboardSchemaValidationWarn})
+		require.NoError(t, s.validateDashboardSchema(1, newDashboard(t, invalid)))
+	})
+
+	t.Run("Should use the validation mode of the organization", func(t *testing.T) {
+		s := newService(setting.DashboardSchemaValidationSettings{
+			Mode:     setting.DashboardSchemaValidationOff,
+			OrgModes: map[int64]setting.DashboardSchemaValidationMode{2: setting.DashboardSchemaValidationEnforce},
+		})
+		require.NoError(t, s.validateDashboardSchema(1, newDashboard(t, invalid)))
+		require.Error(t, s.validateDashboardSchema(2, newDashboard(t, invalid)))
+	})
+
+	t.Run("Should enforce the validation with the feature toggle", func(t *testing.T) {
+		s := newService(setting.DashboardSchemaValidationSettings{
+			OrgModes: map[int64]setting.DashboardSchemaValidationMode{2: setting.DashboardSchemaValidationWarn},
+		}, featuremgmt.FlagValidateDashboardsOnSave)
+		require.Error(t, s.validateDashboardSchema(1, newDashboard(t, invalid)))
+		require.NoError(t, s.validateDashboardSchema(2, newDashboard(t, invalid)))
+	})
+
+	t.Run("Should not validate dashboards older than the handoff schema version", func(t *testing.T) {
+		s := newService(setting.DashboardSchemaValidationSettings{Mode: setting.DashboardSchemaValidationEnforce})
+		require.NoError(t, s.validateDashboardSchema(1, newDashboard(t, `{"title": "Old", "schemaVersion": 30, "style": "blue"}`)))
+	})
+
+	t.Run("Should not validate folders", func(t *testing.T) {
+		s := newService(setting.DashboardSchemaValidationSettings{Mode: setting.DashboardSchemaValidationEnforce})
+		folder := newDashboard(t, invalid)
+		folder.IsFolder = true
+		require.NoError(t, s.validateDashboardSchema(1, folder))
+	})
+}
@@ -261,6 +261,38 @@ func (_m *FakeDashboardStore) GetProvisionedDataByDashboardUID(orgID int64, dash
 	return r0, r1
 }
 
+// GetPublicDashboard provides a mock function with given fields: accessToken
+func (_m *FakeDashboardStore) GetPublicDashboard(accessToken string) (*models.PublicDashboard, *models.Dashboard, error) {
+	ret := _m.Called(accessToken)
+
+	var r0 *models.PublicDashboard
+	if rf, ok := ret.Get(0).(func(string) *models.PublicDashboard); ok {
+		r0 = rf(accessToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PublicDashboard)
+		}
+	}
+
+	var r1 *models.Dashboard
+	if rf, ok := ret.Get(1).(func(string) *models.Dashboard); ok {
+		r1 = rf(accessToken)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.Dashboard)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(accessToken)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetPublicDashboardConfig provides a mock function with given fields: orgId, dashboardUid
 func (_m *FakeDashboardStore) GetPublicDashboardConfig(orgId int64, dashboardUid string) (*models.PublicDashboardConfig, error) {
 	ret := _m.Called(orgId, dashboardUid)
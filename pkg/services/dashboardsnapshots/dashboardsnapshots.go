@@ -5,19 +5,22 @@ import (
 
 	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/query"
 	"github.com/grafana/grafana/pkg/services/secrets"
 	"github.com/grafana/grafana/pkg/services/sqlstore"
 )
 
 type Service struct {
-	SQLStore       sqlstore.Store
-	SecretsService secrets.Service
+	SQLStore         sqlstore.Store
+	SecretsService   secrets.Service
+	QueryDataService QueryDataService
 }
 
-func ProvideService(store sqlstore.Store, secretsService secrets.Service) *Service {
+func ProvideService(store sqlstore.Store, secretsService secrets.Service, queryDataService *query.Service) *Service {
 	s := &Service{
-		SQLStore:       store,
-		SecretsService: secretsService,
+		SQLStore:         store,
+		SecretsService:   secretsService,
+		QueryDataService: queryDataService,
 	}
 
 	return s
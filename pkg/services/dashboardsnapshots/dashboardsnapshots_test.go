@@ -2,10 +2,16 @@ package dashboardsnapshots
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
 
 	"github.com/grafana/grafana/pkg/services/secrets/database"
 
+	"github.com/grafana/grafana/pkg/api/dtos"
 	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/models"
 	secretsManager "github.com/grafana/grafana/pkg/services/secrets/manager"
@@ -69,4 +75,157 @@ func TestDashboardSnapshotsService(t *testing.T) {
 
 		require.Equal(t, rawDashboard, decrypted)
 	})
+	t.Run("take dashboard snapshot should replace the queries with their results", func(t *testing.T) {
+		ctx := context.Background()
+		queryDataService := &fakeQueryDataService{}
+		s.QueryDataService = queryDataService
+
+		dash := models.NewDashboard("Servers")
+		dash.OrgId = 1
+		dash.Uid = "servers"
+		dash.Data.Set("time", map[string]interface{}{"from": "now-1h", "to": "now"})
+		dash.Data.SetPath([]string{"templating", "list"}, []interface{}{
+			map[string]interface{}{"name": "host", "query": "label_values(host)", "current": map[string]interface{}{"value": "server-1"}},
+			map[string]interface{}{"name": "hostname", "query": "label_values(hostname)", "current": map[string]interface{}{"value": []interface{}{"a.1", "b"}}},
+		})
+		dash.Data.Set("panels", []interface{}{
+			map[string]interface{}{"id": 1, "type": "text"},
+			map[string]interface{}{
+				"id":         2,
+				"datasource": map[string]interface{}{"type": "prometheus", "uid": "prom"},
+				"links":      []interface{}{map[string]interface{}{"url": "http://example.com"}},
+				"targets": []interface{}{
+					map[string]interface{}{"refId": "A", "expr": `up{host="$host", hostname=~"${hostname}", hostnames="${hostname:csv}"}`},
+					map[string]interface{}{"refId": "B", "expr": "up", "hide": true},
+				},
+			},
+			map[string]interface{}{"id": 3, "type": "row", "panels": []interface{}{
+				map[string]interface{}{"id": 4, "targets": []interface{}{
+					map[string]interface{}{"refId": "A", "datasource": map[string]interface{}{"uid": "loki"}, "expr": "[[host]]"},
+				}},
+			}},
+		})
+
+		cmd := models.TakeDashboardSnapshotCommand{
+			From:         "now-3h",
+			To:           "now",
+			Variables:    map[string]string{"host": "server-2"},
+			Expires:      3600,
+			Dashboard:    dash,
+			SignedInUser: &models.SignedInUser{UserId: 2, OrgId: 1},
+		}
+		err := s.TakeDashboardSnapshot(ctx, &cmd)
+		require.NoError(t, err)
+		require.Equal(t, "Servers", cmd.Result.Name)
+		require.NotEmpty(t, cmd.Result.Key)
+		require.NotEmpty(t, cmd.Result.DeleteKey)
+		require.Equal(t, int64(2), cmd.Result.UserId)
+		require.True(t, cmd.Result.Expires.After(time.Now()))
+
+		require.Len(t, queryDataService.requests, 2)
+		queries := queryDataService.requests[0].Queries
+		require.Len(t, queries, 1)
+		require.Equal(t, `up{host="server-2", hostname=~"(a\\.1|b)", hostnames="a.1,b"}`, queries[0].Get("expr").MustString())
+		require.Equal(t, "prom", queries[0].GetPath("datasource", "uid").MustString())
+		require.Equal(t, int64(defaultMaxDataPoints), queries[0].Get("maxDataPoints").MustInt64())
+		require.Equal(t, (3*time.Hour).Milliseconds()/defaultMaxDataPoints, queries[0].Get("intervalMs").MustInt64())
+		require.Equal(t, "server-2", queryDataService.requests[1].Queries[0].Get("expr").MustString())
+
+		decrypted, err := s.SecretsService.Decrypt(ctx, cmd.Result.DashboardEncrypted)
+		require.NoError(t, err)
+		snapshot, err := simplejson.NewJson(decrypted)
+		require.NoError(t, err)
+
+		panels := snapshot.Get("panels")
+		_, hasData := panels.GetIndex(0).CheckGet("snapshotData")
+		require.False(t, hasData)
+		panel := panels.GetIndex(1)
+		require.Empty(t, panel.Get("targets").MustArray())
+		require.Empty(t, panel.Get("links").MustArray())
+		require.Nil(t, panel.Get("datasource").Interface())
+		frame := panel.Get("snapshotData").GetIndex(0)
+		require.Equal(t, "A", frame.Get("refId").MustString())
+		require.Equal(t, "time", frame.Get("fields").GetIndex(0).Get("type").MustString())
+		require.Equal(t, []interface{}{json.Number("1654077600000"), json.Number("1654077660000")}, frame.Get("fields").GetIndex(0).Get("values").MustArray())
+		require.Equal(t, "number", frame.Get("fields").GetIndex(1).Get("type").MustString())
+		require.Equal(t, []interface{}{json.Number("1"), nil}, frame.Get("fields").GetIndex(1).Get("values").MustArray())
+		require.Equal(t, "server-2", frame.Get("fields").GetIndex(1).GetPath("labels", "host").MustString())
+		require.Len(t, panels.GetIndex(2).Get("panels").GetIndex(0).Get("snapshotData").MustArray(), 1)
+
+		variable := snapshot.GetPath("templating", "list").GetIndex(0)
+		require.Equal(t, "", variable.Get("query").MustString())
+		require.Equal(t, "server-2", variable.GetPath("current", "value").MustString())
+		require.Empty(t, snapshot.GetPath("annotations", "list").MustArray())
+		require.Equal(t, "/d/servers/servers", snapshot.GetPath("snapshot", "originalUrl").MustString())
+	})
+
+	t.Run("take dashboard snapshot should interpolate the data source variables of the panels", func(t *testing.T) {
+		queryDataService := &fakeQueryDataService{}
+		s.QueryDataService = queryDataService
+
+		dash := models.NewDashboard("Services")
+		dash.OrgId = 1
+		dash.Data.SetPath([]string{"templating", "list"}, []interface{}{
+			map[string]interface{}{"name": "ds", "type": "datasource", "query": "loki", "current": map[string]interface{}{"value": "loki-prod"}},
+			map[string]interface{}{"name": "job", "query": "label_values(job)", "current": map[string]interface{}{"value": []interface{}{"api", "web"}}},
+		})
+		dash.Data.Set("panels", []interface{}{
+			map[string]interface{}{
+				"id":         1,
+				"datasource": map[string]interface{}{"uid": "${ds}"},
+				"targets": []interface{}{
+					map[string]interface{}{"refId": "A", "expr": `{job=~"$job"}`},
+				},
+			},
+			map[string]interface{}{
+				"id":         2,
+				"datasource": map[string]interface{}{"type": "graphite", "uid": "graphite"},
+				"targets": []interface{}{
+					map[string]interface{}{"refId": "A", "target": "services.$job.cpu"},
+				},
+			},
+		})
+
+		cmd := models.TakeDashboardSnapshotCommand{
+			Dashboard:    dash,
+			SignedInUser: &models.SignedInUser{UserId: 2, OrgId: 1},
+		}
+		err := s.TakeDashboardSnapshot(context.Background(), &cmd)
+		require.NoError(t, err)
+
+		require.Len(t, queryDataService.requests, 2)
+		query := queryDataService.requests[0].Queries[0]
+		require.Equal(t, "loki-prod", query.GetPath("datasource", "uid").MustString())
+		require.Equal(t, `{job=~"(api|web)"}`, query.Get("expr").MustString())
+		require.Equal(t, "services.{api,web}.cpu", queryDataService.requests[1].Queries[0].Get("target").MustString())
+	})
+
+	t.Run("take dashboard snapshot should fail for invalid time ranges", func(t *testing.T) {
+		dash := models.NewDashboard("Servers")
+		cmd := models.TakeDashboardSnapshotCommand{
+			From:         "now",
+			To:           "now-1h",
+			Dashboard:    dash,
+			SignedInUser: &models.SignedInUser{UserId: 2, OrgId: 1},
+		}
+		err := s.TakeDashboardSnapshot(context.Background(), &cmd)
+		require.ErrorIs(t, err, ErrInvalidTimeRange)
+	})
+}
+
+type fakeQueryDataService struct {
+	requests []dtos.MetricRequest
+}
+
+func (s *fakeQueryDataService) QueryData(_ context.Context, _ *models.SignedInUser, _ bool, reqDTO dtos.MetricRequest, _ bool) (*backend.QueryDataResponse, error) {
+	s.requests = append(s.requests, reqDTO)
+	from := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	one := 1.0
+	frame := data.NewFrame("",
+		data.NewField("time", nil, []time.Time{from, from.Add(time.Minute)}),
+		data.NewField("value", data.Labels{"host": "server-2"}, []*float64{&one, nil}),
+	)
+	return &backend.QueryDataResponse{Responses: backend.Responses{
+		reqDTO.Queries[0].Get("refId").MustString(): {Frames: data.Frames{frame}},
+	}}, nil
 }
@@ -0,0 +1,389 @@
+package dashboardsnapshots
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/tsdb/legacydata"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+// defaultMaxDataPoints is the maximum number of data points of the queries of the panels that do not set one.
+const defaultMaxDataPoints = 1000
+
+var ErrInvalidTimeRange = errors.New("invalid time range")
+
+// QueryDataService runs the queries of the panels of the snapshots taken by the server.
+type QueryDataService interface {
+	QueryData(ctx context.Context, user *models.SignedInUser, skipCache bool, reqDTO dtos.MetricRequest, handleExpressions bool) (*backend.QueryDataResponse, error)
+}
+
+// TakeDashboardSnapshot creates a snapshot of a saved dashboard. The queries of every panel are run over the time
+// range of the command with the permissions of the signed in user, and their results are embedded in the snapshot
+// in place of the queries, as the browser does when sharing a snapshot.
+func (s *Service) TakeDashboardSnapshot(ctx context.Context, cmd *models.TakeDashboardSnapshotCommand) error {
+	dash := cmd.Dashboard
+	from := cmd.From
+	if from == "" {
+		from = dash.Data.GetPath("time", "from").MustString("now-6h")
+	}
+	to := cmd.To
+	if to == "" {
+		to = dash.Data.GetPath("time", "to").MustString("now")
+	}
+	timeRange := legacydata.NewDataTimeRange(from, to)
+	fromTime, err := timeRange.ParseFrom()
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidTimeRange, err)
+	}
+	toTime, err := timeRange.ParseTo()
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidTimeRange, err)
+	}
+	if !toTime.After(fromTime) {
+		return ErrInvalidTimeRange
+	}
+
+	// the dashboard is modified in place, so work on a copy
+	b, err := dash.Data.Encode()
+	if err != nil {
+		return err
+	}
+	snapshot, err := simplejson.NewJson(b)
+	if err != nil {
+		return err
+	}
+
+	variables := snapshotVariables(snapshot, cmd.Variables)
+	for _, panel := range snapshot.Get("panels").MustArray() {
+		if err := s.snapshotPanel(ctx, cmd.SignedInUser, simplejson.NewFromAny(panel), fromTime, toTime, variables); err != nil {
+			return err
+		}
+	}
+	snapshot.SetPath([]string{"annotations", "list"}, []interface{}{})
+	snapshot.Set("time", map[string]interface{}{
+		"from": fromTime.UTC().Format(time.RFC3339Nano),
+		"to":   toTime.UTC().Format(time.RFC3339Nano),
+	})
+	snapshot.Set("refresh", "")
+	snapshot.Set("snapshot", map[string]interface{}{
+		"timestamp":   time.Now().UTC().Format(time.RFC3339Nano),
+		"originalUrl": dash.GetUrl(),
+	})
+
+	name := cmd.Name
+	if name == "" {
+		name = dash.Title
+	}
+	createCmd := models.CreateDashboardSnapshotCommand{
+		Dashboard: snapshot,
+		Name:      name,
+		Expires:   cmd.Expires,
+		OrgId:     dash.OrgId,
+		UserId:    cmd.SignedInUser.UserId,
+	}
+	if createCmd.Key, err = util.GetRandomString(32); err != nil {
+		return err
+	}
+	if createCmd.DeleteKey, err = util.GetRandomString(32); err != nil {
+		return err
+	}
+	if err := s.CreateDashboardSnapshot(ctx, &createCmd); err != nil {
+		return err
+	}
+	cmd.Result = createCmd.Result
+	return nil
+}
+
+// snapshotPanel replaces the queries of the panel, and of the panels of a collapsed row, with their results.
+func (s *Service) snapshotPanel(ctx context.Context, user *models.SignedInUser, panel *simplejson.Json, from, to time.Time, variables []*snapshotVariable) error {
+	for _, nested := range panel.Get("panels").MustArray() {
+		if err := s.snapshotPanel(ctx, user, simplejson.NewFromAny(nested), from, to, variables); err != nil {
+			return err
+		}
+	}
+
+	targets := panel.Get("targets").MustArray()
+	if len(targets) == 0 {
+		return nil
+	}
+
+	maxDataPoints := panel.Get("maxDataPoints").MustInt64(defaultMaxDataPoints)
+	intervalMs := to.Sub(from).Milliseconds() / maxDataPoints
+	if intervalMs < 1 {
+		intervalMs = 1
+	}
+	metricReq := dtos.MetricRequest{
+		From:    strconv.FormatInt(from.UnixMilli(), 10),
+		To:      strconv.FormatInt(to.UnixMilli(), 10),
+		Queries: []*simplejson.Json{},
+	}
+	for _, target := range targets {
+		query := simplejson.NewFromAny(target)
+		if query.Get("hide").MustBool() {
+			continue
+		}
+		// the queries use the data source of the panel, unless they set their own
+		if ds, ok := panel.CheckGet("datasource"); ok {
+			if _, ok := query.CheckGet("datasource"); !ok {
+				query.Set("datasource", ds.Interface())
+			}
+		}
+		dsType := snapshotDatasourceType(query.Get("datasource"), variables)
+		query = simplejson.NewFromAny(interpolateVariables(query.Interface(), variables, dsType))
+		query.Set("maxDataPoints", maxDataPoints)
+		query.Set("intervalMs", intervalMs)
+		metricReq.Queries = append(metricReq.Queries, query)
+	}
+
+	snapshotData := []interface{}{}
+	if len(metricReq.Queries) > 0 {
+		resp, err := s.QueryDataService.QueryData(ctx, user, false, metricReq, true)
+		if err != nil {
+			return fmt.Errorf("failed to query the data of panel %d: %w", panel.Get("id").MustInt64(), err)
+		}
+		refIDs := make([]string, 0, len(resp.Responses))
+		for refID := range resp.Responses {
+			refIDs = append(refIDs, refID)
+		}
+		sort.Strings(refIDs)
+		for _, refID := range refIDs {
+			for _, frame := range resp.Responses[refID].Frames {
+				if frame.RefID == "" {
+					frame.RefID = refID
+				}
+				snapshotData = append(snapshotData, frameToSnapshotData(frame))
+			}
+		}
+	}
+
+	panel.Set("targets", []interface{}{})
+	panel.Set("links", []interface{}{})
+	panel.Set("datasource", nil)
+	panel.Set("snapshotData", snapshotData)
+	return nil
+}
+
+// frameToSnapshotData returns a data frame in the format of the data of the panels of snapshots, the format of the
+// DataFrameDTO of the frontend.
+func frameToSnapshotData(frame *data.Frame) map[string]interface{} {
+	fields := make([]interface{}, 0, len(frame.Fields))
+	for _, field := range frame.Fields {
+		values := make([]interface{}, field.Len())
+		for i := range values {
+			v, ok := field.ConcreteAt(i)
+			if !ok {
+				continue
+			}
+			switch value := v.(type) {
+			case time.Time:
+				values[i] = value.UnixMilli()
+			case float64:
+				if !math.IsNaN(value) && !math.IsInf(value, 0) {
+					values[i] = value
+				}
+			case float32:
+				if !math.IsNaN(float64(value)) && !math.IsInf(float64(value), 0) {
+					values[i] = value
+				}
+			default:
+				values[i] = value
+			}
+		}
+		f := map[string]interface{}{
+			"name":   field.Name,
+			"type":   snapshotFieldType(field.Type()),
+			"values": values,
+		}
+		if field.Config != nil {
+			f["config"] = field.Config
+		} else {
+			f["config"] = map[string]interface{}{}
+		}
+		if len(field.Labels) > 0 {
+			f["labels"] = field.Labels
+		}
+		fields = append(fields, f)
+	}
+	result := map[string]interface{}{
+		"name":   frame.Name,
+		"refId":  frame.RefID,
+		"fields": fields,
+	}
+	if frame.Meta != nil {
+		result["meta"] = frame.Meta
+	}
+	return result
+}
+
+// snapshotFieldType returns the frontend type of a field.
+func snapshotFieldType(t data.FieldType) string {
+	switch {
+	case t.Time():
+		return "time"
+	case t.Numeric():
+		return "number"
+	case t == data.FieldTypeString || t == data.FieldTypeNullableString:
+		return "string"
+	case t == data.FieldTypeBool || t == data.FieldTypeNullableBool:
+		return "boolean"
+	}
+	return "other"
+}
+
+type snapshotVariable struct {
+	name   string
+	values []string
+	// dsType is the plugin type of the data sources of a data source variable.
+	dsType  string
+	pattern *regexp.Regexp
+}
+
+// snapshotVariables sets the values of the template variables of the dashboard, and removes their queries. It returns
+// the variables, the longest names first so that the variables whose names start with the name of another variable
+// are interpolated first.
+func snapshotVariables(dash *simplejson.Json, values map[string]string) []*snapshotVariable {
+	var variables []*snapshotVariable
+	for _, v := range dash.GetPath("templating", "list").MustArray() {
+		variable := simplejson.NewFromAny(v)
+		name := variable.Get("name").MustString()
+		if name == "" {
+			continue
+		}
+
+		var current []string
+		if value, ok := values[name]; ok {
+			current = []string{value}
+		} else if value, err := variable.GetPath("current", "value").String(); err == nil {
+			current = []string{value}
+		} else {
+			current = variable.GetPath("current", "value").MustStringArray()
+		}
+		var dsType string
+		if variable.Get("type").MustString() == "datasource" {
+			dsType = variable.Get("query").MustString()
+		}
+
+		option := map[string]interface{}{"text": strings.Join(current, " + "), "value": current, "selected": true}
+		if len(current) == 1 {
+			option["value"] = current[0]
+		}
+		variable.Set("current", option)
+		variable.Set("options", []interface{}{option})
+		variable.Set("query", "")
+		variable.Set("refresh", 0)
+
+		quoted := regexp.QuoteMeta(name)
+		variables = append(variables, &snapshotVariable{
+			name:    name,
+			values:  current,
+			dsType:  dsType,
+			pattern: regexp.MustCompile(`\$\{` + quoted + `(?::([^}]*))?\}|\[\[` + quoted + `(?::([^\]]*))?\]\]|\$` + quoted + `\b`),
+		})
+	}
+	sort.SliceStable(variables, func(i, j int) bool {
+		return len(variables[i].name) > len(variables[j].name)
+	})
+	return variables
+}
+
+// snapshotDatasourceType returns the plugin type of the data source of a query, if it is known.
+func snapshotDatasourceType(ds *simplejson.Json, variables []*snapshotVariable) string {
+	if dsType := ds.Get("type").MustString(); dsType != "" {
+		return dsType
+	}
+	// the data sources referenced by the name or the UID of a data source variable have the type of the variable
+	ref := ds.Get("uid").MustString(ds.MustString())
+	for _, variable := range variables {
+		if variable.dsType != "" && variable.pattern.MatchString(ref) {
+			return variable.dsType
+		}
+	}
+	return ""
+}
+
+// interpolateVariables replaces the template variables in every string of a query of a data source of the given type.
+func interpolateVariables(v interface{}, variables []*snapshotVariable, dsType string) interface{} {
+	switch value := v.(type) {
+	case string:
+		for _, variable := range variables {
+			value = variable.pattern.ReplaceAllStringFunc(value, func(match string) string {
+				groups := variable.pattern.FindStringSubmatch(match)
+				return formatVariable(variable.values, groups[1]+groups[2], dsType)
+			})
+		}
+		return value
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for k, item := range value {
+			result[k] = interpolateVariables(item, variables, dsType)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, item := range value {
+			result[i] = interpolateVariables(item, variables, dsType)
+		}
+		return result
+	}
+	return v
+}
+
+// defaultMultiValueFormats are the formats of the values of multi-value variables in the queries of the data sources
+// that expect regular expressions. The variables of the other data sources are formatted as globs, as in the frontend.
+var defaultMultiValueFormats = map[string]string{
+	"prometheus": "promregex",
+	"loki":       "promregex",
+	"influxdb":   "regex",
+}
+
+// formatVariable returns the values of a variable in the format given in the query with ${var:format}, or in the
+// default format of the data source when the variable has several values.
+func formatVariable(values []string, format string, dsType string) string {
+	if format == "" {
+		if len(values) < 2 {
+			return strings.Join(values, ",")
+		}
+		if format = defaultMultiValueFormats[dsType]; format == "" {
+			format = "glob"
+		}
+	}
+
+	switch format {
+	case "pipe":
+		return strings.Join(values, "|")
+	case "glob":
+		if len(values) == 1 {
+			return values[0]
+		}
+		return "{" + strings.Join(values, ",") + "}"
+	case "regex", "promregex":
+		escaped := make([]string, len(values))
+		for i, value := range values {
+			escaped[i] = regexp.QuoteMeta(value)
+			// the regular expressions of PromQL and LogQL are string literals, which escape backslashes
+			if format == "promregex" {
+				escaped[i] = strings.ReplaceAll(escaped[i], `\`, `\\`)
+			}
+		}
+		if len(escaped) == 1 {
+			return escaped[0]
+		}
+		return "(" + strings.Join(escaped, "|") + ")"
+	}
+	// csv, raw and the formats the server does not support
+	return strings.Join(values, ",")
+}
@@ -6,4 +6,5 @@ import (
 
 type Service interface {
 	Get(ctx context.Context, query *GetDashboardVersionQuery) (*DashboardVersion, error)
+	Update(ctx context.Context, cmd *UpdateDashboardVersionCommand) (*DashboardVersion, error)
 }
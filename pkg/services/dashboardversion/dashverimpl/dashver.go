@@ -28,3 +28,10 @@ func (s *Service) Get(ctx context.Context, query *dashver.GetDashboardVersionQue
 	version.Data.Set("id", version.DashboardID)
 	return version, nil
 }
+
+func (s *Service) Update(ctx context.Context, cmd *dashver.UpdateDashboardVersionCommand) (*dashver.DashboardVersion, error) {
+	if err := s.store.Update(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, &dashver.GetDashboardVersionQuery{DashboardID: cmd.DashboardID, OrgID: cmd.OrgID, Version: cmd.Version})
+}
@@ -37,3 +37,7 @@ func newDashboardVersionStoreFake() *FakeDashboardVersionStroe {
 func (f *FakeDashboardVersionStroe) Get(ctx context.Context, query *dashver.GetDashboardVersionQuery) (*dashver.DashboardVersion, error) {
 	return f.ExpectedDashboardVersion, f.ExpectedError
 }
+
+func (f *FakeDashboardVersionStroe) Update(ctx context.Context, cmd *dashver.UpdateDashboardVersionCommand) error {
+	return f.ExpectedError
+}
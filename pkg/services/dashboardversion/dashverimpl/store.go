@@ -11,6 +11,7 @@ import (
 
 type store interface {
 	Get(ctx context.Context, query *dashver.GetDashboardVersionQuery) (*dashver.DashboardVersion, error)
+	Update(ctx context.Context, cmd *dashver.UpdateDashboardVersionCommand) error
 }
 
 type sqlStore struct {
@@ -38,3 +39,34 @@ func (s *sqlStore) Get(ctx context.Context, query *dashver.GetDashboardVersionQu
 	}
 	return &version, nil
 }
+
+func (s *sqlStore) Update(ctx context.Context, cmd *dashver.UpdateDashboardVersionCommand) error {
+	return s.db.WithTransactionalDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		// the version must belong to a dashboard of the organization
+		var id int64
+		has, err := sess.Table("dashboard_version").
+			Join("INNER", "dashboard", `dashboard.id = dashboard_version.dashboard_id`).
+			Where("dashboard_version.dashboard_id=? AND dashboard_version.version=? AND dashboard.org_id=?", cmd.DashboardID, cmd.Version, cmd.OrgID).
+			Cols("dashboard_version.id").
+			Get(&id)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return models.ErrDashboardVersionNotFound
+		}
+
+		columns := map[string]interface{}{}
+		if cmd.Name != nil {
+			columns["name"] = *cmd.Name
+		}
+		if cmd.Pinned != nil {
+			columns["pinned"] = *cmd.Pinned
+		}
+		if len(columns) == 0 {
+			return nil
+		}
+		_, err = sess.Table("dashboard_version").Where("id = ?", id).Update(columns)
+		return err
+	})
+}
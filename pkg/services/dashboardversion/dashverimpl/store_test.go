@@ -58,6 +58,45 @@ func TestIntegrationDashboardVersion(t *testing.T) {
 		require.Error(t, err)
 		require.Equal(t, models.ErrDashboardVersionNotFound, err)
 	})
+
+	t.Run("Name and pin a dashboard version", func(t *testing.T) {
+		savedDash := insertTestDashboard(t, ss, "test dash 27", 1, 0, false, "diff")
+		name := "pre-migration"
+		pinned := true
+
+		err := dashVerStore.Update(context.Background(), &dashver.UpdateDashboardVersionCommand{
+			DashboardID: savedDash.Id,
+			OrgID:       1,
+			Version:     savedDash.Version,
+			Name:        &name,
+		})
+		require.NoError(t, err)
+		err = dashVerStore.Update(context.Background(), &dashver.UpdateDashboardVersionCommand{
+			DashboardID: savedDash.Id,
+			OrgID:       1,
+			Version:     savedDash.Version,
+			Pinned:      &pinned,
+		})
+		require.NoError(t, err)
+
+		res, err := dashVerStore.Get(context.Background(), &dashver.GetDashboardVersionQuery{DashboardID: savedDash.Id, OrgID: 1, Version: savedDash.Version})
+		require.NoError(t, err)
+		require.Equal(t, name, res.Name)
+		require.True(t, res.Pinned)
+	})
+
+	t.Run("Attempt to update a version of another organization", func(t *testing.T) {
+		savedDash := insertTestDashboard(t, ss, "test dash 28", 1, 0, false, "diff")
+		name := "pre-migration"
+
+		err := dashVerStore.Update(context.Background(), &dashver.UpdateDashboardVersionCommand{
+			DashboardID: savedDash.Id,
+			OrgID:       2,
+			Version:     savedDash.Version,
+			Name:        &name,
+		})
+		require.Equal(t, models.ErrDashboardVersionNotFound, err)
+	})
 }
 
 func getDashboard(t *testing.T, sqlStore *sqlstore.SQLStore, dashboard *models.Dashboard) error {
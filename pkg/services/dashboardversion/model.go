@@ -7,11 +7,13 @@ import (
 )
 
 type DashboardVersion struct {
-	ID            int64 `json:"id"`
-	DashboardID   int64 `json:"dashboardId"`
-	ParentVersion int   `json:"parentVersion"`
-	RestoredFrom  int   `json:"restoredFrom"`
-	Version       int   `json:"version"`
+	ID            int64  `json:"id"`
+	DashboardID   int64  `json:"dashboardId"`
+	ParentVersion int    `json:"parentVersion"`
+	RestoredFrom  int    `json:"restoredFrom"`
+	Version       int    `json:"version"`
+	Name          string `json:"name"`
+	Pinned        bool   `json:"pinned"`
 
 	Created   time.Time `json:"created"`
 	CreatedBy int64     `json:"createdBy"`
@@ -25,3 +27,12 @@ type GetDashboardVersionQuery struct {
 	OrgID       int64
 	Version     int
 }
+
+// UpdateDashboardVersionCommand names or pins a dashboard version. The fields that are nil are left unchanged.
+type UpdateDashboardVersionCommand struct {
+	DashboardID int64   `json:"-"`
+	OrgID       int64   `json:"-"`
+	Version     int     `json:"-"`
+	Name        *string `json:"name"`
+	Pinned      *bool   `json:"pinned"`
+}
@@ -153,6 +153,11 @@ var (
 			Description: "Enable annotation comments",
 			State:       FeatureStateAlpha,
 		},
+		{
+			Name:        "alertRuleComments",
+			Description: "Enable alert rule comments",
+			State:       FeatureStateAlpha,
+		},
 		{
 			Name:        "migrationLocking",
 			Description: "Lock database during migrations",
@@ -248,5 +253,10 @@ var (
 			Description: "Enable wide series responses in the Prometheus datasource",
 			State:       FeatureStateAlpha,
 		},
+		{
+			Name:        "migrateDashboardsOnRead",
+			Description: "Migrate the dashboards returned by api/dashboards/uid to the latest schema version",
+			State:       FeatureStateAlpha,
+		},
 	}
 )
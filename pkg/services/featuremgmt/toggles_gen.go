@@ -115,6 +115,10 @@ const (
 	// Enable annotation comments
 	FlagAnnotationComments = "annotationComments"
 
+	// FlagAlertRuleComments
+	// Enable alert rule comments
+	FlagAlertRuleComments = "alertRuleComments"
+
 	// FlagMigrationLocking
 	// Lock database during migrations
 	FlagMigrationLocking = "migrationLocking"
@@ -182,4 +186,8 @@ const (
 	// FlagPrometheusWideSeries
 	// Enable wide series responses in the Prometheus datasource
 	FlagPrometheusWideSeries = "prometheusWideSeries"
+
+	// FlagMigrateDashboardsOnRead
+	// Migrate the dashboards returned by api/dashboards/uid to the latest schema version
+	FlagMigrateDashboardsOnRead = "migrateDashboardsOnRead"
 )
@@ -3,9 +3,11 @@ package libraryelements
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/grafana/grafana/pkg/api/response"
 	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/components/dashdiffs"
 	"github.com/grafana/grafana/pkg/middleware"
 	"github.com/grafana/grafana/pkg/models"
 	"github.com/grafana/grafana/pkg/web"
@@ -20,6 +22,10 @@ func (l *LibraryElementService) registerAPIEndpoints() {
 		entities.Get("/:uid/connections/", middleware.ReqSignedIn, routing.Wrap(l.getConnectionsHandler))
 		entities.Get("/name/:name", middleware.ReqSignedIn, routing.Wrap(l.getByNameHandler))
 		entities.Patch("/:uid", middleware.ReqSignedIn, routing.Wrap(l.patchHandler))
+		entities.Get("/:uid/versions", middleware.ReqSignedIn, routing.Wrap(l.getVersionsHandler))
+		entities.Get("/:uid/versions/:version", middleware.ReqSignedIn, routing.Wrap(l.getVersionHandler))
+		entities.Post("/:uid/calculate-diff", middleware.ReqSignedIn, routing.Wrap(l.calculateDiffHandler))
+		entities.Post("/:uid/restore", middleware.ReqSignedIn, routing.Wrap(l.restoreHandler))
 	})
 }
 
@@ -140,6 +146,75 @@ func (l *LibraryElementService) patchHandler(c *models.ReqContext) response.Resp
 	return response.JSON(http.StatusOK, LibraryElementResponse{Result: element})
 }
 
+// getVersionsHandler handles GET /api/library-elements/:uid/versions.
+func (l *LibraryElementService) getVersionsHandler(c *models.ReqContext) response.Response {
+	versions, err := l.getLibraryElementVersions(c.Req.Context(), c.SignedInUser, web.Params(c.Req)[":uid"], c.QueryInt("limit"), c.QueryInt("start"))
+	if err != nil {
+		return toLibraryElementError(err, "Failed to get library element versions")
+	}
+
+	return response.JSON(http.StatusOK, LibraryElementVersionsResponse{Result: versions})
+}
+
+// getVersionHandler handles GET /api/library-elements/:uid/versions/:version.
+func (l *LibraryElementService) getVersionHandler(c *models.ReqContext) response.Response {
+	version, err := strconv.ParseInt(web.Params(c.Req)[":version"], 10, 64)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "version is invalid", err)
+	}
+
+	elementVersion, err := l.getLibraryElementVersion(c.Req.Context(), c.SignedInUser, web.Params(c.Req)[":uid"], version)
+	if err != nil {
+		return toLibraryElementError(err, "Failed to get library element version")
+	}
+
+	return response.JSON(http.StatusOK, LibraryElementVersionResponse{Result: elementVersion})
+}
+
+// calculateDiffHandler handles POST /api/library-elements/:uid/calculate-diff.
+func (l *LibraryElementService) calculateDiffHandler(c *models.ReqContext) response.Response {
+	cmd := CalculateLibraryElementDiffCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+
+	result, err := l.calculateLibraryElementDiff(c.Req.Context(), c.SignedInUser, web.Params(c.Req)[":uid"], cmd)
+	if err != nil {
+		return toLibraryElementError(err, "Unable to compute diff")
+	}
+
+	if dashdiffs.ParseDiffType(cmd.DiffType) == dashdiffs.DiffDelta {
+		return response.Respond(http.StatusOK, result.Delta).SetHeader("Content-Type", "application/json")
+	}
+
+	return response.Respond(http.StatusOK, result.Delta).SetHeader("Content-Type", "text/html")
+}
+
+// restoreHandler handles POST /api/library-elements/:uid/restore.
+func (l *LibraryElementService) restoreHandler(c *models.ReqContext) response.Response {
+	cmd := RestoreLibraryElementVersionCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+
+	element, err := l.restoreLibraryElementVersion(c.Req.Context(), c.SignedInUser, web.Params(c.Req)[":uid"], cmd.Version)
+	if err != nil {
+		return toLibraryElementError(err, "Failed to restore library element version")
+	}
+
+	if element.FolderID != 0 {
+		folder, err := l.folderService.GetFolderByID(c.Req.Context(), c.SignedInUser, element.FolderID, c.OrgId)
+		if err != nil {
+			return response.Error(http.StatusInternalServerError, "failed to get folder", err)
+		}
+		element.FolderUID = folder.Uid
+		element.Meta.FolderUID = folder.Uid
+		element.Meta.FolderName = folder.Title
+	}
+
+	return response.JSON(http.StatusOK, LibraryElementResponse{Result: element})
+}
+
 // getConnectionsHandler handles GET /api/library-panels/:uid/connections/.
 func (l *LibraryElementService) getConnectionsHandler(c *models.ReqContext) response.Response {
 	connections, err := l.getConnections(c.Req.Context(), c.SignedInUser, web.Params(c.Req)[":uid"])
@@ -188,5 +263,11 @@ func toLibraryElementError(err error, message string) response.Response {
 	if errors.Is(err, errLibraryElementUIDTooLong) {
 		return response.Error(400, errLibraryElementUIDTooLong.Error(), err)
 	}
+	if errors.Is(err, errLibraryElementVersionNotFound) {
+		return response.Error(404, errLibraryElementVersionNotFound.Error(), err)
+	}
+	if errors.Is(err, errLibraryElementVersionsIdentical) {
+		return response.Error(400, errLibraryElementVersionsIdentical.Error(), err)
+	}
 	return response.Error(500, message, err)
 }
@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/dashdiffs"
+	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/models"
 	"github.com/grafana/grafana/pkg/services/search"
 	"github.com/grafana/grafana/pkg/services/sqlstore"
@@ -134,7 +136,7 @@ func (l *LibraryElementService) createLibraryElement(c context.Context, signedIn
 			}
 			return err
 		}
-		return nil
+		return insertLibraryElementVersion(session, element, 0, 0)
 	})
 
 	dto := LibraryElementDTO{
@@ -189,6 +191,9 @@ func (l *LibraryElementService) deleteLibraryElement(c context.Context, signedIn
 			return errLibraryElementHasConnections
 		}
 
+		if _, err := session.Exec("DELETE FROM library_element_version WHERE element_id=?", element.ID); err != nil {
+			return err
+		}
 		result, err := session.Exec("DELETE FROM library_element WHERE id=?", element.ID)
 		if err != nil {
 			return err
@@ -504,6 +509,9 @@ func (l *LibraryElementService) patchLibraryElement(c context.Context, signedInU
 		} else if rowsAffected != 1 {
 			return ErrLibraryElementNotFound
 		}
+		if err := insertLibraryElementVersion(session, libraryElement, elementInDB.Version, cmd.RestoredFrom); err != nil {
+			return err
+		}
 
 		dto = LibraryElementDTO{
 			ID:          libraryElement.ID,
@@ -538,6 +546,156 @@ func (l *LibraryElementService) patchLibraryElement(c context.Context, signedInU
 	return dto, err
 }
 
+// insertLibraryElementVersion saves the current state of a Library Element as a version.
+func insertLibraryElementVersion(session *sqlstore.DBSession, element LibraryElement, parentVersion int64, restoredFrom int64) error {
+	version := libraryElementVersion{
+		ElementID:     element.ID,
+		ParentVersion: parentVersion,
+		RestoredFrom:  restoredFrom,
+		Version:       element.Version,
+		Name:          element.Name,
+		Model:         element.Model,
+		Created:       element.Updated,
+		CreatedBy:     element.UpdatedBy,
+	}
+	_, err := session.Insert(&version)
+	return err
+}
+
+// getLibraryElementVersions gets the versions of a Library Element, latest first.
+func (l *LibraryElementService) getLibraryElementVersions(c context.Context, signedInUser *models.SignedInUser, uid string, limit int, start int) ([]LibraryElementVersionDTO, error) {
+	element, err := l.getLibraryElementByUid(c, signedInUser, uid)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = 1000
+	}
+
+	versions := make([]LibraryElementVersionDTO, 0)
+	err = l.SQLStore.WithDbSession(c, func(session *sqlstore.DBSession) error {
+		var elementVersions []libraryElementVersionWithMeta
+		builder := sqlstore.SQLBuilder{}
+		builder.Write("SELECT lev.id, lev.element_id, lev.parent_version, lev.restored_from, lev.version, lev.name, lev.created, lev.created_by")
+		builder.Write(", u1.login AS created_by_name, u1.email AS created_by_email")
+		builder.Write(" FROM library_element_version AS lev")
+		builder.Write(" LEFT JOIN " + l.SQLStore.Dialect.Quote("user") + " AS u1 ON lev.created_by = u1.id")
+		builder.Write(" WHERE lev.element_id=?", element.ID)
+		builder.Write(" ORDER BY lev.version DESC")
+		builder.Write(l.SQLStore.Dialect.LimitOffset(int64(limit), int64(start)))
+		if err := session.SQL(builder.GetSQLString(), builder.GetParams()...).Find(&elementVersions); err != nil {
+			return err
+		}
+
+		for _, version := range elementVersions {
+			versions = append(versions, toLibraryElementVersionDTO(element.UID, version))
+		}
+		return nil
+	})
+
+	return versions, err
+}
+
+// getLibraryElementVersion gets a version of a Library Element.
+func (l *LibraryElementService) getLibraryElementVersion(c context.Context, signedInUser *models.SignedInUser, uid string, version int64) (LibraryElementVersionDTO, error) {
+	element, err := l.getLibraryElementByUid(c, signedInUser, uid)
+	if err != nil {
+		return LibraryElementVersionDTO{}, err
+	}
+
+	var dto LibraryElementVersionDTO
+	err = l.SQLStore.WithDbSession(c, func(session *sqlstore.DBSession) error {
+		var elementVersions []libraryElementVersionWithMeta
+		builder := sqlstore.SQLBuilder{}
+		builder.Write("SELECT lev.*, u1.login AS created_by_name, u1.email AS created_by_email")
+		builder.Write(" FROM library_element_version AS lev")
+		builder.Write(" LEFT JOIN " + l.SQLStore.Dialect.Quote("user") + " AS u1 ON lev.created_by = u1.id")
+		builder.Write(" WHERE lev.element_id=? AND lev.version=?", element.ID, version)
+		if err := session.SQL(builder.GetSQLString(), builder.GetParams()...).Find(&elementVersions); err != nil {
+			return err
+		}
+		if len(elementVersions) == 0 {
+			return errLibraryElementVersionNotFound
+		}
+
+		dto = toLibraryElementVersionDTO(element.UID, elementVersions[0])
+		return nil
+	})
+
+	return dto, err
+}
+
+// calculateLibraryElementDiff computes the diff of the models of two versions of a Library Element.
+func (l *LibraryElementService) calculateLibraryElementDiff(c context.Context, signedInUser *models.SignedInUser, uid string, cmd CalculateLibraryElementDiffCommand) (*dashdiffs.Result, error) {
+	baseVersion, err := l.getLibraryElementVersion(c, signedInUser, uid, cmd.Base)
+	if err != nil {
+		return nil, err
+	}
+	newVersion, err := l.getLibraryElementVersion(c, signedInUser, uid, cmd.New)
+	if err != nil {
+		return nil, err
+	}
+
+	baseModel, err := simplejson.NewJson(baseVersion.Model)
+	if err != nil {
+		return nil, err
+	}
+	newModel, err := simplejson.NewJson(newVersion.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	options := dashdiffs.Options{
+		OrgId:    signedInUser.OrgId,
+		DiffType: dashdiffs.ParseDiffType(cmd.DiffType),
+	}
+	result, err := dashdiffs.CalculateDiff(c, &options, baseModel, newModel)
+	if errors.Is(err, dashdiffs.ErrNilDiff) {
+		return nil, errLibraryElementVersionsIdentical
+	}
+	return result, err
+}
+
+// restoreLibraryElementVersion updates a Library Element with the name and model of one of its versions.
+func (l *LibraryElementService) restoreLibraryElementVersion(c context.Context, signedInUser *models.SignedInUser, uid string, version int64) (LibraryElementDTO, error) {
+	element, err := l.getLibraryElementByUid(c, signedInUser, uid)
+	if err != nil {
+		return LibraryElementDTO{}, err
+	}
+	elementVersion, err := l.getLibraryElementVersion(c, signedInUser, uid, version)
+	if err != nil {
+		return LibraryElementDTO{}, err
+	}
+
+	cmd := PatchLibraryElementCommand{
+		FolderID:     element.FolderID,
+		Name:         elementVersion.Name,
+		Model:        elementVersion.Model,
+		Kind:         element.Kind,
+		Version:      element.Version,
+		RestoredFrom: elementVersion.Version,
+	}
+	return l.patchLibraryElement(c, signedInUser, cmd, uid)
+}
+
+func toLibraryElementVersionDTO(elementUID string, version libraryElementVersionWithMeta) LibraryElementVersionDTO {
+	return LibraryElementVersionDTO{
+		ID:            version.ID,
+		ElementUID:    elementUID,
+		ParentVersion: version.ParentVersion,
+		RestoredFrom:  version.RestoredFrom,
+		Version:       version.Version,
+		Name:          version.Name,
+		Model:         version.Model,
+		Created:       version.Created,
+		CreatedBy: LibraryElementDTOMetaUser{
+			ID:        version.CreatedBy,
+			Name:      version.CreatedByName,
+			AvatarURL: dtos.GetGravatarUrl(version.CreatedByEmail),
+		},
+	}
+}
+
 // getConnections gets all connections for a Library Element.
 func (l *LibraryElementService) getConnections(c context.Context, signedInUser *models.SignedInUser, uid string) ([]LibraryElementConnectionDTO, error) {
 	connections := make([]LibraryElementConnectionDTO, 0)
@@ -733,6 +891,9 @@ func (l *LibraryElementService) deleteLibraryElementsInFolderUID(c context.Conte
 			if err != nil {
 				return err
 			}
+			if _, err := session.Exec("DELETE FROM library_element_version WHERE element_id=?", elementID.ID); err != nil {
+				return err
+			}
 		}
 		if _, err := session.Exec("DELETE FROM library_element WHERE folder_id=? AND org_id=?", folderID, signedInUser.OrgId); err != nil {
 			return err
@@ -209,7 +209,7 @@ func createDashboard(t *testing.T, sqlStore *sqlstore.SQLStore, user models.Sign
 	dashboardPermissions := acmock.NewMockedPermissionsService()
 	service := dashboardservice.ProvideDashboardService(
 		cfg, dashboardStore, dashAlertExtractor,
-		features, folderPermissions, dashboardPermissions,
+		features, folderPermissions, dashboardPermissions, nil,
 	)
 	dashboard, err := service.SaveDashboard(context.Background(), dashItem, true)
 	require.NoError(t, err)
@@ -230,7 +230,7 @@ func createFolderWithACL(t *testing.T, sqlStore *sqlstore.SQLStore, title string
 
 	d := dashboardservice.ProvideDashboardService(
 		cfg, dashboardStore, nil,
-		features, folderPermissions, dashboardPermissions,
+		features, folderPermissions, dashboardPermissions, nil,
 	)
 	ac := acmock.New()
 	s := dashboardservice.ProvideFolderService(
@@ -332,7 +332,7 @@ func testScenario(t *testing.T, desc string, fn func(t *testing.T, sc scenarioCo
 
 		dashboardService := dashboardservice.ProvideDashboardService(
 			cfg, dashboardStore, nil,
-			features, folderPermissions, dashboardPermissions,
+			features, folderPermissions, dashboardPermissions, nil,
 		)
 		ac := acmock.New()
 		service := LibraryElementService{
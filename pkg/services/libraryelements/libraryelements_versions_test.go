@@ -0,0 +1,130 @@
+package libraryelements
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+func TestLibraryElementVersions(t *testing.T) {
+	patchPanel := func(t *testing.T, sc scenarioContext) {
+		t.Helper()
+		cmd := PatchLibraryElementCommand{
+			FolderID: -1,
+			Name:     "Text - Broken Library Panel",
+			Model:    []byte(`{"id": 1, "title": "Broken", "type": "text"}`),
+			Kind:     int64(models.PanelElement),
+			Version:  1,
+		}
+		sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID})
+		sc.reqContext.Req.Body = mockRequestBody(cmd)
+		resp := sc.service.patchHandler(sc.reqContext)
+		require.Equal(t, 200, resp.Status())
+	}
+
+	getVersions := func(t *testing.T, sc scenarioContext) []LibraryElementVersionDTO {
+		t.Helper()
+		sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID})
+		resp := sc.service.getVersionsHandler(sc.reqContext)
+		require.Equal(t, 200, resp.Status())
+		var result LibraryElementVersionsResponse
+		require.NoError(t, json.Unmarshal(resp.Body(), &result))
+		return result.Result
+	}
+
+	scenarioWithPanel(t, "When an admin creates and patches a library panel, it should save its versions",
+		func(t *testing.T, sc scenarioContext) {
+			versions := getVersions(t, sc)
+			require.Len(t, versions, 1)
+			require.Equal(t, int64(1), versions[0].Version)
+			require.Equal(t, "Text - Library Panel", versions[0].Name)
+			require.Equal(t, userInDbName, versions[0].CreatedBy.Name)
+
+			patchPanel(t, sc)
+
+			versions = getVersions(t, sc)
+			require.Len(t, versions, 2)
+			require.Equal(t, int64(2), versions[0].Version)
+			require.Equal(t, int64(1), versions[0].ParentVersion)
+			require.Equal(t, "Text - Broken Library Panel", versions[0].Name)
+			require.Empty(t, versions[0].Model)
+			require.Equal(t, int64(1), versions[1].Version)
+		})
+
+	scenarioWithPanel(t, "When an admin gets a version of a library panel, it should return its model",
+		func(t *testing.T, sc scenarioContext) {
+			patchPanel(t, sc)
+
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID, ":version": "1"})
+			resp := sc.service.getVersionHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+			var result LibraryElementVersionResponse
+			require.NoError(t, json.Unmarshal(resp.Body(), &result))
+			require.Equal(t, sc.initialResult.Result.UID, result.Result.ElementUID)
+			require.JSONEq(t, `{
+				"datasource": "${DS_GDEV-TESTDATA}",
+				"id": 1,
+				"title": "Text - Library Panel",
+				"type": "text",
+				"description": "A description"
+			}`, string(result.Result.Model))
+
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID, ":version": "3"})
+			resp = sc.service.getVersionHandler(sc.reqContext)
+			require.Equal(t, 404, resp.Status())
+		})
+
+	scenarioWithPanel(t, "When an admin compares two versions of a library panel, it should return their diff",
+		func(t *testing.T, sc scenarioContext) {
+			patchPanel(t, sc)
+
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID})
+			sc.reqContext.Req.Body = mockRequestBody(CalculateLibraryElementDiffCommand{Base: 1, New: 2, DiffType: "delta"})
+			resp := sc.service.calculateDiffHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+			var delta map[string]interface{}
+			require.NoError(t, json.Unmarshal(resp.Body(), &delta))
+			require.Equal(t, []interface{}{"Text - Library Panel", "Broken"}, delta["title"])
+
+			sc.reqContext.Req.Body = mockRequestBody(CalculateLibraryElementDiffCommand{Base: 2, New: 2, DiffType: "delta"})
+			resp = sc.service.calculateDiffHandler(sc.reqContext)
+			require.Equal(t, 400, resp.Status())
+		})
+
+	scenarioWithPanel(t, "When an admin restores a version of a library panel, it should save it as a new version",
+		func(t *testing.T, sc scenarioContext) {
+			patchPanel(t, sc)
+
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID})
+			sc.reqContext.Req.Body = mockRequestBody(RestoreLibraryElementVersionCommand{Version: 1})
+			resp := sc.service.restoreHandler(sc.reqContext)
+			var result = validateAndUnMarshalResponse(t, resp)
+			require.Equal(t, int64(3), result.Result.Version)
+			require.Equal(t, "Text - Library Panel", result.Result.Name)
+			require.Equal(t, "Text - Library Panel", result.Result.Model["title"])
+			require.Equal(t, "A description", result.Result.Description)
+			require.Equal(t, sc.folder.Id, result.Result.FolderID)
+
+			versions := getVersions(t, sc)
+			require.Len(t, versions, 3)
+			require.Equal(t, int64(3), versions[0].Version)
+			require.Equal(t, int64(1), versions[0].RestoredFrom)
+		})
+
+	scenarioWithPanel(t, "When an admin deletes a library panel, it should delete its versions",
+		func(t *testing.T, sc scenarioContext) {
+			patchPanel(t, sc)
+
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID})
+			resp := sc.service.deleteHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+
+			count, err := sc.sqlStore.NewSession(sc.reqContext.Req.Context()).Table("library_element_version").Count()
+			require.NoError(t, err)
+			require.Zero(t, count)
+		})
+}
@@ -125,6 +125,47 @@ type libraryElementConnectionWithMeta struct {
 	CreatedByEmail string
 }
 
+// libraryElementVersion is the model for the versions of library elements.
+type libraryElementVersion struct {
+	ID            int64 `xorm:"pk autoincr 'id'"`
+	ElementID     int64 `xorm:"element_id"`
+	ParentVersion int64
+	RestoredFrom  int64
+	Version       int64
+	Name          string
+	Model         json.RawMessage
+	Created       time.Time
+	CreatedBy     int64
+}
+
+// libraryElementVersionWithMeta is the model for the versions of library elements with meta.
+type libraryElementVersionWithMeta struct {
+	ID             int64 `xorm:"pk autoincr 'id'"`
+	ElementID      int64 `xorm:"element_id"`
+	ParentVersion  int64
+	RestoredFrom   int64
+	Version        int64
+	Name           string
+	Model          json.RawMessage
+	Created        time.Time
+	CreatedBy      int64
+	CreatedByName  string
+	CreatedByEmail string
+}
+
+// LibraryElementVersionDTO is the frontend DTO for the versions of library elements.
+type LibraryElementVersionDTO struct {
+	ID            int64                     `json:"id"`
+	ElementUID    string                    `json:"elementUid"`
+	ParentVersion int64                     `json:"parentVersion"`
+	RestoredFrom  int64                     `json:"restoredFrom"`
+	Version       int64                     `json:"version"`
+	Name          string                    `json:"name"`
+	Model         json.RawMessage           `json:"model,omitempty"`
+	Created       time.Time                 `json:"created"`
+	CreatedBy     LibraryElementDTOMetaUser `json:"createdBy"`
+}
+
 // LibraryElementConnectionDTO is the frontend DTO for element connections.
 type LibraryElementConnectionDTO struct {
 	ID           int64                     `json:"id"`
@@ -154,6 +195,10 @@ var (
 	errLibraryElementInvalidUID = errors.New("uid contains illegal characters")
 	// errLibraryElementUIDTooLong is an error for when the uid of a library element is invalid
 	errLibraryElementUIDTooLong = errors.New("uid too long, max 40 characters")
+	// errLibraryElementVersionNotFound is an error for when a version of a library element can't be found.
+	errLibraryElementVersionNotFound = errors.New("library element version could not be found")
+	// errLibraryElementVersionsIdentical is an error for when the diff of two identical versions is requested.
+	errLibraryElementVersionsIdentical = errors.New("the library element versions are identical")
 )
 
 // Commands
@@ -200,6 +245,24 @@ type PatchLibraryElementCommand struct {
 	Version int64 `json:"version" binding:"Required"`
 	// required: false
 	UID string `json:"uid"`
+
+	RestoredFrom int64 `json:"-"`
+}
+
+// RestoreLibraryElementVersionCommand is the command for restoring a version of a LibraryElement
+type RestoreLibraryElementVersionCommand struct {
+	// Version of the library element to restore.
+	Version int64 `json:"version" binding:"Required"`
+}
+
+// CalculateLibraryElementDiffCommand is the command for the diff of two versions of a LibraryElement
+type CalculateLibraryElementDiffCommand struct {
+	// Version of the library element to compare from.
+	Base int64 `json:"base" binding:"Required"`
+	// Version of the library element to compare to.
+	New int64 `json:"new" binding:"Required"`
+	// Type of the diff, basic, json or delta.
+	DiffType string `json:"diffType" binding:"Required"`
 }
 
 // searchLibraryElementsQuery is the query used for searching for Elements
@@ -234,6 +297,16 @@ type LibraryElementConnectionsResponse struct {
 	Result []LibraryElementConnectionDTO `json:"result"`
 }
 
+// LibraryElementVersionResponse is a response struct for LibraryElementVersionDTO.
+type LibraryElementVersionResponse struct {
+	Result LibraryElementVersionDTO `json:"result"`
+}
+
+// LibraryElementVersionsResponse is a response struct for an array of LibraryElementVersionDTO.
+type LibraryElementVersionsResponse struct {
+	Result []LibraryElementVersionDTO `json:"result"`
+}
+
 // DeleteLibraryElementResponse is the response struct for deleting a library element.
 type DeleteLibraryElementResponse struct {
 	ID      int64  `json:"id"`
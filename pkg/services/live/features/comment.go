@@ -2,6 +2,7 @@ package features
 
 import (
 	"context"
+	"strconv"
 	"strings"
 
 	"github.com/grafana/grafana/pkg/models"
@@ -26,12 +27,20 @@ func (h *CommentHandler) GetHandlerForPath(_ string) (models.ChannelHandler, err
 
 // OnSubscribe handles subscription to comment group channel.
 func (h *CommentHandler) OnSubscribe(ctx context.Context, user *models.SignedInUser, e models.SubscribeEvent) (models.SubscribeReply, backend.SubscribeStreamStatus, error) {
-	parts := strings.Split(e.Path, "/")
+	// Object IDs may contain slashes, for example the IDs of panels.
+	parts := strings.SplitN(e.Path, "/", 2)
 	if len(parts) != 2 {
 		return models.SubscribeReply{}, backend.SubscribeStreamStatusNotFound, nil
 	}
 	objectType := parts[0]
 	objectID := parts[1]
+	if objectType == commentmodel.MentionsPath {
+		// Users can only subscribe to their own mentions.
+		if user.UserId == 0 || objectID != strconv.FormatInt(user.UserId, 10) {
+			return models.SubscribeReply{}, backend.SubscribeStreamStatusPermissionDenied, nil
+		}
+		return models.SubscribeReply{}, backend.SubscribeStreamStatusOK, nil
+	}
 	ok, err := h.permissionChecker.CheckReadPermissions(ctx, user.OrgId, user, objectType, objectID)
 	if err != nil {
 		return models.SubscribeReply{}, 0, err
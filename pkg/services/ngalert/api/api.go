@@ -13,6 +13,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/datasources"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/backtesting"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
 	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
@@ -24,6 +25,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/quota"
 	"github.com/grafana/grafana/pkg/services/secrets"
 	"github.com/grafana/grafana/pkg/setting"
+	"github.com/prometheus/common/model"
 )
 
 // timeNow makes it possible to test usage of time
@@ -36,7 +38,7 @@ type Scheduler interface {
 
 type Alertmanager interface {
 	// Configuration
-	SaveAndApplyConfig(ctx context.Context, config *apimodels.PostableUserConfig) error
+	SaveAndApplyConfig(ctx context.Context, config *apimodels.PostableUserConfig, createdBy int64) error
 	SaveAndApplyDefaultConfig(ctx context.Context) error
 	GetStatus() apimodels.GettableStatus
 
@@ -52,6 +54,7 @@ type Alertmanager interface {
 
 	// Testing
 	TestReceivers(ctx context.Context, c apimodels.TestReceiversConfigBodyParams) (*notifier.TestReceiversResult, error)
+	TestRoutes(lbls model.LabelSet) (*notifier.TestRoutesResult, error)
 }
 
 type AlertingStore interface {
@@ -81,6 +84,7 @@ type API struct {
 	ContactPointService  *provisioning.ContactPointService
 	Templates            *provisioning.TemplateService
 	MuteTimings          *provisioning.MuteTimingService
+	AlertRules           *provisioning.AlertRuleService
 }
 
 // RegisterAPIEndpoints registers API handlers
@@ -94,7 +98,7 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 	api.RegisterAlertmanagerApiEndpoints(NewForkedAM(
 		api.DatasourceCache,
 		NewLotexAM(proxy, logger),
-		&AlertmanagerSrv{crypto: api.MultiOrgAlertmanager.Crypto, log: logger, ac: api.AccessControl, mam: api.MultiOrgAlertmanager},
+		&AlertmanagerSrv{crypto: api.MultiOrgAlertmanager.Crypto, log: logger, ac: api.AccessControl, mam: api.MultiOrgAlertmanager, ruleStore: api.RuleStore},
 	), m)
 	// Register endpoints for proxying to Prometheus-compatible backends.
 	api.RegisterPrometheusApiEndpoints(NewForkedProm(
@@ -118,6 +122,7 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 			ac:              api.AccessControl,
 		},
 	), m)
+	evaluator := eval.NewEvaluator(api.Cfg, log.New("ngalert.eval"), api.DatasourceCache, api.SecretsService)
 	api.RegisterTestingApiEndpoints(NewForkedTestingApi(
 		&TestingApiSrv{
 			AlertingProxy:     proxy,
@@ -125,7 +130,8 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 			DatasourceCache:   api.DatasourceCache,
 			log:               logger,
 			accessControl:     api.AccessControl,
-			evaluator:         eval.NewEvaluator(api.Cfg, log.New("ngalert.eval"), api.DatasourceCache, api.SecretsService),
+			evaluator:         evaluator,
+			backtesting:       backtesting.NewEngine(evaluator, api.ExpressionService),
 		}), m)
 	api.RegisterConfigurationApiEndpoints(NewForkedConfiguration(
 		&AdminSrv{
@@ -142,6 +148,7 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 			contactPointService: api.ContactPointService,
 			templates:           api.Templates,
 			muteTimings:         api.MuteTimings,
+			alertRules:          api.AlertRules,
 		}), m)
 	}
 }
@@ -11,14 +11,17 @@ import (
 
 	"github.com/go-openapi/strfmt"
 	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/components/dashdiffs"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/models"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/services/ngalert/notifier"
 	"github.com/grafana/grafana/pkg/services/ngalert/store"
 	"github.com/grafana/grafana/pkg/util"
 	"github.com/grafana/grafana/pkg/web"
+	"github.com/prometheus/common/model"
 )
 
 const (
@@ -27,10 +30,11 @@ const (
 )
 
 type AlertmanagerSrv struct {
-	log    log.Logger
-	ac     accesscontrol.AccessControl
-	mam    *notifier.MultiOrgAlertmanager
-	crypto notifier.Crypto
+	log       log.Logger
+	ac        accesscontrol.AccessControl
+	mam       *notifier.MultiOrgAlertmanager
+	crypto    notifier.Crypto
+	ruleStore store.RuleStore
 }
 
 type UnknownReceiverError struct {
@@ -130,6 +134,61 @@ func (srv AlertmanagerSrv) RouteGetAlertingConfig(c *models.ReqContext) response
 	return response.JSON(http.StatusOK, config)
 }
 
+func (srv AlertmanagerSrv) RouteGetAlertingConfigHistory(c *models.ReqContext) response.Response {
+	limit := c.QueryInt("limit")
+	if limit < 0 {
+		return ErrResp(http.StatusBadRequest, fmt.Errorf("limit must not be negative"), "")
+	}
+
+	history, err := srv.mam.GetAlertmanagerConfigurationHistory(c.Req.Context(), c.OrgId, limit)
+	if err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "")
+	}
+	return response.JSON(http.StatusOK, history)
+}
+
+func (srv AlertmanagerSrv) RouteGetAlertingConfigDiff(c *models.ReqContext) response.Response {
+	id, err := strconv.ParseInt(web.Params(c.Req)[":ConfigID"], 10, 64)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "failed to parse configuration ID")
+	}
+	baseID := c.QueryInt64("base")
+	diffType := dashdiffs.ParseDiffType(c.Query("diffType"))
+
+	result, err := srv.mam.GetAlertmanagerConfigurationDiff(c.Req.Context(), c.OrgId, id, baseID, diffType)
+	if err != nil {
+		if errors.Is(err, store.ErrNoAlertmanagerConfiguration) {
+			return ErrResp(http.StatusNotFound, err, "")
+		}
+		return ErrResp(http.StatusInternalServerError, err, "failed to compute the diff")
+	}
+
+	if diffType == dashdiffs.DiffDelta {
+		return response.Respond(http.StatusOK, result.Delta).SetHeader("Content-Type", "application/json")
+	}
+	return response.Respond(http.StatusOK, result.Delta).SetHeader("Content-Type", "text/html")
+}
+
+func (srv AlertmanagerSrv) RoutePostAlertingConfigHistoryActivate(c *models.ReqContext) response.Response {
+	id, err := strconv.ParseInt(web.Params(c.Req)[":ConfigID"], 10, 64)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "failed to parse configuration ID")
+	}
+
+	err = srv.mam.RestoreAlertmanagerConfiguration(c.Req.Context(), c.OrgId, id, c.UserId)
+	if err == nil {
+		return response.JSON(http.StatusAccepted, util.DynMap{"message": "configuration restored"})
+	}
+	if errors.Is(err, store.ErrNoAlertmanagerConfiguration) {
+		return ErrResp(http.StatusNotFound, err, "")
+	}
+	var configRejectedError notifier.AlertmanagerConfigRejectedError
+	if errors.As(err, &configRejectedError) {
+		return ErrResp(http.StatusBadRequest, configRejectedError, "")
+	}
+	return ErrResp(http.StatusInternalServerError, err, "")
+}
+
 func (srv AlertmanagerSrv) RouteGetAMAlertGroups(c *models.ReqContext) response.Response {
 	am, errResp := srv.AlertmanagerFor(c.OrgId)
 	if errResp != nil {
@@ -217,7 +276,7 @@ func (srv AlertmanagerSrv) RouteGetSilences(c *models.ReqContext) response.Respo
 }
 
 func (srv AlertmanagerSrv) RoutePostAlertingConfig(c *models.ReqContext, body apimodels.PostableUserConfig) response.Response {
-	err := srv.mam.ApplyAlertmanagerConfiguration(c.Req.Context(), c.OrgId, body)
+	err := srv.mam.ApplyAlertmanagerConfiguration(c.Req.Context(), c.OrgId, body, c.UserId)
 	if err == nil {
 		return response.JSON(http.StatusAccepted, util.DynMap{"message": "configuration created"})
 	}
@@ -282,6 +341,91 @@ func (srv AlertmanagerSrv) RoutePostTestReceivers(c *models.ReqContext, body api
 	return response.JSON(statusForTestReceivers(result.Receivers), newTestReceiversResult(result))
 }
 
+func (srv AlertmanagerSrv) RoutePostTestRoutes(c *models.ReqContext, body apimodels.TestRoutesConfigBodyParams) response.Response {
+	lbls := model.LabelSet{}
+	if body.RuleUID != "" {
+		q := ngmodels.GetAlertRuleByUIDQuery{UID: body.RuleUID, OrgID: c.OrgId}
+		if err := srv.ruleStore.GetAlertRuleByUID(c.Req.Context(), &q); err != nil {
+			if errors.Is(err, ngmodels.ErrAlertRuleNotFound) {
+				return ErrResp(http.StatusNotFound, err, "")
+			}
+			return ErrResp(http.StatusInternalServerError, err, "failed to get alert rule")
+		}
+		rule := q.Result
+
+		namespaceMap, err := srv.ruleStore.GetUserVisibleNamespaces(c.Req.Context(), c.OrgId, c.SignedInUser)
+		if err != nil {
+			return ErrResp(http.StatusInternalServerError, err, "failed to get namespaces visible to the user")
+		}
+		hasAccess := func(evaluator accesscontrol.Evaluator) bool {
+			return accesscontrol.HasAccess(srv.ac, c)(accesscontrol.ReqViewer, evaluator)
+		}
+		if _, ok := namespaceMap[rule.NamespaceUID]; !ok || !authorizeDatasourceAccessForRule(rule, hasAccess) {
+			// Do not disclose the existence of rules the user cannot read.
+			return ErrResp(http.StatusNotFound, ngmodels.ErrAlertRuleNotFound, "")
+		}
+
+		for k, v := range rule.Labels {
+			lbls[model.LabelName(k)] = model.LabelValue(v)
+		}
+		// The labels of the rule take precedence over the labels of the query result, like when the rule is evaluated.
+		for k, v := range body.Labels {
+			if _, ok := lbls[k]; !ok {
+				lbls[k] = v
+			}
+		}
+		lbls[ngmodels.RuleUIDLabel] = model.LabelValue(rule.UID)
+		lbls[ngmodels.NamespaceUIDLabel] = model.LabelValue(rule.NamespaceUID)
+		lbls[model.AlertNameLabel] = model.LabelValue(rule.Title)
+	} else {
+		lbls = body.Labels
+	}
+	if len(lbls) == 0 {
+		return ErrResp(http.StatusBadRequest, errors.New("either labels or a rule UID must be provided"), "")
+	}
+
+	am, errResp := srv.AlertmanagerFor(c.OrgId)
+	if errResp != nil {
+		return errResp
+	}
+
+	result, err := am.TestRoutes(lbls)
+	if err != nil {
+		if errors.Is(err, notifier.ErrAlertmanagerNotReady) {
+			return response.Error(http.StatusConflict, err.Error(), err)
+		}
+		if errors.Is(err, notifier.ErrGetSilencesInternal) {
+			return ErrResp(http.StatusInternalServerError, err, "")
+		}
+		return ErrResp(http.StatusBadRequest, err, "")
+	}
+
+	return response.JSON(http.StatusOK, newTestRoutesResult(result))
+}
+
+func newTestRoutesResult(r *notifier.TestRoutesResult) apimodels.TestRoutesResult {
+	v := apimodels.TestRoutesResult{
+		Labels:      r.Labels,
+		Routes:      make([]apimodels.TestRouteResult, 0, len(r.Routes)),
+		SilencedBy:  r.SilencedBy,
+		EvaluatedAt: r.EvaluatedAt,
+	}
+	for _, route := range r.Routes {
+		v.Routes = append(v.Routes, apimodels.TestRouteResult{
+			Key:                     route.Key,
+			Receiver:                route.Receiver,
+			GroupBy:                 route.GroupBy,
+			GroupKey:                route.GroupKey,
+			GroupWait:               model.Duration(route.GroupWait),
+			GroupInterval:           model.Duration(route.GroupInterval),
+			RepeatInterval:          model.Duration(route.RepeatInterval),
+			MuteTimeIntervals:       route.MuteTimeIntervals,
+			ActiveMuteTimeIntervals: route.ActiveMuteTimeIntervals,
+		})
+	}
+	return v
+}
+
 // contextWithTimeoutFromRequest returns a context with a deadline set from the
 // Request-Timeout header in the HTTP request. If the header is absent then the
 // context will use the default timeout. The timeout in the Request-Timeout
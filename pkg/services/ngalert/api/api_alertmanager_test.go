@@ -11,6 +11,7 @@ import (
 	"github.com/go-openapi/strfmt"
 	amv2 "github.com/prometheus/alertmanager/api/v2/models"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/require"
 
 	"github.com/grafana/grafana/pkg/api/response"
@@ -24,6 +25,7 @@ import (
 	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/services/ngalert/notifier"
 	"github.com/grafana/grafana/pkg/services/ngalert/provisioning"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
 	"github.com/grafana/grafana/pkg/services/secrets/fakes"
 	secretsManager "github.com/grafana/grafana/pkg/services/secrets/manager"
 	"github.com/grafana/grafana/pkg/setting"
@@ -481,6 +483,45 @@ func TestRouteCreateSilence(t *testing.T) {
 	}
 }
 
+func TestRoutePostTestRoutes(t *testing.T) {
+	sut := createSut(t, nil)
+	ruleStore := store.NewFakeRuleStore(t)
+	sut.ruleStore = ruleStore
+
+	rule := ngmodels.AlertRuleGen(func(r *ngmodels.AlertRule) {
+		r.OrgID = 1
+		r.Labels = map[string]string{"team": "a"}
+	})()
+	ruleStore.PutRule(context.Background(), rule)
+
+	rc := models.ReqContext{
+		Context: &web.Context{
+			Req: &http.Request{},
+		},
+		SignedInUser: &models.SignedInUser{
+			OrgRole: models.ROLE_VIEWER,
+			OrgId:   1,
+		},
+	}
+
+	t.Run("should return the labels of the rule", func(t *testing.T) {
+		response := sut.RoutePostTestRoutes(&rc, apimodels.TestRoutesConfigBodyParams{RuleUID: rule.UID})
+		require.Equal(t, http.StatusOK, response.Status())
+
+		result := apimodels.TestRoutesResult{}
+		require.NoError(t, json.Unmarshal(response.Body(), &result))
+		require.Equal(t, model.LabelValue("a"), result.Labels["team"])
+		require.Equal(t, model.LabelValue(rule.NamespaceUID), result.Labels[ngmodels.NamespaceUIDLabel])
+	})
+
+	t.Run("should return 404 if the user cannot read the folder of the rule", func(t *testing.T) {
+		ruleStore.Folders[1] = nil
+		response := sut.RoutePostTestRoutes(&rc, apimodels.TestRoutesConfigBodyParams{RuleUID: rule.UID})
+		require.Equal(t, http.StatusNotFound, response.Status())
+		require.NotContains(t, string(response.Body()), "team")
+	})
+}
+
 func createSut(t *testing.T, accessControl accesscontrol.AccessControl) AlertmanagerSrv {
 	t.Helper()
 
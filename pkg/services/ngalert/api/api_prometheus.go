@@ -19,6 +19,7 @@ import (
 	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/services/ngalert/state"
 	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/web"
 
 	apiv1 "github.com/prometheus/client_golang/api/prometheus/v1"
 )
@@ -48,30 +49,43 @@ func (srv PrometheusSrv) RouteGetAlertStatuses(c *models.ReqContext) response.Re
 	}
 
 	for _, alertState := range srv.manager.GetAll(c.OrgId) {
-		startsAt := alertState.StartsAt
-		valString := ""
+		alertResponse.Data.Alerts = append(alertResponse.Data.Alerts, toAlert(alertState, labelOptions))
+	}
 
-		if alertState.State == eval.Alerting || alertState.State == eval.Pending {
-			valString = formatValues(alertState)
-		}
+	return response.JSON(http.StatusOK, alertResponse)
+}
+
+func toAlert(alertState *state.State, labelOptions []ngmodels.LabelOption) *apimodels.Alert {
+	activeAt := alertState.StartsAt
+	valString := ""
+	if alertState.State == eval.Alerting || alertState.State == eval.Pending {
+		valString = formatValues(alertState)
+	}
 
-		alertResponse.Data.Alerts = append(alertResponse.Data.Alerts, &apimodels.Alert{
-			Labels:      alertState.GetLabels(labelOptions...),
-			Annotations: alertState.Annotations,
+	alert := &apimodels.Alert{
+		Labels:      alertState.GetLabels(labelOptions...),
+		Annotations: alertState.Annotations,
 
-			// TODO: or should we make this two fields? Using one field lets the
-			// frontend use the same logic for parsing text on annotations and this.
-			State: state.InstanceStateAndReason{
-				State:  alertState.State,
-				Reason: alertState.StateReason,
-			}.String(),
+		// TODO: or should we make this two fields? Using one field lets the
+		// frontend use the same logic for parsing text on annotations and this.
+		State: state.InstanceStateAndReason{
+			State:  alertState.State,
+			Reason: alertState.StateReason,
+		}.String(),
 
-			ActiveAt: &startsAt,
-			Value:    valString,
-		})
+		ActiveAt: &activeAt,
+		Value:    valString,
 	}
 
-	return response.JSON(http.StatusOK, alertResponse)
+	if ack := alertState.Acknowledgement; ack != nil {
+		alert.Acknowledgement = &apimodels.AlertAcknowledgement{
+			UserID:  ack.UserID,
+			Comment: ack.Comment,
+			At:      ack.At,
+			Until:   ack.Until,
+		}
+	}
+	return alert
 }
 
 func formatValues(alertState *state.State) string {
@@ -210,26 +224,7 @@ func (srv PrometheusSrv) toRuleGroup(groupName string, folder *models.Folder, ru
 		}
 
 		for _, alertState := range srv.manager.GetStatesForRuleUID(rule.OrgID, rule.UID) {
-			activeAt := alertState.StartsAt
-			valString := ""
-			if alertState.State == eval.Alerting || alertState.State == eval.Pending {
-				valString = formatValues(alertState)
-			}
-
-			alert := &apimodels.Alert{
-				Labels:      alertState.GetLabels(labelOptions...),
-				Annotations: alertState.Annotations,
-
-				// TODO: or should we make this two fields? Using one field lets the
-				// frontend use the same logic for parsing text on annotations and this.
-				State: state.InstanceStateAndReason{
-					State:  alertState.State,
-					Reason: alertState.StateReason,
-				}.String(),
-
-				ActiveAt: &activeAt,
-				Value:    valString,
-			}
+			alert := toAlert(alertState, labelOptions)
 
 			if alertState.LastEvaluationTime.After(newRule.LastEvaluation) {
 				newRule.LastEvaluation = alertState.LastEvaluationTime
@@ -269,6 +264,88 @@ func (srv PrometheusSrv) toRuleGroup(groupName string, folder *models.Folder, ru
 	return newGroup
 }
 
+// RoutePostAlertAcknowledge acknowledges the firing alert instance of a rule, which suppresses its notifications
+// until the acknowledgement expires or the instance resolves.
+func (srv PrometheusSrv) RoutePostAlertAcknowledge(c *models.ReqContext, body apimodels.PostableAlertAcknowledgement) response.Response {
+	if len(body.Labels) == 0 {
+		return ErrResp(http.StatusBadRequest, errors.New("labels are required"), "")
+	}
+	if body.Duration <= 0 {
+		return ErrResp(http.StatusBadRequest, errors.New("duration must be positive"), "")
+	}
+
+	rule, errResp := srv.getRuleForInstanceUpdate(c)
+	if errResp != nil {
+		return errResp
+	}
+
+	now := timeNow()
+	ack := ngmodels.AlertInstanceAcknowledgement{
+		UserID:  c.SignedInUser.UserId,
+		Comment: body.Comment,
+		At:      now,
+		Until:   now.Add(time.Duration(body.Duration)),
+	}
+	alertState, err := srv.manager.Acknowledge(c.Req.Context(), c.OrgId, rule.UID, body.Labels, ack)
+	if err != nil {
+		return toAcknowledgementErrorResponse(err)
+	}
+	return response.JSON(http.StatusOK, toAlert(alertState, []ngmodels.LabelOption{ngmodels.WithoutInternalLabels()}))
+}
+
+// RoutePostAlertUnacknowledge removes the acknowledgement of an alert instance of a rule.
+func (srv PrometheusSrv) RoutePostAlertUnacknowledge(c *models.ReqContext, body apimodels.PostableAlertUnacknowledgement) response.Response {
+	if len(body.Labels) == 0 {
+		return ErrResp(http.StatusBadRequest, errors.New("labels are required"), "")
+	}
+
+	rule, errResp := srv.getRuleForInstanceUpdate(c)
+	if errResp != nil {
+		return errResp
+	}
+
+	alertState, err := srv.manager.Unacknowledge(c.Req.Context(), c.OrgId, rule.UID, body.Labels)
+	if err != nil {
+		return toAcknowledgementErrorResponse(err)
+	}
+	return response.JSON(http.StatusOK, toAlert(alertState, []ngmodels.LabelOption{ngmodels.WithoutInternalLabels()}))
+}
+
+// getRuleForInstanceUpdate returns the rule of the request if the user can read it, and a response otherwise.
+func (srv PrometheusSrv) getRuleForInstanceUpdate(c *models.ReqContext) (*ngmodels.AlertRule, response.Response) {
+	q := ngmodels.GetAlertRuleByUIDQuery{UID: web.Params(c.Req)[":RuleUID"], OrgID: c.OrgId}
+	if err := srv.store.GetAlertRuleByUID(c.Req.Context(), &q); err != nil {
+		if errors.Is(err, ngmodels.ErrAlertRuleNotFound) {
+			return nil, ErrResp(http.StatusNotFound, err, "")
+		}
+		return nil, ErrResp(http.StatusInternalServerError, err, "failed to get alert rule")
+	}
+
+	namespaceMap, err := srv.store.GetUserVisibleNamespaces(c.Req.Context(), c.OrgId, c.SignedInUser)
+	if err != nil {
+		return nil, ErrResp(http.StatusInternalServerError, err, "failed to get namespaces visible to the user")
+	}
+	hasAccess := func(evaluator accesscontrol.Evaluator) bool {
+		return accesscontrol.HasAccess(srv.ac, c)(accesscontrol.ReqViewer, evaluator)
+	}
+	if _, ok := namespaceMap[q.Result.NamespaceUID]; !ok || !authorizeDatasourceAccessForRule(q.Result, hasAccess) {
+		// Do not disclose the existence of rules the user cannot read.
+		return nil, ErrResp(http.StatusNotFound, ngmodels.ErrAlertRuleNotFound, "")
+	}
+	return q.Result, nil
+}
+
+func toAcknowledgementErrorResponse(err error) response.Response {
+	switch {
+	case errors.Is(err, state.ErrStateNotFound):
+		return ErrResp(http.StatusNotFound, err, "")
+	case errors.Is(err, state.ErrStateNotFiring):
+		return ErrResp(http.StatusConflict, err, "")
+	default:
+		return ErrResp(http.StatusInternalServerError, err, "failed to update the acknowledgement of the alert")
+	}
+}
+
 // ruleToQuery attempts to extract the datasource queries from the alert query model.
 // Returns the whole JSON model as a string if it fails to extract a minimum of 1 query.
 func ruleToQuery(logger log.Logger, rule *ngmodels.AlertRule) string {
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
+	prommodel "github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -458,6 +459,78 @@ func TestRouteGetRuleStatuses(t *testing.T) {
 	})
 }
 
+func TestRoutePostAlertAcknowledge(t *testing.T) {
+	timeNow = func() time.Time { return time.Date(2022, 3, 10, 14, 0, 0, 0, time.UTC) }
+	orgID := int64(1)
+
+	fakeStore, fakeAIM, _, api := setupAPI(t)
+	generateRuleAndInstanceWithQuery(t, orgID, fakeAIM, fakeStore, withClassicConditionSingleQuery())
+	rule := fakeStore.Rules[orgID][0]
+	alertState := fakeAIM.GetStatesForRuleUID(orgID, rule.UID)[0]
+
+	newContext := func() *models.ReqContext {
+		req, err := http.NewRequest(http.MethodPost, "/api/prometheus/grafana/api/v1/rules/"+rule.UID+"/alerts/acknowledge", nil)
+		require.NoError(t, err)
+		req = web.SetURLParams(req, map[string]string{":RuleUID": rule.UID})
+		return &models.ReqContext{Context: &web.Context{Req: req}, SignedInUser: &models.SignedInUser{OrgId: orgID, UserId: 2, OrgRole: models.ROLE_EDITOR}}
+	}
+	body := apimodels.PostableAlertAcknowledgement{
+		Labels:   map[string]string{"job": "prometheus"},
+		Comment:  "looking into it",
+		Duration: prommodel.Duration(time.Hour),
+	}
+
+	t.Run("should fail if the alert is not firing", func(t *testing.T) {
+		r := api.RoutePostAlertAcknowledge(newContext(), body)
+		require.Equal(t, http.StatusConflict, r.Status())
+	})
+
+	alertState.State = eval.Alerting
+
+	t.Run("should fail if the duration is not set", func(t *testing.T) {
+		invalid := body
+		invalid.Duration = 0
+		r := api.RoutePostAlertAcknowledge(newContext(), invalid)
+		require.Equal(t, http.StatusBadRequest, r.Status())
+	})
+
+	t.Run("should fail if no alert has the labels", func(t *testing.T) {
+		unknown := body
+		unknown.Labels = map[string]string{"job": "grafana"}
+		r := api.RoutePostAlertAcknowledge(newContext(), unknown)
+		require.Equal(t, http.StatusNotFound, r.Status())
+	})
+
+	t.Run("should acknowledge the alert", func(t *testing.T) {
+		r := api.RoutePostAlertAcknowledge(newContext(), body)
+		require.Equal(t, http.StatusOK, r.Status())
+		expected := &apimodels.AlertAcknowledgement{
+			UserID:  2,
+			Comment: "looking into it",
+			At:      timeNow(),
+			Until:   timeNow().Add(time.Hour),
+		}
+		alert := &apimodels.Alert{}
+		require.NoError(t, json.Unmarshal(r.Body(), alert))
+		require.Equal(t, expected, alert.Acknowledgement)
+		require.Equal(t, map[string]string{"job": "prometheus"}, map[string]string(alert.Labels))
+
+		r = api.RouteGetAlertStatuses(newContext())
+		require.Equal(t, http.StatusOK, r.Status())
+		result := &apimodels.AlertResponse{}
+		require.NoError(t, json.Unmarshal(r.Body(), result))
+		require.Len(t, result.Data.Alerts, 1)
+		require.Equal(t, expected, result.Data.Alerts[0].Acknowledgement)
+	})
+
+	t.Run("should unacknowledge the alert", func(t *testing.T) {
+		r := api.RoutePostAlertUnacknowledge(newContext(), apimodels.PostableAlertUnacknowledgement{Labels: body.Labels})
+		require.Equal(t, http.StatusOK, r.Status())
+		require.Nil(t, alertState.Acknowledgement)
+		require.NotContains(t, string(r.Body()), "acknowledgement")
+	})
+}
+
 func setupAPI(t *testing.T) (*store.FakeRuleStore, *fakeAlertInstanceManager, *acmock.Mock, PrometheusSrv) {
 	fakeStore := store.NewFakeRuleStore(t)
 	fakeAIM := NewFakeAlertInstanceManager(t)
@@ -18,6 +18,9 @@ import (
 
 const namePathParam = ":name"
 const idPathParam = ":ID"
+const uidPathParam = ":UID"
+const folderUIDPathParam = ":FolderUID"
+const groupPathParam = ":Group"
 
 type ProvisioningSrv struct {
 	log                 log.Logger
@@ -25,6 +28,7 @@ type ProvisioningSrv struct {
 	contactPointService ContactPointService
 	templates           TemplateService
 	muteTimings         MuteTimingService
+	alertRules          AlertRuleService
 }
 
 type ContactPointService interface {
@@ -52,6 +56,15 @@ type MuteTimingService interface {
 	DeleteMuteTiming(ctx context.Context, name string, orgID int64) error
 }
 
+type AlertRuleService interface {
+	GetAlertRule(ctx context.Context, orgID int64, ruleUID string) (alerting_models.AlertRule, alerting_models.Provenance, error)
+	CreateAlertRule(ctx context.Context, rule alerting_models.AlertRule, provenance alerting_models.Provenance) (alerting_models.AlertRule, error)
+	UpdateAlertRule(ctx context.Context, rule alerting_models.AlertRule, provenance alerting_models.Provenance) (alerting_models.AlertRule, error)
+	DeleteAlertRule(ctx context.Context, orgID int64, ruleUID string, provenance alerting_models.Provenance) error
+	GetRuleGroup(ctx context.Context, orgID int64, folderUID, title string) (alerting_models.AlertRuleGroup, map[string]alerting_models.Provenance, error)
+	ReplaceRuleGroup(ctx context.Context, orgID int64, group alerting_models.AlertRuleGroup, provenance alerting_models.Provenance) error
+}
+
 func (srv *ProvisioningSrv) RouteGetPolicyTree(c *models.ReqContext) response.Response {
 	policies, err := srv.policies.GetPolicyTree(c.Req.Context(), c.OrgId)
 	if errors.Is(err, store.ErrNoAlertmanagerConfiguration) {
@@ -223,6 +236,77 @@ func (srv *ProvisioningSrv) RouteDeleteMuteTiming(c *models.ReqContext) response
 	return response.JSON(http.StatusNoContent, nil)
 }
 
+func (srv *ProvisioningSrv) RouteGetAlertRule(c *models.ReqContext) response.Response {
+	uid := pathParam(c, uidPathParam)
+	rule, provenance, err := srv.alertRules.GetAlertRule(c.Req.Context(), c.OrgId, uid)
+	if err != nil {
+		return alertRuleErrorResponse(err)
+	}
+	return response.JSON(http.StatusOK, apimodels.NewProvisionedAlertRule(rule, provenance))
+}
+
+func (srv *ProvisioningSrv) RoutePostAlertRule(c *models.ReqContext, ar apimodels.ProvisionedAlertRule) response.Response {
+	upstreamModel := ar.UpstreamModel()
+	upstreamModel.OrgID = c.OrgId
+	createdAlertRule, err := srv.alertRules.CreateAlertRule(c.Req.Context(), upstreamModel, alerting_models.ProvenanceAPI)
+	if err != nil {
+		return alertRuleErrorResponse(err)
+	}
+	return response.JSON(http.StatusCreated, apimodels.NewProvisionedAlertRule(createdAlertRule, alerting_models.ProvenanceAPI))
+}
+
+func (srv *ProvisioningSrv) RoutePutAlertRule(c *models.ReqContext, ar apimodels.ProvisionedAlertRule) response.Response {
+	upstreamModel := ar.UpstreamModel()
+	upstreamModel.OrgID = c.OrgId
+	upstreamModel.UID = pathParam(c, uidPathParam)
+	updatedAlertRule, err := srv.alertRules.UpdateAlertRule(c.Req.Context(), upstreamModel, alerting_models.ProvenanceAPI)
+	if err != nil {
+		return alertRuleErrorResponse(err)
+	}
+	return response.JSON(http.StatusOK, apimodels.NewProvisionedAlertRule(updatedAlertRule, alerting_models.ProvenanceAPI))
+}
+
+func (srv *ProvisioningSrv) RouteDeleteAlertRule(c *models.ReqContext) response.Response {
+	uid := pathParam(c, uidPathParam)
+	err := srv.alertRules.DeleteAlertRule(c.Req.Context(), c.OrgId, uid, alerting_models.ProvenanceAPI)
+	if err != nil {
+		return alertRuleErrorResponse(err)
+	}
+	return response.JSON(http.StatusNoContent, nil)
+}
+
+func (srv *ProvisioningSrv) RouteGetAlertRuleGroup(c *models.ReqContext) response.Response {
+	folderUID := pathParam(c, folderUIDPathParam)
+	group := pathParam(c, groupPathParam)
+	ruleGroup, provenances, err := srv.alertRules.GetRuleGroup(c.Req.Context(), c.OrgId, folderUID, group)
+	if err != nil {
+		return alertRuleErrorResponse(err)
+	}
+	return response.JSON(http.StatusOK, apimodels.NewAlertRuleGroup(ruleGroup, provenances))
+}
+
+func (srv *ProvisioningSrv) RoutePutAlertRuleGroup(c *models.ReqContext, ag apimodels.AlertRuleGroup) response.Response {
+	ag.FolderUID = pathParam(c, folderUIDPathParam)
+	ag.Title = pathParam(c, groupPathParam)
+	err := srv.alertRules.ReplaceRuleGroup(c.Req.Context(), c.OrgId, ag.UpstreamModel(), alerting_models.ProvenanceAPI)
+	if err != nil {
+		return alertRuleErrorResponse(err)
+	}
+	return srv.RouteGetAlertRuleGroup(c)
+}
+
+func alertRuleErrorResponse(err error) response.Response {
+	if errors.Is(err, alerting_models.ErrAlertRuleNotFound) || errors.Is(err, alerting_models.ErrRuleGroupNamespaceNotFound) {
+		return ErrResp(http.StatusNotFound, err, "")
+	}
+	if errors.Is(err, provisioning.ErrValidation) ||
+		errors.Is(err, alerting_models.ErrAlertRuleFailedValidation) ||
+		errors.Is(err, alerting_models.ErrAlertRuleUniqueConstraintViolation) {
+		return ErrResp(http.StatusBadRequest, err, "")
+	}
+	return ErrResp(http.StatusInternalServerError, err, "")
+}
+
 func pathParam(c *models.ReqContext, param string) string {
 	return web.Params(c.Req)[param]
 }
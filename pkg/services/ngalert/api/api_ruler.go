@@ -9,6 +9,7 @@ import (
 
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/ngalert/prom"
 	"github.com/grafana/grafana/pkg/services/ngalert/provisioning"
 	"github.com/grafana/grafana/pkg/services/ngalert/store"
 	"github.com/grafana/grafana/pkg/services/quota"
@@ -339,164 +340,253 @@ func (srv RulerSrv) RoutePostNameRulesConfig(c *models.ReqContext, ruleGroupConf
 	return srv.updateAlertRulesInGroup(c, groupKey, rules)
 }
 
+// RouteImportPrometheusRules converts the Prometheus rule groups into Grafana managed rule groups that query the data
+// source (query parameter datasource_uid), and replaces the rule groups with the same names in the namespace (request
+// parameter :Namespace). If the query parameter dry_run is true, the converted rule groups are validated and returned
+// but not saved.
+func (srv RulerSrv) RouteImportPrometheusRules(c *models.ReqContext, ruleFile apimodels.PrometheusRuleFile) response.Response {
+	namespaceTitle := web.Params(c.Req)[":Namespace"]
+	namespace, err := srv.store.GetNamespaceByTitle(c.Req.Context(), namespaceTitle, c.SignedInUser.OrgId, c.SignedInUser, true)
+	if err != nil {
+		return toNamespaceErrorResponse(err)
+	}
+
+	datasourceUID := c.Query("datasource_uid")
+	if datasourceUID == "" {
+		return ErrResp(http.StatusBadRequest, errors.New("query parameter datasource_uid is required"), "")
+	}
+	ds, err := srv.DatasourceCache.GetDatasourceByUID(c.Req.Context(), datasourceUID, c.SignedInUser, c.SkipCache)
+	if err != nil {
+		if errors.Is(err, models.ErrDataSourceNotFound) {
+			return ErrResp(http.StatusNotFound, err, "")
+		}
+		if errors.Is(err, models.ErrDataSourceAccessDenied) {
+			return ErrResp(http.StatusForbidden, err, "")
+		}
+		return ErrResp(http.StatusInternalServerError, err, "failed to get datasource")
+	}
+
+	groups, warnings, err := prom.ConvertRuleFile(ruleFile, prom.Options{DatasourceUID: ds.Uid, DatasourceType: ds.Type})
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "failed to convert Prometheus rules")
+	}
+
+	rulesByGroup := make([][]*ngmodels.AlertRule, 0, len(groups))
+	for i := range groups {
+		rules, err := validateRuleGroup(&groups[i], c.SignedInUser.OrgId, namespace, conditionValidator(c, srv.DatasourceCache), srv.cfg)
+		if err != nil {
+			return ErrResp(http.StatusBadRequest, fmt.Errorf("rule group %s: %w", groups[i].Name, err), "")
+		}
+		rulesByGroup = append(rulesByGroup, rules)
+	}
+
+	dryRun := c.QueryBoolWithDefault("dry_run", false)
+	if !dryRun {
+		// the rule groups are saved in a single transaction, so that either all or none of them are imported
+		groupChanges := make([]*changes, 0, len(groups))
+		err := srv.xactManager.InTransaction(c.Req.Context(), func(tranCtx context.Context) error {
+			for i, rules := range rulesByGroup {
+				groupKey := ngmodels.AlertRuleGroupKey{
+					OrgID:        c.SignedInUser.OrgId,
+					NamespaceUID: namespace.Uid,
+					RuleGroup:    groups[i].Name,
+				}
+				finalChanges, err := srv.saveRuleGroupChanges(tranCtx, c, groupKey, rules)
+				if err != nil {
+					return fmt.Errorf("rule group %s: %w", groups[i].Name, err)
+				}
+				groupChanges = append(groupChanges, finalChanges)
+			}
+			return nil
+		})
+		if err != nil {
+			return toRuleGroupUpdateErrorResponse(err)
+		}
+		for _, finalChanges := range groupChanges {
+			srv.scheduleRuleChanges(c.SignedInUser.OrgId, finalChanges)
+		}
+	}
+
+	return response.JSON(http.StatusAccepted, apimodels.PrometheusRulesImportResponse{
+		DryRun:   dryRun,
+		Groups:   groups,
+		Warnings: warnings,
+	})
+}
+
 // updateAlertRulesInGroup calculates changes (rules to add,update,delete), verifies that the user is authorized to do the calculated changes and updates database.
 // All operations are performed in a single transaction
-// nolint: gocyclo
 func (srv RulerSrv) updateAlertRulesInGroup(c *models.ReqContext, groupKey ngmodels.AlertRuleGroupKey, rules []*ngmodels.AlertRule) response.Response {
 	var finalChanges *changes
-	hasAccess := accesscontrol.HasAccess(srv.ac, c)
 	err := srv.xactManager.InTransaction(c.Req.Context(), func(tranCtx context.Context) error {
-		logger := srv.log.New("namespace_uid", groupKey.NamespaceUID, "group", groupKey.RuleGroup, "org_id", groupKey.OrgID, "user_id", c.UserId)
-		groupChanges, err := calculateChanges(tranCtx, srv.store, groupKey, rules)
-		if err != nil {
-			return err
-		}
+		var err error
+		finalChanges, err = srv.saveRuleGroupChanges(tranCtx, c, groupKey, rules)
+		return err
+	})
+	if err != nil {
+		return toRuleGroupUpdateErrorResponse(err)
+	}
 
-		if groupChanges.isEmpty() {
-			finalChanges = groupChanges
-			logger.Info("no changes detected in the request. Do nothing")
-			return nil
-		}
+	srv.scheduleRuleChanges(c.SignedInUser.OrgId, finalChanges)
 
-		authorizedChanges := groupChanges // if RBAC is disabled the permission are limited to folder access that is done upstream
-		if !srv.ac.IsDisabled() {
-			authorizedChanges, err = authorizeRuleChanges(groupChanges, func(evaluator accesscontrol.Evaluator) bool {
-				return hasAccess(accesscontrol.ReqOrgAdminOrEditor, evaluator)
-			})
-			if err != nil {
-				return err
-			}
-			if authorizedChanges.isEmpty() {
-				logger.Info("no authorized changes detected in the request. Do nothing", "not_authorized_add", len(groupChanges.New), "not_authorized_update", len(groupChanges.Update), "not_authorized_delete", len(groupChanges.Delete))
-				return nil
-			}
-			if len(groupChanges.Delete) > len(authorizedChanges.Delete) {
-				logger.Info("user is not authorized to delete one or many rules in the group. those rules will be skipped", "expected", len(groupChanges.Delete), "authorized", len(authorizedChanges.Delete))
-			}
-		}
+	if finalChanges.isEmpty() {
+		return response.JSON(http.StatusAccepted, util.DynMap{"message": "no changes detected in the rule group"})
+	}
+
+	return response.JSON(http.StatusAccepted, util.DynMap{"message": "rule group updated successfully"})
+}
+
+// saveRuleGroupChanges saves the authorized changes of the rule group in the transaction of the context, and returns
+// them, so the scheduler can be notified once the transaction is committed.
+// nolint: gocyclo
+func (srv RulerSrv) saveRuleGroupChanges(tranCtx context.Context, c *models.ReqContext, groupKey ngmodels.AlertRuleGroupKey, rules []*ngmodels.AlertRule) (*changes, error) {
+	hasAccess := accesscontrol.HasAccess(srv.ac, c)
+	logger := srv.log.New("namespace_uid", groupKey.NamespaceUID, "group", groupKey.RuleGroup, "org_id", groupKey.OrgID, "user_id", c.UserId)
+	groupChanges, err := calculateChanges(tranCtx, srv.store, groupKey, rules)
+	if err != nil {
+		return nil, err
+	}
 
-		provenances, err := srv.provenanceStore.GetProvenances(c.Req.Context(), c.OrgId, (&ngmodels.AlertRule{}).ResourceType())
+	if groupChanges.isEmpty() {
+		logger.Info("no changes detected in the request. Do nothing")
+		return groupChanges, nil
+	}
+
+	authorizedChanges := groupChanges // if RBAC is disabled the permission are limited to folder access that is done upstream
+	if !srv.ac.IsDisabled() {
+		authorizedChanges, err = authorizeRuleChanges(groupChanges, func(evaluator accesscontrol.Evaluator) bool {
+			return hasAccess(accesscontrol.ReqOrgAdminOrEditor, evaluator)
+		})
 		if err != nil {
-			return err
+			return nil, err
 		}
-
-		// New rules don't need to be checked for provenance, just copy the whole slice.
-		finalChanges = &changes{}
-		finalChanges.New = authorizedChanges.New
-		for _, rule := range authorizedChanges.Update {
-			if provenance, exists := provenances[rule.Existing.UID]; (exists && provenance == ngmodels.ProvenanceNone) || !exists {
-				finalChanges.Update = append(finalChanges.Update, rule)
-			}
+		if authorizedChanges.isEmpty() {
+			logger.Info("no authorized changes detected in the request. Do nothing", "not_authorized_add", len(groupChanges.New), "not_authorized_update", len(groupChanges.Update), "not_authorized_delete", len(groupChanges.Delete))
+			return authorizedChanges, nil
 		}
-		for _, rule := range authorizedChanges.Delete {
-			if provenance, exists := provenances[rule.UID]; (exists && provenance == ngmodels.ProvenanceNone) || !exists {
-				finalChanges.Delete = append(finalChanges.Delete, rule)
-			}
+		if len(groupChanges.Delete) > len(authorizedChanges.Delete) {
+			logger.Info("user is not authorized to delete one or many rules in the group. those rules will be skipped", "expected", len(groupChanges.Delete), "authorized", len(authorizedChanges.Delete))
 		}
+	}
 
-		if finalChanges.isEmpty() {
-			logger.Info("no changes detected that have 'none' provenance in the request. Do nothing",
-				"provenance_invalid_add", len(authorizedChanges.New),
-				"provenance_invalid_update", len(authorizedChanges.Update),
-				"provenance_invalid_delete", len(authorizedChanges.Delete))
-			return nil
-		}
+	provenances, err := srv.provenanceStore.GetProvenances(c.Req.Context(), c.OrgId, (&ngmodels.AlertRule{}).ResourceType())
+	if err != nil {
+		return nil, err
+	}
 
-		if len(authorizedChanges.Delete) > len(finalChanges.Delete) {
-			logger.Info("provenance is not 'none' for one or many rules in the group that should be deleted. those rules will be skipped",
-				"expected", len(authorizedChanges.Delete),
-				"allowed", len(authorizedChanges.Delete))
+	// New rules don't need to be checked for provenance, just copy the whole slice.
+	finalChanges := &changes{}
+	finalChanges.New = authorizedChanges.New
+	for _, rule := range authorizedChanges.Update {
+		if provenance, exists := provenances[rule.Existing.UID]; (exists && provenance == ngmodels.ProvenanceNone) || !exists {
+			finalChanges.Update = append(finalChanges.Update, rule)
 		}
-
-		if len(authorizedChanges.Update) > len(finalChanges.Update) {
-			logger.Info("provenance is not 'none' for one or many rules in the group that should be updated. those rules will be skipped",
-				"expected", len(authorizedChanges.Update),
-				"allowed", len(authorizedChanges.Update))
+	}
+	for _, rule := range authorizedChanges.Delete {
+		if provenance, exists := provenances[rule.UID]; (exists && provenance == ngmodels.ProvenanceNone) || !exists {
+			finalChanges.Delete = append(finalChanges.Delete, rule)
 		}
+	}
 
-		logger.Debug("updating database with the authorized changes", "add", len(finalChanges.New), "update", len(finalChanges.New), "delete", len(finalChanges.Delete))
+	if finalChanges.isEmpty() {
+		logger.Info("no changes detected that have 'none' provenance in the request. Do nothing",
+			"provenance_invalid_add", len(authorizedChanges.New),
+			"provenance_invalid_update", len(authorizedChanges.Update),
+			"provenance_invalid_delete", len(authorizedChanges.Delete))
+		return finalChanges, nil
+	}
 
-		if len(finalChanges.Update) > 0 || len(finalChanges.New) > 0 {
-			updates := make([]store.UpdateRule, 0, len(finalChanges.Update))
-			inserts := make([]ngmodels.AlertRule, 0, len(finalChanges.New))
-			for _, update := range finalChanges.Update {
-				logger.Debug("updating rule", "rule_uid", update.New.UID, "diff", update.Diff.String())
-				updates = append(updates, store.UpdateRule{
-					Existing: update.Existing,
-					New:      *update.New,
-				})
-			}
-			for _, rule := range finalChanges.New {
-				inserts = append(inserts, *rule)
-			}
-			err = srv.store.InsertAlertRules(tranCtx, inserts)
-			if err != nil {
-				return fmt.Errorf("failed to add rules: %w", err)
-			}
-			err = srv.store.UpdateAlertRules(tranCtx, updates)
-			if err != nil {
-				return fmt.Errorf("failed to update rules: %w", err)
-			}
-		}
+	if len(authorizedChanges.Delete) > len(finalChanges.Delete) {
+		logger.Info("provenance is not 'none' for one or many rules in the group that should be deleted. those rules will be skipped",
+			"expected", len(authorizedChanges.Delete),
+			"allowed", len(authorizedChanges.Delete))
+	}
 
-		if len(finalChanges.Delete) > 0 {
-			UIDs := make([]string, 0, len(finalChanges.Delete))
-			for _, rule := range finalChanges.Delete {
-				UIDs = append(UIDs, rule.UID)
-			}
+	if len(authorizedChanges.Update) > len(finalChanges.Update) {
+		logger.Info("provenance is not 'none' for one or many rules in the group that should be updated. those rules will be skipped",
+			"expected", len(authorizedChanges.Update),
+			"allowed", len(authorizedChanges.Update))
+	}
 
-			if err = srv.store.DeleteAlertRulesByUID(tranCtx, c.SignedInUser.OrgId, UIDs...); err != nil {
-				return fmt.Errorf("failed to delete rules: %w", err)
-			}
+	logger.Debug("updating database with the authorized changes", "add", len(finalChanges.New), "update", len(finalChanges.New), "delete", len(finalChanges.Delete))
+
+	if len(finalChanges.Update) > 0 || len(finalChanges.New) > 0 {
+		updates := make([]store.UpdateRule, 0, len(finalChanges.Update))
+		inserts := make([]ngmodels.AlertRule, 0, len(finalChanges.New))
+		for _, update := range finalChanges.Update {
+			logger.Debug("updating rule", "rule_uid", update.New.UID, "diff", update.Diff.String())
+			updates = append(updates, store.UpdateRule{
+				Existing: update.Existing,
+				New:      *update.New,
+			})
+		}
+		for _, rule := range finalChanges.New {
+			inserts = append(inserts, *rule)
 		}
+		err = srv.store.InsertAlertRules(tranCtx, inserts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add rules: %w", err)
+		}
+		err = srv.store.UpdateAlertRules(tranCtx, updates)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update rules: %w", err)
+		}
+	}
 
-		if len(finalChanges.New) > 0 {
-			limitReached, err := srv.QuotaService.CheckQuotaReached(tranCtx, "alert_rule", &quota.ScopeParameters{
-				OrgId:  c.OrgId,
-				UserId: c.UserId,
-			}) // alert rule is table name
-			if err != nil {
-				return fmt.Errorf("failed to get alert rules quota: %w", err)
-			}
-			if limitReached {
-				return errQuotaReached
-			}
+	if len(finalChanges.Delete) > 0 {
+		UIDs := make([]string, 0, len(finalChanges.Delete))
+		for _, rule := range finalChanges.Delete {
+			UIDs = append(UIDs, rule.UID)
 		}
-		return nil
-	})
 
-	if err != nil {
-		if errors.Is(err, ngmodels.ErrAlertRuleNotFound) {
-			return ErrResp(http.StatusNotFound, err, "failed to update rule group")
-		} else if errors.Is(err, ngmodels.ErrAlertRuleFailedValidation) {
-			return ErrResp(http.StatusBadRequest, err, "failed to update rule group")
-		} else if errors.Is(err, errQuotaReached) {
-			return ErrResp(http.StatusForbidden, err, "")
-		} else if errors.Is(err, ErrAuthorization) {
-			return ErrResp(http.StatusUnauthorized, err, "")
+		if err = srv.store.DeleteAlertRulesByUID(tranCtx, c.SignedInUser.OrgId, UIDs...); err != nil {
+			return nil, fmt.Errorf("failed to delete rules: %w", err)
+		}
+	}
+
+	if len(finalChanges.New) > 0 {
+		limitReached, err := srv.QuotaService.CheckQuotaReached(tranCtx, "alert_rule", &quota.ScopeParameters{
+			OrgId:  c.OrgId,
+			UserId: c.UserId,
+		}) // alert rule is table name
+		if err != nil {
+			return nil, fmt.Errorf("failed to get alert rules quota: %w", err)
+		}
+		if limitReached {
+			return nil, errQuotaReached
 		}
-		return ErrResp(http.StatusInternalServerError, err, "failed to update rule group")
 	}
+	return finalChanges, nil
+}
 
+func toRuleGroupUpdateErrorResponse(err error) response.Response {
+	if errors.Is(err, ngmodels.ErrAlertRuleNotFound) {
+		return ErrResp(http.StatusNotFound, err, "failed to update rule group")
+	} else if errors.Is(err, ngmodels.ErrAlertRuleFailedValidation) {
+		return ErrResp(http.StatusBadRequest, err, "failed to update rule group")
+	} else if errors.Is(err, errQuotaReached) {
+		return ErrResp(http.StatusForbidden, err, "")
+	} else if errors.Is(err, ErrAuthorization) {
+		return ErrResp(http.StatusUnauthorized, err, "")
+	}
+	return ErrResp(http.StatusInternalServerError, err, "failed to update rule group")
+}
+
+// scheduleRuleChanges notifies the scheduler of the updated and deleted rules once their changes are committed.
+func (srv RulerSrv) scheduleRuleChanges(orgID int64, finalChanges *changes) {
 	for _, rule := range finalChanges.Update {
 		srv.scheduleService.UpdateAlertRule(ngmodels.AlertRuleKey{
-			OrgID: c.SignedInUser.OrgId,
+			OrgID: orgID,
 			UID:   rule.Existing.UID,
 		})
 	}
 
 	for _, rule := range finalChanges.Delete {
 		srv.scheduleService.DeleteAlertRule(ngmodels.AlertRuleKey{
-			OrgID: c.SignedInUser.OrgId,
+			OrgID: orgID,
 			UID:   rule.UID,
 		})
 	}
-
-	if finalChanges.isEmpty() {
-		return response.JSON(http.StatusAccepted, util.DynMap{"message": "no changes detected in the rule group"})
-	}
-
-	return response.JSON(http.StatusAccepted, util.DynMap{"message": "rule group updated successfully"})
 }
 
 func toGettableRuleGroupConfig(groupName string, rules []*ngmodels.AlertRule, namespaceID int64, provenanceRecords map[string]ngmodels.Provenance) apimodels.GettableRuleGroupConfig {
@@ -6,9 +6,11 @@ import (
 	"errors"
 	"math/rand"
 	"net/http"
+	"net/url"
 	"testing"
 	"time"
 
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -18,11 +20,14 @@ import (
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	acMock "github.com/grafana/grafana/pkg/services/accesscontrol/mock"
 	"github.com/grafana/grafana/pkg/services/datasources"
+	fakes "github.com/grafana/grafana/pkg/services/datasources/fakes"
 	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/services/ngalert/provisioning"
 	"github.com/grafana/grafana/pkg/services/ngalert/schedule"
 	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/services/quota"
+	"github.com/grafana/grafana/pkg/setting"
 	"github.com/grafana/grafana/pkg/util"
 	"github.com/grafana/grafana/pkg/web"
 )
@@ -645,6 +650,122 @@ func TestRouteGetNamespaceRulesConfig(t *testing.T) {
 	})
 }
 
+func TestRouteImportPrometheusRules(t *testing.T) {
+	orgID := rand.Int63()
+	folder := randFolder()
+	ruleStore := store.NewFakeRuleStore(t)
+	ruleStore.Folders[orgID] = append(ruleStore.Folders[orgID], folder)
+
+	srv := createService(acMock.New(), ruleStore, nil)
+	srv.DatasourceCache = &fakes.FakeCacheService{DataSources: []*models2.DataSource{
+		{Uid: "prom", Type: "prometheus"},
+		{Uid: "graphite", Type: "graphite"},
+	}}
+	srv.cfg = &setting.UnifiedAlertingSettings{BaseInterval: 10 * time.Second, DefaultRuleEvaluationInterval: time.Minute}
+
+	ruleFile := apimodels.PrometheusRuleFile{Groups: []apimodels.PrometheusRuleGroup{{
+		Name:     "node",
+		Interval: model.Duration(time.Minute),
+		Rules: []apimodels.ApiRuleNode{
+			{Record: "job:up:sum", Expr: "sum by (job) (up)"},
+			{Alert: "InstanceDown", Expr: "up == 0", For: model.Duration(5 * time.Minute), Labels: map[string]string{"severity": "critical"}},
+		},
+	}}}
+
+	request := func(query string) *models2.ReqContext {
+		c := createRequestContext(orgID, models2.ROLE_EDITOR, map[string]string{":Namespace": folder.Title})
+		c.Req.URL = &url.URL{RawQuery: query}
+		return c
+	}
+
+	t.Run("dry run returns the converted rule groups without saving them", func(t *testing.T) {
+		response := srv.RouteImportPrometheusRules(request("datasource_uid=prom&dry_run=true"), ruleFile)
+		require.Equal(t, http.StatusAccepted, response.Status())
+
+		result := apimodels.PrometheusRulesImportResponse{}
+		require.NoError(t, json.Unmarshal(response.Body(), &result))
+		require.True(t, result.DryRun)
+		require.Len(t, result.Warnings, 1)
+		require.Len(t, result.Groups, 1)
+		require.Len(t, result.Groups[0].Rules, 1)
+		rule := result.Groups[0].Rules[0]
+		require.Equal(t, "InstanceDown", rule.GrafanaManagedAlert.Title)
+		require.Equal(t, model.Duration(5*time.Minute), rule.For)
+		require.Equal(t, "prom", rule.GrafanaManagedAlert.Data[0].DatasourceUID)
+
+		require.Empty(t, ruleStore.GetRecordedCommands(func(cmd interface{}) (interface{}, bool) {
+			_, ok := cmd.([]models.AlertRule)
+			return cmd, ok
+		}))
+	})
+
+	t.Run("returns 400 if the data source is not set or is not supported", func(t *testing.T) {
+		response := srv.RouteImportPrometheusRules(request("dry_run=true"), ruleFile)
+		require.Equal(t, http.StatusBadRequest, response.Status())
+
+		response = srv.RouteImportPrometheusRules(request("datasource_uid=graphite&dry_run=true"), ruleFile)
+		require.Equal(t, http.StatusBadRequest, response.Status())
+	})
+
+	t.Run("returns 404 if the data source does not exist", func(t *testing.T) {
+		response := srv.RouteImportPrometheusRules(request("datasource_uid=missing&dry_run=true"), ruleFile)
+		require.Equal(t, http.StatusNotFound, response.Status())
+	})
+
+	t.Run("returns 400 if a converted rule group is invalid", func(t *testing.T) {
+		invalid := apimodels.PrometheusRuleFile{Groups: []apimodels.PrometheusRuleGroup{{
+			Name:     "node",
+			Interval: model.Duration(15 * time.Second),
+			Rules:    ruleFile.Groups[0].Rules,
+		}}}
+		response := srv.RouteImportPrometheusRules(request("datasource_uid=prom&dry_run=true"), invalid)
+		require.Equal(t, http.StatusBadRequest, response.Status())
+	})
+
+	t.Run("saves all rule groups in a single transaction", func(t *testing.T) {
+		xact := &countingTransactionManager{TransactionManager: ruleStore}
+		importSrv := *srv
+		importSrv.xactManager = xact
+		importSrv.ac = acMock.New().WithDisabled()
+		importSrv.QuotaService = &quota.QuotaService{Cfg: &setting.Cfg{}}
+
+		twoGroups := apimodels.PrometheusRuleFile{Groups: []apimodels.PrometheusRuleGroup{
+			ruleFile.Groups[0],
+			{
+				Name:     "disk",
+				Interval: model.Duration(time.Minute),
+				Rules: []apimodels.ApiRuleNode{
+					{Alert: "DiskFull", Expr: "node_filesystem_avail_bytes == 0"},
+				},
+			},
+		}}
+		response := importSrv.RouteImportPrometheusRules(request("datasource_uid=prom"), twoGroups)
+		require.Equal(t, http.StatusAccepted, response.Status())
+		require.Equal(t, 1, xact.calls)
+
+		var titles []string
+		for _, cmd := range ruleStore.GetRecordedCommands(func(cmd interface{}) (interface{}, bool) {
+			_, ok := cmd.([]models.AlertRule)
+			return cmd, ok
+		}) {
+			for _, rule := range cmd.([]models.AlertRule) {
+				titles = append(titles, rule.Title)
+			}
+		}
+		require.ElementsMatch(t, []string{"InstanceDown", "DiskFull"}, titles)
+	})
+}
+
+type countingTransactionManager struct {
+	provisioning.TransactionManager
+	calls int
+}
+
+func (m *countingTransactionManager) InTransaction(ctx context.Context, f func(ctx context.Context) error) error {
+	m.calls++
+	return m.TransactionManager.InTransaction(ctx, f)
+}
+
 func createService(ac *acMock.Mock, store *store.FakeRuleStore, scheduler schedule.ScheduleService) *RulerSrv {
 	return &RulerSrv{
 		xactManager:     store,
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/datasources"
 	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/backtesting"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
 	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/util"
@@ -30,6 +32,7 @@ type TestingApiSrv struct {
 	log               log.Logger
 	accessControl     accesscontrol.AccessControl
 	evaluator         eval.Evaluator
+	backtesting       *backtesting.Engine
 }
 
 func (srv TestingApiSrv) RouteTestGrafanaRuleConfig(c *models.ReqContext, body apimodels.TestRulePayload) response.Response {
@@ -132,3 +135,57 @@ func (srv TestingApiSrv) RouteEvalQueries(c *models.ReqContext, cmd apimodels.Ev
 
 	return response.JSONStreaming(http.StatusOK, evalResults)
 }
+
+// BacktestAlertRule evaluates the rule at every interval over the past time range, and returns the state of each alert
+// instance after each evaluation.
+func (srv TestingApiSrv) BacktestAlertRule(c *models.ReqContext, cmd apimodels.BacktestConfig) response.Response {
+	if !authorizeDatasourceAccessForRule(&ngmodels.AlertRule{Data: cmd.Data}, func(evaluator accesscontrol.Evaluator) bool {
+		return accesscontrol.HasAccess(srv.accessControl, c)(accesscontrol.ReqSignedIn, evaluator)
+	}) {
+		return ErrResp(http.StatusUnauthorized, fmt.Errorf("%w to query one or many data sources used by the rule", ErrAuthorization), "")
+	}
+
+	evalCond := ngmodels.Condition{
+		Condition: cmd.Condition,
+		OrgID:     c.SignedInUser.OrgId,
+		Data:      cmd.Data,
+	}
+	if err := validateCondition(c.Req.Context(), evalCond, c.SignedInUser, c.SkipCache, srv.DatasourceCache); err != nil {
+		return ErrResp(http.StatusBadRequest, err, "invalid condition")
+	}
+
+	rule := &ngmodels.AlertRule{
+		OrgID:           c.SignedInUser.OrgId,
+		Title:           cmd.Title,
+		Condition:       cmd.Condition,
+		Data:            cmd.Data,
+		IntervalSeconds: int64(time.Duration(cmd.Interval).Seconds()),
+		For:             time.Duration(cmd.For),
+		Labels:          cmd.Labels,
+		NoDataState:     ngmodels.NoData,
+		ExecErrState:    ngmodels.AlertingErrState,
+	}
+	if cmd.NoDataState != "" {
+		noDataState, err := ngmodels.NoDataStateFromString(string(cmd.NoDataState))
+		if err != nil {
+			return ErrResp(http.StatusBadRequest, err, "")
+		}
+		rule.NoDataState = noDataState
+	}
+	if cmd.ExecErrState != "" {
+		execErrState, err := ngmodels.ErrStateFromString(string(cmd.ExecErrState))
+		if err != nil {
+			return ErrResp(http.StatusBadRequest, err, "")
+		}
+		rule.ExecErrState = execErrState
+	}
+
+	frame, err := srv.backtesting.Test(c.Req.Context(), rule, cmd.From, cmd.To)
+	if err != nil {
+		if errors.Is(err, backtesting.ErrInvalidInterval) || errors.Is(err, backtesting.ErrInvalidTimeRange) || errors.Is(err, backtesting.ErrTooManyEvals) {
+			return ErrResp(http.StatusBadRequest, err, "")
+		}
+		return ErrResp(http.StatusInternalServerError, err, "failed to backtest the rule")
+	}
+	return response.JSONStreaming(http.StatusOK, frame)
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/datasources"
 	fakes "github.com/grafana/grafana/pkg/services/datasources/fakes"
 	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/backtesting"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/web"
@@ -266,6 +268,83 @@ func TestRouteEvalQueries(t *testing.T) {
 	})
 }
 
+func TestBacktestAlertRule(t *testing.T) {
+	rc := &models2.ReqContext{
+		Context: &web.Context{
+			Req: &http.Request{},
+		},
+		IsSignedIn: true,
+		SignedInUser: &models2.SignedInUser{
+			OrgId: 1,
+		},
+	}
+	from := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("should return 401 if user cannot query a data source", func(t *testing.T) {
+		data1 := models.GenerateAlertQuery()
+		data2 := models.GenerateAlertQuery()
+
+		ac := acMock.New().WithPermissions([]*accesscontrol.Permission{
+			{Action: datasources.ActionQuery, Scope: datasources.ScopeProvider.GetResourceScopeUID(data1.DatasourceUID)},
+		})
+
+		evaluator := &eval.FakeEvaluator{}
+		srv := createTestingApiSrv(nil, ac, evaluator)
+		srv.backtesting = backtesting.NewEngine(evaluator, nil)
+
+		response := srv.BacktestAlertRule(rc, definitions.BacktestConfig{
+			From:      from,
+			To:        from.Add(time.Hour),
+			Interval:  model.Duration(time.Minute),
+			Condition: data1.RefID,
+			Data:      []models.AlertQuery{data1, data2},
+		})
+
+		require.Equal(t, http.StatusUnauthorized, response.Status())
+		evaluator.AssertNotCalled(t, "ConditionEval", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return the states of the alert instances", func(t *testing.T) {
+		data1 := models.GenerateAlertQuery()
+
+		ac := acMock.New().WithPermissions([]*accesscontrol.Permission{
+			{Action: datasources.ActionQuery, Scope: datasources.ScopeProvider.GetResourceScopeUID(data1.DatasourceUID)},
+		})
+		ds := &fakes.FakeCacheService{DataSources: []*models2.DataSource{
+			{Uid: data1.DatasourceUID},
+		}}
+
+		evaluator := &eval.FakeEvaluator{}
+		evaluator.EXPECT().ConditionEval(mock.Anything, mock.Anything, mock.Anything).Return(eval.Results{{State: eval.Alerting}}, nil)
+		srv := createTestingApiSrv(ds, ac, evaluator)
+		srv.backtesting = backtesting.NewEngine(evaluator, nil)
+
+		response := srv.BacktestAlertRule(rc, definitions.BacktestConfig{
+			From:      from,
+			To:        from.Add(time.Hour),
+			Interval:  model.Duration(time.Minute),
+			Condition: data1.RefID,
+			Data:      []models.AlertQuery{data1},
+			Title:     "test",
+		})
+
+		require.Equal(t, http.StatusOK, response.Status())
+		evaluator.AssertNumberOfCalls(t, "ConditionEval", 61)
+
+		t.Run("and 400 for an invalid time range", func(t *testing.T) {
+			response := srv.BacktestAlertRule(rc, definitions.BacktestConfig{
+				From:      from,
+				To:        from.Add(-time.Hour),
+				Interval:  model.Duration(time.Minute),
+				Condition: data1.RefID,
+				Data:      []models.AlertQuery{data1},
+			})
+
+			require.Equal(t, http.StatusBadRequest, response.Status())
+		})
+	})
+}
+
 func createTestingApiSrv(ds *fakes.FakeCacheService, ac *acMock.Mock, evaluator *eval.FakeEvaluator) *TestingApiSrv {
 	if ac == nil {
 		ac = acMock.New().WithDisabled()
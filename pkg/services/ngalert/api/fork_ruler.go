@@ -136,3 +136,7 @@ func (f *ForkedRulerApi) forkRoutePostNameGrafanaRulesConfig(ctx *models.ReqCont
 	}
 	return f.GrafanaRuler.RoutePostNameRulesConfig(ctx, conf)
 }
+
+func (f *ForkedRulerApi) forkRoutePostImportPrometheusRules(ctx *models.ReqContext, conf apimodels.PrometheusRuleFile) response.Response {
+	return f.GrafanaRuler.RouteImportPrometheusRules(ctx, conf)
+}
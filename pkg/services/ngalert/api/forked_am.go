@@ -183,6 +183,22 @@ func (f *ForkedAlertmanagerApi) forkRouteGetGrafanaAlertingConfig(ctx *models.Re
 	return f.GrafanaSvc.RouteGetAlertingConfig(ctx)
 }
 
+func (f *ForkedAlertmanagerApi) forkRoutePostTestGrafanaRoutes(ctx *models.ReqContext, conf apimodels.TestRoutesConfigBodyParams) response.Response {
+	return f.GrafanaSvc.RoutePostTestRoutes(ctx, conf)
+}
+
+func (f *ForkedAlertmanagerApi) forkRouteGetGrafanaAlertingConfigHistory(ctx *models.ReqContext) response.Response {
+	return f.GrafanaSvc.RouteGetAlertingConfigHistory(ctx)
+}
+
+func (f *ForkedAlertmanagerApi) forkRouteGetGrafanaAlertingConfigDiff(ctx *models.ReqContext) response.Response {
+	return f.GrafanaSvc.RouteGetAlertingConfigDiff(ctx)
+}
+
+func (f *ForkedAlertmanagerApi) forkRoutePostGrafanaAlertingConfigHistoryActivate(ctx *models.ReqContext) response.Response {
+	return f.GrafanaSvc.RoutePostAlertingConfigHistoryActivate(ctx)
+}
+
 func (f *ForkedAlertmanagerApi) forkRouteGetGrafanaSilence(ctx *models.ReqContext) response.Response {
 	return f.GrafanaSvc.RouteGetSilence(ctx)
 }
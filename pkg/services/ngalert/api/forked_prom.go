@@ -59,3 +59,11 @@ func (f *ForkedPrometheusApi) forkRouteGetGrafanaAlertStatuses(ctx *models.ReqCo
 func (f *ForkedPrometheusApi) forkRouteGetGrafanaRuleStatuses(ctx *models.ReqContext) response.Response {
 	return f.GrafanaSvc.RouteGetRuleStatuses(ctx)
 }
+
+func (f *ForkedPrometheusApi) forkRoutePostGrafanaAlertAcknowledge(ctx *models.ReqContext, body apimodels.PostableAlertAcknowledgement) response.Response {
+	return f.GrafanaSvc.RoutePostAlertAcknowledge(ctx, body)
+}
+
+func (f *ForkedPrometheusApi) forkRoutePostGrafanaAlertUnacknowledge(ctx *models.ReqContext, body apimodels.PostableAlertUnacknowledgement) response.Response {
+	return f.GrafanaSvc.RoutePostAlertUnacknowledge(ctx, body)
+}
@@ -78,3 +78,27 @@ func (f *ForkedProvisioningApi) forkRoutePutMuteTiming(ctx *models.ReqContext, m
 func (f *ForkedProvisioningApi) forkRouteDeleteMuteTiming(ctx *models.ReqContext) response.Response {
 	return f.svc.RouteDeleteMuteTiming(ctx)
 }
+
+func (f *ForkedProvisioningApi) forkRouteGetAlertRule(ctx *models.ReqContext) response.Response {
+	return f.svc.RouteGetAlertRule(ctx)
+}
+
+func (f *ForkedProvisioningApi) forkRoutePostAlertRule(ctx *models.ReqContext, ar apimodels.ProvisionedAlertRule) response.Response {
+	return f.svc.RoutePostAlertRule(ctx, ar)
+}
+
+func (f *ForkedProvisioningApi) forkRoutePutAlertRule(ctx *models.ReqContext, ar apimodels.ProvisionedAlertRule) response.Response {
+	return f.svc.RoutePutAlertRule(ctx, ar)
+}
+
+func (f *ForkedProvisioningApi) forkRouteDeleteAlertRule(ctx *models.ReqContext) response.Response {
+	return f.svc.RouteDeleteAlertRule(ctx)
+}
+
+func (f *ForkedProvisioningApi) forkRouteGetAlertRuleGroup(ctx *models.ReqContext) response.Response {
+	return f.svc.RouteGetAlertRuleGroup(ctx)
+}
+
+func (f *ForkedProvisioningApi) forkRoutePutAlertRuleGroup(ctx *models.ReqContext, ag apimodels.AlertRuleGroup) response.Response {
+	return f.svc.RoutePutAlertRuleGroup(ctx, ag)
+}
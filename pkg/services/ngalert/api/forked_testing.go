@@ -29,3 +29,7 @@ func (f *ForkedTestingApi) forkRouteTestRuleGrafanaConfig(c *models.ReqContext,
 func (f *ForkedTestingApi) forkRouteEvalQueries(c *models.ReqContext, body apimodels.EvalQueriesPayload) response.Response {
 	return f.svc.RouteEvalQueries(c, body)
 }
+
+func (f *ForkedTestingApi) forkRouteBacktestConfig(c *models.ReqContext, body apimodels.BacktestConfig) response.Response {
+	return f.svc.BacktestAlertRule(c, body)
+}
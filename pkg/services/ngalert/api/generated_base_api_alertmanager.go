@@ -34,6 +34,8 @@ type AlertmanagerApiForkingService interface {
 	RouteGetGrafanaAMAlerts(*models.ReqContext) response.Response
 	RouteGetGrafanaAMStatus(*models.ReqContext) response.Response
 	RouteGetGrafanaAlertingConfig(*models.ReqContext) response.Response
+	RouteGetGrafanaAlertingConfigDiff(*models.ReqContext) response.Response
+	RouteGetGrafanaAlertingConfigHistory(*models.ReqContext) response.Response
 	RouteGetGrafanaSilence(*models.ReqContext) response.Response
 	RouteGetGrafanaSilences(*models.ReqContext) response.Response
 	RouteGetSilence(*models.ReqContext) response.Response
@@ -42,7 +44,9 @@ type AlertmanagerApiForkingService interface {
 	RoutePostAlertingConfig(*models.ReqContext) response.Response
 	RoutePostGrafanaAMAlerts(*models.ReqContext) response.Response
 	RoutePostGrafanaAlertingConfig(*models.ReqContext) response.Response
+	RoutePostGrafanaAlertingConfigHistoryActivate(*models.ReqContext) response.Response
 	RoutePostTestGrafanaReceivers(*models.ReqContext) response.Response
+	RoutePostTestGrafanaRoutes(*models.ReqContext) response.Response
 	RoutePostTestReceivers(*models.ReqContext) response.Response
 }
 
@@ -110,6 +114,14 @@ func (f *ForkedAlertmanagerApi) RouteGetGrafanaAlertingConfig(ctx *models.ReqCon
 	return f.forkRouteGetGrafanaAlertingConfig(ctx)
 }
 
+func (f *ForkedAlertmanagerApi) RouteGetGrafanaAlertingConfigDiff(ctx *models.ReqContext) response.Response {
+	return f.forkRouteGetGrafanaAlertingConfigDiff(ctx)
+}
+
+func (f *ForkedAlertmanagerApi) RouteGetGrafanaAlertingConfigHistory(ctx *models.ReqContext) response.Response {
+	return f.forkRouteGetGrafanaAlertingConfigHistory(ctx)
+}
+
 func (f *ForkedAlertmanagerApi) RouteGetGrafanaSilence(ctx *models.ReqContext) response.Response {
 	return f.forkRouteGetGrafanaSilence(ctx)
 }
@@ -158,6 +170,10 @@ func (f *ForkedAlertmanagerApi) RoutePostGrafanaAlertingConfig(ctx *models.ReqCo
 	return f.forkRoutePostGrafanaAlertingConfig(ctx, conf)
 }
 
+func (f *ForkedAlertmanagerApi) RoutePostGrafanaAlertingConfigHistoryActivate(ctx *models.ReqContext) response.Response {
+	return f.forkRoutePostGrafanaAlertingConfigHistoryActivate(ctx)
+}
+
 func (f *ForkedAlertmanagerApi) RoutePostTestGrafanaReceivers(ctx *models.ReqContext) response.Response {
 	conf := apimodels.TestReceiversConfigBodyParams{}
 	if err := web.Bind(ctx.Req, &conf); err != nil {
@@ -166,6 +182,14 @@ func (f *ForkedAlertmanagerApi) RoutePostTestGrafanaReceivers(ctx *models.ReqCon
 	return f.forkRoutePostTestGrafanaReceivers(ctx, conf)
 }
 
+func (f *ForkedAlertmanagerApi) RoutePostTestGrafanaRoutes(ctx *models.ReqContext) response.Response {
+	conf := apimodels.TestRoutesConfigBodyParams{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.forkRoutePostTestGrafanaRoutes(ctx, conf)
+}
+
 func (f *ForkedAlertmanagerApi) RoutePostTestReceivers(ctx *models.ReqContext) response.Response {
 	conf := apimodels.TestReceiversConfigBodyParams{}
 	if err := web.Bind(ctx.Req, &conf); err != nil {
@@ -316,6 +340,26 @@ func (api *API) RegisterAlertmanagerApiEndpoints(srv AlertmanagerApiForkingServi
 				m,
 			),
 		)
+		group.Get(
+			toMacaronPath("/api/alertmanager/grafana/config/history/{ConfigID}/diff"),
+			api.authorize(http.MethodGet, "/api/alertmanager/grafana/config/history/{ConfigID}/diff"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/alertmanager/grafana/config/history/{ConfigID}/diff",
+				srv.RouteGetGrafanaAlertingConfigDiff,
+				m,
+			),
+		)
+		group.Get(
+			toMacaronPath("/api/alertmanager/grafana/config/history"),
+			api.authorize(http.MethodGet, "/api/alertmanager/grafana/config/history"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/alertmanager/grafana/config/history",
+				srv.RouteGetGrafanaAlertingConfigHistory,
+				m,
+			),
+		)
 		group.Get(
 			toMacaronPath("/api/alertmanager/grafana/api/v2/silence/{SilenceId}"),
 			api.authorize(http.MethodGet, "/api/alertmanager/grafana/api/v2/silence/{SilenceId}"),
@@ -396,6 +440,16 @@ func (api *API) RegisterAlertmanagerApiEndpoints(srv AlertmanagerApiForkingServi
 				m,
 			),
 		)
+		group.Post(
+			toMacaronPath("/api/alertmanager/grafana/config/history/{ConfigID}/_activate"),
+			api.authorize(http.MethodPost, "/api/alertmanager/grafana/config/history/{ConfigID}/_activate"),
+			metrics.Instrument(
+				http.MethodPost,
+				"/api/alertmanager/grafana/config/history/{ConfigID}/_activate",
+				srv.RoutePostGrafanaAlertingConfigHistoryActivate,
+				m,
+			),
+		)
 		group.Post(
 			toMacaronPath("/api/alertmanager/grafana/config/api/v1/receivers/test"),
 			api.authorize(http.MethodPost, "/api/alertmanager/grafana/config/api/v1/receivers/test"),
@@ -406,6 +460,16 @@ func (api *API) RegisterAlertmanagerApiEndpoints(srv AlertmanagerApiForkingServi
 				m,
 			),
 		)
+		group.Post(
+			toMacaronPath("/api/alertmanager/grafana/config/api/v1/routes/test"),
+			api.authorize(http.MethodPost, "/api/alertmanager/grafana/config/api/v1/routes/test"),
+			metrics.Instrument(
+				http.MethodPost,
+				"/api/alertmanager/grafana/config/api/v1/routes/test",
+				srv.RoutePostTestGrafanaRoutes,
+				m,
+			),
+		)
 		group.Post(
 			toMacaronPath("/api/alertmanager/{DatasourceUID}/config/api/v1/receivers/test"),
 			api.authorize(http.MethodPost, "/api/alertmanager/{DatasourceUID}/config/api/v1/receivers/test"),
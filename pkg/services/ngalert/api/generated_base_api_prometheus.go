@@ -14,7 +14,9 @@ import (
 	"github.com/grafana/grafana/pkg/api/routing"
 	"github.com/grafana/grafana/pkg/middleware"
 	"github.com/grafana/grafana/pkg/models"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/web"
 )
 
 type PrometheusApiForkingService interface {
@@ -22,6 +24,8 @@ type PrometheusApiForkingService interface {
 	RouteGetGrafanaAlertStatuses(*models.ReqContext) response.Response
 	RouteGetGrafanaRuleStatuses(*models.ReqContext) response.Response
 	RouteGetRuleStatuses(*models.ReqContext) response.Response
+	RoutePostGrafanaAlertAcknowledge(*models.ReqContext) response.Response
+	RoutePostGrafanaAlertUnacknowledge(*models.ReqContext) response.Response
 }
 
 func (f *ForkedPrometheusApi) RouteGetAlertStatuses(ctx *models.ReqContext) response.Response {
@@ -40,6 +44,22 @@ func (f *ForkedPrometheusApi) RouteGetRuleStatuses(ctx *models.ReqContext) respo
 	return f.forkRouteGetRuleStatuses(ctx)
 }
 
+func (f *ForkedPrometheusApi) RoutePostGrafanaAlertAcknowledge(ctx *models.ReqContext) response.Response {
+	conf := apimodels.PostableAlertAcknowledgement{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.forkRoutePostGrafanaAlertAcknowledge(ctx, conf)
+}
+
+func (f *ForkedPrometheusApi) RoutePostGrafanaAlertUnacknowledge(ctx *models.ReqContext) response.Response {
+	conf := apimodels.PostableAlertUnacknowledgement{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.forkRoutePostGrafanaAlertUnacknowledge(ctx, conf)
+}
+
 func (api *API) RegisterPrometheusApiEndpoints(srv PrometheusApiForkingService, m *metrics.API) {
 	api.RouteRegister.Group("", func(group routing.RouteRegister) {
 		group.Get(
@@ -82,5 +102,25 @@ func (api *API) RegisterPrometheusApiEndpoints(srv PrometheusApiForkingService,
 				m,
 			),
 		)
+		group.Post(
+			toMacaronPath("/api/prometheus/grafana/api/v1/rules/{RuleUID}/alerts/acknowledge"),
+			api.authorize(http.MethodPost, "/api/prometheus/grafana/api/v1/rules/{RuleUID}/alerts/acknowledge"),
+			metrics.Instrument(
+				http.MethodPost,
+				"/api/prometheus/grafana/api/v1/rules/{RuleUID}/alerts/acknowledge",
+				srv.RoutePostGrafanaAlertAcknowledge,
+				m,
+			),
+		)
+		group.Post(
+			toMacaronPath("/api/prometheus/grafana/api/v1/rules/{RuleUID}/alerts/unacknowledge"),
+			api.authorize(http.MethodPost, "/api/prometheus/grafana/api/v1/rules/{RuleUID}/alerts/unacknowledge"),
+			metrics.Instrument(
+				http.MethodPost,
+				"/api/prometheus/grafana/api/v1/rules/{RuleUID}/alerts/unacknowledge",
+				srv.RoutePostGrafanaAlertUnacknowledge,
+				m,
+			),
+		)
 	}, middleware.ReqSignedIn)
 }
@@ -20,23 +20,33 @@ import (
 )
 
 type ProvisioningApiForkingService interface {
+	RouteDeleteAlertRule(*models.ReqContext) response.Response
 	RouteDeleteContactpoints(*models.ReqContext) response.Response
 	RouteDeleteMuteTiming(*models.ReqContext) response.Response
 	RouteDeleteTemplate(*models.ReqContext) response.Response
+	RouteGetAlertRule(*models.ReqContext) response.Response
+	RouteGetAlertRuleGroup(*models.ReqContext) response.Response
 	RouteGetContactpoints(*models.ReqContext) response.Response
 	RouteGetMuteTiming(*models.ReqContext) response.Response
 	RouteGetMuteTimings(*models.ReqContext) response.Response
 	RouteGetPolicyTree(*models.ReqContext) response.Response
 	RouteGetTemplate(*models.ReqContext) response.Response
 	RouteGetTemplates(*models.ReqContext) response.Response
+	RoutePostAlertRule(*models.ReqContext) response.Response
 	RoutePostContactpoints(*models.ReqContext) response.Response
 	RoutePostMuteTiming(*models.ReqContext) response.Response
+	RoutePutAlertRule(*models.ReqContext) response.Response
+	RoutePutAlertRuleGroup(*models.ReqContext) response.Response
 	RoutePutContactpoint(*models.ReqContext) response.Response
 	RoutePutMuteTiming(*models.ReqContext) response.Response
 	RoutePutPolicyTree(*models.ReqContext) response.Response
 	RoutePutTemplate(*models.ReqContext) response.Response
 }
 
+func (f *ForkedProvisioningApi) RouteDeleteAlertRule(ctx *models.ReqContext) response.Response {
+	return f.forkRouteDeleteAlertRule(ctx)
+}
+
 func (f *ForkedProvisioningApi) RouteDeleteContactpoints(ctx *models.ReqContext) response.Response {
 	return f.forkRouteDeleteContactpoints(ctx)
 }
@@ -49,6 +59,14 @@ func (f *ForkedProvisioningApi) RouteDeleteTemplate(ctx *models.ReqContext) resp
 	return f.forkRouteDeleteTemplate(ctx)
 }
 
+func (f *ForkedProvisioningApi) RouteGetAlertRule(ctx *models.ReqContext) response.Response {
+	return f.forkRouteGetAlertRule(ctx)
+}
+
+func (f *ForkedProvisioningApi) RouteGetAlertRuleGroup(ctx *models.ReqContext) response.Response {
+	return f.forkRouteGetAlertRuleGroup(ctx)
+}
+
 func (f *ForkedProvisioningApi) RouteGetContactpoints(ctx *models.ReqContext) response.Response {
 	return f.forkRouteGetContactpoints(ctx)
 }
@@ -73,6 +91,14 @@ func (f *ForkedProvisioningApi) RouteGetTemplates(ctx *models.ReqContext) respon
 	return f.forkRouteGetTemplates(ctx)
 }
 
+func (f *ForkedProvisioningApi) RoutePostAlertRule(ctx *models.ReqContext) response.Response {
+	conf := apimodels.ProvisionedAlertRule{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.forkRoutePostAlertRule(ctx, conf)
+}
+
 func (f *ForkedProvisioningApi) RoutePostContactpoints(ctx *models.ReqContext) response.Response {
 	conf := apimodels.EmbeddedContactPoint{}
 	if err := web.Bind(ctx.Req, &conf); err != nil {
@@ -89,6 +115,22 @@ func (f *ForkedProvisioningApi) RoutePostMuteTiming(ctx *models.ReqContext) resp
 	return f.forkRoutePostMuteTiming(ctx, conf)
 }
 
+func (f *ForkedProvisioningApi) RoutePutAlertRule(ctx *models.ReqContext) response.Response {
+	conf := apimodels.ProvisionedAlertRule{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.forkRoutePutAlertRule(ctx, conf)
+}
+
+func (f *ForkedProvisioningApi) RoutePutAlertRuleGroup(ctx *models.ReqContext) response.Response {
+	conf := apimodels.AlertRuleGroup{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.forkRoutePutAlertRuleGroup(ctx, conf)
+}
+
 func (f *ForkedProvisioningApi) RoutePutContactpoint(ctx *models.ReqContext) response.Response {
 	conf := apimodels.EmbeddedContactPoint{}
 	if err := web.Bind(ctx.Req, &conf); err != nil {
@@ -123,6 +165,16 @@ func (f *ForkedProvisioningApi) RoutePutTemplate(ctx *models.ReqContext) respons
 
 func (api *API) RegisterProvisioningApiEndpoints(srv ProvisioningApiForkingService, m *metrics.API) {
 	api.RouteRegister.Group("", func(group routing.RouteRegister) {
+		group.Delete(
+			toMacaronPath("/api/provisioning/alert-rules/{UID}"),
+			api.authorize(http.MethodDelete, "/api/provisioning/alert-rules/{UID}"),
+			metrics.Instrument(
+				http.MethodDelete,
+				"/api/provisioning/alert-rules/{UID}",
+				srv.RouteDeleteAlertRule,
+				m,
+			),
+		)
 		group.Delete(
 			toMacaronPath("/api/provisioning/contact-points/{ID}"),
 			api.authorize(http.MethodDelete, "/api/provisioning/contact-points/{ID}"),
@@ -153,6 +205,26 @@ func (api *API) RegisterProvisioningApiEndpoints(srv ProvisioningApiForkingServi
 				m,
 			),
 		)
+		group.Get(
+			toMacaronPath("/api/provisioning/alert-rules/{UID}"),
+			api.authorize(http.MethodGet, "/api/provisioning/alert-rules/{UID}"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/provisioning/alert-rules/{UID}",
+				srv.RouteGetAlertRule,
+				m,
+			),
+		)
+		group.Get(
+			toMacaronPath("/api/provisioning/folder/{FolderUID}/rule-groups/{Group}"),
+			api.authorize(http.MethodGet, "/api/provisioning/folder/{FolderUID}/rule-groups/{Group}"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/provisioning/folder/{FolderUID}/rule-groups/{Group}",
+				srv.RouteGetAlertRuleGroup,
+				m,
+			),
+		)
 		group.Get(
 			toMacaronPath("/api/provisioning/contact-points"),
 			api.authorize(http.MethodGet, "/api/provisioning/contact-points"),
@@ -213,6 +285,16 @@ func (api *API) RegisterProvisioningApiEndpoints(srv ProvisioningApiForkingServi
 				m,
 			),
 		)
+		group.Post(
+			toMacaronPath("/api/provisioning/alert-rules"),
+			api.authorize(http.MethodPost, "/api/provisioning/alert-rules"),
+			metrics.Instrument(
+				http.MethodPost,
+				"/api/provisioning/alert-rules",
+				srv.RoutePostAlertRule,
+				m,
+			),
+		)
 		group.Post(
 			toMacaronPath("/api/provisioning/contact-points"),
 			api.authorize(http.MethodPost, "/api/provisioning/contact-points"),
@@ -233,6 +315,26 @@ func (api *API) RegisterProvisioningApiEndpoints(srv ProvisioningApiForkingServi
 				m,
 			),
 		)
+		group.Put(
+			toMacaronPath("/api/provisioning/alert-rules/{UID}"),
+			api.authorize(http.MethodPut, "/api/provisioning/alert-rules/{UID}"),
+			metrics.Instrument(
+				http.MethodPut,
+				"/api/provisioning/alert-rules/{UID}",
+				srv.RoutePutAlertRule,
+				m,
+			),
+		)
+		group.Put(
+			toMacaronPath("/api/provisioning/folder/{FolderUID}/rule-groups/{Group}"),
+			api.authorize(http.MethodPut, "/api/provisioning/folder/{FolderUID}/rule-groups/{Group}"),
+			metrics.Instrument(
+				http.MethodPut,
+				"/api/provisioning/folder/{FolderUID}/rule-groups/{Group}",
+				srv.RoutePutAlertRuleGroup,
+				m,
+			),
+		)
 		group.Put(
 			toMacaronPath("/api/provisioning/contact-points/{ID}"),
 			api.authorize(http.MethodPut, "/api/provisioning/contact-points/{ID}"),
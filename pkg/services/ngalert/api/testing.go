@@ -1,12 +1,14 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/services/ngalert/state"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
@@ -46,6 +48,38 @@ func (f *fakeAlertInstanceManager) GetStatesForRuleUID(orgID int64, alertRuleUID
 	return f.states[orgID][alertRuleUID]
 }
 
+func (f *fakeAlertInstanceManager) Acknowledge(_ context.Context, orgID int64, alertRuleUID string, labels data.Labels, ack models.AlertInstanceAcknowledgement) (*state.State, error) {
+	s, err := f.findState(orgID, alertRuleUID, labels)
+	if err != nil {
+		return nil, err
+	}
+	if s.State != eval.Alerting && s.State != eval.NoData && s.State != eval.Error {
+		return nil, state.ErrStateNotFiring
+	}
+	s.Acknowledgement = &ack
+	return s, nil
+}
+
+func (f *fakeAlertInstanceManager) Unacknowledge(_ context.Context, orgID int64, alertRuleUID string, labels data.Labels) (*state.State, error) {
+	s, err := f.findState(orgID, alertRuleUID, labels)
+	if err != nil {
+		return nil, err
+	}
+	s.Acknowledgement = nil
+	return s, nil
+}
+
+func (f *fakeAlertInstanceManager) findState(orgID int64, alertRuleUID string, labels data.Labels) (*state.State, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	for _, s := range f.states[orgID][alertRuleUID] {
+		if data.Labels(s.GetLabels(models.WithoutInternalLabels())).String() == labels.String() {
+			return s, nil
+		}
+	}
+	return nil, state.ErrStateNotFound
+}
+
 // forEachState represents the callback used when generating alert instances that allows us to modify the generated result
 type forEachState func(s *state.State) *state.State
 
@@ -70,6 +70,46 @@ import (
 //       200: Ack
 //       400: ValidationError
 
+// swagger:route POST /api/alertmanager/grafana/config/api/v1/routes/test alertmanager RoutePostTestGrafanaRoutes
+//
+// Simulate the routing of an alert through the notification policy tree, without sending any notification
+//
+//     Responses:
+//       200: TestRoutesResult
+//       400: ValidationError
+//       404: AlertManagerNotFound
+//       409: AlertManagerNotReady
+
+// swagger:route GET /api/alertmanager/grafana/config/history alertmanager RouteGetGrafanaAlertingConfigHistory
+//
+// lists the stored versions of the Alerting config, newest first
+//
+//     Responses:
+//       200: GettableAlertingConfigHistory
+//       400: ValidationError
+
+// swagger:route GET /api/alertmanager/grafana/config/history/{ConfigID}/diff alertmanager RouteGetGrafanaAlertingConfigDiff
+//
+// compares a stored version of the Alerting config with another one, the active one by default
+//
+//     Produces:
+//     - application/json
+//     - text/html
+//
+//     Responses:
+//       200: description: The diff of the two versions.
+//       400: ValidationError
+//       404: description: Not found.
+
+// swagger:route POST /api/alertmanager/grafana/config/history/{ConfigID}/_activate alertmanager RoutePostGrafanaAlertingConfigHistoryActivate
+//
+// restores a stored version of the Alerting config by saving it as a new version and applying it
+//
+//     Responses:
+//       202: Ack
+//       400: ValidationError
+//       404: description: Not found.
+
 // swagger:route GET /api/alertmanager/grafana/api/v2/status alertmanager RouteGetGrafanaAMStatus
 //
 // get alertmanager status and configuration
@@ -279,6 +319,41 @@ type TestReceiverConfigResult struct {
 	Error  string `json:"error,omitempty"`
 }
 
+// swagger:parameters RoutePostTestGrafanaRoutes
+type TestRoutesConfigParams struct {
+	// in:body
+	Body TestRoutesConfigBodyParams
+}
+
+// TestRoutesConfigBodyParams describes the alert to route. When RuleUID is set, the labels of the rule
+// are used and Labels are added to them, like the labels of the query result of the rule would be. The rule is
+// not found unless the user can read its folder.
+type TestRoutesConfigBodyParams struct {
+	Labels  model.LabelSet `yaml:"labels,omitempty" json:"labels,omitempty"`
+	RuleUID string         `yaml:"rule_uid,omitempty" json:"rule_uid,omitempty"`
+}
+
+// swagger:model
+type TestRoutesResult struct {
+	Labels      model.LabelSet    `json:"labels"`
+	Routes      []TestRouteResult `json:"routes"`
+	SilencedBy  []string          `json:"silenced_by"`
+	EvaluatedAt time.Time         `json:"evaluated_at"`
+}
+
+// swagger:model
+type TestRouteResult struct {
+	Key                     string         `json:"key"`
+	Receiver                string         `json:"receiver"`
+	GroupBy                 []string       `json:"group_by"`
+	GroupKey                string         `json:"group_key"`
+	GroupWait               model.Duration `json:"group_wait"`
+	GroupInterval           model.Duration `json:"group_interval"`
+	RepeatInterval          model.Duration `json:"repeat_interval"`
+	MuteTimeIntervals       []string       `json:"mute_time_intervals"`
+	ActiveMuteTimeIntervals []string       `json:"active_mute_time_intervals"`
+}
+
 // swagger:parameters RouteCreateSilence RouteCreateGrafanaSilence
 type CreateSilenceParams struct {
 	// in:body
@@ -291,6 +366,47 @@ type GetDeleteSilenceParams struct {
 	SilenceId string
 }
 
+// swagger:parameters RouteGetGrafanaAlertingConfigHistory
+type AlertingConfigHistoryParams struct {
+	// Maximum number of versions to return, all versions are returned when it is not set.
+	// in:query
+	// required: false
+	Limit int `json:"limit"`
+}
+
+// swagger:parameters RouteGetGrafanaAlertingConfigDiff RoutePostGrafanaAlertingConfigHistoryActivate
+type AlertingConfigHistoryIDParams struct {
+	// in:path
+	ConfigID int64
+}
+
+// swagger:parameters RouteGetGrafanaAlertingConfigDiff
+type AlertingConfigDiffParams struct {
+	// ID of the version to compare with, the active version is used when it is not set.
+	// in:query
+	// required: false
+	Base int64 `json:"base"`
+	// Format of the diff, one of json, basic or delta.
+	// in:query
+	// required: false
+	// default: basic
+	DiffType string `json:"diffType"`
+}
+
+// swagger:model
+type GettableAlertingConfigHistory []GettableAlertingConfigHistoryEntry
+
+// GettableAlertingConfigHistoryEntry describes a stored version of the Alerting config.
+type GettableAlertingConfigHistoryEntry struct {
+	ID             int64     `json:"id"`
+	Hash           string    `json:"hash"`
+	CreatedAt      time.Time `json:"createdAt"`
+	CreatedBy      int64     `json:"createdBy"`
+	CreatedByLogin string    `json:"createdByLogin,omitempty"`
+	Default        bool      `json:"default"`
+	Active         bool      `json:"active"`
+}
+
 // swagger:parameters RouteGetSilences RouteGetGrafanaSilences
 type GetSilencesParams struct {
 	// in:query
@@ -934,6 +1050,22 @@ func (c *PostableApiAlertingConfig) validate() error {
 		}
 	}
 
+	for _, r := range c.Receivers {
+		seen := make(map[string]struct{}, len(r.FallbackReceivers))
+		for _, fallback := range r.FallbackReceivers {
+			if _, ok := receivers[fallback]; !ok {
+				return fmt.Errorf("fallback receiver (%s) of receiver (%s) is undefined", fallback, r.Name)
+			}
+			if fallback == r.Name {
+				return fmt.Errorf("receiver (%s) cannot be its own fallback", r.Name)
+			}
+			if _, ok := seen[fallback]; ok {
+				return fmt.Errorf("fallback receiver (%s) of receiver (%s) is duplicated", fallback, r.Name)
+			}
+			seen[fallback] = struct{}{}
+		}
+	}
+
 	return nil
 }
 
@@ -1157,10 +1289,14 @@ func (r *PostableApiReceiver) Type() ReceiverType {
 
 type GettableGrafanaReceivers struct {
 	GrafanaManagedReceivers []*GettableGrafanaReceiver `yaml:"grafana_managed_receiver_configs,omitempty" json:"grafana_managed_receiver_configs,omitempty"`
+	FallbackReceivers       []string                   `yaml:"fallback_receivers,omitempty" json:"fallback_receivers,omitempty"`
 }
 
 type PostableGrafanaReceivers struct {
 	GrafanaManagedReceivers []*PostableGrafanaReceiver `yaml:"grafana_managed_receiver_configs,omitempty" json:"grafana_managed_receiver_configs,omitempty"`
+	// FallbackReceivers are the names of the contact points, in order, whose integrations
+	// are used when an integration of this contact point fails to send a notification.
+	FallbackReceivers []string `yaml:"fallback_receivers,omitempty" json:"fallback_receivers,omitempty"`
 }
 
 type EncryptFn func(ctx context.Context, payload []byte, scope secrets.EncryptionOptions) ([]byte, error)
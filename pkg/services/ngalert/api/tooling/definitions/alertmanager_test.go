@@ -340,6 +340,125 @@ func Test_ApiAlertingConfig_Marshaling(t *testing.T) {
 			},
 			err: true,
 		},
+		{
+			desc: "success graf with fallback receivers",
+			input: PostableApiAlertingConfig{
+				Config: Config{
+					Route: &Route{
+						Receiver: "graf",
+					},
+				},
+				Receivers: []*PostableApiReceiver{
+					{
+						Receiver: config.Receiver{
+							Name: "graf",
+						},
+						PostableGrafanaReceivers: PostableGrafanaReceivers{
+							GrafanaManagedReceivers: []*PostableGrafanaReceiver{{}},
+							FallbackReceivers:       []string{"backup"},
+						},
+					},
+					{
+						Receiver: config.Receiver{
+							Name: "backup",
+						},
+						PostableGrafanaReceivers: PostableGrafanaReceivers{
+							GrafanaManagedReceivers: []*PostableGrafanaReceiver{{}},
+						},
+					},
+				},
+			},
+		},
+		{
+			desc: "failure undefined fallback receiver",
+			input: PostableApiAlertingConfig{
+				Config: Config{
+					Route: &Route{
+						Receiver: "graf",
+					},
+				},
+				Receivers: []*PostableApiReceiver{
+					{
+						Receiver: config.Receiver{
+							Name: "graf",
+						},
+						PostableGrafanaReceivers: PostableGrafanaReceivers{
+							GrafanaManagedReceivers: []*PostableGrafanaReceiver{{}},
+							FallbackReceivers:       []string{"unmentioned"},
+						},
+					},
+					{
+						Receiver: config.Receiver{
+							Name: "backup",
+						},
+						PostableGrafanaReceivers: PostableGrafanaReceivers{
+							GrafanaManagedReceivers: []*PostableGrafanaReceiver{{}},
+						},
+					},
+				},
+			},
+			err: true,
+		},
+		{
+			desc: "failure receiver is its own fallback",
+			input: PostableApiAlertingConfig{
+				Config: Config{
+					Route: &Route{
+						Receiver: "graf",
+					},
+				},
+				Receivers: []*PostableApiReceiver{
+					{
+						Receiver: config.Receiver{
+							Name: "graf",
+						},
+						PostableGrafanaReceivers: PostableGrafanaReceivers{
+							GrafanaManagedReceivers: []*PostableGrafanaReceiver{{}},
+							FallbackReceivers:       []string{"graf"},
+						},
+					},
+					{
+						Receiver: config.Receiver{
+							Name: "backup",
+						},
+						PostableGrafanaReceivers: PostableGrafanaReceivers{
+							GrafanaManagedReceivers: []*PostableGrafanaReceiver{{}},
+						},
+					},
+				},
+			},
+			err: true,
+		},
+		{
+			desc: "failure duplicated fallback receiver",
+			input: PostableApiAlertingConfig{
+				Config: Config{
+					Route: &Route{
+						Receiver: "graf",
+					},
+				},
+				Receivers: []*PostableApiReceiver{
+					{
+						Receiver: config.Receiver{
+							Name: "graf",
+						},
+						PostableGrafanaReceivers: PostableGrafanaReceivers{
+							GrafanaManagedReceivers: []*PostableGrafanaReceiver{{}},
+							FallbackReceivers:       []string{"backup", "backup"},
+						},
+					},
+					{
+						Receiver: config.Receiver{
+							Name: "backup",
+						},
+						PostableGrafanaReceivers: PostableGrafanaReceivers{
+							GrafanaManagedReceivers: []*PostableGrafanaReceiver{{}},
+						},
+					},
+				},
+			},
+			err: true,
+		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
 			encoded, err := json.Marshal(tc.input)
@@ -122,6 +122,21 @@ import (
 //     Responses:
 //       202: Ack
 
+// swagger:route POST /api/ruler/grafana/api/v1/import/prometheus/{Namespace} ruler RoutePostImportPrometheusRules
+//
+// Converts Prometheus alerting rule groups into Grafana managed rule groups querying a Prometheus or Loki data source, and
+// creates or replaces them in the namespace
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       202: PrometheusRulesImportResponse
+//       400: ValidationError
+
 // swagger:parameters RoutePostNameRulesConfig RoutePostNameGrafanaRulesConfig
 type NamespaceConfig struct {
 	// in:path
@@ -130,6 +145,21 @@ type NamespaceConfig struct {
 	Body PostableRuleGroupConfig
 }
 
+// swagger:parameters RoutePostImportPrometheusRules
+type ImportPrometheusRulesParams struct {
+	// in:path
+	Namespace string
+	// UID of the Prometheus or Loki data source the imported rules query.
+	// in:query
+	// required:true
+	DatasourceUID string `json:"datasource_uid"`
+	// If true, the converted rule groups are returned without being saved.
+	// in:query
+	DryRun bool `json:"dry_run"`
+	// in:body
+	Body PrometheusRuleFile
+}
+
 // swagger:parameters RouteGetNamespaceRulesConfig RouteDeleteNamespaceRulesConfig RouteGetNamespaceGrafanaRulesConfig RouteDeleteNamespaceGrafanaRulesConfig
 type PathNamespaceConfig struct {
 	// in: path
@@ -152,6 +182,29 @@ type PathGetRulesParams struct {
 	PanelID int64
 }
 
+// PrometheusRuleFile is the content of a Prometheus rule file.
+// swagger:model
+type PrometheusRuleFile struct {
+	Groups []PrometheusRuleGroup `yaml:"groups" json:"groups"`
+}
+
+// swagger:model
+type PrometheusRuleGroup struct {
+	Name     string         `yaml:"name" json:"name"`
+	Interval model.Duration `yaml:"interval,omitempty" json:"interval,omitempty"`
+	Rules    []ApiRuleNode  `yaml:"rules" json:"rules"`
+}
+
+// swagger:model
+type PrometheusRulesImportResponse struct {
+	// DryRun is true if the rule groups were not saved.
+	DryRun bool `json:"dryRun"`
+	// Groups are the Grafana managed rule groups the Prometheus rule groups were converted to.
+	Groups []PostableRuleGroupConfig `json:"groups"`
+	// Warnings lists the rules that could not be converted as-is or were skipped.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
 // swagger:model
 type RuleGroupConfigResponse struct {
 	GettableRuleGroupConfig
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
 )
 
 // swagger:route GET /api/prometheus/grafana/api/v1/rules prometheus RouteGetGrafanaRuleStatuses
@@ -34,6 +35,25 @@ import (
 //     Responses:
 //       200: AlertResponse
 
+// swagger:route POST /api/prometheus/grafana/api/v1/rules/{RuleUID}/alerts/acknowledge prometheus RoutePostGrafanaAlertAcknowledge
+//
+// acknowledges a firing alert of the rule, which suppresses its notifications until the acknowledgement expires or the alert resolves
+//
+//     Responses:
+//       200: Alert
+//       400: ValidationError
+//       404: NotFound
+//       409: ValidationError
+
+// swagger:route POST /api/prometheus/grafana/api/v1/rules/{RuleUID}/alerts/unacknowledge prometheus RoutePostGrafanaAlertUnacknowledge
+//
+// removes the acknowledgement of an alert of the rule
+//
+//     Responses:
+//       200: Alert
+//       400: ValidationError
+//       404: NotFound
+
 // swagger:model
 type RuleResponse struct {
 	// in: body
@@ -137,6 +157,17 @@ type Alert struct {
 	ActiveAt *time.Time `json:"activeAt"`
 	// required: true
 	Value string `json:"value"`
+	// required: false
+	Acknowledgement *AlertAcknowledgement `json:"acknowledgement,omitempty"`
+}
+
+// AlertAcknowledgement is the acknowledgement of a firing alert by a user.
+// swagger:model
+type AlertAcknowledgement struct {
+	UserID  int64     `json:"userId"`
+	Comment string    `json:"comment"`
+	At      time.Time `json:"at"`
+	Until   time.Time `json:"until"`
 }
 
 // override the labels type with a map for generation.
@@ -170,3 +201,36 @@ type GetGrafanaRuleStatusesParams struct {
 	// required: false
 	PanelID int64
 }
+
+// swagger:parameters RoutePostGrafanaAlertAcknowledge
+type AlertAcknowledgeParams struct {
+	// in:path
+	RuleUID string
+	// in:body
+	Body PostableAlertAcknowledgement
+}
+
+// PostableAlertAcknowledgement identifies the alert to acknowledge by its labels, without the Grafana specific ones.
+type PostableAlertAcknowledgement struct {
+	// required: true
+	Labels map[string]string `json:"labels"`
+	// required: false
+	Comment string `json:"comment"`
+	// How long the notifications of the alert are suppressed for, e.g. "2h".
+	// required: true
+	Duration model.Duration `json:"duration"`
+}
+
+// swagger:parameters RoutePostGrafanaAlertUnacknowledge
+type AlertUnacknowledgeParams struct {
+	// in:path
+	RuleUID string
+	// in:body
+	Body PostableAlertUnacknowledgement
+}
+
+// PostableAlertUnacknowledgement identifies the alert to unacknowledge by its labels, without the Grafana specific ones.
+type PostableAlertUnacknowledgement struct {
+	// required: true
+	Labels map[string]string `json:"labels"`
+}
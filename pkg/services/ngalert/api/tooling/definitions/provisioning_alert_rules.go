@@ -0,0 +1,212 @@
+package definitions
+
+import (
+	"time"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// swagger:route GET /api/provisioning/alert-rules/{UID} provisioning RouteGetAlertRule
+//
+// Get a specific alert rule by UID.
+//
+//     Responses:
+//       200: ProvisionedAlertRule
+//       404: NotFound
+
+// swagger:route POST /api/provisioning/alert-rules provisioning RoutePostAlertRule
+//
+// Create a new alert rule.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       201: ProvisionedAlertRule
+//       400: ValidationError
+
+// swagger:route PUT /api/provisioning/alert-rules/{UID} provisioning RoutePutAlertRule
+//
+// Update an existing alert rule.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       200: ProvisionedAlertRule
+//       400: ValidationError
+//       404: NotFound
+
+// swagger:route DELETE /api/provisioning/alert-rules/{UID} provisioning RouteDeleteAlertRule
+//
+// Delete a specific alert rule by UID.
+//
+//     Responses:
+//       204: Ack
+//       400: ValidationError
+
+// swagger:route GET /api/provisioning/folder/{FolderUID}/rule-groups/{Group} provisioning RouteGetAlertRuleGroup
+//
+// Get a rule group.
+//
+//     Responses:
+//       200: AlertRuleGroup
+//       404: NotFound
+
+// swagger:route PUT /api/provisioning/folder/{FolderUID}/rule-groups/{Group} provisioning RoutePutAlertRuleGroup
+//
+// Create or replace the rules of a rule group.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       200: AlertRuleGroup
+//       400: ValidationError
+
+// swagger:parameters RouteGetAlertRule RoutePutAlertRule RouteDeleteAlertRule
+type AlertRuleUIDReference struct {
+	// Alert rule UID
+	// in:path
+	UID string
+}
+
+// swagger:parameters RoutePostAlertRule RoutePutAlertRule
+type AlertRulePayload struct {
+	// in:body
+	Body ProvisionedAlertRule
+}
+
+// swagger:parameters RouteGetAlertRuleGroup RoutePutAlertRuleGroup
+type FolderUIDPathParam struct {
+	// in:path
+	FolderUID string `json:"FolderUID"`
+}
+
+// swagger:parameters RouteGetAlertRuleGroup RoutePutAlertRuleGroup
+type RuleGroupPathParam struct {
+	// in:path
+	Group string `json:"Group"`
+}
+
+// swagger:parameters RoutePutAlertRuleGroup
+type AlertRuleGroupPayload struct {
+	// in:body
+	Body AlertRuleGroup
+}
+
+// swagger:model
+type ProvisionedAlertRule struct {
+	ID    int64  `json:"id"`
+	UID   string `json:"uid"`
+	OrgID int64  `json:"orgID"`
+	// required: true
+	FolderUID string `json:"folderUID"`
+	// required: true
+	RuleGroup string `json:"ruleGroup"`
+	// required: true
+	Title string `json:"title"`
+	// required: true
+	Condition string `json:"condition"`
+	// required: true
+	Data []models.AlertQuery `json:"data"`
+	// readonly: true
+	Updated time.Time `json:"updated,omitempty"`
+	// required: true
+	NoDataState models.NoDataState `json:"noDataState"`
+	// required: true
+	ExecErrState models.ExecutionErrorState `json:"execErrState"`
+	// required: true
+	For          time.Duration     `json:"for"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	DashboardUID *string           `json:"dashboardUid,omitempty"`
+	PanelID      *int64            `json:"panelId,omitempty"`
+	// readonly: true
+	Provenance models.Provenance `json:"provenance,omitempty"`
+}
+
+// UpstreamModel converts the alert rule to the model used by the rule store.
+func (a *ProvisionedAlertRule) UpstreamModel() models.AlertRule {
+	return models.AlertRule{
+		ID:           a.ID,
+		UID:          a.UID,
+		OrgID:        a.OrgID,
+		NamespaceUID: a.FolderUID,
+		RuleGroup:    a.RuleGroup,
+		Title:        a.Title,
+		Condition:    a.Condition,
+		Data:         a.Data,
+		Updated:      a.Updated,
+		NoDataState:  a.NoDataState,
+		ExecErrState: a.ExecErrState,
+		For:          a.For,
+		Annotations:  a.Annotations,
+		Labels:       a.Labels,
+		DashboardUID: a.DashboardUID,
+		PanelID:      a.PanelID,
+	}
+}
+
+func NewProvisionedAlertRule(rule models.AlertRule, provenance models.Provenance) ProvisionedAlertRule {
+	return ProvisionedAlertRule{
+		ID:           rule.ID,
+		UID:          rule.UID,
+		OrgID:        rule.OrgID,
+		FolderUID:    rule.NamespaceUID,
+		RuleGroup:    rule.RuleGroup,
+		Title:        rule.Title,
+		Condition:    rule.Condition,
+		Data:         rule.Data,
+		Updated:      rule.Updated,
+		NoDataState:  rule.NoDataState,
+		ExecErrState: rule.ExecErrState,
+		For:          rule.For,
+		Annotations:  rule.Annotations,
+		Labels:       rule.Labels,
+		DashboardUID: rule.DashboardUID,
+		PanelID:      rule.PanelID,
+		Provenance:   provenance,
+	}
+}
+
+// swagger:model
+type AlertRuleGroup struct {
+	Title     string `json:"title"`
+	FolderUID string `json:"folderUid"`
+	// Interval is the evaluation interval of all rules in the group, in seconds.
+	Interval int64                  `json:"interval"`
+	Rules    []ProvisionedAlertRule `json:"rules"`
+}
+
+// UpstreamModel converts the rule group to the model used by the rule store.
+func (a *AlertRuleGroup) UpstreamModel() models.AlertRuleGroup {
+	rules := make([]models.AlertRule, 0, len(a.Rules))
+	for i := range a.Rules {
+		rules = append(rules, a.Rules[i].UpstreamModel())
+	}
+	return models.AlertRuleGroup{
+		Title:     a.Title,
+		FolderUID: a.FolderUID,
+		Interval:  a.Interval,
+		Rules:     rules,
+	}
+}
+
+// NewAlertRuleGroup converts the rule group of the rule store, with the provenances of its rules by UID.
+func NewAlertRuleGroup(group models.AlertRuleGroup, provenances map[string]models.Provenance) AlertRuleGroup {
+	rules := make([]ProvisionedAlertRule, 0, len(group.Rules))
+	for _, rule := range group.Rules {
+		provenance, ok := provenances[rule.UID]
+		if !ok {
+			provenance = models.ProvenanceNone
+		}
+		rules = append(rules, NewProvisionedAlertRule(rule, provenance))
+	}
+	return AlertRuleGroup{
+		Title:     group.Title,
+		FolderUID: group.FolderUID,
+		Interval:  group.Interval,
+		Rules:     rules,
+	}
+}
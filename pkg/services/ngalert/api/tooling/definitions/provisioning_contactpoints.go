@@ -64,8 +64,8 @@ type ContactPointPayload struct {
 // EmbeddedContactPoint is the contact point type that is used
 // by grafanas embedded alertmanager implementation.
 type EmbeddedContactPoint struct {
-	// UID is the unique identifier of the contact point. This will be
-	// automatically set be the Grafana.
+	// UID is the unique identifier of the contact point. The UID can be
+	// set by the user, otherwise it will be generated by Grafana.
 	UID string `json:"uid"`
 	// Name is used as grouping key in the UI. Contact points with the
 	// same name will be grouped in the UI.
@@ -111,6 +111,8 @@ func (e *EmbeddedContactPoint) SecretKeys() ([]string, error) {
 		return []string{}, nil
 	case "googlechat":
 		return []string{}, nil
+	case "http":
+		return []string{"password"}, nil
 	case "kafka":
 		return []string{}, nil
 	case "line":
@@ -133,6 +135,8 @@ func (e *EmbeddedContactPoint) SecretKeys() ([]string, error) {
 		return []string{"api_secret"}, nil
 	case "victorops":
 		return []string{}, nil
+	case "webex":
+		return []string{"bot_token"}, nil
 	case "webhook":
 		return []string{}, nil
 	case "wecom":
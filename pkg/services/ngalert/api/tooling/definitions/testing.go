@@ -6,8 +6,10 @@ import (
 	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
 
 	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/promql"
 
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
@@ -52,6 +54,20 @@ import (
 //     Responses:
 //       200: EvalQueriesResponse
 
+// swagger:route Post /api/v1/rule/backtest testing RouteBacktestConfig
+//
+// Test rule against historical data
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: BacktestResult
+//       400: ValidationError
+
 // swagger:parameters RouteTestReceiverConfig
 type TestReceiverRequest struct {
 	// in:body
@@ -84,6 +100,37 @@ type EvalQueriesPayload struct {
 	Now  time.Time           `json:"now"`
 }
 
+// swagger:parameters RouteBacktestConfig
+type BacktestConfigRequest struct {
+	// in:body
+	Body BacktestConfig
+}
+
+// swagger:model
+type BacktestConfig struct {
+	// From is the time of the first evaluation of the rule.
+	From time.Time `json:"from"`
+	// To is the time after which the rule is not evaluated anymore.
+	To time.Time `json:"to"`
+	// Interval is the interval between two evaluations of the rule.
+	Interval model.Duration `json:"interval"`
+
+	Condition string              `json:"condition"`
+	Data      []models.AlertQuery `json:"data"`
+
+	Title        string              `json:"title"`
+	Labels       map[string]string   `json:"labels,omitempty"`
+	For          model.Duration      `json:"for,omitempty"`
+	NoDataState  NoDataState         `json:"no_data_state"`
+	ExecErrState ExecutionErrorState `json:"exec_err_state"`
+}
+
+// BacktestResult is a data frame with the time of each evaluation of the rule in the first field, and the state of
+// an alert instance after each evaluation in each of the other fields. The fields are labeled with the labels of the
+// alert instances.
+// swagger:model
+type BacktestResult = data.Frame
+
 func (p *TestRulePayload) UnmarshalJSON(b []byte) error {
 	type plain TestRulePayload
 	if err := json.Unmarshal(b, (*plain)(p)); err != nil {
@@ -0,0 +1,125 @@
+// Package backtesting evaluates alert rules against historical data.
+package backtesting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/expr"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+)
+
+// MaxEvaluations is the maximum number of evaluations of a rule in a single test.
+const MaxEvaluations = 1000
+
+var (
+	ErrInvalidInterval  = errors.New("the evaluation interval must be positive")
+	ErrInvalidTimeRange = errors.New("the end of the time range must be after its start")
+	ErrTooManyEvals     = fmt.Errorf("the time range must not span more than %d evaluations", MaxEvaluations)
+)
+
+// Engine evaluates alert rules at regular intervals over a past time range.
+type Engine struct {
+	evaluator         eval.Evaluator
+	expressionService *expr.Service
+	log               log.Logger
+}
+
+func NewEngine(evaluator eval.Evaluator, expressionService *expr.Service) *Engine {
+	return &Engine{
+		evaluator:         evaluator,
+		expressionService: expressionService,
+		log:               log.New("ngalert.backtesting"),
+	}
+}
+
+// Test evaluates the rule at every interval of the rule from the start of the time range until its end, and replays
+// the results through a state manager of its own. It returns the state of each alert instance after each evaluation,
+// as a data frame with the time of the evaluations in its first field and the states of an alert instance, labeled
+// with the labels of the instance, in each of the other fields. The state of an instance is null at the times the
+// instance did not exist.
+func (e *Engine) Test(ctx context.Context, rule *models.AlertRule, from, to time.Time) (*data.Frame, error) {
+	interval := time.Duration(rule.IntervalSeconds) * time.Second
+	if interval <= 0 {
+		return nil, ErrInvalidInterval
+	}
+	if !to.After(from) {
+		return nil, ErrInvalidTimeRange
+	}
+	evaluations := int(to.Sub(from)/interval) + 1
+	if evaluations > MaxEvaluations {
+		return nil, ErrTooManyEvals
+	}
+
+	clk := clock.NewMock()
+	manager := state.NewEphemeralManager(e.log, clk)
+	condition := &models.Condition{
+		Condition: rule.Condition,
+		OrgID:     rule.OrgID,
+		Data:      rule.Data,
+	}
+
+	times := make([]time.Time, 0, evaluations)
+	instances := make(map[string]*data.Field)
+	for i := 0; i < evaluations; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		now := from.Add(time.Duration(i) * interval)
+		clk.Set(now)
+		results, err := e.evaluator.ConditionEval(condition, now, e.expressionService)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate the rule at %s: %w", now.Format(time.RFC3339), err)
+		}
+		manager.ProcessEvalResults(ctx, rule, results)
+
+		times = append(times, now)
+		for _, s := range manager.GetStatesForRuleUID(rule.OrgID, rule.UID) {
+			field, ok := instances[s.CacheId]
+			if !ok {
+				field = data.NewField("State", instanceLabels(s.Labels), make([]*string, i))
+				instances[s.CacheId] = field
+			}
+			value := state.InstanceStateAndReason{State: s.State, Reason: s.StateReason}.String()
+			field.Append(&value)
+		}
+		// The instances that have been removed as stale have no state anymore.
+		for _, field := range instances {
+			if field.Len() < len(times) {
+				field.Append(nil)
+			}
+		}
+	}
+
+	fields := make([]*data.Field, 0, len(instances)+1)
+	for _, field := range instances {
+		fields = append(fields, field)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Labels.String() < fields[j].Labels.String()
+	})
+	fields = append([]*data.Field{data.NewField("Time", nil, times)}, fields...)
+	return data.NewFrame("backtesting", fields...), nil
+}
+
+// instanceLabels returns the labels of an alert instance without the private labels added by Grafana.
+func instanceLabels(labels data.Labels) data.Labels {
+	result := make(data.Labels, len(labels))
+	for k, v := range labels {
+		if !strings.HasPrefix(k, "__") && !strings.HasSuffix(k, "__") {
+			result[k] = v
+		}
+	}
+	return result
+}
@@ -0,0 +1,90 @@
+package backtesting
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/expr"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+func TestEngine(t *testing.T) {
+	from := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	rule := &models.AlertRule{
+		OrgID:           1,
+		UID:             "backtest",
+		Title:           "High CPU",
+		Condition:       "A",
+		IntervalSeconds: 60,
+		For:             2 * time.Minute,
+		Labels:          map[string]string{"team": "ops"},
+		NoDataState:     models.NoData,
+		ExecErrState:    models.AlertingErrState,
+	}
+
+	// server-1 fires during the first five minutes, server-2 only shows up at the third minute.
+	evaluator := &eval.FakeEvaluator{}
+	evaluator.On("ConditionEval", mock.Anything, mock.Anything, mock.Anything).Return(func(_ *models.Condition, now time.Time, _ *expr.Service) eval.Results {
+		minute := int(now.Sub(from) / time.Minute)
+		server1 := eval.Result{Instance: data.Labels{"instance": "server-1"}, State: eval.Normal, EvaluatedAt: now}
+		if minute < 5 {
+			server1.State = eval.Alerting
+		}
+		results := eval.Results{server1}
+		if minute == 2 {
+			results = append(results, eval.Result{Instance: data.Labels{"instance": "server-2"}, State: eval.Alerting, EvaluatedAt: now})
+		}
+		return results
+	}, nil)
+
+	engine := NewEngine(evaluator, nil)
+
+	t.Run("returns the state timeline of each alert instance", func(t *testing.T) {
+		frame, err := engine.Test(context.Background(), rule, from, from.Add(6*time.Minute))
+		require.NoError(t, err)
+		require.Len(t, frame.Fields, 3)
+
+		times := frame.Fields[0]
+		require.Equal(t, 7, times.Len())
+		require.Equal(t, from, times.At(0))
+		require.Equal(t, from.Add(6*time.Minute), times.At(6))
+
+		states := func(field *data.Field) []string {
+			var result []string
+			for i := 0; i < field.Len(); i++ {
+				s := field.At(i).(*string)
+				if s == nil {
+					result = append(result, "")
+					continue
+				}
+				result = append(result, *s)
+			}
+			return result
+		}
+
+		server1, server2 := frame.Fields[1], frame.Fields[2]
+		require.Equal(t, data.Labels{"alertname": "High CPU", "team": "ops", "instance": "server-1"}, server1.Labels)
+		require.Equal(t, []string{"Pending", "Pending", "Alerting", "Alerting", "Alerting", "Normal", "Normal"}, states(server1))
+		require.Equal(t, data.Labels{"alertname": "High CPU", "team": "ops", "instance": "server-2"}, server2.Labels)
+		require.Equal(t, []string{"", "", "Pending", "Pending", "Pending", "", ""}, states(server2))
+	})
+
+	t.Run("fails for invalid time ranges and intervals", func(t *testing.T) {
+		_, err := engine.Test(context.Background(), rule, from, from)
+		require.ErrorIs(t, err, ErrInvalidTimeRange)
+
+		_, err = engine.Test(context.Background(), rule, from, from.Add(MaxEvaluations*time.Minute))
+		require.ErrorIs(t, err, ErrTooManyEvals)
+
+		noInterval := *rule
+		noInterval.IntervalSeconds = 0
+		_, err = engine.Test(context.Background(), &noInterval, from, from.Add(time.Hour))
+		require.ErrorIs(t, err, ErrInvalidInterval)
+	})
+}
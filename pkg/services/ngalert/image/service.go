@@ -9,6 +9,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/grafana/grafana/pkg/components/imguploader"
+	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/services/ngalert/store"
@@ -38,14 +39,18 @@ const (
 
 // ScreenshotImageService takes screenshots of the panel for an alert rule and
 // saves the image in the store. The image contains a unique token that can be
-// passed as an annotation or label to the Alertmanager.
+// passed as an annotation or label to the Alertmanager. When screenshots are
+// uploaded, the image also contains the URL of the uploaded screenshot so
+// notifications can link to it instead of attaching the file.
 type ScreenshotImageService struct {
+	logger      log.Logger
 	screenshots screenshot.ScreenshotService
 	store       store.ImageStore
 }
 
 func NewScreenshotImageService(screenshots screenshot.ScreenshotService, store store.ImageStore) ImageService {
 	return &ScreenshotImageService{
+		logger:      log.New("ngalert.image"),
 		screenshots: screenshots,
 		store:       store,
 	}
@@ -74,10 +79,7 @@ func NewScreenshotImageServiceFromCfg(cfg *setting.Cfg, metrics prometheus.Regis
 	s = screenshot.NewCachableScreenshotService(metrics, screenshotCacheTTL, s)
 	s = screenshot.NewObservableScreenshotService(metrics, s)
 
-	return &ScreenshotImageService{
-		store:       db,
-		screenshots: s,
-	}, nil
+	return NewScreenshotImageService(s, db), nil
 }
 
 // NewImage returns a screenshot of the panel for the alert rule. It returns
@@ -96,10 +98,13 @@ func (s *ScreenshotImageService) NewImage(ctx context.Context, r *models.AlertRu
 		DashboardUID: *r.DashboardUID,
 		PanelID:      *r.PanelID,
 	})
-	// TODO: Check for screenshot upload failures. These images should still be
-	// stored because we have a local disk path that could be useful.
 	if err != nil {
-		return nil, fmt.Errorf("failed to take screenshot: %w", err)
+		// A screenshot that could not be uploaded is still stored, as the
+		// notifiers that support it can attach the file on disk instead.
+		if screenshot == nil || screenshot.Path == "" {
+			return nil, fmt.Errorf("failed to take screenshot: %w", err)
+		}
+		s.logger.Warn("failed to upload screenshot, using the screenshot on disk", "rule_uid", r.UID, "path", screenshot.Path, "error", err)
 	}
 
 	v := models.Image{
@@ -0,0 +1,78 @@
+package image
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/screenshot"
+)
+
+type fakeImageStore struct {
+	images []*models.Image
+}
+
+func (s *fakeImageStore) GetImage(_ context.Context, _ string) (*models.Image, error) {
+	return nil, models.ErrImageNotFound
+}
+
+func (s *fakeImageStore) GetImages(_ context.Context, _ []string) ([]models.Image, error) {
+	return nil, models.ErrImageNotFound
+}
+
+func (s *fakeImageStore) SaveImage(_ context.Context, img *models.Image) error {
+	s.images = append(s.images, img)
+	return nil
+}
+
+func TestScreenshotImageService(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	dashboardUID := "foo"
+	panelID := int64(1)
+	rule := &models.AlertRule{UID: "rule", DashboardUID: &dashboardUID, PanelID: &panelID}
+	opts := screenshot.ScreenshotOptions{Timeout: screenshotTimeout, DashboardUID: dashboardUID, PanelID: panelID}
+
+	ctx := context.Background()
+
+	t.Run("image has the URL of the uploaded screenshot", func(t *testing.T) {
+		m := screenshot.NewMockScreenshotService(c)
+		store := &fakeImageStore{}
+		s := NewScreenshotImageService(m, store)
+
+		m.EXPECT().Take(ctx, opts).Return(&screenshot.Screenshot{Path: "foo.png", URL: "https://example.com/foo.png"}, nil)
+		image, err := s.NewImage(ctx, rule)
+		require.NoError(t, err)
+		assert.Equal(t, &models.Image{Path: "foo.png", URL: "https://example.com/foo.png"}, image)
+		assert.Equal(t, []*models.Image{image}, store.images)
+	})
+
+	t.Run("image is saved without URL if the screenshot failed to upload", func(t *testing.T) {
+		m := screenshot.NewMockScreenshotService(c)
+		store := &fakeImageStore{}
+		s := NewScreenshotImageService(m, store)
+
+		m.EXPECT().Take(ctx, opts).Return(&screenshot.Screenshot{Path: "foo.png"}, errors.New("failed to upload screenshot"))
+		image, err := s.NewImage(ctx, rule)
+		require.NoError(t, err)
+		assert.Equal(t, &models.Image{Path: "foo.png"}, image)
+		assert.Equal(t, []*models.Image{image}, store.images)
+	})
+
+	t.Run("error is returned if the screenshot could not be taken", func(t *testing.T) {
+		m := screenshot.NewMockScreenshotService(c)
+		store := &fakeImageStore{}
+		s := NewScreenshotImageService(m, store)
+
+		m.EXPECT().Take(ctx, opts).Return(nil, errors.New("renderer unavailable"))
+		_, err := s.NewImage(ctx, rule)
+		assert.EqualError(t, err, "failed to take screenshot: renderer unavailable")
+		assert.Empty(t, store.images)
+	})
+}
@@ -70,6 +70,7 @@ type API struct {
 type Alertmanager struct {
 	Registerer prometheus.Registerer
 	*metrics.Alerts
+	NotificationFailovers *prometheus.CounterVec
 }
 
 type State struct {
@@ -109,6 +110,12 @@ func NewAlertmanagerMetrics(r prometheus.Registerer) *Alertmanager {
 	return &Alertmanager{
 		Registerer: r,
 		Alerts:     metrics.NewAlerts("grafana", prometheus.WrapRegistererWithPrefix(fmt.Sprintf("%s_%s_", Namespace, Subsystem), r)),
+		NotificationFailovers: promauto.With(r).NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "notification_failovers_total",
+			Help:      "The total number of notifications sent by a fallback integration after the primary integration failed.",
+		}, []string{"integration", "fallback_integration"}),
 	}
 }
 
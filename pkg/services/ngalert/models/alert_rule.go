@@ -85,6 +85,10 @@ const (
 	// This isn't a hard-coded secret token, hence the nolint.
 	//nolint:gosec
 	ScreenshotTokenAnnotation = "__alertScreenshotToken__"
+
+	// AcknowledgedUntilAnnotation is the time, in RFC3339 format, until which the notifications of an acknowledged
+	// alert instance are suppressed.
+	AcknowledgedUntilAnnotation = "__alertAcknowledgedUntil__"
 )
 
 var (
@@ -172,6 +176,14 @@ func (alertRule *AlertRule) Diff(rule *AlertRule, ignore ...string) cmputil.Diff
 	return reporter.Diffs
 }
 
+// AlertRuleGroup is a group of alert rules in a folder, which share the same evaluation interval.
+type AlertRuleGroup struct {
+	Title     string
+	FolderUID string
+	Interval  int64
+	Rules     []AlertRule
+}
+
 // AlertRuleKey is the alert definition identifier
 type AlertRuleKey struct {
 	OrgID int64
@@ -10,21 +10,49 @@ type AlertConfiguration struct {
 	ConfigurationHash         string
 	ConfigurationVersion      string
 	CreatedAt                 int64 `xorm:"created"`
+	CreatedBy                 int64 `xorm:"created_by"`
 	Default                   bool
 	OrgID                     int64 `xorm:"org_id"`
 }
 
+// AlertConfigurationHistoryEntry describes a stored version of the Alertmanager configuration
+// without the configuration itself.
+type AlertConfigurationHistoryEntry struct {
+	ID                int64  `xorm:"id"`
+	ConfigurationHash string `xorm:"configuration_hash"`
+	CreatedAt         int64  `xorm:"created_at"`
+	CreatedBy         int64  `xorm:"created_by"`
+	CreatedByLogin    string `xorm:"created_by_login"`
+	Default           bool   `xorm:"default"`
+}
+
 // GetLatestAlertmanagerConfigurationQuery is the query to get the latest alertmanager configuration.
 type GetLatestAlertmanagerConfigurationQuery struct {
 	OrgID  int64
 	Result *AlertConfiguration
 }
 
+// GetAlertmanagerConfigurationQuery is the query to get a specific version of the alertmanager configuration.
+type GetAlertmanagerConfigurationQuery struct {
+	OrgID  int64
+	ID     int64
+	Result *AlertConfiguration
+}
+
+// GetAlertmanagerConfigurationHistoryQuery is the query to list the stored versions of the alertmanager configuration,
+// newest first. A Limit of zero returns every version.
+type GetAlertmanagerConfigurationHistoryQuery struct {
+	OrgID  int64
+	Limit  int
+	Result []*AlertConfigurationHistoryEntry
+}
+
 // SaveAlertmanagerConfigurationCmd is the command to save an alertmanager configuration.
 type SaveAlertmanagerConfigurationCmd struct {
 	AlertmanagerConfiguration string
 	FetchedConfigurationHash  string
 	ConfigurationVersion      string
+	CreatedBy                 int64
 	Default                   bool
 	OrgID                     int64
 }
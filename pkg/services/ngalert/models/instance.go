@@ -16,6 +16,38 @@ type AlertInstance struct {
 	CurrentStateSince time.Time
 	CurrentStateEnd   time.Time
 	LastEvalTime      time.Time
+
+	AcknowledgedBy      int64
+	AcknowledgedComment string
+	AcknowledgedAt      time.Time
+	AcknowledgedUntil   time.Time
+}
+
+// Acknowledgement returns the acknowledgement of the alert instance, if any.
+func (i *AlertInstance) Acknowledgement() *AlertInstanceAcknowledgement {
+	if i.AcknowledgedUntil.Unix() <= 0 {
+		return nil
+	}
+	return &AlertInstanceAcknowledgement{
+		UserID:  i.AcknowledgedBy,
+		Comment: i.AcknowledgedComment,
+		At:      i.AcknowledgedAt,
+		Until:   i.AcknowledgedUntil,
+	}
+}
+
+// AlertInstanceAcknowledgement is the acknowledgement of a firing alert instance by a user.
+// It suppresses the notifications of the instance until it expires or the instance resolves.
+type AlertInstanceAcknowledgement struct {
+	UserID  int64
+	Comment string
+	At      time.Time
+	Until   time.Time
+}
+
+// IsActive returns true if the acknowledgement has not expired at the given time.
+func (a *AlertInstanceAcknowledgement) IsActive(now time.Time) bool {
+	return a != nil && now.Before(a.Until)
 }
 
 // InstanceStateType is an enum for instance states.
@@ -54,6 +86,7 @@ type SaveAlertInstanceCommand struct {
 	LastEvalTime      time.Time
 	CurrentStateSince time.Time
 	CurrentStateEnd   time.Time
+	Acknowledgement   *AlertInstanceAcknowledgement
 }
 
 // GetAlertInstanceQuery is the query for retrieving/deleting an alert definition by ID.
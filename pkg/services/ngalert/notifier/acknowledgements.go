@@ -0,0 +1,59 @@
+package notifier
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// acknowledgementStage filters out the firing alerts whose instance has been acknowledged by a user, until the
+// acknowledgement expires. Resolved alerts are always notified, as resolving clears the acknowledgement.
+type acknowledgementStage struct {
+	now func() time.Time
+}
+
+func newAcknowledgementStage() *acknowledgementStage {
+	return &acknowledgementStage{now: time.Now}
+}
+
+// Exec implements the notify.Stage interface.
+func (s *acknowledgementStage) Exec(ctx context.Context, l log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	now := s.now()
+	filtered := make([]*types.Alert, 0, len(alerts))
+	for _, a := range alerts {
+		value, ok := a.Annotations[ngmodels.AcknowledgedUntilAnnotation]
+		if !ok {
+			filtered = append(filtered, a)
+			continue
+		}
+
+		until, err := time.Parse(time.RFC3339, string(value))
+		if err != nil {
+			level.Warn(l).Log("msg", "Ignoring invalid acknowledgement annotation", "alert", a.Name(), "value", value, "err", err)
+		} else if !a.ResolvedAt(now) && now.Before(until) {
+			level.Debug(l).Log("msg", "Alert is acknowledged, not notifying", "alert", a.Name(), "until", until)
+			continue
+		}
+
+		// The annotation is internal, so it must not show up in the notifications.
+		filtered = append(filtered, withoutAcknowledgementAnnotation(a))
+	}
+	return ctx, filtered, nil
+}
+
+func withoutAcknowledgementAnnotation(a *types.Alert) *types.Alert {
+	c := *a
+	c.Annotations = make(model.LabelSet, len(a.Annotations)-1)
+	for k, v := range a.Annotations {
+		if k != ngmodels.AcknowledgedUntilAnnotation {
+			c.Annotations[k] = v
+		}
+	}
+	return &c
+}
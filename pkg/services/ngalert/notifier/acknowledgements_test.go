@@ -0,0 +1,54 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+func TestAcknowledgementStage(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	stage := &acknowledgementStage{now: func() time.Time { return now }}
+
+	newAlert := func(name string, ackUntil string, endsAt time.Time) *types.Alert {
+		a := &types.Alert{Alert: model.Alert{
+			Labels:      model.LabelSet{"alertname": model.LabelValue(name)},
+			Annotations: model.LabelSet{"summary": "test"},
+			EndsAt:      endsAt,
+		}}
+		if ackUntil != "" {
+			a.Annotations[ngmodels.AcknowledgedUntilAnnotation] = model.LabelValue(ackUntil)
+		}
+		return a
+	}
+
+	firing := now.Add(time.Hour)
+	alerts := []*types.Alert{
+		newAlert("not-acknowledged", "", firing),
+		newAlert("acknowledged", now.Add(time.Minute).Format(time.RFC3339), firing),
+		newAlert("expired", now.Add(-time.Minute).Format(time.RFC3339), firing),
+		newAlert("resolved", now.Add(time.Minute).Format(time.RFC3339), now.Add(-time.Second)),
+		newAlert("invalid", "tomorrow", firing),
+	}
+
+	_, sent, err := stage.Exec(context.Background(), log.NewNopLogger(), alerts...)
+	require.NoError(t, err)
+
+	var names []string
+	for _, a := range sent {
+		names = append(names, a.Name())
+		require.NotContains(t, a.Annotations, model.LabelName(ngmodels.AcknowledgedUntilAnnotation))
+		require.Equal(t, model.LabelValue("test"), a.Annotations["summary"])
+	}
+	require.Equal(t, []string{"not-acknowledged", "expired", "resolved", "invalid"}, names)
+
+	// The alerts held by the Alertmanager are left untouched.
+	require.Contains(t, alerts[2].Annotations, model.LabelName(ngmodels.AcknowledgedUntilAnnotation))
+}
@@ -276,8 +276,8 @@ func (am *Alertmanager) SaveAndApplyDefaultConfig(ctx context.Context) error {
 }
 
 // SaveAndApplyConfig saves the configuration the database and applies the configuration to the Alertmanager.
-// It rollbacks the save if we fail to apply the configuration.
-func (am *Alertmanager) SaveAndApplyConfig(ctx context.Context, cfg *apimodels.PostableUserConfig) error {
+// It rollbacks the save if we fail to apply the configuration. createdBy is the ID of the user saving the configuration.
+func (am *Alertmanager) SaveAndApplyConfig(ctx context.Context, cfg *apimodels.PostableUserConfig, createdBy int64) error {
 	rawConfig, err := json.Marshal(&cfg)
 	if err != nil {
 		return fmt.Errorf("failed to serialize to the Alertmanager configuration: %w", err)
@@ -289,6 +289,7 @@ func (am *Alertmanager) SaveAndApplyConfig(ctx context.Context, cfg *apimodels.P
 	cmd := &ngmodels.SaveAlertmanagerConfigurationCmd{
 		AlertmanagerConfiguration: string(rawConfig),
 		ConfigurationVersion:      fmt.Sprintf("v%d", ngmodels.AlertConfigurationVersion),
+		CreatedBy:                 createdBy,
 		OrgID:                     am.orgID,
 	}
 
@@ -421,9 +422,11 @@ func (am *Alertmanager) applyConfig(cfg *apimodels.PostableUserConfig, rawConfig
 	inhibitionStage := notify.NewMuteStage(am.inhibitor)
 	timeMuteStage := notify.NewTimeMuteStage(am.muteTimes)
 	silencingStage := notify.NewMuteStage(am.silencer)
+	acknowledgementStage := newAcknowledgementStage()
+	fallbacksMap := buildFallbacksMap(cfg.AlertmanagerConfig.Receivers, integrationsMap)
 	for name := range integrationsMap {
-		stage := am.createReceiverStage(name, integrationsMap[name], am.waitFunc, am.notificationLog)
-		routingStage[name] = notify.MultiStage{meshStage, silencingStage, timeMuteStage, inhibitionStage, stage}
+		stage := am.createReceiverStage(name, integrationsMap[name], fallbacksMap[name], am.waitFunc, am.notificationLog)
+		routingStage[name] = notify.MultiStage{meshStage, silencingStage, acknowledgementStage, timeMuteStage, inhibitionStage, stage}
 	}
 
 	am.route = dispatch.NewRoute(cfg.AlertmanagerConfig.Route.AsAMRoute(), nil)
@@ -666,8 +669,20 @@ func (e AlertValidationError) Error() string {
 	return errMsg
 }
 
+// buildFallbacksMap builds a map of receiver name to the integrations of its fallback receivers, in order.
+// The fallbacks of the fallback receivers are not followed.
+func buildFallbacksMap(receivers []*apimodels.PostableApiReceiver, integrationsMap map[string][]notify.Integration) map[string][]notify.Integration {
+	fallbacks := make(map[string][]notify.Integration, len(receivers))
+	for _, r := range receivers {
+		for _, name := range r.FallbackReceivers {
+			fallbacks[r.Name] = append(fallbacks[r.Name], integrationsMap[name]...)
+		}
+	}
+	return fallbacks
+}
+
 // createReceiverStage creates a pipeline of stages for a receiver.
-func (am *Alertmanager) createReceiverStage(name string, integrations []notify.Integration, wait func() time.Duration, notificationLog notify.NotificationLog) notify.Stage {
+func (am *Alertmanager) createReceiverStage(name string, integrations []notify.Integration, fallbacks []notify.Integration, wait func() time.Duration, notificationLog notify.NotificationLog) notify.Stage {
 	var fs notify.FanoutStage
 	for i := range integrations {
 		recv := &nflogpb.Receiver{
@@ -678,7 +693,11 @@ func (am *Alertmanager) createReceiverStage(name string, integrations []notify.I
 		var s notify.MultiStage
 		s = append(s, notify.NewWaitStage(wait))
 		s = append(s, notify.NewDedupStage(&integrations[i], notificationLog, recv))
-		s = append(s, notify.NewRetryStage(integrations[i], name, am.stageMetrics))
+		if len(fallbacks) > 0 {
+			s = append(s, newFallbackStage(integrations[i], fallbacks, name, am.stageMetrics, am.Metrics))
+		} else {
+			s = append(s, notify.NewRetryStage(integrations[i], name, am.stageMetrics))
+		}
 		s = append(s, notify.NewSetNotifiesStage(notificationLog, recv))
 
 		fs = append(fs, s)
@@ -2,9 +2,13 @@ package notifier
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/grafana/grafana/pkg/components/dashdiffs"
+	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
@@ -37,7 +41,25 @@ func (moa *MultiOrgAlertmanager) GetAlertmanagerConfiguration(ctx context.Contex
 	if err != nil {
 		return definitions.GettableUserConfig{}, fmt.Errorf("failed to get latest configuration: %w", err)
 	}
-	cfg, err := Load([]byte(query.Result.AlertmanagerConfiguration))
+	result, err := moa.gettableUserConfigFromStore(query.Result)
+	if err != nil {
+		return definitions.GettableUserConfig{}, err
+	}
+
+	if moa.settings.IsFeatureToggleEnabled(featuremgmt.FlagAlertProvisioning) {
+		result, err = moa.mergeProvenance(ctx, result, org)
+		if err != nil {
+			return definitions.GettableUserConfig{}, err
+		}
+	}
+
+	return result, nil
+}
+
+// gettableUserConfigFromStore converts a stored configuration into its API representation.
+// Secure settings are replaced by the names of the fields that are set.
+func (moa *MultiOrgAlertmanager) gettableUserConfigFromStore(stored *models.AlertConfiguration) (definitions.GettableUserConfig, error) {
+	cfg, err := Load([]byte(stored.AlertmanagerConfiguration))
 	if err != nil {
 		return definitions.GettableUserConfig{}, fmt.Errorf("failed to unmarshal alertmanager configuration: %w", err)
 	}
@@ -76,23 +98,19 @@ func (moa *MultiOrgAlertmanager) GetAlertmanagerConfiguration(ctx context.Contex
 		gettableApiReceiver := definitions.GettableApiReceiver{
 			GettableGrafanaReceivers: definitions.GettableGrafanaReceivers{
 				GrafanaManagedReceivers: receivers,
+				FallbackReceivers:       recv.FallbackReceivers,
 			},
 		}
 		gettableApiReceiver.Name = recv.Name
 		result.AlertmanagerConfig.Receivers = append(result.AlertmanagerConfig.Receivers, &gettableApiReceiver)
 	}
 
-	if moa.settings.IsFeatureToggleEnabled(featuremgmt.FlagAlertProvisioning) {
-		result, err = moa.mergeProvenance(ctx, result, org)
-		if err != nil {
-			return definitions.GettableUserConfig{}, err
-		}
-	}
-
 	return result, nil
 }
 
-func (moa *MultiOrgAlertmanager) ApplyAlertmanagerConfiguration(ctx context.Context, org int64, config definitions.PostableUserConfig) error {
+// ApplyAlertmanagerConfiguration saves a new version of the configuration of an organization and applies it.
+// createdBy is the ID of the user saving the configuration.
+func (moa *MultiOrgAlertmanager) ApplyAlertmanagerConfiguration(ctx context.Context, org int64, config definitions.PostableUserConfig, createdBy int64) error {
 	// Get the last known working configuration
 	query := models.GetLatestAlertmanagerConfigurationQuery{OrgID: org}
 	if err := moa.configStore.GetLatestAlertmanagerConfiguration(ctx, &query); err != nil {
@@ -118,7 +136,7 @@ func (moa *MultiOrgAlertmanager) ApplyAlertmanagerConfiguration(ctx context.Cont
 		}
 	}
 
-	if err := am.SaveAndApplyConfig(ctx, &config); err != nil {
+	if err := am.SaveAndApplyConfig(ctx, &config, createdBy); err != nil {
 		moa.logger.Error("unable to save and apply alertmanager configuration", "err", err)
 		return AlertmanagerConfigRejectedError{err}
 	}
@@ -126,6 +144,143 @@ func (moa *MultiOrgAlertmanager) ApplyAlertmanagerConfiguration(ctx context.Cont
 	return nil
 }
 
+// GetAlertmanagerConfigurationHistory returns the stored versions of the configuration of an organization, newest first.
+// A limit of zero returns every version.
+func (moa *MultiOrgAlertmanager) GetAlertmanagerConfigurationHistory(ctx context.Context, org int64, limit int) (definitions.GettableAlertingConfigHistory, error) {
+	query := models.GetAlertmanagerConfigurationHistoryQuery{OrgID: org, Limit: limit}
+	if err := moa.configStore.GetAlertmanagerConfigurationHistory(ctx, &query); err != nil {
+		return nil, fmt.Errorf("failed to get configuration history: %w", err)
+	}
+
+	result := make(definitions.GettableAlertingConfigHistory, 0, len(query.Result))
+	for i, entry := range query.Result {
+		result = append(result, definitions.GettableAlertingConfigHistoryEntry{
+			ID:             entry.ID,
+			Hash:           entry.ConfigurationHash,
+			CreatedAt:      time.Unix(entry.CreatedAt, 0).UTC(),
+			CreatedBy:      entry.CreatedBy,
+			CreatedByLogin: entry.CreatedByLogin,
+			Default:        entry.Default,
+			// The history is sorted newest first and the newest version is the one being used.
+			Active: i == 0,
+		})
+	}
+	return result, nil
+}
+
+// GetAlertmanagerConfigurationDiff compares the stored version id of the configuration of an organization with the
+// version baseID, or with the active configuration if baseID is zero. Secure settings are not part of the comparison.
+func (moa *MultiOrgAlertmanager) GetAlertmanagerConfigurationDiff(ctx context.Context, org int64, id int64, baseID int64, diffType dashdiffs.DiffType) (*dashdiffs.Result, error) {
+	newConfig, err := moa.getAlertmanagerConfigurationVersion(ctx, org, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var baseConfig *models.AlertConfiguration
+	if baseID == 0 {
+		query := models.GetLatestAlertmanagerConfigurationQuery{OrgID: org}
+		if err := moa.configStore.GetLatestAlertmanagerConfiguration(ctx, &query); err != nil {
+			return nil, fmt.Errorf("failed to get latest configuration: %w", err)
+		}
+		baseConfig = query.Result
+	} else {
+		baseConfig, err = moa.getAlertmanagerConfigurationVersion(ctx, org, baseID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	baseData, err := moa.comparableConfiguration(baseConfig)
+	if err != nil {
+		return nil, err
+	}
+	newData, err := moa.comparableConfiguration(newConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := dashdiffs.CalculateDiff(ctx, &dashdiffs.Options{OrgId: org, DiffType: diffType}, baseData, newData)
+	if errors.Is(err, dashdiffs.ErrNilDiff) {
+		if diffType == dashdiffs.DiffDelta {
+			return &dashdiffs.Result{Delta: []byte("{}")}, nil
+		}
+		return &dashdiffs.Result{Delta: []byte{}}, nil
+	}
+	return result, err
+}
+
+// RestoreAlertmanagerConfiguration saves the stored version id of the configuration of an organization as its newest
+// version and applies it. Nothing is saved if the configuration cannot be applied.
+func (moa *MultiOrgAlertmanager) RestoreAlertmanagerConfiguration(ctx context.Context, org int64, id int64, createdBy int64) error {
+	stored, err := moa.getAlertmanagerConfigurationVersion(ctx, org, id)
+	if err != nil {
+		return err
+	}
+
+	// The stored configuration already has its secure settings encrypted, so it can be saved as-is.
+	cfg, err := Load([]byte(stored.AlertmanagerConfiguration))
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal alertmanager configuration: %w", err)
+	}
+
+	am, err := moa.AlertmanagerFor(org)
+	if err != nil {
+		if errors.Is(err, ErrNoAlertmanagerForOrg) {
+			// There is no Alertmanager to apply the configuration to yet. Store it and let the sync create one using it.
+			cmd := models.SaveAlertmanagerConfigurationCmd{
+				AlertmanagerConfiguration: stored.AlertmanagerConfiguration,
+				ConfigurationVersion:      fmt.Sprintf("v%d", models.AlertConfigurationVersion),
+				CreatedBy:                 createdBy,
+				OrgID:                     org,
+			}
+			if err := moa.configStore.SaveAlertmanagerConfiguration(ctx, &cmd); err != nil {
+				return err
+			}
+			return moa.LoadAndSyncAlertmanagersForOrgs(ctx)
+		}
+		// It's okay if the alertmanager isn't ready yet, we're changing its config anyway.
+		if !errors.Is(err, ErrAlertmanagerNotReady) {
+			return err
+		}
+	}
+
+	if err := am.SaveAndApplyConfig(ctx, cfg, createdBy); err != nil {
+		moa.logger.Error("unable to restore alertmanager configuration", "org", org, "id", id, "err", err)
+		return AlertmanagerConfigRejectedError{err}
+	}
+
+	return nil
+}
+
+func (moa *MultiOrgAlertmanager) getAlertmanagerConfigurationVersion(ctx context.Context, org int64, id int64) (*models.AlertConfiguration, error) {
+	query := models.GetAlertmanagerConfigurationQuery{OrgID: org, ID: id}
+	if err := moa.configStore.GetAlertmanagerConfiguration(ctx, &query); err != nil {
+		return nil, fmt.Errorf("failed to get configuration %d: %w", id, err)
+	}
+	return query.Result, nil
+}
+
+// comparableConfiguration returns the JSON representation of a stored configuration that is used to compute diffs.
+func (moa *MultiOrgAlertmanager) comparableConfiguration(stored *models.AlertConfiguration) (*simplejson.Json, error) {
+	cfg, err := moa.gettableUserConfigFromStore(stored)
+	if err != nil {
+		return nil, err
+	}
+	// GettableUserConfig only implements json.Marshaler on its pointer, which relies on
+	// fields that are only set when it's decoded from YAML. Encode the plain value instead.
+	raw, err := json.Marshal(struct {
+		TemplateFiles      map[string]string                     `json:"template_files"`
+		AlertmanagerConfig definitions.GettableApiAlertingConfig `json:"alertmanager_config"`
+	}{
+		TemplateFiles:      cfg.TemplateFiles,
+		AlertmanagerConfig: cfg.AlertmanagerConfig,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize alertmanager configuration: %w", err)
+	}
+	return simplejson.NewJson(raw)
+}
+
 func (moa *MultiOrgAlertmanager) mergeProvenance(ctx context.Context, config definitions.GettableUserConfig, org int64) (definitions.GettableUserConfig, error) {
 	if config.AlertmanagerConfig.Route != nil {
 		provenance, err := moa.ProvStore.GetProvenance(ctx, config.AlertmanagerConfig.Route, org)
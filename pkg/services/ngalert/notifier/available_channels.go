@@ -674,6 +674,110 @@ func GetAvailableNotifiers() []*alerting.NotifierPlugin {
 				},
 			},
 		},
+		{
+			Type:        "http",
+			Name:        "Templated HTTP",
+			Description: "Sends an HTTP request whose method, headers and body are templates",
+			Heading:     "Templated HTTP settings",
+			Options: []alerting.NotifierOption{
+				{
+					Label:        "Url",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					PropertyName: "url",
+					Required:     true,
+				},
+				{
+					Label:        "Http Method",
+					Description:  "Must render to POST or PUT. You can use template variables.",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					Placeholder:  "POST",
+					PropertyName: "httpMethod",
+				},
+				{
+					Label:        "Headers",
+					Description:  "JSON object of header names to values. You can use template variables in the values.",
+					Element:      alerting.ElementTypeTextArea,
+					Placeholder:  `{"X-Ticket-Priority": "{{ .CommonLabels.severity }}"}`,
+					PropertyName: "headers",
+				},
+				{
+					Label:        "Body",
+					Description:  "Body of the request. You can use template variables.",
+					Element:      alerting.ElementTypeTextArea,
+					Placeholder:  `{"summary": "{{ .CommonAnnotations.summary }}"}`,
+					PropertyName: "body",
+					Required:     true,
+				},
+				{
+					Label:        "Content Type",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					Placeholder:  "application/json",
+					PropertyName: "contentType",
+				},
+				{
+					Label:        "Username",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					PropertyName: "username",
+				},
+				{
+					Label:        "Password",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypePassword,
+					PropertyName: "password",
+					Secure:       true,
+				},
+				{
+					Label:        "Max Alerts",
+					Description:  "Max alerts to include in a notification. Remaining alerts in the same batch will be ignored above this number. 0 means no limit.",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					PropertyName: "maxAlerts",
+				},
+			},
+		},
+		{
+			Type:        "webex",
+			Name:        "Cisco Webex",
+			Description: "Sends notifications to a Cisco Webex room",
+			Heading:     "Webex settings",
+			Options: []alerting.NotifierOption{
+				{
+					Label:        "Room ID",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					Description:  "Identifier of the Webex room to send messages to",
+					PropertyName: "room_id",
+					Required:     true,
+				},
+				{
+					Label:        "Bot Token",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypePassword,
+					Description:  "Access token of the Webex bot sending the messages",
+					PropertyName: "bot_token",
+					Required:     true,
+					Secure:       true,
+				},
+				{
+					Label:        "API URL",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					Placeholder:  channels.DefaultWebexAPIURL,
+					PropertyName: "api_url",
+				},
+				{
+					Label:        "Message",
+					Description:  "Markdown message. You can use template variables.",
+					Element:      alerting.ElementTypeTextArea,
+					Placeholder:  `{{ template "default.message" . }}`,
+					PropertyName: "message",
+				},
+			},
+		},
 		{
 			Type:        "wecom",
 			Name:        "WeCom",
@@ -52,6 +52,7 @@ var receiverFactories = map[string]func(FactoryConfig) (NotificationChannel, err
 	"discord":                 DiscordFactory,
 	"email":                   EmailFactory,
 	"googlechat":              GoogleChatFactory,
+	"http":                    HTTPFactory,
 	"kafka":                   KafkaFactory,
 	"line":                    LineFactory,
 	"opsgenie":                OpsgenieFactory,
@@ -63,6 +64,7 @@ var receiverFactories = map[string]func(FactoryConfig) (NotificationChannel, err
 	"telegram":                TelegramFactory,
 	"threema":                 ThreemaFactory,
 	"victorops":               VictorOpsFactory,
+	"webex":                   WebexFactory,
 	"webhook":                 WebHookFactory,
 	"wecom":                   WeComFactory,
 }
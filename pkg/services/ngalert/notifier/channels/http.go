@@ -0,0 +1,191 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/notifications"
+)
+
+// HTTPNotifier is responsible for sending alert notifications as HTTP requests
+// whose method, headers and body are templates, for endpoints that do not
+// accept the fixed payload of the webhook notifier.
+type HTTPNotifier struct {
+	*Base
+	URL         string
+	User        string
+	Password    string
+	HTTPMethod  string
+	Headers     map[string]string
+	Body        string
+	ContentType string
+	MaxAlerts   int
+	log         log.Logger
+	ns          notifications.WebhookSender
+	tmpl        *template.Template
+}
+
+type HTTPConfig struct {
+	*NotificationChannelConfig
+	URL         string
+	User        string
+	Password    string
+	HTTPMethod  string
+	Headers     map[string]string
+	Body        string
+	ContentType string
+	MaxAlerts   int
+}
+
+func HTTPFactory(fc FactoryConfig) (NotificationChannel, error) {
+	cfg, err := NewHTTPConfig(fc.Config, fc.DecryptFunc)
+	if err != nil {
+		return nil, receiverInitError{
+			Reason: err.Error(),
+			Cfg:    *fc.Config,
+		}
+	}
+	return NewHTTPNotifier(cfg, fc.NotificationService, fc.Template), nil
+}
+
+func NewHTTPConfig(config *NotificationChannelConfig, decryptFunc GetDecryptedValueFn) (*HTTPConfig, error) {
+	url := config.Settings.Get("url").MustString()
+	if url == "" {
+		return nil, errors.New("could not find url property in settings")
+	}
+	body := config.Settings.Get("body").MustString()
+	if body == "" {
+		return nil, errors.New("could not find body property in settings")
+	}
+	headers, err := httpHeadersFromSettings(config.Settings.Get("headers"))
+	if err != nil {
+		return nil, err
+	}
+	return &HTTPConfig{
+		NotificationChannelConfig: config,
+		URL:                       url,
+		User:                      config.Settings.Get("username").MustString(),
+		Password:                  decryptFunc(context.Background(), config.SecureSettings, "password", config.Settings.Get("password").MustString()),
+		HTTPMethod:                config.Settings.Get("httpMethod").MustString(http.MethodPost),
+		Headers:                   headers,
+		Body:                      body,
+		ContentType:               config.Settings.Get("contentType").MustString("application/json"),
+		MaxAlerts:                 config.Settings.Get("maxAlerts").MustInt(0),
+	}, nil
+}
+
+// httpHeadersFromSettings reads the header templates, which are either a JSON
+// object or a string containing one, as the frontend only edits text.
+func httpHeadersFromSettings(settings *simplejson.Json) (map[string]string, error) {
+	headers := map[string]string{}
+	if s, err := settings.String(); err == nil {
+		if strings.TrimSpace(s) == "" {
+			return headers, nil
+		}
+		if err := json.Unmarshal([]byte(s), &headers); err != nil {
+			return nil, fmt.Errorf("headers must be a JSON object of header names to values: %w", err)
+		}
+		return headers, nil
+	}
+	m, err := settings.Map()
+	if err != nil {
+		// The headers are optional.
+		if settings.Interface() == nil {
+			return headers, nil
+		}
+		return nil, errors.New("headers must be an object of header names to values")
+	}
+	for k, v := range m {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("value of header %q must be a string", k)
+		}
+		headers[k] = s
+	}
+	return headers, nil
+}
+
+// NewHTTPNotifier is the constructor for the templated HTTP notifier.
+func NewHTTPNotifier(config *HTTPConfig, ns notifications.WebhookSender, t *template.Template) *HTTPNotifier {
+	return &HTTPNotifier{
+		Base: NewBase(&models.AlertNotification{
+			Uid:                   config.UID,
+			Name:                  config.Name,
+			Type:                  config.Type,
+			DisableResolveMessage: config.DisableResolveMessage,
+			Settings:              config.Settings,
+		}),
+		URL:         config.URL,
+		User:        config.User,
+		Password:    config.Password,
+		HTTPMethod:  config.HTTPMethod,
+		Headers:     config.Headers,
+		Body:        config.Body,
+		ContentType: config.ContentType,
+		MaxAlerts:   config.MaxAlerts,
+		log:         log.New("alerting.notifier.http"),
+		ns:          ns,
+		tmpl:        t,
+	}
+}
+
+// Notify implements the Notifier interface. Unlike most notifiers, a failure
+// to template the request fails the notification, as the receiving system
+// would most likely reject a partially rendered request anyway.
+func (hn *HTTPNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	as, _ = truncateAlerts(hn.MaxAlerts, as)
+	var tmplErr error
+	tmpl, _ := TmplText(ctx, hn.tmpl, as, hn.log, &tmplErr)
+
+	method := strings.ToUpper(strings.TrimSpace(tmpl(hn.HTTPMethod)))
+	if tmplErr != nil {
+		return false, fmt.Errorf("failed to template HTTP method: %w", tmplErr)
+	}
+	if method != http.MethodPost && method != http.MethodPut {
+		return false, fmt.Errorf("unsupported HTTP method %q, only POST and PUT are supported", method)
+	}
+
+	headers := make(map[string]string, len(hn.Headers))
+	for name, value := range hn.Headers {
+		headers[name] = tmpl(value)
+		if tmplErr != nil {
+			return false, fmt.Errorf("failed to template HTTP header %q: %w", name, tmplErr)
+		}
+	}
+
+	body := tmpl(hn.Body)
+	if tmplErr != nil {
+		return false, fmt.Errorf("failed to template HTTP body: %w", tmplErr)
+	}
+
+	cmd := &models.SendWebhookSync{
+		Url:         hn.URL,
+		User:        hn.User,
+		Password:    hn.Password,
+		Body:        body,
+		HttpMethod:  method,
+		HttpHeader:  headers,
+		ContentType: hn.ContentType,
+	}
+
+	if err := hn.ns.SendWebhookSync(ctx, cmd); err != nil {
+		hn.log.Error("failed to send HTTP notification", "error", err, "notification", hn.Name)
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (hn *HTTPNotifier) SendResolved() bool {
+	return !hn.GetDisableResolveMessage()
+}
@@ -0,0 +1,186 @@
+package channels
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/bus"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/notifications"
+	"github.com/grafana/grafana/pkg/services/secrets/fakes"
+	secretsManager "github.com/grafana/grafana/pkg/services/secrets/manager"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+// receivedRequest is a request received by a server started with newRecordingServer.
+type receivedRequest struct {
+	Method string
+	Header http.Header
+	Body   string
+}
+
+// newRecordingServer starts a local HTTP server recording the requests sent to it.
+func newRecordingServer(t *testing.T) (*httptest.Server, *[]receivedRequest) {
+	t.Helper()
+	var requests []receivedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests = append(requests, receivedRequest{Method: r.Method, Header: r.Header, Body: string(b)})
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// newTestWebhookSender returns a notification service that sends real HTTP requests.
+func newTestWebhookSender(t *testing.T) notifications.WebhookSender {
+	t.Helper()
+	cfg := setting.NewCfg()
+	cfg.Smtp.FromAddress = "from@address.com"
+	ns, err := notifications.ProvideService(bus.New(), cfg, notifications.NewFakeMailer(), nil)
+	require.NoError(t, err)
+	return ns
+}
+
+func TestHTTPNotifier(t *testing.T) {
+	tmpl := templateForTests(t)
+
+	externalURL, err := url.Parse("http://localhost")
+	require.NoError(t, err)
+	tmpl.ExternalURL = externalURL
+
+	firing := &types.Alert{
+		Alert: model.Alert{
+			Labels:      model.LabelSet{"alertname": "alert1", "severity": "critical"},
+			Annotations: model.LabelSet{"summary": "disk is full"},
+		},
+	}
+
+	cases := []struct {
+		name         string
+		settings     string
+		alerts       []*types.Alert
+		expMethod    string
+		expHeaders   map[string]string
+		expBody      string
+		expInitError string
+		expMsgError  string
+	}{
+		{
+			name: "Templated body and headers",
+			settings: `{
+				"body": "{\"summary\": \"{{ .CommonAnnotations.summary }}\", \"count\": {{ len .Alerts.Firing }}}",
+				"headers": {"X-Priority": "{{ .CommonLabels.severity }}"}
+			}`,
+			alerts:     []*types.Alert{firing},
+			expMethod:  http.MethodPost,
+			expHeaders: map[string]string{"X-Priority": "critical", "Content-Type": "application/json"},
+			expBody:    `{"summary": "disk is full", "count": 1}`,
+		}, {
+			name: "Templated method and headers as a JSON string",
+			settings: `{
+				"body": "{{ .Status }}",
+				"contentType": "text/plain",
+				"httpMethod": "{{ if eq .Status \"firing\" }}post{{ else }}put{{ end }}",
+				"headers": "{\"X-Receiver\": \"{{ .Receiver }}\"}"
+			}`,
+			alerts:     []*types.Alert{firing},
+			expMethod:  http.MethodPost,
+			expHeaders: map[string]string{"X-Receiver": "my_receiver", "Content-Type": "text/plain"},
+			expBody:    "firing",
+		}, {
+			name: "Basic auth",
+			settings: `{
+				"body": "test",
+				"username": "user",
+				"password": "pass"
+			}`,
+			alerts:     []*types.Alert{firing},
+			expMethod:  http.MethodPost,
+			expHeaders: map[string]string{"Authorization": "Basic dXNlcjpwYXNz"},
+			expBody:    "test",
+		}, {
+			name: "Unsupported method",
+			settings: `{
+				"body": "test",
+				"httpMethod": "DELETE"
+			}`,
+			alerts:      []*types.Alert{firing},
+			expMsgError: `unsupported HTTP method "DELETE", only POST and PUT are supported`,
+		}, {
+			name: "Invalid body template",
+			settings: `{
+				"body": "{{ .Missing }"
+			}`,
+			alerts:      []*types.Alert{firing},
+			expMsgError: "failed to template HTTP body",
+		}, {
+			name:         "Error in initing: missing body",
+			settings:     `{}`,
+			expInitError: `could not find body property in settings`,
+		}, {
+			name:         "Error in initing: invalid headers",
+			settings:     `{"body": "test", "headers": "not json"}`,
+			expInitError: `headers must be a JSON object of header names to values`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			server, requests := newRecordingServer(t)
+
+			settingsJSON, err := simplejson.NewJson([]byte(c.settings))
+			require.NoError(t, err)
+			if _, ok := settingsJSON.CheckGet("url"); !ok {
+				settingsJSON.Set("url", server.URL)
+			}
+
+			m := &NotificationChannelConfig{
+				Name:     "http_testing",
+				Type:     "http",
+				Settings: settingsJSON,
+			}
+
+			secretsService := secretsManager.SetupTestService(t, fakes.NewFakeSecretsStore())
+			decryptFn := secretsService.GetDecryptedValue
+			cfg, err := NewHTTPConfig(m, decryptFn)
+			if c.expInitError != "" {
+				require.ErrorContains(t, err, c.expInitError)
+				return
+			}
+			require.NoError(t, err)
+
+			ctx := notify.WithGroupKey(context.Background(), "alertname")
+			ctx = notify.WithGroupLabels(ctx, model.LabelSet{"alertname": ""})
+			ctx = notify.WithReceiverName(ctx, "my_receiver")
+			pn := NewHTTPNotifier(cfg, newTestWebhookSender(t), tmpl)
+			ok, err := pn.Notify(ctx, c.alerts...)
+			if c.expMsgError != "" {
+				require.False(t, ok)
+				require.ErrorContains(t, err, c.expMsgError)
+				require.Empty(t, *requests)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			require.Len(t, *requests, 1)
+			req := (*requests)[0]
+			require.Equal(t, c.expMethod, req.Method)
+			for k, v := range c.expHeaders {
+				require.Equal(t, v, req.Header.Get(k))
+			}
+			require.Equal(t, c.expBody, req.Body)
+		})
+	}
+}
@@ -44,6 +44,24 @@ type ExtendedData struct {
 	CommonAnnotations template.KV `json:"commonAnnotations"`
 
 	ExternalURL string `json:"externalURL"`
+
+	// FailoverFrom is the integration that failed to send the notification
+	// when it is sent by one of the fallback integrations of the contact point.
+	FailoverFrom string `json:"failoverFrom,omitempty"`
+}
+
+type failoverKey struct{}
+
+// WithFailoverFrom returns a context recording that the notification is sent
+// by a fallback integration because the given integration failed to send it.
+func WithFailoverFrom(ctx context.Context, integration string) context.Context {
+	return context.WithValue(ctx, failoverKey{}, integration)
+}
+
+// FailoverFrom returns the integration that failed to send the notification, if any.
+func FailoverFrom(ctx context.Context) (string, bool) {
+	v, ok := ctx.Value(failoverKey{}).(string)
+	return v, ok
 }
 
 func removePrivateItems(kv template.KV) template.KV {
@@ -138,6 +156,9 @@ func ExtendData(data *template.Data, logger log.Logger) *ExtendedData {
 func TmplText(ctx context.Context, tmpl *template.Template, alerts []*types.Alert, l log.Logger, tmplErr *error) (func(string) string, *ExtendedData) {
 	promTmplData := notify.GetTemplateData(ctx, tmpl, alerts, l)
 	data := ExtendData(promTmplData, l)
+	if from, ok := FailoverFrom(ctx); ok {
+		data.FailoverFrom = from
+	}
 
 	return func(name string) (s string) {
 		if *tmplErr != nil {
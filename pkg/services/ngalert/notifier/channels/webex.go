@@ -0,0 +1,146 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/notifications"
+)
+
+const DefaultWebexAPIURL = "https://webexapis.com/v1/messages"
+
+type WebexConfig struct {
+	*NotificationChannelConfig
+	APIURL   string
+	RoomID   string
+	BotToken string
+	Message  string
+}
+
+func WebexFactory(fc FactoryConfig) (NotificationChannel, error) {
+	cfg, err := NewWebexConfig(fc.Config, fc.DecryptFunc)
+	if err != nil {
+		return nil, receiverInitError{
+			Reason: err.Error(),
+			Cfg:    *fc.Config,
+		}
+	}
+	return NewWebexNotifier(cfg, fc.NotificationService, fc.ImageStore, fc.Template), nil
+}
+
+func NewWebexConfig(config *NotificationChannelConfig, decryptFunc GetDecryptedValueFn) (*WebexConfig, error) {
+	roomID := config.Settings.Get("room_id").MustString()
+	if roomID == "" {
+		return nil, errors.New("could not find room_id property in settings")
+	}
+	botToken := decryptFunc(context.Background(), config.SecureSettings, "bot_token", config.Settings.Get("bot_token").MustString())
+	if botToken == "" {
+		return nil, errors.New("could not find bot_token property in settings")
+	}
+	return &WebexConfig{
+		NotificationChannelConfig: config,
+		APIURL:                    config.Settings.Get("api_url").MustString(DefaultWebexAPIURL),
+		RoomID:                    roomID,
+		BotToken:                  botToken,
+		Message:                   config.Settings.Get("message").MustString(`{{ template "default.message" . }}`),
+	}, nil
+}
+
+// NewWebexNotifier is the constructor for the Webex notifier.
+func NewWebexNotifier(config *WebexConfig, ns notifications.WebhookSender, images ImageStore, t *template.Template) *WebexNotifier {
+	return &WebexNotifier{
+		Base: NewBase(&models.AlertNotification{
+			Uid:                   config.UID,
+			Name:                  config.Name,
+			Type:                  config.Type,
+			DisableResolveMessage: config.DisableResolveMessage,
+			Settings:              config.Settings,
+		}),
+		APIURL:   config.APIURL,
+		RoomID:   config.RoomID,
+		BotToken: config.BotToken,
+		Message:  config.Message,
+		log:      log.New("alerting.notifier.webex"),
+		ns:       ns,
+		images:   images,
+		tmpl:     t,
+	}
+}
+
+// WebexNotifier is responsible for sending alert notifications to Cisco Webex rooms.
+type WebexNotifier struct {
+	*Base
+	APIURL   string
+	RoomID   string
+	BotToken string
+	Message  string
+	log      log.Logger
+	ns       notifications.WebhookSender
+	images   ImageStore
+	tmpl     *template.Template
+}
+
+// webexMessage defines the JSON object sent to the Webex messages API.
+type webexMessage struct {
+	RoomID   string   `json:"roomId"`
+	Markdown string   `json:"markdown"`
+	Files    []string `json:"files,omitempty"`
+}
+
+// Notify sends an alert notification to Webex.
+func (wn *WebexNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	wn.log.Debug("executing Webex notification", "notification", wn.Name)
+
+	var tmplErr error
+	tmpl, _ := TmplText(ctx, wn.tmpl, as, wn.log, &tmplErr)
+
+	msg := &webexMessage{
+		RoomID:   wn.RoomID,
+		Markdown: tmpl(wn.Message),
+	}
+	if tmplErr != nil {
+		wn.log.Warn("failed to template Webex message", "err", tmplErr.Error())
+	}
+
+	// Webex accepts a single file per message, so only the first image is attached.
+	_ = withStoredImages(ctx, wn.log, wn.images,
+		func(index int, image *ngmodels.Image) error {
+			if image != nil && len(image.URL) != 0 && len(msg.Files) == 0 {
+				msg.Files = []string{image.URL}
+			}
+			return nil
+		},
+		as...)
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return false, err
+	}
+
+	cmd := &models.SendWebhookSync{
+		Url:  wn.APIURL,
+		Body: string(body),
+		HttpHeader: map[string]string{
+			"Authorization": fmt.Sprintf("Bearer %s", wn.BotToken),
+		},
+	}
+
+	if err := wn.ns.SendWebhookSync(ctx, cmd); err != nil {
+		wn.log.Error("failed to send Webex message", "error", err, "notification", wn.Name)
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (wn *WebexNotifier) SendResolved() bool {
+	return !wn.GetDisableResolveMessage()
+}
@@ -0,0 +1,115 @@
+package channels
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/secrets/fakes"
+	secretsManager "github.com/grafana/grafana/pkg/services/secrets/manager"
+)
+
+func TestWebexNotifier(t *testing.T) {
+	tmpl := templateForTests(t)
+
+	externalURL, err := url.Parse("http://localhost")
+	require.NoError(t, err)
+	tmpl.ExternalURL = externalURL
+
+	cases := []struct {
+		name         string
+		settings     string
+		alerts       []*types.Alert
+		expBody      string
+		expInitError string
+	}{
+		{
+			name:     "Default config with one alert",
+			settings: `{"room_id": "room1", "bot_token": "token"}`,
+			alerts: []*types.Alert{
+				{
+					Alert: model.Alert{
+						Labels:      model.LabelSet{"alertname": "alert1", "lbl1": "val1"},
+						Annotations: model.LabelSet{"ann1": "annv1", "__dashboardUid__": "abcd", "__panelId__": "efgh"},
+					},
+				},
+			},
+			expBody: `{
+				"roomId": "room1",
+				"markdown": "**Firing**\n\nValue: [no value]\nLabels:\n - alertname = alert1\n - lbl1 = val1\nAnnotations:\n - ann1 = annv1\nSilence: http://localhost/alerting/silence/new?alertmanager=grafana&matcher=alertname%3Dalert1&matcher=lbl1%3Dval1\nDashboard: http://localhost/d/abcd\nPanel: http://localhost/d/abcd?viewPanel=efgh\n"
+			}`,
+		}, {
+			name: "Custom message with multiple alerts",
+			settings: `{
+				"room_id": "room1",
+				"bot_token": "token",
+				"message": "{{ len .Alerts.Firing }} alerts are firing, {{ len .Alerts.Resolved }} are resolved"
+			}`,
+			alerts: []*types.Alert{
+				{
+					Alert: model.Alert{
+						Labels: model.LabelSet{"alertname": "alert1", "lbl1": "val1"},
+					},
+				}, {
+					Alert: model.Alert{
+						Labels: model.LabelSet{"alertname": "alert1", "lbl1": "val2"},
+					},
+				},
+			},
+			expBody: `{"roomId": "room1", "markdown": "2 alerts are firing, 0 are resolved"}`,
+		}, {
+			name:         "Error in initing: missing room",
+			settings:     `{"bot_token": "token"}`,
+			expInitError: `could not find room_id property in settings`,
+		}, {
+			name:         "Error in initing: missing token",
+			settings:     `{"room_id": "room1"}`,
+			expInitError: `could not find bot_token property in settings`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			server, requests := newRecordingServer(t)
+
+			settingsJSON, err := simplejson.NewJson([]byte(c.settings))
+			require.NoError(t, err)
+			settingsJSON.Set("api_url", server.URL)
+
+			m := &NotificationChannelConfig{
+				Name:     "webex_testing",
+				Type:     "webex",
+				Settings: settingsJSON,
+			}
+
+			secretsService := secretsManager.SetupTestService(t, fakes.NewFakeSecretsStore())
+			decryptFn := secretsService.GetDecryptedValue
+			cfg, err := NewWebexConfig(m, decryptFn)
+			if c.expInitError != "" {
+				require.Equal(t, c.expInitError, err.Error())
+				return
+			}
+			require.NoError(t, err)
+
+			ctx := notify.WithGroupKey(context.Background(), "alertname")
+			ctx = notify.WithGroupLabels(ctx, model.LabelSet{"alertname": ""})
+			pn := NewWebexNotifier(cfg, newTestWebhookSender(t), &UnavailableImageStore{}, tmpl)
+			ok, err := pn.Notify(ctx, c.alerts...)
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			require.Len(t, *requests, 1)
+			req := (*requests)[0]
+			require.Equal(t, http.MethodPost, req.Method)
+			require.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+			require.JSONEq(t, c.expBody, req.Body)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier/channels"
+)
+
+// fallbackStage retries a notification with an integration and, once that integration has exhausted its retries,
+// sends it with each of the fallback integrations in order until one of them succeeds.
+type fallbackStage struct {
+	integration notify.Integration
+	fallbacks   []notify.Integration
+	groupName   string
+	metrics     *notify.Metrics
+	failovers   *metrics.Alertmanager
+}
+
+func newFallbackStage(integration notify.Integration, fallbacks []notify.Integration, groupName string, stageMetrics *notify.Metrics, m *metrics.Alertmanager) *fallbackStage {
+	return &fallbackStage{
+		integration: integration,
+		fallbacks:   fallbacks,
+		groupName:   groupName,
+		metrics:     stageMetrics,
+		failovers:   m,
+	}
+}
+
+// Exec implements the notify.Stage interface. The retries of the primary integration are bounded to half of the time
+// left before the notification times out, and the rest of the time is shared equally between the fallback integrations.
+func (s *fallbackStage) Exec(ctx context.Context, l log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	primaryCtx, cancel := withShareOfDeadline(ctx, 2)
+	_, sent, err := notify.NewRetryStage(s.integration, s.groupName, s.metrics).Exec(primaryCtx, l, alerts...)
+	cancel()
+	if err == nil {
+		return ctx, sent, nil
+	}
+
+	primaryErr := err
+	failoverCtx := channels.WithFailoverFrom(ctx, s.integration.String())
+	for i, fallback := range s.fallbacks {
+		if ctx.Err() != nil {
+			break
+		}
+		level.Warn(l).Log("msg", "Integration failed to send notification, failing over to fallback integration", "receiver", s.groupName, "integration", s.integration.String(), "fallback", fallback.String(), "err", err)
+
+		fallbackCtx, cancel := withShareOfDeadline(failoverCtx, len(s.fallbacks)-i)
+		_, sent, err = notify.NewRetryStage(fallback, s.groupName, s.metrics).Exec(fallbackCtx, l, alerts...)
+		cancel()
+		if err == nil {
+			s.failovers.NotificationFailovers.WithLabelValues(s.integration.Name(), fallback.Name()).Inc()
+			return ctx, sent, nil
+		}
+	}
+
+	if err == primaryErr {
+		return ctx, nil, primaryErr
+	}
+	return ctx, nil, fmt.Errorf("%w; all fallback integrations failed, last error: %s", primaryErr, err)
+}
+
+// withShareOfDeadline returns a context whose deadline is the given share of the time left before the deadline of ctx.
+func withShareOfDeadline(ctx context.Context, shares int) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok || shares <= 1 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, time.Until(deadline)/time.Duration(shares))
+}
@@ -0,0 +1,90 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier/channels"
+)
+
+type fakeFallbackNotifier struct {
+	err          error
+	retry        bool
+	calls        int
+	failoverFrom string
+}
+
+func (n *fakeFallbackNotifier) Notify(ctx context.Context, _ ...*types.Alert) (bool, error) {
+	n.calls++
+	n.failoverFrom, _ = channels.FailoverFrom(ctx)
+	return n.retry, n.err
+}
+
+func (n *fakeFallbackNotifier) SendResolved() bool { return true }
+
+func TestFallbackStage(t *testing.T) {
+	alert := &types.Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "test"}}}
+
+	exec := func(t *testing.T, primary *fakeFallbackNotifier, fallbacks ...*fakeFallbackNotifier) (*metrics.Alertmanager, []*types.Alert, error) {
+		t.Helper()
+		reg := prometheus.NewRegistry()
+		m := metrics.NewAlertmanagerMetrics(reg)
+		var fallbackIntegrations []notify.Integration
+		for i, f := range fallbacks {
+			fallbackIntegrations = append(fallbackIntegrations, notify.NewIntegration(f, f, "webhook", i))
+		}
+		stage := newFallbackStage(notify.NewIntegration(primary, primary, "slack", 0), fallbackIntegrations, "receiver", notify.NewMetrics(reg), m)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_, sent, err := stage.Exec(ctx, log.NewNopLogger(), alert)
+		return m, sent, err
+	}
+
+	t.Run("fallbacks are not used when the primary integration succeeds", func(t *testing.T) {
+		primary, fallback := &fakeFallbackNotifier{}, &fakeFallbackNotifier{}
+		m, sent, err := exec(t, primary, fallback)
+		require.NoError(t, err)
+		require.Len(t, sent, 1)
+		require.Equal(t, 1, primary.calls)
+		require.Equal(t, 0, fallback.calls)
+		require.Empty(t, primary.failoverFrom)
+		require.Equal(t, 0.0, testutil.ToFloat64(m.NotificationFailovers.WithLabelValues("slack", "webhook")))
+	})
+
+	t.Run("fallbacks are used in order once the primary integration exhausted its retries", func(t *testing.T) {
+		primary := &fakeFallbackNotifier{err: errors.New("expired token"), retry: true}
+		broken := &fakeFallbackNotifier{err: errors.New("unrecoverable"), retry: false}
+		working, unused := &fakeFallbackNotifier{}, &fakeFallbackNotifier{}
+		m, sent, err := exec(t, primary, broken, working, unused)
+		require.NoError(t, err)
+		require.Len(t, sent, 1)
+		require.GreaterOrEqual(t, primary.calls, 1)
+		require.Equal(t, 1, broken.calls)
+		require.Equal(t, 1, working.calls)
+		require.Equal(t, 0, unused.calls)
+		require.Equal(t, "slack[0]", working.failoverFrom)
+		require.Equal(t, 1.0, testutil.ToFloat64(m.NotificationFailovers.WithLabelValues("slack", "webhook")))
+	})
+
+	t.Run("the notification fails when all fallbacks fail", func(t *testing.T) {
+		primary := &fakeFallbackNotifier{err: errors.New("primary"), retry: false}
+		fallback := &fakeFallbackNotifier{err: errors.New("fallback"), retry: false}
+		m, _, err := exec(t, primary, fallback)
+		require.ErrorContains(t, err, "primary")
+		require.ErrorContains(t, err, "fallback")
+		require.Equal(t, 1, fallback.calls)
+		require.Equal(t, 0.0, testutil.ToFloat64(m.NotificationFailovers.WithLabelValues("slack", "webhook")))
+	})
+}
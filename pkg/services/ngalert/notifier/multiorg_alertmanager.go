@@ -123,6 +123,7 @@ func (moa *MultiOrgAlertmanager) Run(ctx context.Context) error {
 			if err := moa.LoadAndSyncAlertmanagersForOrgs(ctx); err != nil {
 				moa.logger.Error("error while synchronizing Alertmanager orgs", "err", err)
 			}
+			moa.deleteOldConfigurations(ctx)
 		}
 	}
 }
@@ -144,6 +145,32 @@ func (moa *MultiOrgAlertmanager) LoadAndSyncAlertmanagersForOrgs(ctx context.Con
 	return nil
 }
 
+// deleteOldConfigurations deletes the versions of the Alertmanager configuration of every organization that are older
+// than the number of versions to keep.
+func (moa *MultiOrgAlertmanager) deleteOldConfigurations(ctx context.Context) {
+	limit := moa.settings.UnifiedAlerting.AlertmanagerConfigHistoryLimit
+	if limit <= 0 {
+		return
+	}
+
+	orgIDs, err := moa.orgStore.GetOrgs(ctx)
+	if err != nil {
+		moa.logger.Error("failed to load organizations to clean up the Alertmanager configuration history", "err", err)
+		return
+	}
+
+	for _, orgID := range orgIDs {
+		deleted, err := moa.configStore.DeleteOldAlertmanagerConfigurations(ctx, orgID, limit)
+		if err != nil {
+			moa.logger.Error("failed to clean up the Alertmanager configuration history", "org", orgID, "err", err)
+			continue
+		}
+		if deleted > 0 {
+			moa.logger.Debug("deleted old Alertmanager configurations", "org", orgID, "count", deleted)
+		}
+	}
+}
+
 // getLatestConfigs retrieves the latest Alertmanager configuration for every organization. It returns a map where the key is the ID of each organization and the value is the configuration.
 func (moa *MultiOrgAlertmanager) getLatestConfigs(ctx context.Context) (map[int64]*models.AlertConfiguration, error) {
 	configs, err := moa.configStore.GetAllLatestAlertmanagerConfiguration(ctx)
@@ -10,10 +10,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/grafana/grafana/pkg/components/dashdiffs"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/services/ngalert/provisioning"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
 	"github.com/grafana/grafana/pkg/services/secrets/fakes"
 	secretsManager "github.com/grafana/grafana/pkg/services/secrets/manager"
 	"github.com/grafana/grafana/pkg/setting"
@@ -287,3 +289,66 @@ var brokenConfig = `
 		}]
 	}
 }`
+
+func TestMultiOrgAlertmanager_AlertmanagerConfigurationHistory(t *testing.T) {
+	configStore := &FakeConfigStore{
+		configs: map[int64]*models.AlertConfiguration{
+			1: {ID: 7, AlertmanagerConfiguration: setting.GetAlertmanagerDefaultConfiguration(), OrgID: 1, CreatedBy: 3},
+		},
+	}
+	orgStore := &FakeOrgStore{
+		orgs: []int64{1},
+	}
+	tmpDir := t.TempDir()
+	cfg := &setting.Cfg{
+		DataPath: tmpDir,
+		UnifiedAlerting: setting.UnifiedAlertingSettings{
+			AlertmanagerConfigPollInterval: 3 * time.Minute,
+			DefaultConfiguration:           setting.GetAlertmanagerDefaultConfiguration(),
+		}, // do not poll in tests.
+	}
+	kvStore := NewFakeKVStore(t)
+	provStore := provisioning.NewFakeProvisioningStore()
+	secretsService := secretsManager.SetupTestService(t, fakes.NewFakeSecretsStore())
+	decryptFn := secretsService.GetDecryptedValue
+	reg := prometheus.NewPedanticRegistry()
+	m := metrics.NewNGAlert(reg)
+	mam, err := NewMultiOrgAlertmanager(cfg, configStore, orgStore, kvStore, provStore, decryptFn, m.GetMultiOrgAlertmanagerMetrics(), nil, log.New("testlogger"), secretsService)
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, mam.LoadAndSyncAlertmanagersForOrgs(ctx))
+
+	t.Run("the newest version is the active one", func(t *testing.T) {
+		history, err := mam.GetAlertmanagerConfigurationHistory(ctx, 1, 0)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		require.Equal(t, int64(7), history[0].ID)
+		require.Equal(t, int64(3), history[0].CreatedBy)
+		require.True(t, history[0].Active)
+	})
+
+	t.Run("comparing identical versions returns an empty diff", func(t *testing.T) {
+		result, err := mam.GetAlertmanagerConfigurationDiff(ctx, 1, 7, 0, dashdiffs.DiffDelta)
+		require.NoError(t, err)
+		require.JSONEq(t, "{}", string(result.Delta))
+	})
+
+	t.Run("comparing an unknown version returns an error", func(t *testing.T) {
+		_, err := mam.GetAlertmanagerConfigurationDiff(ctx, 1, 8, 0, dashdiffs.DiffBasic)
+		require.ErrorIs(t, err, store.ErrNoAlertmanagerConfiguration)
+	})
+
+	t.Run("restoring a version saves it as a new version", func(t *testing.T) {
+		require.NoError(t, mam.RestoreAlertmanagerConfiguration(ctx, 1, 7, 42))
+		restored := configStore.configs[1]
+		require.Equal(t, int64(42), restored.CreatedBy)
+		cfg, err := Load([]byte(restored.AlertmanagerConfiguration))
+		require.NoError(t, err)
+		require.Equal(t, "grafana-default-email", cfg.AlertmanagerConfig.Route.Receiver)
+	})
+
+	t.Run("restoring an unknown version returns an error", func(t *testing.T) {
+		err := mam.RestoreAlertmanagerConfiguration(ctx, 1, 8, 42)
+		require.ErrorIs(t, err, store.ErrNoAlertmanagerConfiguration)
+	})
+}
@@ -0,0 +1,117 @@
+package notifier
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/prometheus/alertmanager/dispatch"
+	"github.com/prometheus/alertmanager/silence"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+)
+
+type TestRoutesResult struct {
+	Labels      model.LabelSet
+	Routes      []TestRouteResult
+	SilencedBy  []string
+	EvaluatedAt time.Time
+}
+
+type TestRouteResult struct {
+	// Key identifies the route by the matchers of the route and of its parents.
+	Key                     string
+	Receiver                string
+	GroupBy                 []string
+	GroupKey                string
+	GroupWait               time.Duration
+	GroupInterval           time.Duration
+	RepeatInterval          time.Duration
+	MuteTimeIntervals       []string
+	ActiveMuteTimeIntervals []string
+}
+
+// TestRoutes returns the routes of the notification policy tree an alert with the given labels would be routed to,
+// along with the mute timings and silences that would currently prevent it from being notified. Nothing is sent.
+func (am *Alertmanager) TestRoutes(lbls model.LabelSet) (*TestRoutesResult, error) {
+	if err := lbls.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid labels: %w", err)
+	}
+
+	am.reloadConfigMtx.RLock()
+	defer am.reloadConfigMtx.RUnlock()
+	if !am.ready() {
+		return nil, ErrAlertmanagerNotReady
+	}
+
+	now := time.Now()
+	result := &TestRoutesResult{
+		Labels:      lbls,
+		Routes:      make([]TestRouteResult, 0),
+		SilencedBy:  make([]string, 0),
+		EvaluatedAt: now,
+	}
+
+	for _, route := range am.route.Match(lbls) {
+		opts := route.RouteOpts
+		groupLabels := groupLabelsFor(lbls, route)
+		r := TestRouteResult{
+			Key:      route.Key(),
+			Receiver: opts.Receiver,
+			// This is the same key the dispatcher uses for the aggregation group of the alert.
+			GroupKey:                fmt.Sprintf("%s:%s", route.Key(), groupLabels),
+			GroupWait:               opts.GroupWait,
+			GroupInterval:           opts.GroupInterval,
+			RepeatInterval:          opts.RepeatInterval,
+			MuteTimeIntervals:       opts.MuteTimeIntervals,
+			ActiveMuteTimeIntervals: am.activeMuteTimeIntervals(opts.MuteTimeIntervals, now),
+		}
+		if opts.GroupByAll {
+			r.GroupBy = []string{"..."}
+		} else {
+			r.GroupBy = make([]string, 0, len(opts.GroupBy))
+			for ln := range opts.GroupBy {
+				r.GroupBy = append(r.GroupBy, string(ln))
+			}
+			sort.Strings(r.GroupBy)
+		}
+		result.Routes = append(result.Routes, r)
+	}
+
+	sils, _, err := am.silences.Query(silence.QState(types.SilenceStateActive), silence.QMatches(lbls))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrGetSilencesInternal.Error(), err)
+	}
+	for _, s := range sils {
+		result.SilencedBy = append(result.SilencedBy, s.Id)
+	}
+	sort.Strings(result.SilencedBy)
+
+	return result, nil
+}
+
+// activeMuteTimeIntervals returns the names of the mute timings that contain the given time.
+func (am *Alertmanager) activeMuteTimeIntervals(names []string, now time.Time) []string {
+	active := make([]string, 0)
+	for _, name := range names {
+		for _, ti := range am.muteTimes[name] {
+			// The time mute stage of the Alertmanager evaluates time intervals in UTC.
+			if ti.ContainsTime(now.UTC()) {
+				active = append(active, name)
+				break
+			}
+		}
+	}
+	return active
+}
+
+// groupLabelsFor returns the labels the route groups the alert by.
+func groupLabelsFor(lbls model.LabelSet, route *dispatch.Route) model.LabelSet {
+	groupLabels := model.LabelSet{}
+	for ln, lv := range lbls {
+		if _, ok := route.RouteOpts.GroupBy[ln]; ok || route.RouteOpts.GroupByAll {
+			groupLabels[ln] = lv
+		}
+	}
+	return groupLabels
+}
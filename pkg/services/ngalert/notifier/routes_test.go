@@ -0,0 +1,113 @@
+package notifier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+const testRoutesConfig = `{
+	"alertmanager_config": {
+		"route": {
+			"receiver": "default",
+			"group_by": ["alertname"],
+			"group_wait": "10s",
+			"routes": [{
+				"receiver": "sre",
+				"object_matchers": [["team", "=", "sre"]],
+				"group_by": ["..."],
+				"mute_time_intervals": ["always", "never"]
+			}]
+		},
+		"mute_time_intervals": [{
+			"name": "always",
+			"time_intervals": [{}]
+		}, {
+			"name": "never",
+			"time_intervals": [{"years": ["1999"]}]
+		}],
+		"receivers": [{
+			"name": "default",
+			"grafana_managed_receiver_configs": [{
+				"uid": "",
+				"name": "default",
+				"type": "email",
+				"settings": {"addresses": "<example@email.com>"}
+			}]
+		}, {
+			"name": "sre",
+			"grafana_managed_receiver_configs": [{
+				"uid": "",
+				"name": "sre",
+				"type": "email",
+				"settings": {"addresses": "<sre@email.com>"}
+			}]
+		}]
+	}
+}`
+
+func TestTestRoutes(t *testing.T) {
+	am := setupAMTest(t)
+
+	t.Run("fails when the Alertmanager is not ready", func(t *testing.T) {
+		_, err := am.TestRoutes(model.LabelSet{"alertname": "test"})
+		require.ErrorIs(t, err, ErrAlertmanagerNotReady)
+	})
+
+	require.NoError(t, am.ApplyConfig(&ngmodels.AlertConfiguration{AlertmanagerConfiguration: testRoutesConfig}))
+
+	t.Run("fails with invalid labels", func(t *testing.T) {
+		_, err := am.TestRoutes(model.LabelSet{"invalid-name": "test"})
+		require.Error(t, err)
+	})
+
+	t.Run("alerts without a matching policy use the default policy", func(t *testing.T) {
+		result, err := am.TestRoutes(model.LabelSet{"alertname": "test", "team": "dev"})
+		require.NoError(t, err)
+		require.Len(t, result.Routes, 1)
+		route := result.Routes[0]
+		require.Equal(t, "default", route.Receiver)
+		require.Equal(t, []string{"alertname"}, route.GroupBy)
+		require.Equal(t, `{}:{alertname="test"}`, route.GroupKey)
+		require.Equal(t, 10*time.Second, route.GroupWait)
+		require.Empty(t, route.ActiveMuteTimeIntervals)
+		require.Empty(t, result.SilencedBy)
+	})
+
+	t.Run("alerts matching a policy report its timings, mute timings and silences", func(t *testing.T) {
+		now := time.Now()
+		name, value, isEqual, isRegex := "team", "sre", true, false
+		comment, createdBy := "test", "test"
+		startsAt, endsAt := strfmt.DateTime(now.Add(-time.Hour)), strfmt.DateTime(now.Add(time.Hour))
+		silenceID, err := am.CreateSilence(&apimodels.PostableSilence{
+			Silence: models.Silence{
+				Comment:   &comment,
+				CreatedBy: &createdBy,
+				StartsAt:  &startsAt,
+				EndsAt:    &endsAt,
+				Matchers:  models.Matchers{{Name: &name, Value: &value, IsEqual: &isEqual, IsRegex: &isRegex}},
+			},
+		})
+		require.NoError(t, err)
+
+		result, err := am.TestRoutes(model.LabelSet{"alertname": "test", "team": "sre"})
+		require.NoError(t, err)
+		require.Len(t, result.Routes, 1)
+		route := result.Routes[0]
+		require.Equal(t, "sre", route.Receiver)
+		require.Equal(t, []string{"..."}, route.GroupBy)
+		require.Equal(t, `{}/{team="sre"}:{alertname="test", team="sre"}`, route.GroupKey)
+		// Timings are inherited from the parent policy.
+		require.Equal(t, 10*time.Second, route.GroupWait)
+		require.Equal(t, []string{"always", "never"}, route.MuteTimeIntervals)
+		require.Equal(t, []string{"always"}, route.ActiveMuteTimeIntervals)
+		require.Equal(t, []string{silenceID}, result.SilencedBy)
+	})
+}
@@ -57,11 +57,42 @@ func (f *FakeConfigStore) GetLatestAlertmanagerConfiguration(_ context.Context,
 	return nil
 }
 
+func (f *FakeConfigStore) GetAlertmanagerConfiguration(_ context.Context, query *models.GetAlertmanagerConfigurationQuery) error {
+	config, ok := f.configs[query.OrgID]
+	if !ok || config.ID != query.ID {
+		return store.ErrNoAlertmanagerConfiguration
+	}
+
+	query.Result = config
+	return nil
+}
+
+func (f *FakeConfigStore) GetAlertmanagerConfigurationHistory(_ context.Context, query *models.GetAlertmanagerConfigurationHistoryQuery) error {
+	query.Result = []*models.AlertConfigurationHistoryEntry{}
+	if config, ok := f.configs[query.OrgID]; ok {
+		query.Result = append(query.Result, &models.AlertConfigurationHistoryEntry{
+			ID:                config.ID,
+			ConfigurationHash: config.ConfigurationHash,
+			CreatedAt:         config.CreatedAt,
+			CreatedBy:         config.CreatedBy,
+			Default:           config.Default,
+		})
+	}
+
+	return nil
+}
+
+func (f *FakeConfigStore) DeleteOldAlertmanagerConfigurations(_ context.Context, _ int64, _ int) (int64, error) {
+	// Only the latest configuration of each organization is kept.
+	return 0, nil
+}
+
 func (f *FakeConfigStore) SaveAlertmanagerConfiguration(_ context.Context, cmd *models.SaveAlertmanagerConfigurationCmd) error {
 	f.configs[cmd.OrgID] = &models.AlertConfiguration{
 		AlertmanagerConfiguration: cmd.AlertmanagerConfiguration,
 		OrgID:                     cmd.OrgID,
 		ConfigurationVersion:      "v1",
+		CreatedBy:                 cmd.CreatedBy,
 		Default:                   cmd.Default,
 	}
 
@@ -73,6 +104,7 @@ func (f *FakeConfigStore) SaveAlertmanagerConfigurationWithCallback(_ context.Co
 		AlertmanagerConfiguration: cmd.AlertmanagerConfiguration,
 		OrgID:                     cmd.OrgID,
 		ConfigurationVersion:      "v1",
+		CreatedBy:                 cmd.CreatedBy,
 		Default:                   cmd.Default,
 	}
 
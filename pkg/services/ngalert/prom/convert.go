@@ -0,0 +1,218 @@
+// Package prom converts Prometheus alerting rules into Grafana managed alert rules.
+package prom
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/grafana/grafana/pkg/expr"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+const (
+	DatasourceTypePrometheus = "prometheus"
+	DatasourceTypeLoki       = "loki"
+)
+
+const (
+	queryRefID     = "A"
+	reduceRefID    = "B"
+	conditionRefID = "C"
+
+	// queryTimeRange is the relative time range of the data source query. Alerting rules use instant queries, so it
+	// only sets the time at which the expression is evaluated.
+	queryTimeRange = 10 * time.Minute
+
+	// conditionExpression is true for every series returned by the query, whatever its value. This matches Prometheus,
+	// where every series returned by the expression of an alerting rule is an alert.
+	conditionExpression = "is_number($" + reduceRefID + ") || is_nan($" + reduceRefID + ") || is_inf($" + reduceRefID + ")"
+)
+
+var ErrUnsupportedDatasourceType = errors.New("unsupported data source type, only Prometheus and Loki data sources are supported")
+
+// Options are the options of the conversion of Prometheus rules.
+type Options struct {
+	// DatasourceUID is the UID of the data source the converted rules query.
+	DatasourceUID string
+	// DatasourceType is the type of that data source, either DatasourceTypePrometheus or DatasourceTypeLoki.
+	DatasourceType string
+}
+
+// ParseRuleFile parses a Prometheus rule file, in YAML or JSON.
+func ParseRuleFile(b []byte) (*apimodels.PrometheusRuleFile, error) {
+	var file apimodels.PrometheusRuleFile
+	decoder := yaml.NewDecoder(bytes.NewReader(b))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to parse rule file: %w", err)
+	}
+	return &file, nil
+}
+
+// ConvertRuleFile converts the alerting rules of each group of the Prometheus rule file into a Grafana managed rule
+// group. Each rule queries the data source with its expression and fires for every series the expression returns.
+// Recording rules cannot be converted and are skipped. The returned warnings describe the changes made to the rules.
+func ConvertRuleFile(file apimodels.PrometheusRuleFile, opts Options) ([]apimodels.PostableRuleGroupConfig, []string, error) {
+	if opts.DatasourceUID == "" {
+		return nil, nil, errors.New("data source UID is required")
+	}
+	if opts.DatasourceType != DatasourceTypePrometheus && opts.DatasourceType != DatasourceTypeLoki {
+		return nil, nil, fmt.Errorf("%w: %s", ErrUnsupportedDatasourceType, opts.DatasourceType)
+	}
+
+	var warnings []string
+	titles := make(map[string]struct{})
+	groups := make([]apimodels.PostableRuleGroupConfig, 0, len(file.Groups))
+	groupNames := make(map[string]struct{}, len(file.Groups))
+	for _, group := range file.Groups {
+		if group.Name == "" {
+			return nil, nil, errors.New("rule group name cannot be empty")
+		}
+		if _, ok := groupNames[group.Name]; ok {
+			return nil, nil, fmt.Errorf("rule group %s is defined more than once", group.Name)
+		}
+		groupNames[group.Name] = struct{}{}
+
+		converted := apimodels.PostableRuleGroupConfig{
+			Name:     group.Name,
+			Interval: group.Interval,
+			Rules:    make([]apimodels.PostableExtendedRuleNode, 0, len(group.Rules)),
+		}
+		for idx, rule := range group.Rules {
+			if rule.Record != "" {
+				warnings = append(warnings, fmt.Sprintf("rule group %s: recording rule %s was skipped, only alerting rules can be imported", group.Name, rule.Record))
+				continue
+			}
+			if rule.Alert == "" {
+				return nil, nil, fmt.Errorf("rule group %s: rule at index %d is neither an alerting nor a recording rule", group.Name, idx)
+			}
+			if rule.Expr == "" {
+				return nil, nil, fmt.Errorf("rule group %s: alerting rule %s has no expression", group.Name, rule.Alert)
+			}
+
+			title := uniqueTitle(rule.Alert, titles)
+			if title != rule.Alert {
+				warnings = append(warnings, fmt.Sprintf("rule group %s: alerting rule %s was renamed to %s because Grafana rule titles must be unique", group.Name, rule.Alert, title))
+			}
+
+			node, err := convertRule(title, rule, opts)
+			if err != nil {
+				return nil, nil, fmt.Errorf("rule group %s: failed to convert alerting rule %s: %w", group.Name, rule.Alert, err)
+			}
+			converted.Rules = append(converted.Rules, node)
+		}
+		if len(converted.Rules) == 0 {
+			warnings = append(warnings, fmt.Sprintf("rule group %s was skipped because it has no alerting rules", group.Name))
+			continue
+		}
+		groups = append(groups, converted)
+	}
+	return groups, warnings, nil
+}
+
+func convertRule(title string, rule apimodels.ApiRuleNode, opts Options) (apimodels.PostableExtendedRuleNode, error) {
+	query, err := datasourceQuery(rule.Expr, opts)
+	if err != nil {
+		return apimodels.PostableExtendedRuleNode{}, err
+	}
+	reduce, err := expressionQuery(reduceRefID, map[string]interface{}{
+		"refId":      reduceRefID,
+		"type":       "reduce",
+		"expression": queryRefID,
+		"reducer":    "last",
+	})
+	if err != nil {
+		return apimodels.PostableExtendedRuleNode{}, err
+	}
+	condition, err := expressionQuery(conditionRefID, map[string]interface{}{
+		"refId":      conditionRefID,
+		"type":       "math",
+		"expression": conditionExpression,
+	})
+	if err != nil {
+		return apimodels.PostableExtendedRuleNode{}, err
+	}
+
+	return apimodels.PostableExtendedRuleNode{
+		ApiRuleNode: &apimodels.ApiRuleNode{
+			For:         rule.For,
+			Labels:      copyMap(rule.Labels),
+			Annotations: copyMap(rule.Annotations),
+		},
+		GrafanaManagedAlert: &apimodels.PostableGrafanaRule{
+			Title:        title,
+			Condition:    conditionRefID,
+			Data:         []models.AlertQuery{query, reduce, condition},
+			NoDataState:  apimodels.OK,
+			ExecErrState: apimodels.ErrorErrState,
+		},
+	}, nil
+}
+
+func datasourceQuery(expression string, opts Options) (models.AlertQuery, error) {
+	model := map[string]interface{}{
+		"refId": queryRefID,
+		"expr":  expression,
+	}
+	if opts.DatasourceType == DatasourceTypeLoki {
+		model["queryType"] = "instant"
+	} else {
+		model["instant"] = true
+		model["range"] = false
+	}
+	raw, err := json.Marshal(model)
+	if err != nil {
+		return models.AlertQuery{}, err
+	}
+	return models.AlertQuery{
+		RefID:         queryRefID,
+		DatasourceUID: opts.DatasourceUID,
+		RelativeTimeRange: models.RelativeTimeRange{
+			From: models.Duration(queryTimeRange),
+			To:   0,
+		},
+		Model: raw,
+	}, nil
+}
+
+func expressionQuery(refID string, model map[string]interface{}) (models.AlertQuery, error) {
+	raw, err := json.Marshal(model)
+	if err != nil {
+		return models.AlertQuery{}, err
+	}
+	return models.AlertQuery{
+		RefID:         refID,
+		DatasourceUID: expr.DatasourceUID,
+		Model:         raw,
+	}, nil
+}
+
+// uniqueTitle returns the name, suffixed with a number if it is already used, and marks the result as used.
+func uniqueTitle(name string, used map[string]struct{}) string {
+	title := name
+	for i := 2; ; i++ {
+		if _, ok := used[title]; !ok {
+			break
+		}
+		title = fmt.Sprintf("%s (%d)", name, i)
+	}
+	used[title] = struct{}{}
+	return title
+}
+
+func copyMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	c := make(map[string]string, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
@@ -0,0 +1,167 @@
+package prom
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/expr"
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+)
+
+const ruleFile = `
+groups:
+  - name: node
+    interval: 1m
+    rules:
+      - record: instance:node_cpu:rate5m
+        expr: rate(node_cpu_seconds_total[5m])
+      - alert: InstanceDown
+        expr: up == 0
+        for: 5m
+        labels:
+          severity: critical
+        annotations:
+          summary: "{{ $labels.instance }} is down"
+      - alert: InstanceDown
+        expr: up{job="node"} == 0
+  - name: recording
+    rules:
+      - record: job:up:sum
+        expr: sum by (job) (up)
+`
+
+func TestParseRuleFile(t *testing.T) {
+	t.Run("parses YAML rule files", func(t *testing.T) {
+		file, err := ParseRuleFile([]byte(ruleFile))
+		require.NoError(t, err)
+		require.Len(t, file.Groups, 2)
+		require.Equal(t, "node", file.Groups[0].Name)
+		require.Equal(t, model.Duration(time.Minute), file.Groups[0].Interval)
+		require.Len(t, file.Groups[0].Rules, 3)
+		require.Equal(t, model.Duration(5*time.Minute), file.Groups[0].Rules[1].For)
+		require.Equal(t, "{{ $labels.instance }} is down", file.Groups[0].Rules[1].Annotations["summary"])
+	})
+
+	t.Run("parses JSON rule files", func(t *testing.T) {
+		file, err := ParseRuleFile([]byte(`{"groups": [{"name": "node", "rules": [{"alert": "InstanceDown", "expr": "up == 0", "for": "1m"}]}]}`))
+		require.NoError(t, err)
+		require.Len(t, file.Groups, 1)
+		require.Equal(t, model.Duration(time.Minute), file.Groups[0].Rules[0].For)
+	})
+
+	t.Run("rejects unknown fields", func(t *testing.T) {
+		_, err := ParseRuleFile([]byte("groups:\n  - name: node\n    rules:\n      - alert: InstanceDown\n        exp: up == 0\n"))
+		require.ErrorContains(t, err, "failed to parse rule file")
+	})
+}
+
+func TestConvertRuleFile(t *testing.T) {
+	file, err := ParseRuleFile([]byte(ruleFile))
+	require.NoError(t, err)
+
+	t.Run("converts alerting rules for Prometheus", func(t *testing.T) {
+		groups, warnings, err := ConvertRuleFile(*file, Options{DatasourceUID: "prom", DatasourceType: DatasourceTypePrometheus})
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		require.Len(t, warnings, 4)
+		require.Contains(t, warnings[0], "recording rule instance:node_cpu:rate5m was skipped")
+		require.Contains(t, warnings[1], "renamed to InstanceDown (2)")
+		require.Contains(t, warnings[2], "recording rule job:up:sum was skipped")
+		require.Contains(t, warnings[3], "rule group recording was skipped")
+
+		group := groups[0]
+		require.Equal(t, "node", group.Name)
+		require.Equal(t, model.Duration(time.Minute), group.Interval)
+		require.Len(t, group.Rules, 2)
+		require.Equal(t, apimodels.GrafanaBackend, group.Type())
+
+		rule := group.Rules[0]
+		require.Empty(t, rule.Expr)
+		require.Equal(t, model.Duration(5*time.Minute), rule.For)
+		require.Equal(t, map[string]string{"severity": "critical"}, rule.Labels)
+		require.Equal(t, map[string]string{"summary": "{{ $labels.instance }} is down"}, rule.Annotations)
+		require.Equal(t, "InstanceDown", rule.GrafanaManagedAlert.Title)
+		require.Equal(t, "C", rule.GrafanaManagedAlert.Condition)
+		require.Equal(t, apimodels.OK, rule.GrafanaManagedAlert.NoDataState)
+		require.Equal(t, apimodels.ErrorErrState, rule.GrafanaManagedAlert.ExecErrState)
+
+		data := rule.GrafanaManagedAlert.Data
+		require.Len(t, data, 3)
+		require.Equal(t, "A", data[0].RefID)
+		require.Equal(t, "prom", data[0].DatasourceUID)
+		require.Equal(t, 10*time.Minute, time.Duration(data[0].RelativeTimeRange.From))
+		require.JSONEq(t, `{"refId": "A", "expr": "up == 0", "instant": true, "range": false}`, string(data[0].Model))
+		require.Equal(t, expr.DatasourceUID, data[1].DatasourceUID)
+		require.JSONEq(t, `{"refId": "B", "type": "reduce", "expression": "A", "reducer": "last"}`, string(data[1].Model))
+		require.Equal(t, expr.DatasourceUID, data[2].DatasourceUID)
+		require.JSONEq(t, `{"refId": "C", "type": "math", "expression": "is_number($B) || is_nan($B) || is_inf($B)"}`, string(data[2].Model))
+
+		require.Equal(t, "InstanceDown (2)", group.Rules[1].GrafanaManagedAlert.Title)
+		require.JSONEq(t, `{"refId": "A", "expr": "up{job=\"node\"} == 0", "instant": true, "range": false}`, string(group.Rules[1].GrafanaManagedAlert.Data[0].Model))
+	})
+
+	t.Run("converts alerting rules for Loki", func(t *testing.T) {
+		file := apimodels.PrometheusRuleFile{Groups: []apimodels.PrometheusRuleGroup{{
+			Name:  "logs",
+			Rules: []apimodels.ApiRuleNode{{Alert: "Errors", Expr: `sum(rate({app="api"} |= "error" [5m])) > 1`}},
+		}}}
+		groups, warnings, err := ConvertRuleFile(file, Options{DatasourceUID: "loki", DatasourceType: DatasourceTypeLoki})
+		require.NoError(t, err)
+		require.Empty(t, warnings)
+		require.Len(t, groups, 1)
+		query := groups[0].Rules[0].GrafanaManagedAlert.Data[0]
+		require.Equal(t, "loki", query.DatasourceUID)
+		require.JSONEq(t, `{"refId": "A", "expr": "sum(rate({app=\"api\"} |= \"error\" [5m])) > 1", "queryType": "instant"}`, string(query.Model))
+	})
+
+	t.Run("fails for unsupported data sources", func(t *testing.T) {
+		_, _, err := ConvertRuleFile(*file, Options{DatasourceUID: "graphite", DatasourceType: "graphite"})
+		require.ErrorIs(t, err, ErrUnsupportedDatasourceType)
+		_, _, err = ConvertRuleFile(*file, Options{DatasourceType: DatasourceTypePrometheus})
+		require.Error(t, err)
+	})
+
+	t.Run("fails for invalid rules", func(t *testing.T) {
+		for name, group := range map[string]apimodels.PrometheusRuleGroup{
+			"rule group name cannot be empty":          {Rules: []apimodels.ApiRuleNode{{Alert: "a", Expr: "up"}}},
+			"neither an alerting nor a recording rule": {Name: "g", Rules: []apimodels.ApiRuleNode{{Expr: "up"}}},
+			"alerting rule a has no expression":        {Name: "g", Rules: []apimodels.ApiRuleNode{{Alert: "a"}}},
+		} {
+			_, _, err := ConvertRuleFile(apimodels.PrometheusRuleFile{Groups: []apimodels.PrometheusRuleGroup{group}}, Options{DatasourceUID: "prom", DatasourceType: DatasourceTypePrometheus})
+			require.ErrorContains(t, err, name)
+		}
+
+		group := apimodels.PrometheusRuleGroup{Name: "g", Rules: []apimodels.ApiRuleNode{{Alert: "a", Expr: "up"}}}
+		_, _, err := ConvertRuleFile(apimodels.PrometheusRuleFile{Groups: []apimodels.PrometheusRuleGroup{group, group}}, Options{DatasourceUID: "prom", DatasourceType: DatasourceTypePrometheus})
+		require.ErrorContains(t, err, "rule group g is defined more than once")
+	})
+}
+
+func TestConditionExpression(t *testing.T) {
+	e, err := mathexp.New(conditionExpression)
+	require.NoError(t, err)
+
+	values := []float64{0, 1, -1, math.NaN(), math.Inf(1)}
+	reduced := mathexp.Results{}
+	for i, v := range values {
+		v := v
+		n := mathexp.NewNumber("", data.Labels{"instance": string(rune('a' + i))})
+		n.SetValue(&v)
+		reduced.Values = append(reduced.Values, n)
+	}
+
+	results, err := e.Execute("C", mathexp.Vars{"B": reduced})
+	require.NoError(t, err)
+	require.Len(t, results.Values, len(values))
+	for _, r := range results.Values {
+		v := r.(mathexp.Number).GetFloat64Value()
+		require.NotNil(t, v)
+		require.Equal(t, 1.0, *v, "every series returned by the query must fire")
+	}
+}
@@ -0,0 +1,332 @@
+package provisioning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	models2 "github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+type AlertRuleService struct {
+	defaultIntervalSeconds int64
+	ruleStore              RuleStore
+	provenanceStore        ProvisioningStore
+	xact                   TransactionManager
+	log                    log.Logger
+}
+
+func NewAlertRuleService(ruleStore RuleStore, provenanceStore ProvisioningStore, xact TransactionManager,
+	defaultIntervalSeconds int64, log log.Logger) *AlertRuleService {
+	return &AlertRuleService{
+		defaultIntervalSeconds: defaultIntervalSeconds,
+		ruleStore:              ruleStore,
+		provenanceStore:        provenanceStore,
+		xact:                   xact,
+		log:                    log,
+	}
+}
+
+// GetAlertRule returns the alert rule with the given UID together with its provenance.
+func (service *AlertRuleService) GetAlertRule(ctx context.Context, orgID int64, ruleUID string) (models.AlertRule, models.Provenance, error) {
+	rule, err := service.getAlertRule(ctx, orgID, ruleUID)
+	if err != nil {
+		return models.AlertRule{}, models.ProvenanceNone, err
+	}
+	provenance, err := service.provenanceStore.GetProvenance(ctx, rule, orgID)
+	if err != nil {
+		return models.AlertRule{}, models.ProvenanceNone, err
+	}
+	return *rule, provenance, nil
+}
+
+// CreateAlertRule creates a new alert rule. The rule joins the interval of its group, or the default interval if the group does not exist yet.
+func (service *AlertRuleService) CreateAlertRule(ctx context.Context, rule models.AlertRule, provenance models.Provenance) (models.AlertRule, error) {
+	if rule.UID == "" {
+		rule.UID = util.GenerateShortUID()
+	}
+	if err := service.validateRule(ctx, rule); err != nil {
+		return models.AlertRule{}, err
+	}
+	interval, err := service.getRuleGroupInterval(ctx, rule.OrgID, rule.NamespaceUID, rule.RuleGroup)
+	if err != nil {
+		return models.AlertRule{}, err
+	}
+	rule.IntervalSeconds = interval
+	err = service.xact.InTransaction(ctx, func(ctx context.Context) error {
+		err := service.ruleStore.InsertAlertRules(ctx, []models.AlertRule{rule})
+		if err != nil {
+			return err
+		}
+		return service.provenanceStore.SetProvenance(ctx, &rule, rule.OrgID, provenance)
+	})
+	if err != nil {
+		return models.AlertRule{}, err
+	}
+	return rule, nil
+}
+
+// UpdateAlertRule replaces the alert rule with the same UID. The provenance of a rule can only be changed if it has none.
+func (service *AlertRuleService) UpdateAlertRule(ctx context.Context, rule models.AlertRule, provenance models.Provenance) (models.AlertRule, error) {
+	existing, err := service.getAlertRule(ctx, rule.OrgID, rule.UID)
+	if err != nil {
+		return models.AlertRule{}, err
+	}
+	if err := service.checkProvenance(ctx, existing, provenance); err != nil {
+		return models.AlertRule{}, err
+	}
+	if err := service.validateRule(ctx, rule); err != nil {
+		return models.AlertRule{}, err
+	}
+	rule.IntervalSeconds = existing.IntervalSeconds
+	if rule.NamespaceUID != existing.NamespaceUID || rule.RuleGroup != existing.RuleGroup {
+		rule.IntervalSeconds, err = service.getRuleGroupInterval(ctx, rule.OrgID, rule.NamespaceUID, rule.RuleGroup)
+		if err != nil {
+			return models.AlertRule{}, err
+		}
+	}
+	err = service.xact.InTransaction(ctx, func(ctx context.Context) error {
+		err := service.ruleStore.UpdateAlertRules(ctx, []store.UpdateRule{
+			{
+				Existing: existing,
+				New:      rule,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		return service.provenanceStore.SetProvenance(ctx, &rule, rule.OrgID, provenance)
+	})
+	if err != nil {
+		return models.AlertRule{}, err
+	}
+	return rule, nil
+}
+
+// DeleteAlertRule deletes the alert rule with the given UID. Rules provisioned through a different mechanism cannot be deleted.
+func (service *AlertRuleService) DeleteAlertRule(ctx context.Context, orgID int64, ruleUID string, provenance models.Provenance) error {
+	rule, err := service.getAlertRule(ctx, orgID, ruleUID)
+	if err != nil {
+		return err
+	}
+	if err := service.checkProvenance(ctx, rule, provenance); err != nil {
+		return err
+	}
+	return service.xact.InTransaction(ctx, func(ctx context.Context) error {
+		err := service.ruleStore.DeleteAlertRulesByUID(ctx, orgID, ruleUID)
+		if err != nil {
+			return err
+		}
+		return service.provenanceStore.DeleteProvenance(ctx, rule, orgID)
+	})
+}
+
+// GetRuleGroup returns the rules of the group in the given folder together with their provenances, by rule UID.
+func (service *AlertRuleService) GetRuleGroup(ctx context.Context, orgID int64, folderUID, title string) (models.AlertRuleGroup, map[string]models.Provenance, error) {
+	rules, err := service.listRuleGroup(ctx, orgID, folderUID, title)
+	if err != nil {
+		return models.AlertRuleGroup{}, nil, err
+	}
+	if len(rules) == 0 {
+		return models.AlertRuleGroup{}, nil, models.ErrRuleGroupNamespaceNotFound
+	}
+	provenances, err := service.provenanceStore.GetProvenances(ctx, orgID, (&models.AlertRule{}).ResourceType())
+	if err != nil {
+		return models.AlertRuleGroup{}, nil, err
+	}
+	group := models.AlertRuleGroup{
+		Title:     title,
+		FolderUID: folderUID,
+		Interval:  rules[0].IntervalSeconds,
+		Rules:     make([]models.AlertRule, 0, len(rules)),
+	}
+	groupProvenances := make(map[string]models.Provenance, len(rules))
+	for _, rule := range rules {
+		group.Rules = append(group.Rules, *rule)
+		groupProvenances[rule.UID] = models.ProvenanceNone
+		if provenance, ok := provenances[rule.ResourceID()]; ok {
+			groupProvenances[rule.UID] = provenance
+		}
+	}
+	return group, groupProvenances, nil
+}
+
+// ReplaceRuleGroup makes the group contain exactly the given rules. Rules with a known UID are updated,
+// the others are created, and rules of the group that are not part of the request are deleted.
+func (service *AlertRuleService) ReplaceRuleGroup(ctx context.Context, orgID int64, group models.AlertRuleGroup, provenance models.Provenance) error {
+	if group.Interval <= 0 {
+		return fmt.Errorf("%w: interval of the rule group should be positive", ErrValidation)
+	}
+	if err := service.checkFolder(ctx, orgID, group.FolderUID); err != nil {
+		return err
+	}
+	existingRules, err := service.listRuleGroup(ctx, orgID, group.FolderUID, group.Title)
+	if err != nil {
+		return err
+	}
+	toDelete := make(map[string]*models.AlertRule, len(existingRules))
+	for _, rule := range existingRules {
+		toDelete[rule.UID] = rule
+	}
+
+	var toInsert []models.AlertRule
+	var toUpdate []store.UpdateRule
+	for _, rule := range group.Rules {
+		rule.OrgID = orgID
+		rule.NamespaceUID = group.FolderUID
+		rule.RuleGroup = group.Title
+		rule.IntervalSeconds = group.Interval
+		if err := validateCondition(rule); err != nil {
+			return err
+		}
+
+		existing, ok := toDelete[rule.UID]
+		if ok {
+			delete(toDelete, rule.UID)
+		} else if rule.UID != "" {
+			// the rule might be moved from another group
+			existing, err = service.getAlertRule(ctx, orgID, rule.UID)
+			if err != nil && !errors.Is(err, models.ErrAlertRuleNotFound) {
+				return err
+			}
+		}
+
+		if existing == nil {
+			if rule.UID == "" {
+				rule.UID = util.GenerateShortUID()
+			}
+			toInsert = append(toInsert, rule)
+			continue
+		}
+		if err := service.checkProvenance(ctx, existing, provenance); err != nil {
+			return err
+		}
+		toUpdate = append(toUpdate, store.UpdateRule{
+			Existing: existing,
+			New:      rule,
+		})
+	}
+
+	deleteUIDs := make([]string, 0, len(toDelete))
+	for uid, rule := range toDelete {
+		if err := service.checkProvenance(ctx, rule, provenance); err != nil {
+			return err
+		}
+		deleteUIDs = append(deleteUIDs, uid)
+	}
+
+	return service.xact.InTransaction(ctx, func(ctx context.Context) error {
+		if len(deleteUIDs) > 0 {
+			if err := service.ruleStore.DeleteAlertRulesByUID(ctx, orgID, deleteUIDs...); err != nil {
+				return err
+			}
+			for _, rule := range toDelete {
+				if err := service.provenanceStore.DeleteProvenance(ctx, rule, orgID); err != nil {
+					return err
+				}
+			}
+		}
+		if len(toUpdate) > 0 {
+			if err := service.ruleStore.UpdateAlertRules(ctx, toUpdate); err != nil {
+				return err
+			}
+			for i := range toUpdate {
+				if err := service.provenanceStore.SetProvenance(ctx, &toUpdate[i].New, orgID, provenance); err != nil {
+					return err
+				}
+			}
+		}
+		if len(toInsert) > 0 {
+			if err := service.ruleStore.InsertAlertRules(ctx, toInsert); err != nil {
+				return err
+			}
+			for i := range toInsert {
+				if err := service.provenanceStore.SetProvenance(ctx, &toInsert[i], orgID, provenance); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func (service *AlertRuleService) getAlertRule(ctx context.Context, orgID int64, ruleUID string) (*models.AlertRule, error) {
+	query := &models.GetAlertRuleByUIDQuery{
+		OrgID: orgID,
+		UID:   ruleUID,
+	}
+	if err := service.ruleStore.GetAlertRuleByUID(ctx, query); err != nil {
+		return nil, err
+	}
+	if query.Result == nil {
+		return nil, models.ErrAlertRuleNotFound
+	}
+	return query.Result, nil
+}
+
+func (service *AlertRuleService) listRuleGroup(ctx context.Context, orgID int64, folderUID, title string) ([]*models.AlertRule, error) {
+	query := &models.ListAlertRulesQuery{
+		OrgID:         orgID,
+		NamespaceUIDs: []string{folderUID},
+		RuleGroup:     title,
+	}
+	if err := service.ruleStore.ListAlertRules(ctx, query); err != nil {
+		return nil, err
+	}
+	return query.Result, nil
+}
+
+// getRuleGroupInterval returns the interval shared by the rules of the group, or the default interval for a new group.
+func (service *AlertRuleService) getRuleGroupInterval(ctx context.Context, orgID int64, folderUID, title string) (int64, error) {
+	rules, err := service.listRuleGroup(ctx, orgID, folderUID, title)
+	if err != nil {
+		return 0, err
+	}
+	if len(rules) == 0 {
+		return service.defaultIntervalSeconds, nil
+	}
+	return rules[0].IntervalSeconds, nil
+}
+
+// validateRule verifies that the folder of the rule exists and that its condition refers to one of its queries.
+func (service *AlertRuleService) validateRule(ctx context.Context, rule models.AlertRule) error {
+	if err := service.checkFolder(ctx, rule.OrgID, rule.NamespaceUID); err != nil {
+		return err
+	}
+	return validateCondition(rule)
+}
+
+func (service *AlertRuleService) checkFolder(ctx context.Context, orgID int64, folderUID string) error {
+	if _, err := service.ruleStore.GetNamespaceByUID(ctx, folderUID, orgID); err != nil {
+		if errors.Is(err, models2.ErrFolderNotFound) {
+			return fmt.Errorf("%w: folder %q does not exist", ErrValidation, folderUID)
+		}
+		return err
+	}
+	return nil
+}
+
+func validateCondition(rule models.AlertRule) error {
+	for _, query := range rule.Data {
+		if query.RefID == rule.Condition {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: condition %q of alert rule '%s' is not the refId of any of its queries", ErrValidation, rule.Condition, rule.Title)
+}
+
+// checkProvenance verifies that a rule with the stored provenance may be modified by a request with the given provenance.
+func (service *AlertRuleService) checkProvenance(ctx context.Context, rule *models.AlertRule, provenance models.Provenance) error {
+	storedProvenance, err := service.provenanceStore.GetProvenance(ctx, rule, rule.OrgID)
+	if err != nil {
+		return err
+	}
+	if storedProvenance != provenance && storedProvenance != models.ProvenanceNone {
+		return fmt.Errorf("%w: cannot change provenance of alert rule '%s' from '%s' to '%s'", ErrValidation, rule.UID, storedProvenance, provenance)
+	}
+	return nil
+}
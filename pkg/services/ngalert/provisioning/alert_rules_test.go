@@ -0,0 +1,271 @@
+package provisioning
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	models2 "github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertRuleService(t *testing.T) {
+	t.Run("creating a rule assigns a UID and the default interval", func(t *testing.T) {
+		sut, ruleStore := createAlertRuleServiceSut(t)
+		rule := dummyRule("", "folder", "group")
+
+		created, err := sut.CreateAlertRule(context.Background(), rule, models.ProvenanceAPI)
+
+		require.NoError(t, err)
+		require.NotEmpty(t, created.UID)
+		require.Equal(t, int64(60), created.IntervalSeconds)
+		require.Len(t, ruleStore.RecordedOps, 2)
+		inserted, ok := ruleStore.RecordedOps[1].([]models.AlertRule)
+		require.True(t, ok)
+		require.Equal(t, created.UID, inserted[0].UID)
+		provenance, err := sut.provenanceStore.GetProvenance(context.Background(), &created, 1)
+		require.NoError(t, err)
+		require.Equal(t, models.ProvenanceAPI, provenance)
+	})
+
+	t.Run("creating a rule in an existing group uses the group interval", func(t *testing.T) {
+		sut, ruleStore := createAlertRuleServiceSut(t)
+		existing := dummyRule("existing", "folder", "group")
+		existing.IntervalSeconds = 120
+		ruleStore.PutRule(context.Background(), &existing)
+
+		created, err := sut.CreateAlertRule(context.Background(), dummyRule("", "folder", "group"), models.ProvenanceAPI)
+
+		require.NoError(t, err)
+		require.Equal(t, int64(120), created.IntervalSeconds)
+	})
+
+	t.Run("creating a rule in an unknown folder fails", func(t *testing.T) {
+		sut, ruleStore := createAlertRuleServiceSut(t)
+
+		_, err := sut.CreateAlertRule(context.Background(), dummyRule("", "unknown", "group"), models.ProvenanceAPI)
+
+		require.ErrorIs(t, err, ErrValidation)
+		require.Empty(t, ruleStore.Rules[1])
+	})
+
+	t.Run("creating a rule with a condition that is not a query fails", func(t *testing.T) {
+		sut, ruleStore := createAlertRuleServiceSut(t)
+		rule := dummyRule("", "folder", "group")
+		rule.Condition = "B"
+
+		_, err := sut.CreateAlertRule(context.Background(), rule, models.ProvenanceAPI)
+
+		require.ErrorIs(t, err, ErrValidation)
+		require.Empty(t, ruleStore.Rules[1])
+	})
+
+	t.Run("moving a rule to an unknown folder fails", func(t *testing.T) {
+		sut, ruleStore := createAlertRuleServiceSut(t)
+		rule := dummyRule("uid", "folder", "group")
+		ruleStore.PutRule(context.Background(), &rule)
+		moved := dummyRule("uid", "unknown", "group")
+
+		_, err := sut.UpdateAlertRule(context.Background(), moved, models.ProvenanceAPI)
+
+		require.ErrorIs(t, err, ErrValidation)
+		require.Equal(t, "folder", ruleStore.Rules[1][0].NamespaceUID)
+	})
+
+	t.Run("getting an unknown rule returns not found", func(t *testing.T) {
+		sut, _ := createAlertRuleServiceSut(t)
+
+		_, _, err := sut.GetAlertRule(context.Background(), 1, "unknown")
+
+		require.ErrorIs(t, err, models.ErrAlertRuleNotFound)
+	})
+
+	t.Run("rule provenance", func(t *testing.T) {
+		t.Run("can be changed when it is none", func(t *testing.T) {
+			sut, ruleStore := createAlertRuleServiceSut(t)
+			rule := dummyRule("uid", "folder", "group")
+			ruleStore.PutRule(context.Background(), &rule)
+
+			_, err := sut.UpdateAlertRule(context.Background(), rule, models.ProvenanceAPI)
+
+			require.NoError(t, err)
+			_, provenance, err := sut.GetAlertRule(context.Background(), 1, "uid")
+			require.NoError(t, err)
+			require.Equal(t, models.ProvenanceAPI, provenance)
+		})
+
+		t.Run("prevents updates from a different source", func(t *testing.T) {
+			sut, ruleStore := createAlertRuleServiceSut(t)
+			rule := dummyRule("uid", "folder", "group")
+			ruleStore.PutRule(context.Background(), &rule)
+			require.NoError(t, sut.provenanceStore.SetProvenance(context.Background(), &rule, 1, models.ProvenanceFile))
+
+			_, err := sut.UpdateAlertRule(context.Background(), rule, models.ProvenanceAPI)
+
+			require.ErrorIs(t, err, ErrValidation)
+		})
+
+		t.Run("prevents deletes from a different source", func(t *testing.T) {
+			sut, ruleStore := createAlertRuleServiceSut(t)
+			rule := dummyRule("uid", "folder", "group")
+			ruleStore.PutRule(context.Background(), &rule)
+			require.NoError(t, sut.provenanceStore.SetProvenance(context.Background(), &rule, 1, models.ProvenanceFile))
+
+			err := sut.DeleteAlertRule(context.Background(), 1, "uid", models.ProvenanceAPI)
+
+			require.ErrorIs(t, err, ErrValidation)
+			require.Len(t, ruleStore.Rules[1], 1)
+		})
+	})
+
+	t.Run("deleting a rule removes it and its provenance", func(t *testing.T) {
+		sut, ruleStore := createAlertRuleServiceSut(t)
+		rule := dummyRule("uid", "folder", "group")
+		ruleStore.PutRule(context.Background(), &rule)
+		require.NoError(t, sut.provenanceStore.SetProvenance(context.Background(), &rule, 1, models.ProvenanceAPI))
+
+		err := sut.DeleteAlertRule(context.Background(), 1, "uid", models.ProvenanceAPI)
+
+		require.NoError(t, err)
+		require.Empty(t, ruleStore.Rules[1])
+		provenance, err := sut.provenanceStore.GetProvenance(context.Background(), &rule, 1)
+		require.NoError(t, err)
+		require.Equal(t, models.ProvenanceNone, provenance)
+	})
+
+	t.Run("getting an empty rule group returns not found", func(t *testing.T) {
+		sut, _ := createAlertRuleServiceSut(t)
+
+		_, _, err := sut.GetRuleGroup(context.Background(), 1, "folder", "group")
+
+		require.ErrorIs(t, err, models.ErrRuleGroupNamespaceNotFound)
+	})
+
+	t.Run("getting a rule group returns the provenances of its rules", func(t *testing.T) {
+		sut, ruleStore := createAlertRuleServiceSut(t)
+		provisioned := dummyRule("provisioned", "folder", "group")
+		other := dummyRule("other", "folder", "group")
+		ruleStore.PutRule(context.Background(), &provisioned, &other)
+		require.NoError(t, sut.provenanceStore.SetProvenance(context.Background(), &provisioned, 1, models.ProvenanceFile))
+
+		group, provenances, err := sut.GetRuleGroup(context.Background(), 1, "folder", "group")
+
+		require.NoError(t, err)
+		require.Len(t, group.Rules, 2)
+		require.Equal(t, map[string]models.Provenance{
+			"provisioned": models.ProvenanceFile,
+			"other":       models.ProvenanceNone,
+		}, provenances)
+	})
+
+	t.Run("replacing a rule group", func(t *testing.T) {
+		t.Run("inserts, updates and deletes rules", func(t *testing.T) {
+			sut, ruleStore := createAlertRuleServiceSut(t)
+			kept := dummyRule("kept", "folder", "group")
+			removed := dummyRule("removed", "folder", "group")
+			ruleStore.PutRule(context.Background(), &kept, &removed)
+
+			err := sut.ReplaceRuleGroup(context.Background(), 1, models.AlertRuleGroup{
+				Title:     "group",
+				FolderUID: "folder",
+				Interval:  30,
+				Rules:     []models.AlertRule{kept, dummyRule("", "", "")},
+			}, models.ProvenanceAPI)
+
+			require.NoError(t, err)
+			var deleted []string
+			var updated []store.UpdateRule
+			var inserted []models.AlertRule
+			for _, op := range ruleStore.RecordedOps {
+				switch q := op.(type) {
+				case store.GenericRecordedQuery:
+					deleted = q.Params[1].([]string)
+				case []store.UpdateRule:
+					updated = q
+				case []models.AlertRule:
+					inserted = q
+				}
+			}
+			require.Equal(t, []string{"removed"}, deleted)
+			require.Len(t, updated, 1)
+			require.Equal(t, "kept", updated[0].New.UID)
+			require.Equal(t, int64(30), updated[0].New.IntervalSeconds)
+			require.Len(t, inserted, 1)
+			require.NotEmpty(t, inserted[0].UID)
+			require.Equal(t, "folder", inserted[0].NamespaceUID)
+			require.Equal(t, "group", inserted[0].RuleGroup)
+			require.Equal(t, int64(30), inserted[0].IntervalSeconds)
+		})
+
+		t.Run("rejects a non-positive interval", func(t *testing.T) {
+			sut, _ := createAlertRuleServiceSut(t)
+
+			err := sut.ReplaceRuleGroup(context.Background(), 1, models.AlertRuleGroup{
+				Title:     "group",
+				FolderUID: "folder",
+			}, models.ProvenanceAPI)
+
+			require.ErrorIs(t, err, ErrValidation)
+		})
+
+		t.Run("rejects an unknown folder", func(t *testing.T) {
+			sut, ruleStore := createAlertRuleServiceSut(t)
+
+			err := sut.ReplaceRuleGroup(context.Background(), 1, models.AlertRuleGroup{
+				Title:     "group",
+				FolderUID: "unknown",
+				Interval:  30,
+				Rules:     []models.AlertRule{dummyRule("", "", "")},
+			}, models.ProvenanceAPI)
+
+			require.ErrorIs(t, err, ErrValidation)
+			require.Empty(t, ruleStore.Rules[1])
+		})
+
+		t.Run("prevents deleting rules provisioned from a different source", func(t *testing.T) {
+			sut, ruleStore := createAlertRuleServiceSut(t)
+			rule := dummyRule("uid", "folder", "group")
+			ruleStore.PutRule(context.Background(), &rule)
+			require.NoError(t, sut.provenanceStore.SetProvenance(context.Background(), &rule, 1, models.ProvenanceFile))
+
+			err := sut.ReplaceRuleGroup(context.Background(), 1, models.AlertRuleGroup{
+				Title:     "group",
+				FolderUID: "folder",
+				Interval:  30,
+			}, models.ProvenanceAPI)
+
+			require.ErrorIs(t, err, ErrValidation)
+			require.Len(t, ruleStore.Rules[1], 1)
+		})
+	})
+}
+
+func createAlertRuleServiceSut(t *testing.T) (AlertRuleService, *store.FakeRuleStore) {
+	ruleStore := store.NewFakeRuleStore(t)
+	ruleStore.Folders[1] = []*models2.Folder{{Uid: "folder", Title: "Folder"}}
+	return AlertRuleService{
+		defaultIntervalSeconds: 60,
+		ruleStore:              ruleStore,
+		provenanceStore:        NewFakeProvisioningStore(),
+		xact:                   newNopTransactionManager(),
+		log:                    log.NewNopLogger(),
+	}, ruleStore
+}
+
+func dummyRule(uid, folderUID, group string) models.AlertRule {
+	query := models.GenerateAlertQuery()
+	query.RefID = "A"
+	return models.AlertRule{
+		OrgID:        1,
+		UID:          uid,
+		NamespaceUID: folderUID,
+		RuleGroup:    group,
+		Title:        "rule " + uid,
+		Condition:    "A",
+		Data:         []models.AlertQuery{query},
+		NoDataState:  models.NoData,
+		ExecErrState: models.AlertingErrState,
+	}
+}
@@ -3,7 +3,9 @@ package provisioning
 import (
 	"context"
 
+	models2 "github.com/grafana/grafana/pkg/models"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
 )
 
 // AMStore is a store of Alertmanager configurations.
@@ -22,6 +24,16 @@ type ProvisioningStore interface {
 	DeleteProvenance(ctx context.Context, o models.Provisionable, org int64) error
 }
 
+// RuleStore represents the ability to persist and query alert rules.
+type RuleStore interface {
+	GetAlertRuleByUID(ctx context.Context, query *models.GetAlertRuleByUIDQuery) error
+	ListAlertRules(ctx context.Context, query *models.ListAlertRulesQuery) error
+	InsertAlertRules(ctx context.Context, rules []models.AlertRule) error
+	UpdateAlertRules(ctx context.Context, rules []store.UpdateRule) error
+	DeleteAlertRulesByUID(ctx context.Context, orgID int64, ruleUID ...string) error
+	GetNamespaceByUID(ctx context.Context, uid string, orgID int64) (*models2.Folder, error)
+}
+
 // TransactionManager represents the ability to issue and close transactions through contexts.
 type TransactionManager interface {
 	InTransaction(ctx context.Context, work func(ctx context.Context) error) error
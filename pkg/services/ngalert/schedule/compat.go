@@ -43,6 +43,10 @@ func stateToPostableAlert(alertState *state.State, appURL *url.URL) *models.Post
 		nA[ngModels.ScreenshotTokenAnnotation] = alertState.Image.Token
 	}
 
+	if alertState.Acknowledgement.IsActive(time.Now()) {
+		nA[ngModels.AcknowledgedUntilAnnotation] = alertState.Acknowledgement.Until.UTC().Format(time.RFC3339)
+	}
+
 	var urlStr string
 	if uid := nL[ngModels.RuleUIDLabel]; len(uid) > 0 && appURL != nil {
 		u := *appURL
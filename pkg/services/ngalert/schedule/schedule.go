@@ -640,6 +640,7 @@ func (sch *schedule) saveAlertStates(ctx context.Context, states []*state.State)
 			LastEvalTime:      s.LastEvaluationTime,
 			CurrentStateSince: s.StartsAt,
 			CurrentStateEnd:   s.EndsAt,
+			Acknowledgement:   s.Acknowledgement,
 		}
 		err := sch.instanceStore.SaveAlertInstance(ctx, &cmd)
 		if err != nil {
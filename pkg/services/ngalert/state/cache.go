@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 
@@ -71,9 +72,10 @@ func (c *cache) getOrCreate(ctx context.Context, alertRule *ngModels.AlertRule,
 				}
 			}
 		}
-		state.Annotations = annotations
-		c.states[alertRule.OrgID][alertRule.UID][id] = state
-		return state
+		// return a copy, so the caller can change it without holding the lock, and store it with setEvaluated
+		next := *state
+		next.Annotations = annotations
+		return &next
 	}
 
 	// If the first result we get is alerting, set StartsAt to EvaluatedAt because we
@@ -89,7 +91,6 @@ func (c *cache) getOrCreate(ctx context.Context, alertRule *ngModels.AlertRule,
 	if result.State == eval.Alerting {
 		newState.StartsAt = result.EvaluatedAt
 	}
-	c.states[alertRule.OrgID][alertRule.UID][id] = newState
 	return newState
 }
 
@@ -120,6 +121,41 @@ func (c *cache) expandRuleLabelsAndAnnotations(ctx context.Context, alertRule *n
 func (c *cache) set(entry *State) {
 	c.mtxStates.Lock()
 	defer c.mtxStates.Unlock()
+	c.store(entry)
+}
+
+// setEvaluated stores the state of an alert instance after an evaluation. The acknowledgement is taken from the
+// cached state, as it can be changed while the rule is evaluated, and only lasts as long as the instance fires.
+func (c *cache) setEvaluated(entry *State, evaluatedAt time.Time) {
+	c.mtxStates.Lock()
+	defer c.mtxStates.Unlock()
+	entry.Acknowledgement = nil
+	if cached, ok := c.states[entry.OrgID][entry.AlertRuleUID][entry.CacheId]; ok {
+		entry.Acknowledgement = cached.Acknowledgement
+	}
+	if entry.State == eval.Normal || !entry.Acknowledgement.IsActive(evaluatedAt) {
+		entry.Acknowledgement = nil
+	}
+	c.store(entry)
+}
+
+// setAcknowledgement sets or, if ack is nil, clears the acknowledgement of a cached state. The cached state is
+// replaced by a copy rather than changed, since it can be read by others without holding the lock.
+func (c *cache) setAcknowledgement(orgID int64, alertRuleUID, cacheID string, ack *ngModels.AlertInstanceAcknowledgement) (*State, error) {
+	c.mtxStates.Lock()
+	defer c.mtxStates.Unlock()
+	state, ok := c.states[orgID][alertRuleUID][cacheID]
+	if !ok {
+		return nil, ErrStateNotFound
+	}
+	next := *state
+	next.Acknowledgement = ack
+	c.states[orgID][alertRuleUID][cacheID] = &next
+	return &next, nil
+}
+
+// store must be called with the lock held.
+func (c *cache) store(entry *State) {
 	if _, ok := c.states[entry.OrgID]; !ok {
 		c.states[entry.OrgID] = make(map[string]map[string]*State)
 	}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/benbjohnson/clock"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 
 	"github.com/grafana/grafana/pkg/infra/log"
@@ -26,10 +27,17 @@ import (
 
 var ResendDelay = 30 * time.Second
 
+var (
+	ErrStateNotFound  = errors.New("alert instance not found")
+	ErrStateNotFiring = errors.New("alert instance is not firing")
+)
+
 // AlertInstanceManager defines the interface for querying the current alert instances.
 type AlertInstanceManager interface {
 	GetAll(orgID int64) []*State
 	GetStatesForRuleUID(orgID int64, alertRuleUID string) []*State
+	Acknowledge(ctx context.Context, orgID int64, alertRuleUID string, labels data.Labels, ack ngModels.AlertInstanceAcknowledgement) (*State, error)
+	Unacknowledge(ctx context.Context, orgID int64, alertRuleUID string, labels data.Labels) (*State, error)
 }
 
 type Manager struct {
@@ -39,6 +47,9 @@ type Manager struct {
 	cache       *cache
 	quit        chan struct{}
 	ResendDelay time.Duration
+	clock       clock.Clock
+	// ephemeral is true for the managers that only keep the states in memory, see NewEphemeralManager.
+	ephemeral bool
 
 	ruleStore        store.RuleStore
 	instanceStore    store.InstanceStore
@@ -54,6 +65,7 @@ func NewManager(logger log.Logger, metrics *metrics.State, externalURL *url.URL,
 		cache:            newCache(logger, metrics, externalURL),
 		quit:             make(chan struct{}),
 		ResendDelay:      ResendDelay, // TODO: make this configurable
+		clock:            clock.New(),
 		log:              logger,
 		metrics:          metrics,
 		ruleStore:        ruleStore,
@@ -66,6 +78,21 @@ func NewManager(logger log.Logger, metrics *metrics.State, externalURL *url.URL,
 	return manager
 }
 
+// NewEphemeralManager returns a Manager that only keeps the state of the alert instances in memory, and uses the clock
+// to tell whether states are stale. Unlike the managers returned by NewManager, it does not save the alert instances,
+// annotate the changes of state, take screenshots or record metrics, and it does not need to be closed.
+func NewEphemeralManager(logger log.Logger, clk clock.Clock) *Manager {
+	return &Manager{
+		cache:        newCache(logger, nil, nil),
+		quit:         make(chan struct{}),
+		ResendDelay:  ResendDelay,
+		clock:        clk,
+		log:          logger,
+		imageService: &image.NotAvailableImageService{},
+		ephemeral:    true,
+	}
+}
+
 func (st *Manager) Close() {
 	st.quit <- struct{}{}
 }
@@ -126,6 +153,7 @@ func (st *Manager) Warm(ctx context.Context) {
 				EndsAt:               entry.CurrentStateEnd,
 				LastEvaluationTime:   entry.LastEvalTime,
 				Annotations:          ruleForEntry.Annotations,
+				Acknowledgement:      entry.Acknowledgement(),
 			}
 			states = append(states, stateForEntry)
 		}
@@ -253,10 +281,10 @@ func (st *Manager) setNextState(ctx context.Context, alertRule *ngModels.AlertRu
 			"panel", alertRule.PanelID)
 	}
 
-	st.set(currentState)
+	st.cache.setEvaluated(currentState, result.EvaluatedAt)
 
 	shouldUpdateAnnotation := oldState != currentState.State || oldReason != currentState.StateReason
-	if shouldUpdateAnnotation {
+	if shouldUpdateAnnotation && !st.ephemeral {
 		go st.annotateState(ctx, alertRule, currentState.Labels, result.EvaluatedAt, InstanceStateAndReason{State: currentState.State, Reason: currentState.StateReason}, InstanceStateAndReason{State: oldState, Reason: oldReason})
 	}
 	return currentState
@@ -270,6 +298,51 @@ func (st *Manager) GetStatesForRuleUID(orgID int64, alertRuleUID string) []*Stat
 	return st.cache.getStatesForRuleUID(orgID, alertRuleUID)
 }
 
+// Acknowledge acknowledges the firing alert instance of the rule with the given labels, which suppresses its
+// notifications until the acknowledgement expires or the instance resolves.
+func (st *Manager) Acknowledge(ctx context.Context, orgID int64, alertRuleUID string, labels data.Labels, ack ngModels.AlertInstanceAcknowledgement) (*State, error) {
+	s, err := st.findStateByLabels(orgID, alertRuleUID, labels)
+	if err != nil {
+		return nil, err
+	}
+	if s.State != eval.Alerting && s.State != eval.NoData && s.State != eval.Error {
+		return nil, ErrStateNotFiring
+	}
+	return st.setAcknowledgement(ctx, s, &ack)
+}
+
+// Unacknowledge removes the acknowledgement of the alert instance of the rule with the given labels.
+func (st *Manager) Unacknowledge(ctx context.Context, orgID int64, alertRuleUID string, labels data.Labels) (*State, error) {
+	s, err := st.findStateByLabels(orgID, alertRuleUID, labels)
+	if err != nil {
+		return nil, err
+	}
+	return st.setAcknowledgement(ctx, s, nil)
+}
+
+func (st *Manager) setAcknowledgement(ctx context.Context, s *State, ack *ngModels.AlertInstanceAcknowledgement) (*State, error) {
+	ilbs := ngModels.InstanceLabels(s.Labels)
+	_, labelsHash, err := ilbs.StringAndHash()
+	if err != nil {
+		return nil, err
+	}
+	if err := st.instanceStore.SetAlertInstanceAcknowledgement(ctx, s.OrgID, s.AlertRuleUID, labelsHash, ack); err != nil {
+		return nil, fmt.Errorf("failed to save the acknowledgement: %w", err)
+	}
+	return st.cache.setAcknowledgement(s.OrgID, s.AlertRuleUID, s.CacheId, ack)
+}
+
+// findStateByLabels returns the state of the rule whose labels, without the private ones, are the given labels.
+func (st *Manager) findStateByLabels(orgID int64, alertRuleUID string, labels data.Labels) (*State, error) {
+	want := removePrivateLabels(labels)
+	for _, s := range st.GetStatesForRuleUID(orgID, alertRuleUID) {
+		if removePrivateLabels(s.Labels).String() == want.String() {
+			return s, nil
+		}
+	}
+	return nil, ErrStateNotFound
+}
+
 func (st *Manager) recordMetrics() {
 	// TODO: parameterize?
 	// Setting to a reasonable default scrape interval for Prometheus.
@@ -371,9 +444,12 @@ func (st *Manager) staleResultsHandler(ctx context.Context, alertRule *ngModels.
 	allStates := st.GetStatesForRuleUID(alertRule.OrgID, alertRule.UID)
 	for _, s := range allStates {
 		_, ok := states[s.CacheId]
-		if !ok && isItStale(s.LastEvaluationTime, alertRule.IntervalSeconds) {
+		if !ok && isItStale(st.clock.Now(), s.LastEvaluationTime, alertRule.IntervalSeconds) {
 			st.log.Debug("removing stale state entry", "orgID", s.OrgID, "alertRuleUID", s.AlertRuleUID, "cacheID", s.CacheId)
 			st.cache.deleteEntry(s.OrgID, s.AlertRuleUID, s.CacheId)
+			if st.ephemeral {
+				continue
+			}
 			ilbs := ngModels.InstanceLabels(s.Labels)
 			_, labelsHash, err := ilbs.StringAndHash()
 			if err != nil {
@@ -385,7 +461,7 @@ func (st *Manager) staleResultsHandler(ctx context.Context, alertRule *ngModels.
 			}
 
 			if s.State == eval.Alerting {
-				st.annotateState(ctx, alertRule, s.Labels, time.Now(),
+				st.annotateState(ctx, alertRule, s.Labels, st.clock.Now(),
 					InstanceStateAndReason{State: eval.Normal, Reason: ""},
 					InstanceStateAndReason{State: s.State, Reason: s.StateReason})
 			}
@@ -393,8 +469,8 @@ func (st *Manager) staleResultsHandler(ctx context.Context, alertRule *ngModels.
 	}
 }
 
-func isItStale(lastEval time.Time, intervalSeconds int64) bool {
-	return lastEval.Add(2 * time.Duration(intervalSeconds) * time.Second).Before(time.Now())
+func isItStale(now time.Time, lastEval time.Time, intervalSeconds int64) bool {
+	return lastEval.Add(2 * time.Duration(intervalSeconds) * time.Second).Before(now)
 }
 
 func removePrivateLabels(labels data.Labels) data.Labels {
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"sync"
 	"testing"
 	"time"
 
@@ -1920,3 +1921,157 @@ func TestStaleResultsHandler(t *testing.T) {
 		assert.Equal(t, tc.finalStateCount, len(existingStatesForRule))
 	}
 }
+
+func TestAcknowledge(t *testing.T) {
+	evaluationTime, err := time.Parse("2006-01-02", "2021-03-25")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, 1)
+
+	const mainOrgID int64 = 1
+	rule := tests.CreateTestAlertRule(t, ctx, dbstore, 60, mainOrgID)
+
+	newManager := func() *state.Manager {
+		return state.NewManager(log.New("test_acknowledge"), testMetrics.GetStateMetrics(), nil, dbstore, dbstore, mockstore.NewSQLStoreMock(), &dashboards.FakeDashboardService{}, &image.NoopImageService{})
+	}
+	process := func(st *state.Manager, at time.Time, firing eval.State) {
+		states := st.ProcessEvalResults(ctx, rule, eval.Results{
+			{Instance: data.Labels{"instance": "a"}, State: firing, EvaluatedAt: at},
+			{Instance: data.Labels{"instance": "b"}, State: eval.Normal, EvaluatedAt: at},
+		})
+		for _, s := range states {
+			require.NoError(t, dbstore.SaveAlertInstance(ctx, &models.SaveAlertInstanceCommand{
+				RuleOrgID:         s.OrgID,
+				RuleUID:           s.AlertRuleUID,
+				Labels:            models.InstanceLabels(s.Labels),
+				State:             models.InstanceStateType(s.State.String()),
+				LastEvalTime:      s.LastEvaluationTime,
+				CurrentStateSince: s.StartsAt,
+				CurrentStateEnd:   s.EndsAt,
+				Acknowledgement:   s.Acknowledgement,
+			}))
+		}
+	}
+	labels := func(instance string) data.Labels {
+		return data.Labels{"alertname": rule.Title, "instance": instance}
+	}
+	ack := models.AlertInstanceAcknowledgement{
+		UserID:  1,
+		Comment: "looking into it",
+		At:      evaluationTime,
+		Until:   evaluationTime.Add(5 * time.Minute),
+	}
+
+	st := newManager()
+	process(st, evaluationTime, eval.Alerting)
+
+	_, err = st.Acknowledge(ctx, mainOrgID, rule.UID, labels("b"), ack)
+	require.ErrorIs(t, err, state.ErrStateNotFiring)
+	_, err = st.Acknowledge(ctx, mainOrgID, rule.UID, labels("c"), ack)
+	require.ErrorIs(t, err, state.ErrStateNotFound)
+
+	s, err := st.Acknowledge(ctx, mainOrgID, rule.UID, labels("a"), ack)
+	require.NoError(t, err)
+	require.Equal(t, &ack, s.Acknowledgement)
+	cacheID := s.CacheId
+
+	t.Run("acknowledgement is stored with the instance", func(t *testing.T) {
+		warmed := newManager()
+		warmed.Warm(ctx)
+		for _, s := range warmed.GetStatesForRuleUID(mainOrgID, rule.UID) {
+			if s.Labels["instance"] != "a" {
+				require.Nil(t, s.Acknowledgement)
+				continue
+			}
+			require.NotNil(t, s.Acknowledgement)
+			require.Equal(t, ack.UserID, s.Acknowledgement.UserID)
+			require.Equal(t, ack.Comment, s.Acknowledgement.Comment)
+			require.True(t, ack.Until.Equal(s.Acknowledgement.Until))
+		}
+	})
+
+	t.Run("acknowledgement is kept while the instance fires", func(t *testing.T) {
+		process(st, evaluationTime.Add(time.Minute), eval.Alerting)
+		s, err := st.Get(mainOrgID, rule.UID, cacheID)
+		require.NoError(t, err)
+		require.Equal(t, &ack, s.Acknowledgement)
+	})
+
+	t.Run("acknowledgement is cleared when the instance resolves", func(t *testing.T) {
+		process(st, evaluationTime.Add(2*time.Minute), eval.Normal)
+		s, err := st.Get(mainOrgID, rule.UID, cacheID)
+		require.NoError(t, err)
+		require.Nil(t, s.Acknowledgement)
+	})
+
+	t.Run("acknowledgement is cleared when it expires", func(t *testing.T) {
+		process(st, evaluationTime.Add(3*time.Minute), eval.Alerting)
+		_, err := st.Acknowledge(ctx, mainOrgID, rule.UID, labels("a"), ack)
+		require.NoError(t, err)
+		process(st, ack.Until, eval.Alerting)
+		s, err := st.Get(mainOrgID, rule.UID, cacheID)
+		require.NoError(t, err)
+		require.Nil(t, s.Acknowledgement)
+	})
+
+	t.Run("acknowledgement can be removed", func(t *testing.T) {
+		_, err := st.Acknowledge(ctx, mainOrgID, rule.UID, labels("a"), models.AlertInstanceAcknowledgement{Until: ack.Until.Add(time.Hour)})
+		require.NoError(t, err)
+		s, err := st.Unacknowledge(ctx, mainOrgID, rule.UID, labels("a"))
+		require.NoError(t, err)
+		require.Nil(t, s.Acknowledgement)
+	})
+}
+
+func TestAcknowledgeWhileEvaluating(t *testing.T) {
+	evaluationTime, err := time.Parse("2006-01-02", "2021-03-25")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, 1)
+
+	const mainOrgID int64 = 1
+	rule := tests.CreateTestAlertRule(t, ctx, dbstore, 60, mainOrgID)
+
+	st := state.NewManager(log.New("test_acknowledge_while_evaluating"), testMetrics.GetStateMetrics(), nil, dbstore, dbstore, mockstore.NewSQLStoreMock(), &dashboards.FakeDashboardService{}, &image.NoopImageService{})
+	evaluate := func(at time.Time) {
+		st.ProcessEvalResults(ctx, rule, eval.Results{
+			{Instance: data.Labels{"instance": "a"}, State: eval.Alerting, EvaluatedAt: at},
+		})
+	}
+	labels := data.Labels{"alertname": rule.Title, "instance": "a"}
+	ack := models.AlertInstanceAcknowledgement{
+		UserID: 1,
+		At:     evaluationTime,
+		Until:  evaluationTime.Add(time.Hour),
+	}
+
+	evaluate(evaluationTime)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 1; i <= 100; i++ {
+			evaluate(evaluationTime.Add(time.Duration(i) * time.Second))
+		}
+	}()
+	for i := 0; i < 20; i++ {
+		s, err := st.Acknowledge(ctx, mainOrgID, rule.UID, labels, ack)
+		require.NoError(t, err)
+		require.Equal(t, &ack, s.Acknowledgement)
+		s, err = st.Unacknowledge(ctx, mainOrgID, rule.UID, labels)
+		require.NoError(t, err)
+		require.Nil(t, s.Acknowledgement)
+	}
+	wg.Wait()
+
+	// the acknowledgement must not be lost by the next evaluation
+	s, err := st.Acknowledge(ctx, mainOrgID, rule.UID, labels, ack)
+	require.NoError(t, err)
+	evaluate(evaluationTime.Add(2 * time.Minute))
+	s, err = st.Get(mainOrgID, rule.UID, s.CacheId)
+	require.NoError(t, err)
+	require.Equal(t, &ack, s.Acknowledgement)
+}
@@ -34,6 +34,8 @@ type State struct {
 	Labels               data.Labels
 	Image                *models.Image
 	Error                error
+	// Acknowledgement suppresses the notifications of the firing instance until it expires or the instance resolves.
+	Acknowledgement *models.AlertInstanceAcknowledgement
 }
 
 type Evaluation struct {
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -109,18 +110,20 @@ func (st DBstore) GetAlertRuleByUID(ctx context.Context, query *ngmodels.GetAler
 	})
 }
 
-// InsertAlertRules is a handler for creating/updating alert rules.
+// InsertAlertRules is a handler for creating/updating alert rules. A UID is generated for rules that don't have one.
 func (st DBstore) InsertAlertRules(ctx context.Context, rules []ngmodels.AlertRule) error {
 	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *sqlstore.DBSession) error {
 		newRules := make([]ngmodels.AlertRule, 0, len(rules))
 		ruleVersions := make([]ngmodels.AlertRuleVersion, 0, len(rules))
 		for i := range rules {
 			r := rules[i]
-			uid, err := GenerateNewAlertRuleUID(sess, r.OrgID, r.Title)
-			if err != nil {
-				return fmt.Errorf("failed to generate UID for alert rule %q: %w", r.Title, err)
+			if r.UID == "" {
+				uid, err := GenerateNewAlertRuleUID(sess, r.OrgID, r.Title)
+				if err != nil {
+					return fmt.Errorf("failed to generate UID for alert rule %q: %w", r.Title, err)
+				}
+				r.UID = uid
 			}
-			r.UID = uid
 			r.Version = 1
 			if err := st.validateAlertRule(r); err != nil {
 				return err
@@ -344,6 +347,26 @@ func (st DBstore) GetNamespaceByTitle(ctx context.Context, namespace string, org
 	return folder, nil
 }
 
+// GetNamespaceByUID returns the namespace with the given UID. Unlike GetNamespaceByTitle, it does not check the
+// permissions of a user, so it is meant for callers that are authorized otherwise, like provisioning.
+func (st DBstore) GetNamespaceByUID(ctx context.Context, uid string, orgID int64) (*models.Folder, error) {
+	query := &models.GetDashboardQuery{Uid: uid, OrgId: orgID}
+	if err := st.DashboardService.GetDashboard(ctx, query); err != nil {
+		if errors.Is(err, models.ErrDashboardNotFound) {
+			return nil, models.ErrFolderNotFound
+		}
+		return nil, err
+	}
+	if !query.Result.IsFolder {
+		return nil, models.ErrFolderNotFound
+	}
+	return &models.Folder{
+		Id:    query.Result.Id,
+		Uid:   query.Result.Uid,
+		Title: query.Result.Title,
+	}, nil
+}
+
 // GetAlertRulesForScheduling returns a short version of all alert rules except those that belong to an excluded list of organizations
 func (st DBstore) GetAlertRulesForScheduling(ctx context.Context, query *ngmodels.GetAlertRulesForSchedulingQuery) error {
 	return st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
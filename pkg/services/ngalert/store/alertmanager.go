@@ -55,6 +55,75 @@ func (st *DBstore) GetAllLatestAlertmanagerConfiguration(ctx context.Context) ([
 	return result, nil
 }
 
+// GetAlertmanagerConfiguration returns a specific version of the alertmanager configuration.
+// It returns ErrNoAlertmanagerConfiguration if the version does not exist in the organization.
+func (st *DBstore) GetAlertmanagerConfiguration(ctx context.Context, query *models.GetAlertmanagerConfigurationQuery) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		c := &models.AlertConfiguration{}
+		ok, err := sess.Where("org_id = ? AND id = ?", query.OrgID, query.ID).Get(c)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return ErrNoAlertmanagerConfiguration
+		}
+
+		query.Result = c
+		return nil
+	})
+}
+
+// GetAlertmanagerConfigurationHistory returns the stored versions of the alertmanager configuration of an organization,
+// newest first, together with the login of the user that created each of them.
+func (st *DBstore) GetAlertmanagerConfigurationHistory(ctx context.Context, query *models.GetAlertmanagerConfigurationHistoryQuery) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		result := make([]*models.AlertConfigurationHistoryEntry, 0)
+		q := sess.Table("alert_configuration").
+			Select("alert_configuration.id, alert_configuration.configuration_hash, alert_configuration.created_at, alert_configuration.created_by, alert_configuration."+st.SQLStore.Dialect.Quote("default")+", u.login AS created_by_login").
+			Join("LEFT", st.SQLStore.Dialect.Quote("user")+" AS u", "u.id = alert_configuration.created_by").
+			Where("alert_configuration.org_id = ?", query.OrgID).
+			Desc("alert_configuration.id")
+		if query.Limit > 0 {
+			q = q.Limit(query.Limit)
+		}
+		if err := q.Find(&result); err != nil {
+			return err
+		}
+
+		query.Result = result
+		return nil
+	})
+}
+
+// DeleteOldAlertmanagerConfigurations deletes all but the newest keep versions of the alertmanager configuration
+// of an organization. It returns the number of deleted versions.
+func (st *DBstore) DeleteOldAlertmanagerConfigurations(ctx context.Context, orgID int64, keep int) (int64, error) {
+	if keep < 1 {
+		return 0, fmt.Errorf("at least one alertmanager configuration must be kept, got %d", keep)
+	}
+
+	var affected int64
+	err := st.SQLStore.WithTransactionalDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		var ids []int64
+		err := sess.Table("alert_configuration").Cols("id").Where("org_id = ?", orgID).Desc("id").Limit(1, keep-1).Find(&ids)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		res, err := sess.Exec("DELETE FROM alert_configuration WHERE org_id = ? AND id < ?", orgID, ids[0])
+		if err != nil {
+			return err
+		}
+		affected, err = res.RowsAffected()
+		return err
+	})
+	return affected, err
+}
+
 // SaveAlertmanagerConfiguration creates an alertmanager configuration.
 func (st DBstore) SaveAlertmanagerConfiguration(ctx context.Context, cmd *models.SaveAlertmanagerConfigurationCmd) error {
 	return st.SaveAlertmanagerConfigurationWithCallback(ctx, cmd, func() error { return nil })
@@ -70,6 +139,7 @@ func (st DBstore) SaveAlertmanagerConfigurationWithCallback(ctx context.Context,
 			AlertmanagerConfiguration: cmd.AlertmanagerConfiguration,
 			ConfigurationHash:         fmt.Sprintf("%x", md5.Sum([]byte(cmd.AlertmanagerConfiguration))),
 			ConfigurationVersion:      cmd.ConfigurationVersion,
+			CreatedBy:                 cmd.CreatedBy,
 			Default:                   cmd.Default,
 			OrgID:                     cmd.OrgID,
 		}
@@ -91,6 +161,7 @@ func (st *DBstore) UpdateAlertmanagerConfiguration(ctx context.Context, cmd *mod
 			AlertmanagerConfiguration: cmd.AlertmanagerConfiguration,
 			ConfigurationHash:         fmt.Sprintf("%x", md5.Sum([]byte(cmd.AlertmanagerConfiguration))),
 			ConfigurationVersion:      cmd.ConfigurationVersion,
+			CreatedBy:                 cmd.CreatedBy,
 			Default:                   cmd.Default,
 			OrgID:                     cmd.OrgID,
 		}
@@ -82,3 +82,61 @@ func TestIntegrationAlertManagerHash(t *testing.T) {
 		require.EqualError(t, ErrVersionLockedObjectNotFound, err.Error())
 	})
 }
+
+func TestIntegrationAlertmanagerConfigurationHistory(t *testing.T) {
+	sqlStore := sqlstore.InitTestDB(t)
+	store := &DBstore{
+		SQLStore: sqlStore,
+	}
+	saveConfig := func(t *testing.T, orgID int64, config string, createdBy int64) {
+		err := store.SaveAlertmanagerConfiguration(context.Background(), &models.SaveAlertmanagerConfigurationCmd{
+			AlertmanagerConfiguration: config,
+			ConfigurationVersion:      "v1",
+			CreatedBy:                 createdBy,
+			OrgID:                     orgID,
+		})
+		require.NoError(t, err)
+	}
+	for i := 1; i <= 5; i++ {
+		saveConfig(t, 1, fmt.Sprintf("config-%d", i), int64(i))
+	}
+	saveConfig(t, 2, "other-org", 1)
+
+	t.Run("history is sorted newest first and can be limited", func(t *testing.T) {
+		query := &models.GetAlertmanagerConfigurationHistoryQuery{OrgID: 1, Limit: 3}
+		require.NoError(t, store.GetAlertmanagerConfigurationHistory(context.Background(), query))
+		require.Len(t, query.Result, 3)
+		require.Equal(t, int64(5), query.Result[0].CreatedBy)
+		require.Equal(t, int64(4), query.Result[1].CreatedBy)
+		require.Equal(t, int64(3), query.Result[2].CreatedBy)
+	})
+
+	t.Run("a version can only be fetched from its organization", func(t *testing.T) {
+		history := &models.GetAlertmanagerConfigurationHistoryQuery{OrgID: 1}
+		require.NoError(t, store.GetAlertmanagerConfigurationHistory(context.Background(), history))
+		require.Len(t, history.Result, 5)
+
+		query := &models.GetAlertmanagerConfigurationQuery{OrgID: 1, ID: history.Result[4].ID}
+		require.NoError(t, store.GetAlertmanagerConfiguration(context.Background(), query))
+		require.Equal(t, "config-1", query.Result.AlertmanagerConfiguration)
+
+		query = &models.GetAlertmanagerConfigurationQuery{OrgID: 2, ID: history.Result[4].ID}
+		require.ErrorIs(t, store.GetAlertmanagerConfiguration(context.Background(), query), ErrNoAlertmanagerConfiguration)
+	})
+
+	t.Run("old versions are deleted", func(t *testing.T) {
+		deleted, err := store.DeleteOldAlertmanagerConfigurations(context.Background(), 1, 2)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), deleted)
+
+		query := &models.GetAlertmanagerConfigurationHistoryQuery{OrgID: 1}
+		require.NoError(t, store.GetAlertmanagerConfigurationHistory(context.Background(), query))
+		require.Len(t, query.Result, 2)
+		require.Equal(t, int64(5), query.Result[0].CreatedBy)
+		require.Equal(t, int64(4), query.Result[1].CreatedBy)
+
+		query = &models.GetAlertmanagerConfigurationHistoryQuery{OrgID: 2}
+		require.NoError(t, store.GetAlertmanagerConfigurationHistory(context.Background(), query))
+		require.Len(t, query.Result, 1)
+	})
+}
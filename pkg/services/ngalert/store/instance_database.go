@@ -15,6 +15,7 @@ type InstanceStore interface {
 	SaveAlertInstance(ctx context.Context, cmd *models.SaveAlertInstanceCommand) error
 	FetchOrgIds(ctx context.Context) ([]int64, error)
 	DeleteAlertInstance(ctx context.Context, orgID int64, ruleUID, labelsHash string) error
+	SetAlertInstanceAcknowledgement(ctx context.Context, orgID int64, ruleUID, labelsHash string, ack *models.AlertInstanceAcknowledgement) error
 }
 
 // GetAlertInstance is a handler for retrieving an alert instance based on OrgId, AlertDefintionID, and
@@ -111,12 +112,13 @@ func (st DBstore) SaveAlertInstance(ctx context.Context, cmd *models.SaveAlertIn
 			return err
 		}
 
-		params := append(make([]interface{}, 0), alertInstance.RuleOrgID, alertInstance.RuleUID, labelTupleJSON, alertInstance.LabelsHash, alertInstance.CurrentState, alertInstance.CurrentReason, alertInstance.CurrentStateSince.Unix(), alertInstance.CurrentStateEnd.Unix(), alertInstance.LastEvalTime.Unix())
+		ackBy, ackComment, ackAt, ackUntil := acknowledgementParams(cmd.Acknowledgement)
+		params := append(make([]interface{}, 0), alertInstance.RuleOrgID, alertInstance.RuleUID, labelTupleJSON, alertInstance.LabelsHash, alertInstance.CurrentState, alertInstance.CurrentReason, alertInstance.CurrentStateSince.Unix(), alertInstance.CurrentStateEnd.Unix(), alertInstance.LastEvalTime.Unix(), ackBy, ackComment, ackAt, ackUntil)
 
 		upsertSQL := st.SQLStore.Dialect.UpsertSQL(
 			"alert_instance",
 			[]string{"rule_org_id", "rule_uid", "labels_hash"},
-			[]string{"rule_org_id", "rule_uid", "labels", "labels_hash", "current_state", "current_reason", "current_state_since", "current_state_end", "last_eval_time"})
+			[]string{"rule_org_id", "rule_uid", "labels", "labels_hash", "current_state", "current_reason", "current_state_since", "current_state_end", "last_eval_time", "acknowledged_by", "acknowledged_comment", "acknowledged_at", "acknowledged_until"})
 		_, err = sess.SQL(upsertSQL, params...).Query()
 		if err != nil {
 			return err
@@ -158,3 +160,20 @@ func (st DBstore) DeleteAlertInstance(ctx context.Context, orgID int64, ruleUID,
 		return nil
 	})
 }
+
+// SetAlertInstanceAcknowledgement sets or, if ack is nil, clears the acknowledgement of an alert instance.
+func (st DBstore) SetAlertInstanceAcknowledgement(ctx context.Context, orgID int64, ruleUID, labelsHash string, ack *models.AlertInstanceAcknowledgement) error {
+	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		ackBy, ackComment, ackAt, ackUntil := acknowledgementParams(ack)
+		_, err := sess.Exec("UPDATE alert_instance SET acknowledged_by = ?, acknowledged_comment = ?, acknowledged_at = ?, acknowledged_until = ? WHERE rule_org_id = ? AND rule_uid = ? AND labels_hash = ?",
+			ackBy, ackComment, ackAt, ackUntil, orgID, ruleUID, labelsHash)
+		return err
+	})
+}
+
+func acknowledgementParams(ack *models.AlertInstanceAcknowledgement) (int64, string, int64, int64) {
+	if ack == nil {
+		return 0, "", 0, 0
+	}
+	return ack.UserID, ack.Comment, ack.At.Unix(), ack.Until.Unix()
+}
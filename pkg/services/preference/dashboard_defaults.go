@@ -0,0 +1,69 @@
+package pref
+
+import (
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+// ApplyTo sets the time range, refresh interval and constant variable values that a dashboard JSON model leaves
+// unset to the ones of the preference, and returns the values it set, or nil if it set none. The values that the
+// dashboard sets itself take precedence over the defaults.
+func (d *DashboardDefaultsPreference) ApplyTo(dash *simplejson.Json) *DashboardDefaultsPreference {
+	applied := &DashboardDefaultsPreference{}
+	if d.Time != nil && isTimeRangeUnset(dash) {
+		dash.Set("time", map[string]interface{}{"from": d.Time.From, "to": d.Time.To})
+		applied.Time = d.Time
+	}
+	if d.Refresh != "" && isRefreshUnset(dash) {
+		dash.Set("refresh", d.Refresh)
+		applied.Refresh = d.Refresh
+	}
+
+	for _, v := range dash.GetPath("templating", "list").MustArray() {
+		variable, ok := v.(map[string]interface{})
+		if !ok || variable["type"] != "constant" {
+			continue
+		}
+		name, _ := variable["name"].(string)
+		value, ok := d.Variables[name]
+		if !ok {
+			continue
+		}
+		if query, _ := variable["query"].(string); query != "" {
+			continue
+		}
+
+		variable["query"] = value
+		variable["current"] = map[string]interface{}{"text": value, "value": value, "selected": false}
+		variable["options"] = []interface{}{
+			map[string]interface{}{"text": value, "value": value, "selected": true},
+		}
+		if applied.Variables == nil {
+			applied.Variables = make(map[string]string)
+		}
+		applied.Variables[name] = value
+	}
+
+	if applied.Time == nil && applied.Refresh == "" && applied.Variables == nil {
+		return nil
+	}
+	return applied
+}
+
+func isTimeRangeUnset(dash *simplejson.Json) bool {
+	return dash.GetPath("time", "from").MustString() == "" || dash.GetPath("time", "to").MustString() == ""
+}
+
+// isRefreshUnset reports whether the dashboard does not refresh on its own, which the frontend saves as false or as
+// an empty string.
+func isRefreshUnset(dash *simplejson.Json) bool {
+	switch refresh := dash.Get("refresh").Interface().(type) {
+	case nil:
+		return true
+	case bool:
+		return !refresh
+	case string:
+		return refresh == ""
+	default:
+		return false
+	}
+}
@@ -14,6 +14,7 @@ type Preference struct {
 	OrgID           int64   `xorm:"org_id"`
 	UserID          int64   `xorm:"user_id"`
 	TeamID          int64   `xorm:"team_id"`
+	FolderID        int64   `xorm:"folder_id"`
 	Teams           []int64 `xorm:"extends"`
 	Version         int
 	HomeDashboardID int64 `xorm:"home_dashboard_id"`
@@ -32,15 +33,17 @@ type GetPreferenceWithDefaultsQuery struct {
 }
 
 type GetPreferenceQuery struct {
-	OrgID  int64
-	UserID int64
-	TeamID int64
+	OrgID    int64
+	UserID   int64
+	TeamID   int64
+	FolderID int64
 }
 
 type SavePreferenceCommand struct {
-	UserID int64
-	OrgID  int64
-	TeamID int64
+	UserID   int64
+	OrgID    int64
+	TeamID   int64
+	FolderID int64
 
 	HomeDashboardID  int64                   `json:"homeDashboardId,omitempty"`
 	HomeDashboardUID *string                 `json:"homeDashboardUID,omitempty"`
@@ -49,12 +52,15 @@ type SavePreferenceCommand struct {
 	Theme            string                  `json:"theme,omitempty"`
 	Navbar           *NavbarPreference       `json:"navbar,omitempty"`
 	QueryHistory     *QueryHistoryPreference `json:"queryHistory,omitempty"`
+
+	DashboardDefaults *DashboardDefaultsPreference `json:"dashboardDefaults,omitempty"`
 }
 
 type PatchPreferenceCommand struct {
-	UserID int64
-	OrgID  int64
-	TeamID int64
+	UserID   int64
+	OrgID    int64
+	TeamID   int64
+	FolderID int64
 
 	HomeDashboardID  *int64                  `json:"homeDashboardId,omitempty"`
 	HomeDashboardUID *string                 `json:"homeDashboardUID,omitempty"`
@@ -77,8 +83,22 @@ type NavbarPreference struct {
 }
 
 type PreferenceJSONData struct {
-	Navbar       NavbarPreference       `json:"navbar"`
-	QueryHistory QueryHistoryPreference `json:"queryHistory"`
+	Navbar            NavbarPreference             `json:"navbar"`
+	QueryHistory      QueryHistoryPreference       `json:"queryHistory"`
+	DashboardDefaults *DashboardDefaultsPreference `json:"dashboardDefaults,omitempty"`
+}
+
+// DashboardDefaultsPreference holds the time range, refresh interval and constant variable values that the
+// dashboards of a folder are served with.
+type DashboardDefaultsPreference struct {
+	Time      *TimeRangePreference `json:"time,omitempty"`
+	Refresh   string               `json:"refresh,omitempty"`
+	Variables map[string]string    `json:"variables,omitempty"`
+}
+
+type TimeRangePreference struct {
+	From string `json:"from"`
+	To   string `json:"to"`
 }
 
 type QueryHistoryPreference struct {
@@ -60,9 +60,10 @@ func (s *Service) GetWithDefaults(ctx context.Context, query *pref.GetPreference
 
 func (s *Service) Get(ctx context.Context, query *pref.GetPreferenceQuery) (*pref.Preference, error) {
 	getPref := &pref.Preference{
-		OrgID:  query.OrgID,
-		UserID: query.UserID,
-		TeamID: query.TeamID,
+		OrgID:    query.OrgID,
+		UserID:   query.UserID,
+		TeamID:   query.TeamID,
+		FolderID: query.FolderID,
 	}
 	prefs, err := s.store.Get(ctx, getPref)
 	if errors.Is(err, pref.ErrPrefNotFound) {
@@ -76,9 +77,10 @@ func (s *Service) Get(ctx context.Context, query *pref.GetPreferenceQuery) (*pre
 
 func (s *Service) Save(ctx context.Context, cmd *pref.SavePreferenceCommand) error {
 	preference, err := s.store.Get(ctx, &pref.Preference{
-		OrgID:  cmd.OrgID,
-		UserID: cmd.UserID,
-		TeamID: cmd.TeamID,
+		OrgID:    cmd.OrgID,
+		UserID:   cmd.UserID,
+		TeamID:   cmd.TeamID,
+		FolderID: cmd.FolderID,
 	})
 	if err != nil {
 		if errors.Is(err, pref.ErrPrefNotFound) {
@@ -86,6 +88,7 @@ func (s *Service) Save(ctx context.Context, cmd *pref.SavePreferenceCommand) err
 				UserID:          cmd.UserID,
 				OrgID:           cmd.OrgID,
 				TeamID:          cmd.TeamID,
+				FolderID:        cmd.FolderID,
 				HomeDashboardID: cmd.HomeDashboardID,
 				Timezone:        cmd.Timezone,
 				WeekStart:       cmd.WeekStart,
@@ -93,6 +96,9 @@ func (s *Service) Save(ctx context.Context, cmd *pref.SavePreferenceCommand) err
 				Created:         time.Now(),
 				Updated:         time.Now(),
 			}
+			if cmd.DashboardDefaults != nil {
+				preference.JSONData = &pref.PreferenceJSONData{DashboardDefaults: cmd.DashboardDefaults}
+			}
 			_, err = s.store.Insert(ctx, preference)
 			if err != nil {
 				return err
@@ -115,15 +121,17 @@ func (s *Service) Save(ctx context.Context, cmd *pref.SavePreferenceCommand) err
 	if cmd.QueryHistory != nil {
 		preference.JSONData.QueryHistory = *cmd.QueryHistory
 	}
+	preference.JSONData.DashboardDefaults = cmd.DashboardDefaults
 	return s.store.Update(ctx, preference)
 }
 
 func (s *Service) Patch(ctx context.Context, cmd *pref.PatchPreferenceCommand) error {
 	var exists bool
 	preference, err := s.store.Get(ctx, &pref.Preference{
-		OrgID:  cmd.OrgID,
-		UserID: cmd.UserID,
-		TeamID: cmd.TeamID,
+		OrgID:    cmd.OrgID,
+		UserID:   cmd.UserID,
+		TeamID:   cmd.TeamID,
+		FolderID: cmd.FolderID,
 	})
 	if err != nil && !errors.Is(err, pref.ErrPrefNotFound) {
 		return err
@@ -134,6 +142,7 @@ func (s *Service) Patch(ctx context.Context, cmd *pref.PatchPreferenceCommand) e
 			UserID:   cmd.UserID,
 			OrgID:    cmd.OrgID,
 			TeamID:   cmd.TeamID,
+			FolderID: cmd.FolderID,
 			Created:  time.Now(),
 			JSONData: &pref.PreferenceJSONData{},
 		}
@@ -23,7 +23,7 @@ type sqlStore struct {
 func (s *sqlStore) Get(ctx context.Context, query *pref.Preference) (*pref.Preference, error) {
 	var prefs pref.Preference
 	err := s.db.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
-		exist, err := sess.Where("org_id=? AND user_id=? AND team_id=?", query.OrgID, query.UserID, query.TeamID).Get(&prefs)
+		exist, err := sess.Where("org_id=? AND user_id=? AND team_id=? AND folder_id=?", query.OrgID, query.UserID, query.TeamID, query.FolderID).Get(&prefs)
 		if err != nil {
 			return err
 		}
@@ -51,7 +51,7 @@ func (s *sqlStore) List(ctx context.Context, query *pref.Preference) ([]*pref.Pr
 		}
 	}
 
-	filter += "(org_id=? AND user_id=? AND team_id=0) OR (org_id=? AND team_id=0 AND user_id=0)"
+	filter += "(org_id=? AND user_id=? AND team_id=0 AND folder_id=0) OR (org_id=? AND team_id=0 AND user_id=0 AND folder_id=0)"
 	params = append(params, query.OrgID)
 	params = append(params, query.UserID)
 	params = append(params, query.OrgID)
@@ -167,4 +167,26 @@ func TestIntegrationPreferencesDataAccess(t *testing.T) {
 			})
 		require.NoError(t, err)
 	})
+	t.Run("folder preferences are not listed with the org and user preferences", func(t *testing.T) {
+		_, err := prefStore.Insert(context.Background(),
+			&pref.Preference{
+				OrgID:    5,
+				FolderID: 10,
+				Created:  time.Now(),
+				Updated:  time.Now(),
+				JSONData: &pref.PreferenceJSONData{DashboardDefaults: &pref.DashboardDefaultsPreference{Refresh: "1m"}},
+			})
+		require.NoError(t, err)
+
+		prefs, err := prefStore.List(context.Background(), &pref.Preference{OrgID: 5, UserID: 1})
+		require.NoError(t, err)
+		require.Empty(t, prefs)
+
+		_, err = prefStore.Get(context.Background(), &pref.Preference{OrgID: 5})
+		require.ErrorIs(t, err, pref.ErrPrefNotFound)
+
+		folderPrefs, err := prefStore.Get(context.Background(), &pref.Preference{OrgID: 5, FolderID: 10})
+		require.NoError(t, err)
+		require.Equal(t, "1m", folderPrefs.JSONData.DashboardDefaults.Refresh)
+	})
 }
@@ -0,0 +1,273 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	alert_models "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/provisioning/utils"
+)
+
+type AlertRuleService interface {
+	DeleteAlertRule(ctx context.Context, orgID int64, ruleUID string, provenance alert_models.Provenance) error
+	ReplaceRuleGroup(ctx context.Context, orgID int64, group alert_models.AlertRuleGroup, provenance alert_models.Provenance) error
+}
+
+type ContactPointService interface {
+	GetContactPoints(ctx context.Context, orgID int64) ([]definitions.EmbeddedContactPoint, error)
+	CreateContactPoint(ctx context.Context, orgID int64, contactPoint definitions.EmbeddedContactPoint, provenance alert_models.Provenance) (definitions.EmbeddedContactPoint, error)
+	UpdateContactPoint(ctx context.Context, orgID int64, contactPoint definitions.EmbeddedContactPoint, provenance alert_models.Provenance) error
+	DeleteContactPoint(ctx context.Context, orgID int64, uid string) error
+}
+
+type NotificationPolicyService interface {
+	UpdatePolicyTree(ctx context.Context, orgID int64, tree definitions.Route, p alert_models.Provenance) error
+}
+
+type MuteTimingService interface {
+	CreateMuteTiming(ctx context.Context, mt definitions.MuteTimeInterval, orgID int64) (*definitions.MuteTimeInterval, error)
+	UpdateMuteTiming(ctx context.Context, mt definitions.MuteTimeInterval, orgID int64) (*definitions.MuteTimeInterval, error)
+	DeleteMuteTiming(ctx context.Context, name string, orgID int64) error
+}
+
+type TemplateService interface {
+	SetTemplate(ctx context.Context, orgID int64, tmpl definitions.MessageTemplate) (definitions.MessageTemplate, error)
+	DeleteTemplate(ctx context.Context, orgID int64, name string) error
+}
+
+// ProvisionerConfig holds the services used to apply the alerting provisioning files.
+type ProvisionerConfig struct {
+	Path                      string
+	OrgStore                  utils.OrgStore
+	DashboardStore            utils.DashboardStore
+	DashboardProvService      dashboards.DashboardProvisioningService
+	RuleService               AlertRuleService
+	ContactPointService       ContactPointService
+	NotificationPolicyService NotificationPolicyService
+	MuteTimingService         MuteTimingService
+	TemplateService           TemplateService
+}
+
+// Provision applies the alerting resources declared in the configuration files. Everything is stored
+// with file provenance, so that it cannot be modified through the API or the UI afterwards.
+func Provision(ctx context.Context, cfg ProvisionerConfig) error {
+	ap := newAlertingProvisioner(cfg, log.New("provisioning.alerting"))
+	return ap.applyChanges(ctx, cfg.Path)
+}
+
+// AlertingProvisioner is responsible for provisioning Grafana-managed alerting resources
+type AlertingProvisioner struct {
+	log         log.Logger
+	cfgProvider *configReader
+	cfg         ProvisionerConfig
+}
+
+func newAlertingProvisioner(cfg ProvisionerConfig, log log.Logger) AlertingProvisioner {
+	return AlertingProvisioner{
+		log: log,
+		cfgProvider: &configReader{
+			orgStore: cfg.OrgStore,
+			log:      log,
+		},
+		cfg: cfg,
+	}
+}
+
+func (ap *AlertingProvisioner) applyChanges(ctx context.Context, configPath string) error {
+	configs, err := ap.cfgProvider.readConfig(ctx, configPath)
+	if err != nil {
+		return err
+	}
+
+	for _, cfg := range configs {
+		if err := ap.apply(ctx, cfg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// apply creates the referenced resources before the resources referencing them, and deletes last,
+// so that e.g. a contact point is only removed once the policies no longer route to it.
+func (ap *AlertingProvisioner) apply(ctx context.Context, cfg *alertingAsConfig) error {
+	if err := ap.provisionTemplates(ctx, cfg.Templates); err != nil {
+		return err
+	}
+	if err := ap.provisionContactPoints(ctx, cfg.ContactPoints); err != nil {
+		return err
+	}
+	if err := ap.provisionMuteTimes(ctx, cfg.MuteTimes); err != nil {
+		return err
+	}
+	if err := ap.provisionPolicies(ctx, cfg.Policies); err != nil {
+		return err
+	}
+	if err := ap.provisionRuleGroups(ctx, cfg.RuleGroups); err != nil {
+		return err
+	}
+	if err := ap.deleteRules(ctx, cfg.DeleteRules); err != nil {
+		return err
+	}
+	if err := ap.deleteContactPoints(ctx, cfg.DeleteContactPoints); err != nil {
+		return err
+	}
+	if err := ap.deleteMuteTimes(ctx, cfg.DeleteMuteTimes); err != nil {
+		return err
+	}
+	return ap.deleteTemplates(ctx, cfg.DeleteTemplates)
+}
+
+func (ap *AlertingProvisioner) provisionTemplates(ctx context.Context, templates []*templateFromConfig) error {
+	for _, tmpl := range templates {
+		ap.log.Debug("provisioning template", "name", tmpl.Template.Name, "orgId", tmpl.OrgID)
+		if _, err := ap.cfg.TemplateService.SetTemplate(ctx, tmpl.OrgID, tmpl.Template); err != nil {
+			return fmt.Errorf("failed to provision template %q: %w", tmpl.Template.Name, err)
+		}
+	}
+	return nil
+}
+
+func (ap *AlertingProvisioner) provisionContactPoints(ctx context.Context, contactPoints []*contactPointFromConfig) error {
+	for _, cp := range contactPoints {
+		existing, err := ap.cfg.ContactPointService.GetContactPoints(ctx, cp.OrgID)
+		if err != nil {
+			return err
+		}
+		existingUIDs := make(map[string]struct{}, len(existing))
+		for _, e := range existing {
+			existingUIDs[e.UID] = struct{}{}
+		}
+
+		for _, receiver := range cp.Receivers {
+			ap.log.Debug("provisioning contact point", "name", receiver.Name, "uid", receiver.UID, "orgId", cp.OrgID)
+			if _, ok := existingUIDs[receiver.UID]; ok {
+				err = ap.cfg.ContactPointService.UpdateContactPoint(ctx, cp.OrgID, receiver, alert_models.ProvenanceFile)
+			} else {
+				_, err = ap.cfg.ContactPointService.CreateContactPoint(ctx, cp.OrgID, receiver, alert_models.ProvenanceFile)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to provision contact point %q: %w", receiver.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (ap *AlertingProvisioner) provisionMuteTimes(ctx context.Context, muteTimes []*muteTimeFromConfig) error {
+	for _, mt := range muteTimes {
+		ap.log.Debug("provisioning mute timing", "name", mt.MuteTime.Name, "orgId", mt.OrgID)
+		updated, err := ap.cfg.MuteTimingService.UpdateMuteTiming(ctx, mt.MuteTime, mt.OrgID)
+		if err == nil && updated == nil {
+			_, err = ap.cfg.MuteTimingService.CreateMuteTiming(ctx, mt.MuteTime, mt.OrgID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to provision mute timing %q: %w", mt.MuteTime.Name, err)
+		}
+	}
+	return nil
+}
+
+func (ap *AlertingProvisioner) provisionPolicies(ctx context.Context, policies []*policyFromConfig) error {
+	for _, policy := range policies {
+		ap.log.Debug("provisioning notification policy", "orgId", policy.OrgID)
+		if err := ap.cfg.NotificationPolicyService.UpdatePolicyTree(ctx, policy.OrgID, policy.Policy, alert_models.ProvenanceFile); err != nil {
+			return fmt.Errorf("failed to provision notification policy of org %d: %w", policy.OrgID, err)
+		}
+	}
+	return nil
+}
+
+func (ap *AlertingProvisioner) provisionRuleGroups(ctx context.Context, groups []*ruleGroupFromConfig) error {
+	for _, group := range groups {
+		ap.log.Debug("provisioning rule group", "name", group.Name, "folder", group.Folder, "orgId", group.OrgID)
+		folderUID, err := ap.getOrCreateFolderUID(ctx, group.OrgID, group.Folder)
+		if err != nil {
+			return fmt.Errorf("failed to provision folder %q of rule group %q: %w", group.Folder, group.Name, err)
+		}
+		err = ap.cfg.RuleService.ReplaceRuleGroup(ctx, group.OrgID, alert_models.AlertRuleGroup{
+			Title:     group.Name,
+			FolderUID: folderUID,
+			Interval:  int64(group.Interval.Seconds()),
+			Rules:     group.Rules,
+		}, alert_models.ProvenanceFile)
+		if err != nil {
+			return fmt.Errorf("failed to provision rule group %q: %w", group.Name, err)
+		}
+	}
+	return nil
+}
+
+func (ap *AlertingProvisioner) deleteRules(ctx context.Context, rules []*deleteRuleConfig) error {
+	for _, rule := range rules {
+		ap.log.Debug("deleting alert rule", "uid", rule.UID, "orgId", rule.OrgID)
+		err := ap.cfg.RuleService.DeleteAlertRule(ctx, rule.OrgID, rule.UID, alert_models.ProvenanceFile)
+		if err != nil && !errors.Is(err, alert_models.ErrAlertRuleNotFound) {
+			return fmt.Errorf("failed to delete alert rule %q: %w", rule.UID, err)
+		}
+	}
+	return nil
+}
+
+func (ap *AlertingProvisioner) deleteContactPoints(ctx context.Context, contactPoints []*deleteContactPointConfig) error {
+	for _, cp := range contactPoints {
+		ap.log.Debug("deleting contact point", "uid", cp.UID, "orgId", cp.OrgID)
+		if err := ap.cfg.ContactPointService.DeleteContactPoint(ctx, cp.OrgID, cp.UID); err != nil {
+			return fmt.Errorf("failed to delete contact point %q: %w", cp.UID, err)
+		}
+	}
+	return nil
+}
+
+func (ap *AlertingProvisioner) deleteMuteTimes(ctx context.Context, muteTimes []*deleteMuteTimeConfig) error {
+	for _, mt := range muteTimes {
+		ap.log.Debug("deleting mute timing", "name", mt.Name, "orgId", mt.OrgID)
+		if err := ap.cfg.MuteTimingService.DeleteMuteTiming(ctx, mt.Name, mt.OrgID); err != nil {
+			return fmt.Errorf("failed to delete mute timing %q: %w", mt.Name, err)
+		}
+	}
+	return nil
+}
+
+func (ap *AlertingProvisioner) deleteTemplates(ctx context.Context, templates []*deleteTemplateConfig) error {
+	for _, tmpl := range templates {
+		ap.log.Debug("deleting template", "name", tmpl.Name, "orgId", tmpl.OrgID)
+		if err := ap.cfg.TemplateService.DeleteTemplate(ctx, tmpl.OrgID, tmpl.Name); err != nil {
+			return fmt.Errorf("failed to delete template %q: %w", tmpl.Name, err)
+		}
+	}
+	return nil
+}
+
+func (ap *AlertingProvisioner) getOrCreateFolderUID(ctx context.Context, orgID int64, folderName string) (string, error) {
+	cmd := &models.GetDashboardQuery{Slug: models.SlugifyTitle(folderName), OrgId: orgID}
+	err := ap.cfg.DashboardStore.GetDashboard(ctx, cmd)
+	if err != nil && !errors.Is(err, models.ErrDashboardNotFound) {
+		return "", err
+	}
+
+	// folder not found. create one.
+	if errors.Is(err, models.ErrDashboardNotFound) {
+		dash := &dashboards.SaveDashboardDTO{}
+		dash.Dashboard = models.NewDashboardFolder(folderName)
+		dash.Dashboard.IsFolder = true
+		dash.Overwrite = true
+		dash.OrgId = orgID
+		dbDash, err := ap.cfg.DashboardProvService.SaveFolderForProvisionedDashboards(ctx, dash)
+		if err != nil {
+			return "", err
+		}
+		return dbDash.Uid, nil
+	}
+
+	if !cmd.Result.IsFolder {
+		return "", fmt.Errorf("got invalid response. expected folder, found dashboard")
+	}
+
+	return cmd.Result.Uid, nil
+}
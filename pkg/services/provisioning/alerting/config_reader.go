@@ -0,0 +1,231 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/provisioning/utils"
+	"gopkg.in/yaml.v2"
+)
+
+type configReader struct {
+	orgStore utils.OrgStore
+	log      log.Logger
+}
+
+func (cr *configReader) readConfig(ctx context.Context, path string) ([]*alertingAsConfig, error) {
+	var configs []*alertingAsConfig
+	cr.log.Debug("Looking for alerting provisioning files", "path", path)
+
+	files, err := ioutil.ReadDir(path)
+	if err != nil {
+		cr.log.Error("Can't read alerting provisioning files from directory", "path", path, "error", err)
+		return configs, nil
+	}
+
+	for _, file := range files {
+		if strings.HasSuffix(file.Name(), ".yaml") || strings.HasSuffix(file.Name(), ".yml") {
+			cr.log.Debug("Parsing alerting provisioning file", "path", path, "file.Name", file.Name())
+			cfg, err := cr.parseConfig(path, file)
+			if err != nil {
+				return nil, fmt.Errorf("failure to parse file %s: %w", file.Name(), err)
+			}
+
+			if cfg != nil {
+				configs = append(configs, cfg)
+			}
+		}
+	}
+
+	cr.log.Debug("Validating alerting provisioning files")
+	if err := validateRequiredFields(configs); err != nil {
+		return nil, err
+	}
+
+	if err := cr.checkOrgIDs(ctx, configs); err != nil {
+		return nil, err
+	}
+
+	return configs, nil
+}
+
+func (cr *configReader) parseConfig(path string, file os.FileInfo) (*alertingAsConfig, error) {
+	filename, err := filepath.Abs(filepath.Join(path, file.Name()))
+	if err != nil {
+		return nil, err
+	}
+
+	// nolint:gosec
+	// We can ignore the gosec G304 warning on this one because `filename` comes from ps.Cfg.ProvisioningPath
+	yamlFile, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg *AlertingAsConfigV1
+	err = yaml.Unmarshal(yamlFile, &cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg != nil && cfg.APIVersion.Value() != 1 {
+		return nil, fmt.Errorf("unsupported apiVersion %d, expected 1", cfg.APIVersion.Value())
+	}
+
+	return cfg.mapToAlertingFromConfig()
+}
+
+// checkOrgIDs defaults every missing orgId to the main org and verifies that the referenced orgs exist.
+func (cr *configReader) checkOrgIDs(ctx context.Context, configs []*alertingAsConfig) error {
+	checked := map[int64]struct{}{}
+	check := func(orgID *int64, kind, name string) error {
+		if *orgID < 1 {
+			*orgID = 1
+			return nil
+		}
+		if _, ok := checked[*orgID]; ok {
+			return nil
+		}
+		if err := utils.CheckOrgExists(ctx, cr.orgStore, *orgID); err != nil {
+			return fmt.Errorf("failed to provision %s %q: %w", kind, name, err)
+		}
+		checked[*orgID] = struct{}{}
+		return nil
+	}
+
+	for _, cfg := range configs {
+		for _, group := range cfg.RuleGroups {
+			if err := check(&group.OrgID, "rule group", group.Name); err != nil {
+				return err
+			}
+		}
+		for _, rule := range cfg.DeleteRules {
+			if err := check(&rule.OrgID, "alert rule", rule.UID); err != nil {
+				return err
+			}
+		}
+		for _, cp := range cfg.ContactPoints {
+			if err := check(&cp.OrgID, "contact point", cp.Receivers[0].Name); err != nil {
+				return err
+			}
+		}
+		for _, cp := range cfg.DeleteContactPoints {
+			if err := check(&cp.OrgID, "contact point", cp.UID); err != nil {
+				return err
+			}
+		}
+		for _, policy := range cfg.Policies {
+			if err := check(&policy.OrgID, "notification policy", policy.Policy.Receiver); err != nil {
+				return err
+			}
+		}
+		for _, mt := range cfg.MuteTimes {
+			if err := check(&mt.OrgID, "mute timing", mt.MuteTime.Name); err != nil {
+				return err
+			}
+		}
+		for _, mt := range cfg.DeleteMuteTimes {
+			if err := check(&mt.OrgID, "mute timing", mt.Name); err != nil {
+				return err
+			}
+		}
+		for _, tmpl := range cfg.Templates {
+			if err := check(&tmpl.OrgID, "template", tmpl.Template.Name); err != nil {
+				return err
+			}
+		}
+		for _, tmpl := range cfg.DeleteTemplates {
+			if err := check(&tmpl.OrgID, "template", tmpl.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateRequiredFields(configs []*alertingAsConfig) error {
+	for _, cfg := range configs {
+		var errStrings []string
+		for index, group := range cfg.RuleGroups {
+			if group.Name == "" {
+				errStrings = append(errStrings, fmt.Sprintf("Rule group item %d in configuration doesn't contain required field name", index+1))
+			}
+			if group.Folder == "" {
+				errStrings = append(errStrings, fmt.Sprintf("Rule group item %d in configuration doesn't contain required field folder", index+1))
+			}
+			if group.Interval <= 0 {
+				errStrings = append(errStrings, fmt.Sprintf("Rule group item %d in configuration doesn't contain required field interval", index+1))
+			}
+			for ruleIndex, rule := range group.Rules {
+				if rule.UID == "" {
+					errStrings = append(errStrings, fmt.Sprintf("Rule %d of rule group item %d in configuration doesn't contain required field uid", ruleIndex+1, index+1))
+				}
+				if rule.Title == "" {
+					errStrings = append(errStrings, fmt.Sprintf("Rule %d of rule group item %d in configuration doesn't contain required field title", ruleIndex+1, index+1))
+				}
+			}
+		}
+
+		for index, rule := range cfg.DeleteRules {
+			if rule.UID == "" {
+				errStrings = append(errStrings, fmt.Sprintf("Deleted rule item %d in configuration doesn't contain required field uid", index+1))
+			}
+		}
+
+		for index, cp := range cfg.ContactPoints {
+			if len(cp.Receivers) == 0 {
+				errStrings = append(errStrings, fmt.Sprintf("Contact point item %d in configuration doesn't contain any receivers", index+1))
+				continue
+			}
+			if cp.Receivers[0].Name == "" {
+				errStrings = append(errStrings, fmt.Sprintf("Contact point item %d in configuration doesn't contain required field name", index+1))
+			}
+			for receiverIndex, receiver := range cp.Receivers {
+				if receiver.UID == "" {
+					errStrings = append(errStrings, fmt.Sprintf("Receiver %d of contact point item %d in configuration doesn't contain required field uid", receiverIndex+1, index+1))
+				}
+			}
+		}
+
+		for index, cp := range cfg.DeleteContactPoints {
+			if cp.UID == "" {
+				errStrings = append(errStrings, fmt.Sprintf("Deleted contact point item %d in configuration doesn't contain required field uid", index+1))
+			}
+		}
+
+		for index, mt := range cfg.MuteTimes {
+			if mt.MuteTime.Name == "" {
+				errStrings = append(errStrings, fmt.Sprintf("Mute timing item %d in configuration doesn't contain required field name", index+1))
+			}
+		}
+
+		for index, mt := range cfg.DeleteMuteTimes {
+			if mt.Name == "" {
+				errStrings = append(errStrings, fmt.Sprintf("Deleted mute timing item %d in configuration doesn't contain required field name", index+1))
+			}
+		}
+
+		for index, tmpl := range cfg.Templates {
+			if tmpl.Template.Name == "" {
+				errStrings = append(errStrings, fmt.Sprintf("Template item %d in configuration doesn't contain required field name", index+1))
+			}
+		}
+
+		for index, tmpl := range cfg.DeleteTemplates {
+			if tmpl.Name == "" {
+				errStrings = append(errStrings, fmt.Sprintf("Deleted template item %d in configuration doesn't contain required field name", index+1))
+			}
+		}
+
+		if len(errStrings) != 0 {
+			return fmt.Errorf(strings.Join(errStrings, "\n"))
+		}
+	}
+
+	return nil
+}
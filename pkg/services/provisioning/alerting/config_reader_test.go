@@ -0,0 +1,143 @@
+package alerting
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	alert_models "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	correctProperties  = "./testdata/test-configs/correct-properties"
+	noRequiredFields   = "./testdata/test-configs/no-required-fields"
+	brokenYaml         = "./testdata/test-configs/broken-yaml"
+	unsupportedVersion = "./testdata/test-configs/unsupported-version"
+	unknownOrg         = "./testdata/test-configs/unknown-org"
+	emptyFolder        = "./testdata/test-configs/empty_folder"
+)
+
+type fakeOrgStore struct{}
+
+func (f *fakeOrgStore) GetOrgById(_ context.Context, q *models.GetOrgByIdQuery) error {
+	if q.Id != 1 {
+		return models.ErrOrgNotFound
+	}
+	q.Result = &models.Org{Id: 1}
+	return nil
+}
+
+func TestAlertingAsConfig(t *testing.T) {
+	cfgProvider := &configReader{
+		orgStore: &fakeOrgStore{},
+		log:      log.New("test logger"),
+	}
+
+	t.Run("Can read correct properties", func(t *testing.T) {
+		_ = os.Setenv("TEST_VAR", "interpolated")
+		cfgs, err := cfgProvider.readConfig(context.Background(), correctProperties)
+		_ = os.Unsetenv("TEST_VAR")
+		require.NoError(t, err)
+		require.Len(t, cfgs, 1)
+		cfg := cfgs[0]
+
+		require.Len(t, cfg.RuleGroups, 1)
+		group := cfg.RuleGroups[0]
+		require.Equal(t, int64(1), group.OrgID)
+		require.Equal(t, "my_rule_group", group.Name)
+		require.Equal(t, "my_folder", group.Folder)
+		require.Equal(t, 2*time.Minute, group.Interval)
+		require.Len(t, group.Rules, 1)
+		rule := group.Rules[0]
+		require.Equal(t, "my_rule_1", rule.UID)
+		require.Equal(t, "my_first_rule", rule.Title)
+		require.Equal(t, "B", rule.Condition)
+		require.Equal(t, alert_models.OK, rule.NoDataState)
+		require.Equal(t, alert_models.ErrorErrState, rule.ExecErrState)
+		require.Equal(t, 5*time.Minute, rule.For)
+		require.Equal(t, "my_dashboard", *rule.DashboardUID)
+		require.Equal(t, int64(2), *rule.PanelID)
+		require.Equal(t, map[string]string{"summary": "interpolated"}, rule.Annotations)
+		require.Equal(t, map[string]string{"team": "sre"}, rule.Labels)
+		require.Len(t, rule.Data, 2)
+		require.Equal(t, "A", rule.Data[0].RefID)
+		require.Equal(t, "my_datasource", rule.Data[0].DatasourceUID)
+		require.Equal(t, alert_models.Duration(10*time.Minute), rule.Data[0].RelativeTimeRange.From)
+		require.JSONEq(t, `{"expr":"up"}`, string(rule.Data[0].Model))
+		require.JSONEq(t, `{"type":"math","expression":"$A > 1"}`, string(rule.Data[1].Model))
+
+		require.Len(t, cfg.DeleteRules, 1)
+		require.Equal(t, "my_rule_2", cfg.DeleteRules[0].UID)
+
+		require.Len(t, cfg.ContactPoints, 1)
+		require.Len(t, cfg.ContactPoints[0].Receivers, 1)
+		receiver := cfg.ContactPoints[0].Receivers[0]
+		require.Equal(t, "my_receiver_1", receiver.UID)
+		require.Equal(t, "my_contact_point", receiver.Name)
+		require.Equal(t, "email", receiver.Type)
+		require.True(t, receiver.DisableResolveMessage)
+		require.Equal(t, "example@example.com", receiver.Settings.Get("addresses").MustString())
+
+		require.Len(t, cfg.DeleteContactPoints, 1)
+		require.Equal(t, int64(1), cfg.DeleteContactPoints[0].OrgID)
+		require.Equal(t, "my_receiver_2", cfg.DeleteContactPoints[0].UID)
+
+		require.Len(t, cfg.Policies, 1)
+		require.Equal(t, "my_contact_point", cfg.Policies[0].Policy.Receiver)
+		require.Equal(t, []string{"alertname"}, cfg.Policies[0].Policy.GroupByStr)
+		require.Len(t, cfg.Policies[0].Policy.Routes, 1)
+		require.Equal(t, []string{"weekends"}, cfg.Policies[0].Policy.Routes[0].MuteTimeIntervals)
+
+		require.Len(t, cfg.MuteTimes, 1)
+		require.Equal(t, "weekends", cfg.MuteTimes[0].MuteTime.Name)
+		require.Len(t, cfg.MuteTimes[0].MuteTime.TimeIntervals, 1)
+		require.Equal(t, alert_models.ProvenanceFile, cfg.MuteTimes[0].MuteTime.Provenance)
+		require.Len(t, cfg.DeleteMuteTimes, 1)
+		require.Equal(t, "holidays", cfg.DeleteMuteTimes[0].Name)
+
+		require.Len(t, cfg.Templates, 1)
+		require.Equal(t, "my_template", cfg.Templates[0].Template.Name)
+		require.Equal(t, `{{ define "my_template" }}custom template{{ end }}`, cfg.Templates[0].Template.Template)
+		require.Equal(t, alert_models.ProvenanceFile, cfg.Templates[0].Template.Provenance)
+		require.Len(t, cfg.DeleteTemplates, 1)
+		require.Equal(t, "old_template", cfg.DeleteTemplates[0].Name)
+	})
+
+	t.Run("Missing required fields should fail", func(t *testing.T) {
+		_, err := cfgProvider.readConfig(context.Background(), noRequiredFields)
+		require.Error(t, err)
+
+		errString := err.Error()
+		require.Contains(t, errString, "Rule group item 1 in configuration doesn't contain required field name")
+		require.Contains(t, errString, "Rule group item 1 in configuration doesn't contain required field interval")
+		require.Contains(t, errString, "Rule 1 of rule group item 1 in configuration doesn't contain required field uid")
+		require.Contains(t, errString, "Receiver 1 of contact point item 1 in configuration doesn't contain required field uid")
+		require.Contains(t, errString, "Deleted rule item 1 in configuration doesn't contain required field uid")
+		require.Contains(t, errString, "Deleted mute timing item 1 in configuration doesn't contain required field name")
+	})
+
+	t.Run("Broken yaml should return error", func(t *testing.T) {
+		_, err := cfgProvider.readConfig(context.Background(), brokenYaml)
+		require.Error(t, err)
+	})
+
+	t.Run("Unsupported apiVersion should return error", func(t *testing.T) {
+		_, err := cfgProvider.readConfig(context.Background(), unsupportedVersion)
+		require.ErrorContains(t, err, "unsupported apiVersion 2")
+	})
+
+	t.Run("Unknown org should return error", func(t *testing.T) {
+		_, err := cfgProvider.readConfig(context.Background(), unknownOrg)
+		require.ErrorIs(t, err, models.ErrOrgNotFound)
+	})
+
+	t.Run("Empty or missing folder should not return error", func(t *testing.T) {
+		cfgs, err := cfgProvider.readConfig(context.Background(), emptyFolder)
+		require.NoError(t, err)
+		require.Empty(t, cfgs)
+	})
+}
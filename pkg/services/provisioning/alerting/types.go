@@ -0,0 +1,368 @@
+package alerting
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/provisioning/values"
+	"github.com/prometheus/alertmanager/config"
+)
+
+// alertingAsConfig is normalized data object for alerting config data. Any config version should be mappable
+// to this type.
+type alertingAsConfig struct {
+	RuleGroups          []*ruleGroupFromConfig
+	DeleteRules         []*deleteRuleConfig
+	ContactPoints       []*contactPointFromConfig
+	DeleteContactPoints []*deleteContactPointConfig
+	Policies            []*policyFromConfig
+	MuteTimes           []*muteTimeFromConfig
+	DeleteMuteTimes     []*deleteMuteTimeConfig
+	Templates           []*templateFromConfig
+	DeleteTemplates     []*deleteTemplateConfig
+}
+
+type ruleGroupFromConfig struct {
+	OrgID    int64
+	Name     string
+	Folder   string
+	Interval time.Duration
+	Rules    []models.AlertRule
+}
+
+type deleteRuleConfig struct {
+	OrgID int64
+	UID   string
+}
+
+type contactPointFromConfig struct {
+	OrgID     int64
+	Receivers []definitions.EmbeddedContactPoint
+}
+
+type deleteContactPointConfig struct {
+	OrgID int64
+	UID   string
+}
+
+type policyFromConfig struct {
+	OrgID  int64
+	Policy definitions.Route
+}
+
+type muteTimeFromConfig struct {
+	OrgID    int64
+	MuteTime definitions.MuteTimeInterval
+}
+
+type deleteMuteTimeConfig struct {
+	OrgID int64
+	Name  string
+}
+
+type templateFromConfig struct {
+	OrgID    int64
+	Template definitions.MessageTemplate
+}
+
+type deleteTemplateConfig struct {
+	OrgID int64
+	Name  string
+}
+
+// AlertingAsConfigV1 is mapping for version 1 configs. This is mapped to its normalised version. It is also used to
+// write provisioning files, so the sections that are left empty are omitted.
+type AlertingAsConfigV1 struct {
+	APIVersion          values.Int64Value        `json:"apiVersion" yaml:"apiVersion"`
+	RuleGroups          []*RuleGroupV1           `json:"groups" yaml:"groups,omitempty"`
+	DeleteRules         []*deleteRuleV1          `json:"deleteRules" yaml:"deleteRules,omitempty"`
+	ContactPoints       []*contactPointV1        `json:"contactPoints" yaml:"contactPoints,omitempty"`
+	DeleteContactPoints []*deleteContactPointV1  `json:"deleteContactPoints" yaml:"deleteContactPoints,omitempty"`
+	Policies            []*policyV1              `json:"policies" yaml:"policies,omitempty"`
+	MuteTimes           []*muteTimeV1            `json:"muteTimes" yaml:"muteTimes,omitempty"`
+	DeleteMuteTimes     []*deleteNamedResourceV1 `json:"deleteMuteTimes" yaml:"deleteMuteTimes,omitempty"`
+	Templates           []*templateV1            `json:"templates" yaml:"templates,omitempty"`
+	DeleteTemplates     []*deleteNamedResourceV1 `json:"deleteTemplates" yaml:"deleteTemplates,omitempty"`
+}
+
+// RuleGroupV1 is a rule group of version 1 configs.
+type RuleGroupV1 struct {
+	OrgID    values.Int64Value  `json:"orgId" yaml:"orgId"`
+	Name     values.StringValue `json:"name" yaml:"name"`
+	Folder   values.StringValue `json:"folder" yaml:"folder"`
+	Interval values.StringValue `json:"interval" yaml:"interval"`
+	Rules    []*RuleV1          `json:"rules" yaml:"rules"`
+}
+
+// RuleV1 is an alert rule of version 1 configs.
+type RuleV1 struct {
+	UID          values.StringValue    `json:"uid" yaml:"uid"`
+	Title        values.StringValue    `json:"title" yaml:"title"`
+	Condition    values.StringValue    `json:"condition" yaml:"condition"`
+	Data         []*QueryV1            `json:"data" yaml:"data"`
+	DashboardUID values.StringValue    `json:"dashboardUid" yaml:"dashboardUid,omitempty"`
+	PanelID      values.Int64Value     `json:"panelId" yaml:"panelId,omitempty"`
+	NoDataState  values.StringValue    `json:"noDataState" yaml:"noDataState"`
+	ExecErrState values.StringValue    `json:"execErrState" yaml:"execErrState"`
+	For          values.StringValue    `json:"for" yaml:"for,omitempty"`
+	Annotations  values.StringMapValue `json:"annotations" yaml:"annotations,omitempty"`
+	Labels       values.StringMapValue `json:"labels" yaml:"labels,omitempty"`
+}
+
+// QueryV1 is a query of an alert rule of version 1 configs.
+type QueryV1 struct {
+	RefID             values.StringValue  `json:"refId" yaml:"refId"`
+	QueryType         values.StringValue  `json:"queryType" yaml:"queryType,omitempty"`
+	RelativeTimeRange RelativeTimeRangeV1 `json:"relativeTimeRange" yaml:"relativeTimeRange"`
+	DatasourceUID     values.StringValue  `json:"datasourceUid" yaml:"datasourceUid"`
+	Model             values.JSONValue    `json:"model" yaml:"model"`
+}
+
+// RelativeTimeRangeV1 holds the offsets of the query range in seconds.
+type RelativeTimeRangeV1 struct {
+	From values.Int64Value `json:"from" yaml:"from"`
+	To   values.Int64Value `json:"to" yaml:"to"`
+}
+
+type deleteRuleV1 struct {
+	OrgID values.Int64Value  `json:"orgId" yaml:"orgId"`
+	UID   values.StringValue `json:"uid" yaml:"uid"`
+}
+
+type contactPointV1 struct {
+	OrgID     values.Int64Value  `json:"orgId" yaml:"orgId"`
+	Name      values.StringValue `json:"name" yaml:"name"`
+	Receivers []*receiverV1      `json:"receivers" yaml:"receivers"`
+}
+
+type receiverV1 struct {
+	UID                   values.StringValue `json:"uid" yaml:"uid"`
+	Type                  values.StringValue `json:"type" yaml:"type"`
+	Settings              values.JSONValue   `json:"settings" yaml:"settings"`
+	DisableResolveMessage values.BoolValue   `json:"disableResolveMessage" yaml:"disableResolveMessage"`
+}
+
+type deleteContactPointV1 struct {
+	OrgID values.Int64Value  `json:"orgId" yaml:"orgId"`
+	UID   values.StringValue `json:"uid" yaml:"uid"`
+}
+
+// policyV1 is the root of a notification policy tree. The routing options are declared next to the orgId.
+type policyV1 struct {
+	OrgID  values.Int64Value
+	Policy definitions.Route
+}
+
+func (p *policyV1) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var org struct {
+		OrgID values.Int64Value `yaml:"orgId"`
+	}
+	if err := unmarshal(&org); err != nil {
+		return err
+	}
+	p.OrgID = org.OrgID
+	return unmarshal(&p.Policy)
+}
+
+// muteTimeV1 is a mute timing. The time intervals are declared next to the orgId.
+type muteTimeV1 struct {
+	OrgID    values.Int64Value
+	MuteTime config.MuteTimeInterval
+}
+
+func (m *muteTimeV1) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var org struct {
+		OrgID values.Int64Value `yaml:"orgId"`
+	}
+	if err := unmarshal(&org); err != nil {
+		return err
+	}
+	m.OrgID = org.OrgID
+	return unmarshal(&m.MuteTime)
+}
+
+type templateV1 struct {
+	OrgID    values.Int64Value  `json:"orgId" yaml:"orgId"`
+	Name     values.StringValue `json:"name" yaml:"name"`
+	Template values.StringValue `json:"template" yaml:"template"`
+}
+
+type deleteNamedResourceV1 struct {
+	OrgID values.Int64Value  `json:"orgId" yaml:"orgId"`
+	Name  values.StringValue `json:"name" yaml:"name"`
+}
+
+// mapToAlertingFromConfig maps config syntax to normalized alertingAsConfig object. Every version
+// of the config syntax should have this function.
+func (cfg *AlertingAsConfigV1) mapToAlertingFromConfig() (*alertingAsConfig, error) {
+	r := &alertingAsConfig{}
+	if cfg == nil {
+		return r, nil
+	}
+
+	for _, group := range cfg.RuleGroups {
+		g, err := group.mapToModel()
+		if err != nil {
+			return nil, err
+		}
+		r.RuleGroups = append(r.RuleGroups, g)
+	}
+
+	for _, rule := range cfg.DeleteRules {
+		r.DeleteRules = append(r.DeleteRules, &deleteRuleConfig{
+			OrgID: rule.OrgID.Value(),
+			UID:   rule.UID.Value(),
+		})
+	}
+
+	for _, cp := range cfg.ContactPoints {
+		contactPoint := &contactPointFromConfig{OrgID: cp.OrgID.Value()}
+		for _, receiver := range cp.Receivers {
+			settings := simplejson.New()
+			for k, v := range receiver.Settings.Value() {
+				settings.Set(k, v)
+			}
+			contactPoint.Receivers = append(contactPoint.Receivers, definitions.EmbeddedContactPoint{
+				UID:                   receiver.UID.Value(),
+				Name:                  cp.Name.Value(),
+				Type:                  receiver.Type.Value(),
+				DisableResolveMessage: receiver.DisableResolveMessage.Value(),
+				Settings:              settings,
+			})
+		}
+		r.ContactPoints = append(r.ContactPoints, contactPoint)
+	}
+
+	for _, cp := range cfg.DeleteContactPoints {
+		r.DeleteContactPoints = append(r.DeleteContactPoints, &deleteContactPointConfig{
+			OrgID: cp.OrgID.Value(),
+			UID:   cp.UID.Value(),
+		})
+	}
+
+	for _, policy := range cfg.Policies {
+		r.Policies = append(r.Policies, &policyFromConfig{
+			OrgID:  policy.OrgID.Value(),
+			Policy: policy.Policy,
+		})
+	}
+
+	for _, mt := range cfg.MuteTimes {
+		r.MuteTimes = append(r.MuteTimes, &muteTimeFromConfig{
+			OrgID: mt.OrgID.Value(),
+			MuteTime: definitions.MuteTimeInterval{
+				MuteTimeInterval: mt.MuteTime,
+				Provenance:       models.ProvenanceFile,
+			},
+		})
+	}
+
+	for _, mt := range cfg.DeleteMuteTimes {
+		r.DeleteMuteTimes = append(r.DeleteMuteTimes, &deleteMuteTimeConfig{
+			OrgID: mt.OrgID.Value(),
+			Name:  mt.Name.Value(),
+		})
+	}
+
+	for _, tmpl := range cfg.Templates {
+		r.Templates = append(r.Templates, &templateFromConfig{
+			OrgID: tmpl.OrgID.Value(),
+			Template: definitions.MessageTemplate{
+				Name:       tmpl.Name.Value(),
+				Template:   tmpl.Template.Value(),
+				Provenance: models.ProvenanceFile,
+			},
+		})
+	}
+
+	for _, tmpl := range cfg.DeleteTemplates {
+		r.DeleteTemplates = append(r.DeleteTemplates, &deleteTemplateConfig{
+			OrgID: tmpl.OrgID.Value(),
+			Name:  tmpl.Name.Value(),
+		})
+	}
+
+	return r, nil
+}
+
+func (group *RuleGroupV1) mapToModel() (*ruleGroupFromConfig, error) {
+	g := &ruleGroupFromConfig{
+		OrgID:  group.OrgID.Value(),
+		Name:   group.Name.Value(),
+		Folder: group.Folder.Value(),
+	}
+	if interval := group.Interval.Value(); interval != "" {
+		d, err := time.ParseDuration(interval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid interval of rule group %q: %w", g.Name, err)
+		}
+		g.Interval = d
+	}
+	for _, rule := range group.Rules {
+		r, err := rule.mapToModel()
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule %q in rule group %q: %w", rule.Title.Value(), g.Name, err)
+		}
+		g.Rules = append(g.Rules, r)
+	}
+	return g, nil
+}
+
+func (rule *RuleV1) mapToModel() (models.AlertRule, error) {
+	r := models.AlertRule{
+		UID:          rule.UID.Value(),
+		Title:        rule.Title.Value(),
+		Condition:    rule.Condition.Value(),
+		NoDataState:  models.NoData,
+		ExecErrState: models.AlertingErrState,
+		Annotations:  rule.Annotations.Value(),
+		Labels:       rule.Labels.Value(),
+	}
+	if s := rule.NoDataState.Value(); s != "" {
+		noDataState, err := models.NoDataStateFromString(s)
+		if err != nil {
+			return models.AlertRule{}, err
+		}
+		r.NoDataState = noDataState
+	}
+	if s := rule.ExecErrState.Value(); s != "" {
+		execErrState, err := models.ErrStateFromString(s)
+		if err != nil {
+			return models.AlertRule{}, err
+		}
+		r.ExecErrState = execErrState
+	}
+	if s := rule.For.Value(); s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return models.AlertRule{}, fmt.Errorf("invalid for: %w", err)
+		}
+		r.For = d
+	}
+	if dashboardUID := rule.DashboardUID.Value(); dashboardUID != "" {
+		panelID := rule.PanelID.Value()
+		r.DashboardUID = &dashboardUID
+		r.PanelID = &panelID
+	}
+	for _, query := range rule.Data {
+		model, err := json.Marshal(query.Model.Value())
+		if err != nil {
+			return models.AlertRule{}, err
+		}
+		r.Data = append(r.Data, models.AlertQuery{
+			RefID:         query.RefID.Value(),
+			QueryType:     query.QueryType.Value(),
+			DatasourceUID: query.DatasourceUID.Value(),
+			RelativeTimeRange: models.RelativeTimeRange{
+				From: models.Duration(time.Duration(query.RelativeTimeRange.From.Value()) * time.Second),
+				To:   models.Duration(time.Duration(query.RelativeTimeRange.To.Value()) * time.Second),
+			},
+			Model: model,
+		})
+	}
+	return r, nil
+}
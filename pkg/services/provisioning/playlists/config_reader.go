@@ -0,0 +1,123 @@
+package playlists
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/provisioning/utils"
+)
+
+// playlistItemTypes are the types of the items of provisioned playlists. The items cannot refer to dashboards by
+// ID, since the IDs of the dashboards differ between instances.
+var playlistItemTypes = map[string]struct{}{
+	"dashboard_by_uid": {},
+	"dashboard_by_tag": {},
+}
+
+type configReader struct {
+	log      log.Logger
+	orgStore utils.OrgStore
+}
+
+func (cr *configReader) readConfig(ctx context.Context, path string) ([]*playlistsAsConfig, error) {
+	var playlists []*playlistsAsConfig
+	cr.log.Debug("Looking for playlist provisioning files", "path", path)
+
+	files, err := ioutil.ReadDir(path)
+	if err != nil {
+		cr.log.Error("Failed to read playlist provisioning files from directory", "path", path, "error", err)
+		return playlists, nil
+	}
+
+	for _, file := range files {
+		if strings.HasSuffix(file.Name(), ".yaml") || strings.HasSuffix(file.Name(), ".yml") {
+			cr.log.Debug("Parsing playlist provisioning file", "path", path, "file.Name", file.Name())
+			playlist, err := cr.parsePlaylistConfig(path, file)
+			if err != nil {
+				return nil, err
+			}
+
+			if playlist != nil {
+				playlists = append(playlists, playlist)
+			}
+		}
+	}
+
+	cr.log.Debug("Validating playlists")
+	if err := cr.validatePlaylists(ctx, playlists); err != nil {
+		return nil, err
+	}
+
+	return playlists, nil
+}
+
+func (cr *configReader) parsePlaylistConfig(path string, file os.FileInfo) (*playlistsAsConfig, error) {
+	filename, err := filepath.Abs(filepath.Join(path, file.Name()))
+	if err != nil {
+		return nil, err
+	}
+
+	// nolint:gosec
+	// We can ignore the gosec G304 warning on this one because `filename` comes from ps.Cfg.ProvisioningPath
+	yamlFile, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg *playlistsAsConfigV1
+	err = yaml.Unmarshal(yamlFile, &cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg.mapToPlaylistsFromConfig(), nil
+}
+
+func (cr *configReader) validatePlaylists(ctx context.Context, playlists []*playlistsAsConfig) error {
+	for i := range playlists {
+		for index, playlist := range playlists[i].Playlists {
+			if playlist.UID == "" {
+				return fmt.Errorf("playlist %d in configuration doesn't contain required field uid", index+1)
+			}
+			if playlist.Name == "" {
+				return fmt.Errorf("playlist %q in configuration doesn't contain required field name", playlist.UID)
+			}
+			if playlist.Interval == "" {
+				return fmt.Errorf("playlist %q in configuration doesn't contain required field interval", playlist.UID)
+			}
+			for _, item := range playlist.Items {
+				if _, ok := playlistItemTypes[item.Type]; !ok {
+					return fmt.Errorf("playlist %q in configuration contains an item with invalid type %q", playlist.UID, item.Type)
+				}
+				if item.Value == "" {
+					return fmt.Errorf("playlist %q in configuration contains an item without value", playlist.UID)
+				}
+			}
+
+			if playlist.OrgID < 1 {
+				playlist.OrgID = 1
+			}
+			if err := utils.CheckOrgExists(ctx, cr.orgStore, playlist.OrgID); err != nil {
+				return fmt.Errorf("failed to provision %q playlist: %w", playlist.UID, err)
+			}
+		}
+
+		for index, playlist := range playlists[i].DeletePlaylists {
+			if playlist.UID == "" {
+				return fmt.Errorf("delete playlist %d in configuration doesn't contain required field uid", index+1)
+			}
+			if playlist.OrgID < 1 {
+				playlist.OrgID = 1
+			}
+		}
+	}
+
+	return nil
+}
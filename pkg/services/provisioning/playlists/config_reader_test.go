@@ -0,0 +1,150 @@
+package playlists
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+)
+
+const (
+	correctProperties = "./testdata/correct-properties"
+	brokenYaml        = "./testdata/broken-yaml"
+	invalidItemType   = "./testdata/invalid-item-type"
+	missingUID        = "./testdata/missing-uid"
+	emptyFolder       = "./testdata/empty_folder"
+)
+
+func TestConfigReader(t *testing.T) {
+	t.Run("Broken yaml should return error", func(t *testing.T) {
+		reader := &configReader{log: log.New("test logger"), orgStore: &fakeOrgStore{}}
+		_, err := reader.readConfig(context.Background(), brokenYaml)
+		require.Error(t, err)
+	})
+
+	t.Run("Skip invalid directory", func(t *testing.T) {
+		reader := &configReader{log: log.New("test logger"), orgStore: &fakeOrgStore{}}
+		cfg, err := reader.readConfig(context.Background(), emptyFolder)
+		require.NoError(t, err)
+		require.Len(t, cfg, 0)
+	})
+
+	t.Run("Items referring to dashboards by ID should return error", func(t *testing.T) {
+		reader := &configReader{log: log.New("test logger"), orgStore: &fakeOrgStore{}}
+		_, err := reader.readConfig(context.Background(), invalidItemType)
+		require.EqualError(t, err, `playlist "noc" in configuration contains an item with invalid type "dashboard_by_id"`)
+	})
+
+	t.Run("Playlist without uid should return error", func(t *testing.T) {
+		reader := &configReader{log: log.New("test logger"), orgStore: &fakeOrgStore{}}
+		_, err := reader.readConfig(context.Background(), missingUID)
+		require.EqualError(t, err, "playlist 1 in configuration doesn't contain required field uid")
+	})
+
+	t.Run("Playlist of an unknown org should return error", func(t *testing.T) {
+		reader := &configReader{log: log.New("test logger"), orgStore: &fakeOrgStore{missingOrgs: map[int64]bool{2: true}}}
+		_, err := reader.readConfig(context.Background(), correctProperties)
+		require.ErrorIs(t, err, models.ErrOrgNotFound)
+	})
+
+	t.Run("Can read correct properties", func(t *testing.T) {
+		reader := &configReader{log: log.New("test logger"), orgStore: &fakeOrgStore{}}
+		cfg, err := reader.readConfig(context.Background(), correctProperties)
+		require.NoError(t, err)
+		require.Len(t, cfg, 1)
+		require.Len(t, cfg[0].Playlists, 2)
+
+		noc := cfg[0].Playlists[0]
+		require.Equal(t, "noc", noc.UID)
+		require.Equal(t, int64(2), noc.OrgID)
+		require.Equal(t, "NOC", noc.Name)
+		require.Equal(t, "5m", noc.Interval)
+		require.Equal(t, []models.PlaylistItemDTO{
+			{Type: "dashboard_by_uid", Value: "backend-latency", Title: "Backend latency", Order: 1, Interval: "30s", TimeFrom: "now-1h", TimeTo: "now"},
+			{Type: "dashboard_by_tag", Value: "backend", Order: 2},
+		}, noc.Items)
+
+		lobby := cfg[0].Playlists[1]
+		require.Equal(t, "lobby", lobby.UID)
+		require.Equal(t, int64(1), lobby.OrgID)
+		require.Empty(t, lobby.Items)
+
+		require.Len(t, cfg[0].DeletePlaylists, 1)
+		require.Equal(t, &deletePlaylistConfig{OrgID: 1, UID: "old"}, cfg[0].DeletePlaylists[0])
+	})
+}
+
+func TestPlaylistProvisioner(t *testing.T) {
+	t.Run("Should create new playlists, update existing playlists and delete playlists", func(t *testing.T) {
+		store := &fakeStore{existing: map[string]bool{"lobby": true}}
+		pp := PlaylistProvisioner{
+			log:         log.New("test logger"),
+			cfgProvider: &configReader{log: log.New("test logger"), orgStore: &fakeOrgStore{}},
+			store:       store,
+		}
+
+		err := pp.applyChanges(context.Background(), correctProperties)
+		require.NoError(t, err)
+
+		require.Len(t, store.created, 1)
+		require.Equal(t, "noc", store.created[0].UID)
+		require.Equal(t, int64(2), store.created[0].OrgId)
+		require.Len(t, store.created[0].Items, 2)
+
+		require.Len(t, store.updated, 1)
+		require.Equal(t, "lobby", store.updated[0].UID)
+		require.Equal(t, "Lobby", store.updated[0].Name)
+
+		require.Len(t, store.deleted, 1)
+		require.Equal(t, "old", store.deleted[0].UID)
+	})
+}
+
+type fakeOrgStore struct {
+	missingOrgs map[int64]bool
+}
+
+func (s *fakeOrgStore) GetOrgById(_ context.Context, query *models.GetOrgByIdQuery) error {
+	if s.missingOrgs[query.Id] {
+		return models.ErrOrgNotFound
+	}
+	query.Result = &models.Org{Id: query.Id}
+	return nil
+}
+
+type fakeStore struct {
+	existing map[string]bool
+	created  []*models.CreatePlaylistCommand
+	updated  []*models.UpdatePlaylistCommand
+	deleted  []*models.DeletePlaylistCommand
+}
+
+func (s *fakeStore) GetPlaylist(_ context.Context, query *models.GetPlaylistByUidQuery) error {
+	if !s.existing[query.UID] {
+		return models.ErrPlaylistNotFound
+	}
+	query.Result = &models.Playlist{UID: query.UID, OrgId: query.OrgId}
+	return nil
+}
+
+func (s *fakeStore) CreatePlaylist(_ context.Context, cmd *models.CreatePlaylistCommand) error {
+	s.created = append(s.created, cmd)
+	return nil
+}
+
+func (s *fakeStore) UpdatePlaylist(_ context.Context, cmd *models.UpdatePlaylistCommand) error {
+	s.updated = append(s.updated, cmd)
+	return nil
+}
+
+func (s *fakeStore) DeletePlaylist(_ context.Context, cmd *models.DeletePlaylistCommand) error {
+	if cmd.UID == "" {
+		return errors.New("missing uid")
+	}
+	s.deleted = append(s.deleted, cmd)
+	return nil
+}
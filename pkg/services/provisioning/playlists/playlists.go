@@ -0,0 +1,97 @@
+package playlists
+
+import (
+	"context"
+	"errors"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/provisioning/utils"
+)
+
+type Store interface {
+	GetPlaylist(ctx context.Context, query *models.GetPlaylistByUidQuery) error
+	CreatePlaylist(ctx context.Context, cmd *models.CreatePlaylistCommand) error
+	UpdatePlaylist(ctx context.Context, cmd *models.UpdatePlaylistCommand) error
+	DeletePlaylist(ctx context.Context, cmd *models.DeletePlaylistCommand) error
+}
+
+// Provision scans a directory for provisioning config files
+// and provisions the playlists in those files.
+func Provision(ctx context.Context, configDirectory string, store Store, orgStore utils.OrgStore) error {
+	logger := log.New("provisioning.playlists")
+	pp := PlaylistProvisioner{
+		log:         logger,
+		cfgProvider: &configReader{log: logger, orgStore: orgStore},
+		store:       store,
+	}
+	return pp.applyChanges(ctx, configDirectory)
+}
+
+// PlaylistProvisioner is responsible for provisioning playlists based on
+// configuration read by the `configReader`
+type PlaylistProvisioner struct {
+	log         log.Logger
+	cfgProvider *configReader
+	store       Store
+}
+
+func (pp *PlaylistProvisioner) apply(ctx context.Context, cfg *playlistsAsConfig) error {
+	for _, playlist := range cfg.DeletePlaylists {
+		pp.log.Debug("Deleting playlist from configuration", "uid", playlist.UID, "orgId", playlist.OrgID)
+		err := pp.store.DeletePlaylist(ctx, &models.DeletePlaylistCommand{UID: playlist.UID, OrgId: playlist.OrgID})
+		if err != nil && !errors.Is(err, models.ErrPlaylistNotFound) {
+			return err
+		}
+	}
+
+	for _, playlist := range cfg.Playlists {
+		query := &models.GetPlaylistByUidQuery{UID: playlist.UID, OrgId: playlist.OrgID}
+		err := pp.store.GetPlaylist(ctx, query)
+		if err != nil && !errors.Is(err, models.ErrPlaylistNotFound) {
+			return err
+		}
+
+		if errors.Is(err, models.ErrPlaylistNotFound) {
+			pp.log.Info("Inserting playlist from configuration", "uid", playlist.UID, "orgId", playlist.OrgID)
+			if err := pp.store.CreatePlaylist(ctx, &models.CreatePlaylistCommand{
+				UID:      playlist.UID,
+				Name:     playlist.Name,
+				Interval: playlist.Interval,
+				Items:    playlist.Items,
+				OrgId:    playlist.OrgID,
+			}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		pp.log.Debug("Updating playlist from configuration", "uid", playlist.UID, "orgId", playlist.OrgID)
+		if err := pp.store.UpdatePlaylist(ctx, &models.UpdatePlaylistCommand{
+			UID:      playlist.UID,
+			Name:     playlist.Name,
+			Interval: playlist.Interval,
+			Items:    playlist.Items,
+			OrgId:    playlist.OrgID,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (pp *PlaylistProvisioner) applyChanges(ctx context.Context, configPath string) error {
+	configs, err := pp.cfgProvider.readConfig(ctx, configPath)
+	if err != nil {
+		return err
+	}
+
+	for _, cfg := range configs {
+		if err := pp.apply(ctx, cfg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
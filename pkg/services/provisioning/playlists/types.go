@@ -0,0 +1,94 @@
+package playlists
+
+import (
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/provisioning/values"
+)
+
+// playlistsAsConfig is a normalized data object for playlists config data. Any config version should be mappable
+// to this type.
+type playlistsAsConfig struct {
+	Playlists       []*playlistFromConfig
+	DeletePlaylists []*deletePlaylistConfig
+}
+
+type playlistFromConfig struct {
+	OrgID    int64
+	UID      string
+	Name     string
+	Interval string
+	Items    []models.PlaylistItemDTO
+}
+
+type deletePlaylistConfig struct {
+	OrgID int64
+	UID   string
+}
+
+type playlistsAsConfigV1 struct {
+	Playlists       []*playlistFromConfigV1   `json:"playlists" yaml:"playlists"`
+	DeletePlaylists []*deletePlaylistConfigV1 `json:"deletePlaylists" yaml:"deletePlaylists"`
+}
+
+type playlistFromConfigV1 struct {
+	OrgID    values.Int64Value           `json:"orgId" yaml:"orgId"`
+	UID      values.StringValue          `json:"uid" yaml:"uid"`
+	Name     values.StringValue          `json:"name" yaml:"name"`
+	Interval values.StringValue          `json:"interval" yaml:"interval"`
+	Items    []*playlistItemFromConfigV1 `json:"items" yaml:"items"`
+}
+
+type playlistItemFromConfigV1 struct {
+	Type     values.StringValue `json:"type" yaml:"type"`
+	Value    values.StringValue `json:"value" yaml:"value"`
+	Title    values.StringValue `json:"title" yaml:"title"`
+	Interval values.StringValue `json:"interval" yaml:"interval"`
+	TimeFrom values.StringValue `json:"timeFrom" yaml:"timeFrom"`
+	TimeTo   values.StringValue `json:"timeTo" yaml:"timeTo"`
+}
+
+type deletePlaylistConfigV1 struct {
+	OrgID values.Int64Value  `json:"orgId" yaml:"orgId"`
+	UID   values.StringValue `json:"uid" yaml:"uid"`
+}
+
+// mapToPlaylistsFromConfig maps config syntax to a normalized playlistsAsConfig object. Every version
+// of the config syntax should have this function.
+func (cfg *playlistsAsConfigV1) mapToPlaylistsFromConfig() *playlistsAsConfig {
+	r := &playlistsAsConfig{}
+	if cfg == nil {
+		return r
+	}
+
+	for _, playlist := range cfg.Playlists {
+		items := make([]models.PlaylistItemDTO, 0, len(playlist.Items))
+		for index, item := range playlist.Items {
+			items = append(items, models.PlaylistItemDTO{
+				Type:     item.Type.Value(),
+				Value:    item.Value.Value(),
+				Title:    item.Title.Value(),
+				Order:    index + 1,
+				Interval: item.Interval.Value(),
+				TimeFrom: item.TimeFrom.Value(),
+				TimeTo:   item.TimeTo.Value(),
+			})
+		}
+
+		r.Playlists = append(r.Playlists, &playlistFromConfig{
+			OrgID:    playlist.OrgID.Value(),
+			UID:      playlist.UID.Value(),
+			Name:     playlist.Name.Value(),
+			Interval: playlist.Interval.Value(),
+			Items:    items,
+		})
+	}
+
+	for _, playlist := range cfg.DeletePlaylists {
+		r.DeletePlaylists = append(r.DeletePlaylists, &deletePlaylistConfig{
+			OrgID: playlist.OrgID.Value(),
+			UID:   playlist.UID.Value(),
+		})
+	}
+
+	return r
+}
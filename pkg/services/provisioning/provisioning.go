@@ -12,13 +12,18 @@ import (
 	dashboardservice "github.com/grafana/grafana/pkg/services/dashboards"
 	datasourceservice "github.com/grafana/grafana/pkg/services/datasources"
 	"github.com/grafana/grafana/pkg/services/encryption"
+	ngalertprovisioning "github.com/grafana/grafana/pkg/services/ngalert/provisioning"
+	ngalertstore "github.com/grafana/grafana/pkg/services/ngalert/store"
 	"github.com/grafana/grafana/pkg/services/notifications"
 	"github.com/grafana/grafana/pkg/services/pluginsettings"
+	alertingprovisioning "github.com/grafana/grafana/pkg/services/provisioning/alerting"
 	"github.com/grafana/grafana/pkg/services/provisioning/dashboards"
 	"github.com/grafana/grafana/pkg/services/provisioning/datasources"
 	"github.com/grafana/grafana/pkg/services/provisioning/notifiers"
+	"github.com/grafana/grafana/pkg/services/provisioning/playlists"
 	"github.com/grafana/grafana/pkg/services/provisioning/plugins"
 	"github.com/grafana/grafana/pkg/services/provisioning/utils"
+	"github.com/grafana/grafana/pkg/services/secrets"
 	"github.com/grafana/grafana/pkg/services/sqlstore"
 	"github.com/grafana/grafana/pkg/setting"
 	"github.com/grafana/grafana/pkg/util/errutil"
@@ -30,6 +35,7 @@ func ProvideService(cfg *setting.Cfg, sqlStore *sqlstore.SQLStore, pluginStore p
 	datasourceService datasourceservice.DataSourceService,
 	dashboardService dashboardservice.DashboardService,
 	alertingService *alerting.AlertNotificationService, pluginSettings pluginsettings.Service,
+	secretsService secrets.Service,
 ) (*ProvisioningServiceImpl, error) {
 	s := &ProvisioningServiceImpl{
 		Cfg:                          cfg,
@@ -42,11 +48,14 @@ func ProvideService(cfg *setting.Cfg, sqlStore *sqlstore.SQLStore, pluginStore p
 		provisionNotifiers:           notifiers.Provision,
 		provisionDatasources:         datasources.Provision,
 		provisionPlugins:             plugins.Provision,
+		provisionAlerting:            alertingprovisioning.Provision,
+		provisionPlaylists:           playlists.Provision,
 		dashboardProvisioningService: dashboardProvisioningService,
 		dashboardService:             dashboardService,
 		datasourceService:            datasourceService,
 		alertingService:              alertingService,
 		pluginsSettings:              pluginSettings,
+		secretsService:               secretsService,
 	}
 	return s, nil
 }
@@ -58,6 +67,8 @@ type ProvisioningService interface {
 	ProvisionPlugins(ctx context.Context) error
 	ProvisionNotifications(ctx context.Context) error
 	ProvisionDashboards(ctx context.Context) error
+	ProvisionAlerting(ctx context.Context) error
+	ProvisionPlaylists(ctx context.Context) error
 	GetDashboardProvisionerResolvedPath(name string) string
 	GetAllowUIUpdatesFromConfig(name string) bool
 }
@@ -70,6 +81,8 @@ func NewProvisioningServiceImpl() *ProvisioningServiceImpl {
 		provisionNotifiers:      notifiers.Provision,
 		provisionDatasources:    datasources.Provision,
 		provisionPlugins:        plugins.Provision,
+		provisionAlerting:       alertingprovisioning.Provision,
+		provisionPlaylists:      playlists.Provision,
 	}
 }
 
@@ -86,6 +99,8 @@ func newProvisioningServiceImpl(
 		provisionNotifiers:      provisionNotifiers,
 		provisionDatasources:    provisionDatasources,
 		provisionPlugins:        provisionPlugins,
+		provisionAlerting:       alertingprovisioning.Provision,
+		provisionPlaylists:      playlists.Provision,
 	}
 }
 
@@ -102,12 +117,15 @@ type ProvisioningServiceImpl struct {
 	provisionNotifiers           func(context.Context, string, notifiers.Manager, notifiers.SQLStore, encryption.Internal, *notifications.NotificationService) error
 	provisionDatasources         func(context.Context, string, datasources.Store, utils.OrgStore) error
 	provisionPlugins             func(context.Context, string, plugins.Store, plugifaces.Store, pluginsettings.Service) error
+	provisionAlerting            func(context.Context, alertingprovisioning.ProvisionerConfig) error
+	provisionPlaylists           func(context.Context, string, playlists.Store, utils.OrgStore) error
 	mutex                        sync.Mutex
 	dashboardProvisioningService dashboardservice.DashboardProvisioningService
 	dashboardService             dashboardservice.DashboardService
 	datasourceService            datasourceservice.DataSourceService
 	alertingService              *alerting.AlertNotificationService
 	pluginsSettings              pluginsettings.Service
+	secretsService               secrets.Service
 }
 
 func (ps *ProvisioningServiceImpl) RunInitProvisioners(ctx context.Context) error {
@@ -126,6 +144,16 @@ func (ps *ProvisioningServiceImpl) RunInitProvisioners(ctx context.Context) erro
 		return err
 	}
 
+	err = ps.ProvisionAlerting(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = ps.ProvisionPlaylists(ctx)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -188,6 +216,50 @@ func (ps *ProvisioningServiceImpl) ProvisionNotifications(ctx context.Context) e
 	return nil
 }
 
+func (ps *ProvisioningServiceImpl) ProvisionAlerting(ctx context.Context) error {
+	if !ps.Cfg.UnifiedAlerting.IsEnabled() {
+		ps.log.Debug("Skipping alerting provisioning, unified alerting is disabled")
+		return nil
+	}
+
+	alertingPath := filepath.Join(ps.Cfg.ProvisioningPath, "alerting")
+	st := &ngalertstore.DBstore{
+		BaseInterval:     ps.Cfg.UnifiedAlerting.BaseInterval,
+		DefaultInterval:  ps.Cfg.UnifiedAlerting.DefaultRuleEvaluationInterval,
+		SQLStore:         ps.SQLStore,
+		Logger:           ps.log,
+		DashboardService: ps.dashboardService,
+	}
+	cfg := alertingprovisioning.ProvisionerConfig{
+		Path:                 alertingPath,
+		OrgStore:             ps.SQLStore,
+		DashboardStore:       ps.dashboardService,
+		DashboardProvService: ps.dashboardProvisioningService,
+		RuleService: ngalertprovisioning.NewAlertRuleService(st, st, st,
+			int64(ps.Cfg.UnifiedAlerting.DefaultRuleEvaluationInterval.Seconds()), ps.log),
+		ContactPointService:       ngalertprovisioning.NewContactPointService(st, ps.secretsService, st, st, ps.log),
+		NotificationPolicyService: ngalertprovisioning.NewNotificationPolicyService(st, st, st, ps.log),
+		MuteTimingService:         ngalertprovisioning.NewMuteTimingService(st, st, st, ps.log),
+		TemplateService:           ngalertprovisioning.NewTemplateService(st, st, st, ps.log),
+	}
+	if err := ps.provisionAlerting(ctx, cfg); err != nil {
+		err = errutil.Wrap("Alerting provisioning error", err)
+		ps.log.Error("Failed to provision alerting", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (ps *ProvisioningServiceImpl) ProvisionPlaylists(ctx context.Context) error {
+	playlistPath := filepath.Join(ps.Cfg.ProvisioningPath, "playlists")
+	if err := ps.provisionPlaylists(ctx, playlistPath, ps.SQLStore, ps.SQLStore); err != nil {
+		err = errutil.Wrap("Playlist provisioning error", err)
+		ps.log.Error("Failed to provision playlists", "error", err)
+		return err
+	}
+	return nil
+}
+
 func (ps *ProvisioningServiceImpl) ProvisionDashboards(ctx context.Context) error {
 	dashboardPath := filepath.Join(ps.Cfg.ProvisioningPath, "dashboards")
 	dashProvisioner, err := ps.newDashboardProvisioner(ctx, dashboardPath, ps.dashboardProvisioningService, ps.SQLStore, ps.dashboardService)
@@ -49,6 +49,11 @@ func (val *IntValue) Value() int {
 	return val.value
 }
 
+// MarshalYAML converts the IntValue into YAML without interpolating it
+func (val IntValue) MarshalYAML() (interface{}, error) {
+	return rawNumber(val.Raw), nil
+}
+
 // Int64Value represents a string value in a YAML
 // config that can be overridden by environment variables
 type Int64Value struct {
@@ -76,6 +81,11 @@ func (val *Int64Value) Value() int64 {
 	return val.value
 }
 
+// MarshalYAML converts the Int64Value into YAML without interpolating it
+func (val Int64Value) MarshalYAML() (interface{}, error) {
+	return rawNumber(val.Raw), nil
+}
+
 // StringValue represents a string value in a YAML
 // config that can be overridden by environment variables
 type StringValue struct {
@@ -99,6 +109,11 @@ func (val *StringValue) Value() string {
 	return val.value
 }
 
+// MarshalYAML converts the StringValue into YAML without interpolating it
+func (val StringValue) MarshalYAML() (interface{}, error) {
+	return val.Raw, nil
+}
+
 // BoolValue represents a string value in a YAML
 // config that can be overridden by environment variables
 type BoolValue struct {
@@ -122,6 +137,14 @@ func (val *BoolValue) Value() bool {
 	return val.value
 }
 
+// MarshalYAML converts the BoolValue into YAML without interpolating it
+func (val BoolValue) MarshalYAML() (interface{}, error) {
+	if b, err := strconv.ParseBool(val.Raw); err == nil {
+		return b, nil
+	}
+	return val.Raw, nil
+}
+
 // JSONValue represents a string value in a YAML
 // config that can be overridden by environment variables
 type JSONValue struct {
@@ -155,6 +178,11 @@ func (val *JSONValue) Value() map[string]interface{} {
 	return val.value
 }
 
+// MarshalYAML converts the JSONValue into YAML without interpolating it
+func (val JSONValue) MarshalYAML() (interface{}, error) {
+	return val.Raw, nil
+}
+
 // StringMapValue represents a string value in a YAML
 // config that can be overridden by environment variables
 type StringMapValue struct {
@@ -187,6 +215,19 @@ func (val *StringMapValue) Value() map[string]string {
 	return val.value
 }
 
+// MarshalYAML converts the StringMapValue into YAML without interpolating it
+func (val StringMapValue) MarshalYAML() (interface{}, error) {
+	return val.Raw, nil
+}
+
+// rawNumber returns the raw value of a number as a number, unless it refers to environment variables.
+func rawNumber(raw string) interface{} {
+	if i, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return i
+	}
+	return raw
+}
+
 // transformInterface tries to transform any interface type into proper value with env expansion. It traverses maps and
 // slices and the actual interpolation is done on all simple string values in the structure. It returns a copy of any
 // map or slice value instead of modifying them in place and also return value without interpolation but with converted
@@ -253,6 +253,30 @@ func TestValues(t *testing.T) {
 	})
 }
 
+func TestValues_marshal(t *testing.T) {
+	type Data struct {
+		Int    Int64Value     `yaml:"int"`
+		String StringValue    `yaml:"string"`
+		Bool   BoolValue      `yaml:"bool"`
+		JSON   JSONValue      `yaml:"json"`
+		Map    StringMapValue `yaml:"map"`
+	}
+	doc := `int: 1
+string: $$test $STRING
+bool: true
+json:
+  one: $STRING
+map:
+  two: $$test
+`
+	d := &Data{}
+	unmarshalingTest(t, doc, d)
+
+	out, err := yaml.Marshal(d)
+	require.NoError(t, err)
+	require.Equal(t, doc, string(out))
+}
+
 func unmarshalingTest(t *testing.T, document string, out interface{}) {
 	err := yaml.Unmarshal([]byte(document), out)
 	require.NoError(t, err)
@@ -75,7 +75,9 @@ func (s ScreenshotOptions) SetDefaults() ScreenshotOptions {
 	return s
 }
 
-// ScreenshotService is an interface for taking screenshots.
+// ScreenshotService is an interface for taking screenshots. Take can return
+// a screenshot together with an error when the screenshot was taken but could
+// not be uploaded, so callers can still use the screenshot on disk.
 //go:generate mockgen -destination=mock.go -package=screenshot github.com/grafana/grafana/pkg/services/screenshot ScreenshotService
 type ScreenshotService interface {
 	Take(ctx context.Context, opts ScreenshotOptions) (*Screenshot, error)
@@ -184,7 +186,8 @@ func (s *CachableScreenshotService) Take(ctx context.Context, opts ScreenshotOpt
 	defer s.cacheMisses.Inc()
 	screenshot, err := s.service.Take(ctx, opts)
 	if err != nil {
-		return nil, err
+		// Failed uploads are not cached so the next screenshot is uploaded again.
+		return screenshot, err
 	}
 
 	s.cache.Set(k, screenshot, 0)
@@ -267,11 +270,7 @@ func (s *SingleFlightScreenshotService) Take(ctx context.Context, opts Screensho
 	v, err, _ := s.f.Do(k, func() (interface{}, error) {
 		return s.service.Take(ctx, opts)
 	})
-	if err != nil {
-		return nil, err
-	}
-
-	screenshot := v.(*Screenshot)
+	screenshot, _ := v.(*Screenshot)
 	return screenshot, err
 }
 
@@ -157,6 +157,15 @@ func TestCachableScreenshotService(t *testing.T) {
 	screenshot, err = s.Take(ctx, opts)
 	require.NoError(t, err)
 	assert.Equal(t, Screenshot{Path: "panel.png"}, *screenshot)
+
+	// screenshots that failed to upload should be returned but not cached
+	opts = ScreenshotOptions{DashboardUID: "bar", PanelID: 1}
+	m.EXPECT().Take(ctx, opts).Return(&Screenshot{Path: "bar.png"}, errors.New("failed to upload screenshot")).Times(2)
+	for i := 0; i < 2; i++ {
+		screenshot, err = s.Take(ctx, opts)
+		assert.EqualError(t, err, "failed to upload screenshot")
+		assert.Equal(t, Screenshot{Path: "bar.png"}, *screenshot)
+	}
 }
 
 func TestNoopScreenshotService(t *testing.T) {
@@ -19,7 +19,10 @@ func ProvideService(sqlStore *sqlstore.SQLStore) *ShortURLService {
 
 type Service interface {
 	GetShortURLByUID(ctx context.Context, user *models.SignedInUser, uid string) (*models.ShortUrl, error)
-	CreateShortURL(ctx context.Context, user *models.SignedInUser, path string) (*models.ShortUrl, error)
+	CreateShortURL(ctx context.Context, user *models.SignedInUser, cmd *models.CreateShortUrlCommand) (*models.ShortUrl, error)
+	UpdateShortURL(ctx context.Context, user *models.SignedInUser, cmd *models.UpdateShortUrlCommand) (*models.ShortUrl, error)
+	SearchShortURLs(ctx context.Context, user *models.SignedInUser, query *models.SearchShortUrlsQuery) ([]*models.ShortUrl, error)
+	DeleteShortURL(ctx context.Context, user *models.SignedInUser, uid string) error
 	UpdateLastSeenAt(ctx context.Context, shortURL *models.ShortUrl) error
 	DeleteStaleShortURLs(ctx context.Context, cmd *models.DeleteShortUrlCommand) error
 }
@@ -60,18 +63,35 @@ func (s ShortURLService) UpdateLastSeenAt(ctx context.Context, shortURL *models.
 	})
 }
 
-func (s ShortURLService) CreateShortURL(ctx context.Context, user *models.SignedInUser, path string) (*models.ShortUrl, error) {
+func (s ShortURLService) CreateShortURL(ctx context.Context, user *models.SignedInUser, cmd *models.CreateShortUrlCommand) (*models.ShortUrl, error) {
+	uid := cmd.Uid
+	if uid == "" {
+		uid = util.GenerateShortUID()
+	} else if !util.IsValidShortUID(uid) || util.IsShortUIDTooLong(uid) {
+		return nil, models.ErrShortURLInvalidUID
+	}
+
 	now := time.Now().Unix()
 	shortURL := models.ShortUrl{
 		OrgId:     user.OrgId,
-		Uid:       util.GenerateShortUID(),
-		Path:      path,
+		Uid:       uid,
+		Path:      cmd.Path,
 		CreatedBy: user.UserId,
 		CreatedAt: now,
+		ExpiresAt: cmd.ExpiresAt,
+		Pinned:    cmd.Pinned,
 	}
 
 	err := s.SQLStore.WithDbSession(ctx, func(session *sqlstore.DBSession) error {
-		_, err := session.Insert(&shortURL)
+		exists, err := session.Where("org_id=? AND uid=?", user.OrgId, uid).Exist(&models.ShortUrl{})
+		if err != nil {
+			return err
+		}
+		if exists {
+			return models.ErrShortURLAlreadyExists
+		}
+
+		_, err = session.Insert(&shortURL)
 		return err
 	})
 	if err != nil {
@@ -81,11 +101,77 @@ func (s ShortURLService) CreateShortURL(ctx context.Context, user *models.Signed
 	return &shortURL, nil
 }
 
+func (s ShortURLService) UpdateShortURL(ctx context.Context, user *models.SignedInUser, cmd *models.UpdateShortUrlCommand) (*models.ShortUrl, error) {
+	shortURL, err := s.GetShortURLByUID(ctx, user, cmd.Uid)
+	if err != nil {
+		return nil, err
+	}
+
+	shortURL.ExpiresAt = cmd.ExpiresAt
+	shortURL.Pinned = cmd.Pinned
+	err = s.SQLStore.WithTransactionalDbSession(ctx, func(dbSession *sqlstore.DBSession) error {
+		_, err := dbSession.ID(shortURL.Id).Cols("expires_at", "pinned").Update(shortURL)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return shortURL, nil
+}
+
+// SearchShortURLs returns the short URLs of the organization of the user, the most recent first. The query matches
+// the uid or the path of the short URLs.
+func (s ShortURLService) SearchShortURLs(ctx context.Context, user *models.SignedInUser, query *models.SearchShortUrlsQuery) ([]*models.ShortUrl, error) {
+	shortURLs := make([]*models.ShortUrl, 0)
+	err := s.SQLStore.WithDbSession(ctx, func(dbSession *sqlstore.DBSession) error {
+		sess := dbSession.Where("org_id=?", user.OrgId)
+		if query.Query != "" {
+			like := s.SQLStore.Dialect.LikeStr()
+			sess.And("(uid "+like+" ? OR path "+like+" ?)", "%"+query.Query+"%", "%"+query.Query+"%")
+		}
+
+		limit := query.Limit
+		if limit <= 0 {
+			limit = 1000
+		}
+		page := query.Page
+		if page <= 0 {
+			page = 1
+		}
+
+		return sess.Desc("created_at").Desc("id").Limit(limit, (page-1)*limit).Find(&shortURLs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return shortURLs, nil
+}
+
+func (s ShortURLService) DeleteShortURL(ctx context.Context, user *models.SignedInUser, uid string) error {
+	return s.SQLStore.WithTransactionalDbSession(ctx, func(dbSession *sqlstore.DBSession) error {
+		deleted, err := dbSession.Where("org_id=? AND uid=?", user.OrgId, uid).Delete(&models.ShortUrl{})
+		if err != nil {
+			return err
+		}
+		if deleted == 0 {
+			return models.ErrShortURLNotFound
+		}
+
+		return nil
+	})
+}
+
+// DeleteStaleShortURLs deletes the short URLs that have expired, and the short URLs without expiry date that were
+// created before cmd.OlderThan and never visited. Pinned short URLs are never deleted.
 func (s ShortURLService) DeleteStaleShortURLs(ctx context.Context, cmd *models.DeleteShortUrlCommand) error {
 	return s.SQLStore.WithTransactionalDbSession(ctx, func(session *sqlstore.DBSession) error {
-		var rawSql = "DELETE FROM short_url WHERE created_at <= ? AND (last_seen_at IS NULL OR last_seen_at = 0)"
+		var rawSql = `DELETE FROM short_url WHERE pinned = ? AND (
+			(expires_at > 0 AND expires_at <= ?) OR
+			(created_at <= ? AND (last_seen_at IS NULL OR last_seen_at = 0) AND (expires_at IS NULL OR expires_at = 0)))`
 
-		if result, err := session.Exec(rawSql, cmd.OlderThan.Unix()); err != nil {
+		if result, err := session.Exec(rawSql, s.SQLStore.Dialect.BooleanStr(false), getTime().Unix(), cmd.OlderThan.Unix()); err != nil {
 			return err
 		} else if cmd.NumDeleted, err = result.RowsAffected(); err != nil {
 			return err
@@ -19,7 +19,7 @@ func TestShortURLService(t *testing.T) {
 
 		service := ShortURLService{SQLStore: sqlStore}
 
-		newShortURL, err := service.CreateShortURL(context.Background(), user, refPath)
+		newShortURL, err := service.CreateShortURL(context.Background(), user, &models.CreateShortUrlCommand{Path: refPath})
 		require.NoError(t, err)
 		require.NotNil(t, newShortURL)
 		require.NotEmpty(t, newShortURL.Uid)
@@ -49,7 +49,7 @@ func TestShortURLService(t *testing.T) {
 		})
 
 		t.Run("and stale short urls can be deleted", func(t *testing.T) {
-			staleShortURL, err := service.CreateShortURL(context.Background(), user, refPath)
+			staleShortURL, err := service.CreateShortURL(context.Background(), user, &models.CreateShortUrlCommand{Path: refPath})
 			require.NoError(t, err)
 			require.NotNil(t, staleShortURL)
 			require.NotEmpty(t, staleShortURL.Uid)
@@ -74,6 +74,92 @@ func TestShortURLService(t *testing.T) {
 		})
 	})
 
+	t.Run("User can create short URLs with a custom uid", func(t *testing.T) {
+		service := ShortURLService{SQLStore: sqlStore}
+
+		shortURL, err := service.CreateShortURL(context.Background(), user, &models.CreateShortUrlCommand{Path: "d/runbook", Uid: "runbook"})
+		require.NoError(t, err)
+		require.Equal(t, "runbook", shortURL.Uid)
+
+		_, err = service.CreateShortURL(context.Background(), user, &models.CreateShortUrlCommand{Path: "d/other", Uid: "runbook"})
+		require.ErrorIs(t, err, models.ErrShortURLAlreadyExists)
+
+		_, err = service.CreateShortURL(context.Background(), user, &models.CreateShortUrlCommand{Path: "d/other", Uid: "run book"})
+		require.ErrorIs(t, err, models.ErrShortURLInvalidUID)
+
+		otherOrgUser := &models.SignedInUser{UserId: 1, OrgId: 2}
+		_, err = service.CreateShortURL(context.Background(), otherOrgUser, &models.CreateShortUrlCommand{Path: "d/other", Uid: "runbook"})
+		require.NoError(t, err)
+	})
+
+	t.Run("User can search, update and delete short URLs", func(t *testing.T) {
+		sqlStore := sqlstore.InitTestDB(t)
+		service := ShortURLService{SQLStore: sqlStore}
+
+		for _, uid := range []string{"first", "second", "third"} {
+			_, err := service.CreateShortURL(context.Background(), user, &models.CreateShortUrlCommand{Path: "d/" + uid, Uid: uid})
+			require.NoError(t, err)
+		}
+		_, err := service.CreateShortURL(context.Background(), &models.SignedInUser{UserId: 1, OrgId: 2}, &models.CreateShortUrlCommand{Path: "d/other"})
+		require.NoError(t, err)
+
+		shortURLs, err := service.SearchShortURLs(context.Background(), user, &models.SearchShortUrlsQuery{})
+		require.NoError(t, err)
+		require.Len(t, shortURLs, 3)
+
+		shortURLs, err = service.SearchShortURLs(context.Background(), user, &models.SearchShortUrlsQuery{Query: "sec"})
+		require.NoError(t, err)
+		require.Len(t, shortURLs, 1)
+		require.Equal(t, "second", shortURLs[0].Uid)
+
+		shortURLs, err = service.SearchShortURLs(context.Background(), user, &models.SearchShortUrlsQuery{Limit: 2, Page: 2})
+		require.NoError(t, err)
+		require.Len(t, shortURLs, 1)
+
+		updated, err := service.UpdateShortURL(context.Background(), user, &models.UpdateShortUrlCommand{Uid: "first", Pinned: true})
+		require.NoError(t, err)
+		require.True(t, updated.Pinned)
+
+		updated, err = service.GetShortURLByUID(context.Background(), user, "first")
+		require.NoError(t, err)
+		require.True(t, updated.Pinned)
+
+		_, err = service.UpdateShortURL(context.Background(), user, &models.UpdateShortUrlCommand{Uid: "unknown"})
+		require.ErrorIs(t, err, models.ErrShortURLNotFound)
+
+		require.NoError(t, service.DeleteShortURL(context.Background(), user, "third"))
+		_, err = service.GetShortURLByUID(context.Background(), user, "third")
+		require.ErrorIs(t, err, models.ErrShortURLNotFound)
+		require.ErrorIs(t, service.DeleteShortURL(context.Background(), user, "third"), models.ErrShortURLNotFound)
+	})
+
+	t.Run("Pinned short URLs are never deleted and expired short URLs are", func(t *testing.T) {
+		sqlStore := sqlstore.InitTestDB(t)
+		service := ShortURLService{SQLStore: sqlStore}
+
+		now := time.Now()
+		pinned, err := service.CreateShortURL(context.Background(), user, &models.CreateShortUrlCommand{Path: "d/pinned", Pinned: true})
+		require.NoError(t, err)
+		expired, err := service.CreateShortURL(context.Background(), user, &models.CreateShortUrlCommand{Path: "d/expired", ExpiresAt: now.Add(-time.Minute).Unix()})
+		require.NoError(t, err)
+		require.True(t, expired.IsExpired(now))
+		expiring, err := service.CreateShortURL(context.Background(), user, &models.CreateShortUrlCommand{Path: "d/expiring", ExpiresAt: now.Add(time.Hour).Unix()})
+		require.NoError(t, err)
+		require.False(t, expiring.IsExpired(now))
+
+		cmd := models.DeleteShortUrlCommand{OlderThan: now.Add(time.Minute)}
+		err = service.DeleteStaleShortURLs(context.Background(), &cmd)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), cmd.NumDeleted)
+
+		_, err = service.GetShortURLByUID(context.Background(), user, expired.Uid)
+		require.ErrorIs(t, err, models.ErrShortURLNotFound)
+		_, err = service.GetShortURLByUID(context.Background(), user, pinned.Uid)
+		require.NoError(t, err)
+		_, err = service.GetShortURLByUID(context.Background(), user, expiring.Uid)
+		require.NoError(t, err)
+	})
+
 	t.Run("User cannot look up nonexistent short URLs", func(t *testing.T) {
 		service := ShortURLService{SQLStore: sqlStore}
 
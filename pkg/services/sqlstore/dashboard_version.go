@@ -21,6 +21,8 @@ func (ss *SQLStore) GetDashboardVersions(ctx context.Context, query *models.GetD
 				dashboard_version.parent_version,
 				dashboard_version.restored_from,
 				dashboard_version.version,
+				dashboard_version.name,
+				dashboard_version.pinned,
 				dashboard_version.created,
 				dashboard_version.created_by as created_by_id,
 				dashboard_version.message,
@@ -64,18 +66,21 @@ func (ss *SQLStore) deleteExpiredVersions(ctx context.Context, cmd *models.Delet
 			// min_version_to_keep = min_version + (versions_count - versions_to_keep)
 			// where version stats is processed for each dashboard. This guarantees that we keep at least versions_to_keep
 			// versions, but in some cases (when versions are sparse) this number may be more.
+			// Named and pinned versions are never deleted, and are not counted in the versions to keep.
 			versionIdsToDeleteQuery := `SELECT id
 				FROM dashboard_version, (
 					SELECT dashboard_id, count(version) as count, min(version) as min
 					FROM dashboard_version
+					WHERE pinned = ? AND name = ''
 					GROUP BY dashboard_id
 				) AS vtd
 				WHERE dashboard_version.dashboard_id=vtd.dashboard_id
+				AND dashboard_version.pinned = ? AND dashboard_version.name = ''
 				AND version < vtd.min + vtd.count - ?
 				LIMIT ?`
 
 			var versionIdsToDelete []interface{}
-			err := sess.SQL(versionIdsToDeleteQuery, versionsToKeep, perBatch).Find(&versionIdsToDelete)
+			err := sess.SQL(versionIdsToDeleteQuery, false, false, versionsToKeep, perBatch).Find(&versionIdsToDelete)
 			if err != nil {
 				return err
 			}
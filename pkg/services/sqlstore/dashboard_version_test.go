@@ -142,6 +142,34 @@ func TestIntegrationDeleteExpiredVersions(t *testing.T) {
 		require.Equal(t, versionsToWrite, query.Result[0].Version)
 	})
 
+	t.Run("Keep named and pinned dashboard versions", func(t *testing.T) {
+		setup(t)
+		setting.DashboardVersionsToKeep = versionsToKeep
+		err := sqlStore.WithDbSession(context.Background(), func(sess *DBSession) error {
+			if _, err := sess.Exec("UPDATE dashboard_version SET pinned = ? WHERE dashboard_id = ? AND version = 1", true, savedDash.Id); err != nil {
+				return err
+			}
+			_, err := sess.Exec("UPDATE dashboard_version SET name = ? WHERE dashboard_id = ? AND version = 2", "pre-migration", savedDash.Id)
+			return err
+		})
+		require.NoError(t, err)
+
+		err = sqlStore.DeleteExpiredVersions(context.Background(), &models.DeleteExpiredVersionsCommand{})
+		require.NoError(t, err)
+
+		query := models.GetDashboardVersionsQuery{DashboardId: savedDash.Id, OrgId: 1}
+		err = sqlStore.GetDashboardVersions(context.Background(), &query)
+		require.NoError(t, err)
+
+		// the pinned and named versions are kept in addition to the versions to keep
+		require.Len(t, query.Result, versionsToKeep+2)
+		require.Equal(t, versionsToWrite-versionsToKeep+1, query.Result[versionsToKeep-1].Version)
+		require.Equal(t, "pre-migration", query.Result[versionsToKeep].Name)
+		require.Equal(t, 2, query.Result[versionsToKeep].Version)
+		require.True(t, query.Result[versionsToKeep+1].Pinned)
+		require.Equal(t, 1, query.Result[versionsToKeep+1].Version)
+	})
+
 	t.Run("Don't delete anything if there are no expired versions", func(t *testing.T) {
 		setup(t)
 		setting.DashboardVersionsToKeep = versionsToWrite
@@ -23,4 +23,28 @@ func addPublicDashboardMigration(mg *Migrator) {
 
 	mg.AddMigration("create dashboard public config v1", NewAddTableMigration(dashboardPublicCfgV1))
 	addTableIndicesMigrations(mg, "v1", dashboardPublicCfgV1)
+
+	// The public dashboards are now looked up by their access token.
+	mg.AddMigration("drop dashboard public config v1", NewDropTableMigration(dashboardPublicCfgV1.Name))
+
+	var dashboardPublicV1 = Table{
+		Name: "dashboard_public",
+		Columns: []*Column{
+			{Name: "uid", Type: DB_NVarchar, Length: 40, IsPrimaryKey: true},
+			{Name: "dashboard_uid", Type: DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "org_id", Type: DB_BigInt, Nullable: false},
+			{Name: "access_token", Type: DB_NVarchar, Length: 64, Nullable: false},
+			{Name: "is_enabled", Type: DB_Bool, Nullable: false, Default: "0"},
+			{Name: "time_settings", Type: DB_Text, Nullable: true},
+			{Name: "created", Type: DB_DateTime, Nullable: false},
+			{Name: "updated", Type: DB_DateTime, Nullable: false},
+		},
+		Indices: []*Index{
+			{Cols: []string{"org_id", "dashboard_uid"}, Type: UniqueIndex},
+			{Cols: []string{"access_token"}, Type: UniqueIndex},
+		},
+	}
+
+	mg.AddMigration("create dashboard public v1", NewAddTableMigration(dashboardPublicV1))
+	addTableIndicesMigrations(mg, "v1", dashboardPublicV1)
 }
@@ -56,4 +56,12 @@ FROM dashboard;`
 	// change column type of dashboard_version.data
 	mg.AddMigration("alter dashboard_version.data to mediumtext v1", NewRawSQLMigration("").
 		Mysql("ALTER TABLE dashboard_version MODIFY data MEDIUMTEXT;"))
+
+	// named and pinned versions are kept by the clean up of old versions
+	mg.AddMigration("Add column name in dashboard_version", NewAddColumnMigration(dashboardVersionV1, &Column{
+		Name: "name", Type: DB_NVarchar, Length: 255, Nullable: false, Default: "''",
+	}))
+	mg.AddMigration("Add column pinned in dashboard_version", NewAddColumnMigration(dashboardVersionV1, &Column{
+		Name: "pinned", Type: DB_Bool, Nullable: false, Default: "0",
+	}))
 }
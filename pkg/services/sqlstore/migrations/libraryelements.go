@@ -58,4 +58,30 @@ func addLibraryElementsMigrations(mg *migrator.Migrator) {
 	mg.AddMigration("increase max description length to 2048", migrator.NewTableCharsetMigration("library_element", []*migrator.Column{
 		{Name: "description", Type: migrator.DB_NVarchar, Length: 2048, Nullable: false},
 	}))
+
+	libraryElementVersionV1 := migrator.Table{
+		Name: "library_element_version",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "element_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "parent_version", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "restored_from", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "version", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "name", Type: migrator.DB_NVarchar, Length: 150, Nullable: false},
+			{Name: "model", Type: migrator.DB_Text, Nullable: false},
+			{Name: "created", Type: migrator.DB_DateTime, Nullable: false},
+			{Name: "created_by", Type: migrator.DB_BigInt, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"element_id", "version"}, Type: migrator.UniqueIndex},
+		},
+	}
+
+	mg.AddMigration("create library_element_version table v1", migrator.NewAddTableMigration(libraryElementVersionV1))
+	mg.AddMigration("add unique index library_element_version element_id-version", migrator.NewAddIndexMigration(libraryElementVersionV1, libraryElementVersionV1.Indices[0]))
+
+	// The current state of the existing library elements is their first saved version.
+	mg.AddMigration("save existing library elements as versions", migrator.NewRawSQLMigration(`
+		INSERT INTO library_element_version (element_id, parent_version, restored_from, version, name, model, created, created_by)
+		SELECT id, 0, 0, version, name, model, updated, updated_by FROM library_element`))
 }
@@ -43,4 +43,28 @@ func addPlaylistMigrations(mg *Migrator) {
 		{Name: "value", Type: DB_Text, Nullable: false},
 		{Name: "title", Type: DB_Text, Nullable: false},
 	}))
+
+	mg.AddMigration("Add column uid in playlist", NewAddColumnMigration(playlistV2, &Column{
+		Name: "uid", Type: DB_NVarchar, Length: 40, Nullable: false, Default: "'0'",
+	}))
+
+	// the uid of the existing playlists is their id, so that their URLs keep working
+	mg.AddMigration("Update uid column values in playlist", NewRawSQLMigration("").
+		SQLite("UPDATE playlist SET uid=printf('%d',id);").
+		Postgres("UPDATE playlist SET uid=id::text;").
+		Mysql("UPDATE playlist SET uid=id;"))
+
+	mg.AddMigration("Add unique index playlist_org_id_uid", NewAddIndexMigration(playlistV2, &Index{
+		Cols: []string{"org_id", "uid"}, Type: UniqueIndex,
+	}))
+
+	mg.AddMigration("Add column interval in playlist_item", NewAddColumnMigration(playlistItemV2, &Column{
+		Name: "interval", Type: DB_NVarchar, Length: 255, Nullable: false, Default: "''",
+	}))
+	mg.AddMigration("Add column time_from in playlist_item", NewAddColumnMigration(playlistItemV2, &Column{
+		Name: "time_from", Type: DB_NVarchar, Length: 255, Nullable: false, Default: "''",
+	}))
+	mg.AddMigration("Add column time_to in playlist_item", NewAddColumnMigration(playlistItemV2, &Column{
+		Name: "time_to", Type: DB_NVarchar, Length: 255, Nullable: false, Default: "''",
+	}))
 }
@@ -24,4 +24,12 @@ func addShortURLMigrations(mg *Migrator) {
 	mg.AddMigration("create short_url table v1", NewAddTableMigration(shortURLV1))
 
 	mg.AddMigration("add index short_url.org_id-uid", NewAddIndexMigration(shortURLV1, shortURLV1.Indices[0]))
+
+	mg.AddMigration("add expires_at column to short_url", NewAddColumnMigration(shortURLV1, &Column{
+		Name: "expires_at", Type: DB_Int, Nullable: true,
+	}))
+
+	mg.AddMigration("add pinned column to short_url", NewAddColumnMigration(shortURLV1, &Column{
+		Name: "pinned", Type: DB_Bool, Nullable: false, Default: "0",
+	}))
 }
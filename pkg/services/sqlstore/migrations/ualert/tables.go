@@ -160,6 +160,19 @@ func AlertInstanceMigration(mg *migrator.Migrator) {
 		migrator.NewAddColumnMigration(alertInstance, &migrator.Column{
 			Name: "current_reason", Type: migrator.DB_NVarchar, Length: 190, Nullable: true,
 		}))
+
+	mg.AddMigration("add acknowledged_by column to alert_instance", migrator.NewAddColumnMigration(alertInstance, &migrator.Column{
+		Name: "acknowledged_by", Type: migrator.DB_BigInt, Nullable: false, Default: "0",
+	}))
+	mg.AddMigration("add acknowledged_comment column to alert_instance", migrator.NewAddColumnMigration(alertInstance, &migrator.Column{
+		Name: "acknowledged_comment", Type: migrator.DB_Text, Nullable: true,
+	}))
+	mg.AddMigration("add acknowledged_at column to alert_instance", migrator.NewAddColumnMigration(alertInstance, &migrator.Column{
+		Name: "acknowledged_at", Type: migrator.DB_BigInt, Nullable: false, Default: "0",
+	}))
+	mg.AddMigration("add acknowledged_until column to alert_instance", migrator.NewAddColumnMigration(alertInstance, &migrator.Column{
+		Name: "acknowledged_until", Type: migrator.DB_BigInt, Nullable: false, Default: "0",
+	}))
 }
 
 func AddAlertRuleMigrations(mg *migrator.Migrator, defaultIntervalSeconds int64) {
@@ -317,6 +330,10 @@ func AddAlertmanagerConfigMigrations(mg *migrator.Migrator) {
 	mg.AddMigration("add configuration_hash column to alert_configuration", migrator.NewAddColumnMigration(alertConfiguration, &migrator.Column{
 		Name: "configuration_hash", Type: migrator.DB_Varchar, Nullable: false, Default: "'not-yet-calculated'", Length: 32,
 	}))
+
+	mg.AddMigration("add created_by column to alert_configuration", migrator.NewAddColumnMigration(alertConfiguration, &migrator.Column{
+		Name: "created_by", Type: migrator.DB_BigInt, Nullable: false, Default: "0",
+	}))
 }
 
 func AddAlertAdminConfigMigrations(mg *migrator.Migrator) {
@@ -378,4 +395,10 @@ func AddAlertImageMigrations(mg *migrator.Migrator) {
 	}
 	mg.AddMigration("create alert_image table", migrator.NewAddTableMigration(imageTable))
 	mg.AddMigration("add unique index on token to alert_image table", migrator.NewAddIndexMigration(imageTable, imageTable.Indices[0]))
+
+	// Signed URLs of uploaded images, e.g. by GCS, are longer than 190 characters.
+	// SQLite does not enforce the length of VARCHAR columns.
+	mg.AddMigration("support longer URLs in alert_image table", migrator.NewRawSQLMigration("").
+		Mysql("ALTER TABLE alert_image MODIFY url VARCHAR(2048) NOT NULL;").
+		Postgres("ALTER TABLE alert_image ALTER COLUMN url TYPE VARCHAR(2048);"))
 }
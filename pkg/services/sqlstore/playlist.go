@@ -3,18 +3,37 @@ package sqlstore
 import (
 	"context"
 
+	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
+
 	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/util"
 )
 
 func (ss *SQLStore) CreatePlaylist(ctx context.Context, cmd *models.CreatePlaylistCommand) error {
+	if cmd.UID == "" {
+		cmd.UID = util.GenerateShortUID()
+	}
+	if err := validatePlaylist(cmd.UID, cmd.Items); err != nil {
+		return err
+	}
+
 	return ss.WithTransactionalDbSession(ctx, func(sess *DBSession) error {
+		exists, err := sess.Where("org_id = ? AND uid = ?", cmd.OrgId, cmd.UID).Exist(&models.Playlist{})
+		if err != nil {
+			return err
+		}
+		if exists {
+			return models.ErrPlaylistWithSameUIDExists
+		}
+
 		playlist := models.Playlist{
+			UID:      cmd.UID,
 			Name:     cmd.Name,
 			Interval: cmd.Interval,
 			OrgId:    cmd.OrgId,
 		}
 
-		_, err := sess.Insert(&playlist)
+		_, err = sess.Insert(&playlist)
 		if err != nil {
 			return err
 		}
@@ -27,6 +46,9 @@ func (ss *SQLStore) CreatePlaylist(ctx context.Context, cmd *models.CreatePlayli
 				Value:      item.Value,
 				Order:      item.Order,
 				Title:      item.Title,
+				Interval:   item.Interval,
+				TimeFrom:   item.TimeFrom,
+				TimeTo:     item.TimeTo,
 			})
 		}
 
@@ -38,35 +60,44 @@ func (ss *SQLStore) CreatePlaylist(ctx context.Context, cmd *models.CreatePlayli
 }
 
 func (ss *SQLStore) UpdatePlaylist(ctx context.Context, cmd *models.UpdatePlaylistCommand) error {
+	if err := validatePlaylist(cmd.UID, cmd.Items); err != nil {
+		return err
+	}
+
 	return ss.WithTransactionalDbSession(ctx, func(sess *DBSession) error {
+		existingPlaylist := models.Playlist{}
+		has, err := sess.Where("org_id = ? AND uid = ?", cmd.OrgId, cmd.UID).Get(&existingPlaylist)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return models.ErrPlaylistNotFound
+		}
+
 		playlist := models.Playlist{
-			Id:       cmd.Id,
+			Id:       existingPlaylist.Id,
+			UID:      existingPlaylist.UID,
 			OrgId:    cmd.OrgId,
 			Name:     cmd.Name,
 			Interval: cmd.Interval,
 		}
 
-		existingPlaylist := sess.Where("id = ? AND org_id = ?", cmd.Id, cmd.OrgId).Find(models.Playlist{})
-
-		if existingPlaylist == nil {
-			return models.ErrPlaylistNotFound
-		}
-
 		cmd.Result = &models.PlaylistDTO{
 			Id:       playlist.Id,
+			UID:      playlist.UID,
 			OrgId:    playlist.OrgId,
 			Name:     playlist.Name,
 			Interval: playlist.Interval,
 		}
 
-		_, err := sess.ID(cmd.Id).Cols("name", "interval").Update(&playlist)
+		_, err = sess.ID(playlist.Id).Cols("name", "interval").Update(&playlist)
 
 		if err != nil {
 			return err
 		}
 
 		rawSQL := "DELETE FROM playlist_item WHERE playlist_id = ?"
-		_, err = sess.Exec(rawSQL, cmd.Id)
+		_, err = sess.Exec(rawSQL, playlist.Id)
 
 		if err != nil {
 			return err
@@ -81,6 +112,9 @@ func (ss *SQLStore) UpdatePlaylist(ctx context.Context, cmd *models.UpdatePlayli
 				Value:      item.Value,
 				Order:      index + 1,
 				Title:      item.Title,
+				Interval:   item.Interval,
+				TimeFrom:   item.TimeFrom,
+				TimeTo:     item.TimeTo,
 			})
 		}
 
@@ -90,36 +124,51 @@ func (ss *SQLStore) UpdatePlaylist(ctx context.Context, cmd *models.UpdatePlayli
 	})
 }
 
-func (ss *SQLStore) GetPlaylist(ctx context.Context, query *models.GetPlaylistByIdQuery) error {
-	if query.Id == 0 {
+func (ss *SQLStore) GetPlaylist(ctx context.Context, query *models.GetPlaylistByUidQuery) error {
+	if query.UID == "" || query.OrgId == 0 {
 		return models.ErrCommandValidationFailed
 	}
 
 	return ss.WithDbSession(ctx, func(sess *DBSession) error {
 		playlist := models.Playlist{}
-		_, err := sess.ID(query.Id).Get(&playlist)
+		has, err := sess.Where("org_id = ? AND uid = ?", query.OrgId, query.UID).Get(&playlist)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return models.ErrPlaylistNotFound
+		}
 
 		query.Result = &playlist
 
-		return err
+		return nil
 	})
 }
 
 func (ss *SQLStore) DeletePlaylist(ctx context.Context, cmd *models.DeletePlaylistCommand) error {
-	if cmd.Id == 0 || cmd.OrgId == 0 {
+	if cmd.UID == "" || cmd.OrgId == 0 {
 		return models.ErrCommandValidationFailed
 	}
 
 	return ss.WithTransactionalDbSession(ctx, func(sess *DBSession) error {
+		playlist := models.Playlist{}
+		has, err := sess.Where("org_id = ? AND uid = ?", cmd.OrgId, cmd.UID).Get(&playlist)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return models.ErrPlaylistNotFound
+		}
+
 		var rawPlaylistSQL = "DELETE FROM playlist WHERE id = ? and org_id = ?"
-		_, err := sess.Exec(rawPlaylistSQL, cmd.Id, cmd.OrgId)
+		_, err = sess.Exec(rawPlaylistSQL, playlist.Id, cmd.OrgId)
 
 		if err != nil {
 			return err
 		}
 
 		var rawItemSQL = "DELETE FROM playlist_item WHERE playlist_id = ?"
-		_, err2 := sess.Exec(rawItemSQL, cmd.Id)
+		_, err2 := sess.Exec(rawItemSQL, playlist.Id)
 
 		return err2
 	})
@@ -150,10 +199,28 @@ func (ss *SQLStore) GetPlaylistItem(ctx context.Context, query *models.GetPlayli
 		}
 
 		var playlistItems = make([]models.PlaylistItem, 0)
-		err := sess.Where("playlist_id=?", query.PlaylistId).Find(&playlistItems)
+		err := sess.Where("playlist_id=?", query.PlaylistId).Asc("order").Find(&playlistItems)
 
 		query.Result = &playlistItems
 
 		return err
 	})
 }
+
+func validatePlaylist(uid string, items []models.PlaylistItemDTO) error {
+	if !util.IsValidShortUID(uid) {
+		return models.ErrPlaylistInvalidUID
+	}
+	if util.IsShortUIDTooLong(uid) {
+		return models.ErrPlaylistUIDTooLong
+	}
+	for _, item := range items {
+		if item.Interval == "" {
+			continue
+		}
+		if _, err := gtime.ParseDuration(item.Interval); err != nil {
+			return models.ErrPlaylistInvalidItemInterval
+		}
+	}
+	return nil
+}
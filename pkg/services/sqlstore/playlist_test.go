@@ -22,33 +22,109 @@ func TestIntegrationPlaylistDataAccess(t *testing.T) {
 		cmd := models.CreatePlaylistCommand{Name: "NYC office", Interval: "10m", OrgId: 1, Items: items}
 		err := ss.CreatePlaylist(context.Background(), &cmd)
 		require.NoError(t, err)
+		uid := cmd.Result.UID
+		require.NotEmpty(t, uid)
+
+		t.Run("Can get playlist by uid", func(t *testing.T) {
+			getQuery := models.GetPlaylistByUidQuery{UID: uid, OrgId: 1}
+			err = ss.GetPlaylist(context.Background(), &getQuery)
+			require.NoError(t, err)
+			require.Equal(t, cmd.Result.Id, getQuery.Result.Id)
+			require.Equal(t, "NYC office", getQuery.Result.Name)
+		})
+
+		t.Run("Can not get playlist of another org", func(t *testing.T) {
+			getQuery := models.GetPlaylistByUidQuery{UID: uid, OrgId: 2}
+			err = ss.GetPlaylist(context.Background(), &getQuery)
+			require.ErrorIs(t, err, models.ErrPlaylistNotFound)
+		})
+
+		t.Run("Can not create playlist with the same uid", func(t *testing.T) {
+			cmd := models.CreatePlaylistCommand{UID: uid, Name: "LA office", Interval: "10m", OrgId: 1}
+			err := ss.CreatePlaylist(context.Background(), &cmd)
+			require.ErrorIs(t, err, models.ErrPlaylistWithSameUIDExists)
+		})
 
 		t.Run("Can update playlist", func(t *testing.T) {
 			items := []models.PlaylistItemDTO{
 				{Title: "influxdb", Value: "influxdb", Type: "dashboard_by_tag"},
 				{Title: "Backend response times", Value: "2", Type: "dashboard_by_id"},
 			}
-			query := models.UpdatePlaylistCommand{Name: "NYC office ", OrgId: 1, Id: 1, Interval: "10s", Items: items}
+			query := models.UpdatePlaylistCommand{Name: "NYC office ", OrgId: 1, UID: uid, Interval: "10s", Items: items}
 			err = ss.UpdatePlaylist(context.Background(), &query)
 			require.NoError(t, err)
+			require.Equal(t, uid, query.Result.UID)
+		})
+
+		t.Run("Can not update playlist that doesn't exist", func(t *testing.T) {
+			query := models.UpdatePlaylistCommand{Name: "NYC office", OrgId: 1, UID: "unknown", Interval: "10s"}
+			err = ss.UpdatePlaylist(context.Background(), &query)
+			require.ErrorIs(t, err, models.ErrPlaylistNotFound)
 		})
 
 		t.Run("Can remove playlist", func(t *testing.T) {
-			deleteQuery := models.DeletePlaylistCommand{Id: 1, OrgId: 1}
+			deleteQuery := models.DeletePlaylistCommand{UID: uid, OrgId: 1}
 			err = ss.DeletePlaylist(context.Background(), &deleteQuery)
 			require.NoError(t, err)
 
-			getQuery := models.GetPlaylistByIdQuery{Id: 1}
+			getQuery := models.GetPlaylistByUidQuery{UID: uid, OrgId: 1}
 			err = ss.GetPlaylist(context.Background(), &getQuery)
-			require.NoError(t, err)
-			require.Equal(t, int64(0), getQuery.Result.Id, "playlist should've been removed")
+			require.ErrorIs(t, err, models.ErrPlaylistNotFound, "playlist should've been removed")
 		})
 	})
 
+	t.Run("Can create playlist with uid and item settings", func(t *testing.T) {
+		items := []models.PlaylistItemDTO{
+			{Title: "Backend response times", Value: "abc", Type: "dashboard_by_uid", Interval: "30s", TimeFrom: "now-1h", TimeTo: "now"},
+			{Title: "graphite", Value: "graphite", Type: "dashboard_by_tag"},
+		}
+		cmd := models.CreatePlaylistCommand{UID: "noc", Name: "NOC", Interval: "5m", OrgId: 1, Items: items}
+		err := ss.CreatePlaylist(context.Background(), &cmd)
+		require.NoError(t, err)
+		require.Equal(t, "noc", cmd.Result.UID)
+
+		itemsQuery := models.GetPlaylistItemsByIdQuery{PlaylistId: cmd.Result.Id}
+		err = ss.GetPlaylistItem(context.Background(), &itemsQuery)
+		require.NoError(t, err)
+		require.Len(t, *itemsQuery.Result, 2)
+		item := (*itemsQuery.Result)[0]
+		require.Equal(t, "30s", item.Interval)
+		require.Equal(t, "now-1h", item.TimeFrom)
+		require.Equal(t, "now", item.TimeTo)
+	})
+
+	t.Run("Create playlist with invalid command yields error", func(t *testing.T) {
+		testCases := []struct {
+			desc string
+			cmd  models.CreatePlaylistCommand
+			err  error
+		}{
+			{
+				desc: "invalid uid",
+				cmd:  models.CreatePlaylistCommand{UID: "noc/1", Name: "NOC", OrgId: 1},
+				err:  models.ErrPlaylistInvalidUID,
+			},
+			{
+				desc: "invalid item interval",
+				cmd: models.CreatePlaylistCommand{Name: "NOC", OrgId: 1, Items: []models.PlaylistItemDTO{
+					{Title: "graphite", Value: "graphite", Type: "dashboard_by_tag", Interval: "soon"},
+				}},
+				err: models.ErrPlaylistInvalidItemInterval,
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.desc, func(t *testing.T) {
+				err := ss.CreatePlaylist(context.Background(), &tc.cmd)
+				require.ErrorIs(t, err, tc.err)
+			})
+		}
+	})
+
 	t.Run("Delete playlist that doesn't exist", func(t *testing.T) {
-		deleteQuery := models.DeletePlaylistCommand{Id: 1, OrgId: 1}
+		deleteQuery := models.DeletePlaylistCommand{UID: "unknown", OrgId: 1}
 		err := ss.DeletePlaylist(context.Background(), &deleteQuery)
-		require.NoError(t, err)
+		require.ErrorIs(t, err, models.ErrPlaylistNotFound)
 	})
 
 	t.Run("Delete playlist with invalid command yields error", func(t *testing.T) {
@@ -57,8 +133,8 @@ func TestIntegrationPlaylistDataAccess(t *testing.T) {
 			cmd  models.DeletePlaylistCommand
 		}{
 			{desc: "none", cmd: models.DeletePlaylistCommand{}},
-			{desc: "no OrgId", cmd: models.DeletePlaylistCommand{Id: 1}},
-			{desc: "no Id", cmd: models.DeletePlaylistCommand{OrgId: 1}},
+			{desc: "no OrgId", cmd: models.DeletePlaylistCommand{UID: "1"}},
+			{desc: "no UID", cmd: models.DeletePlaylistCommand{OrgId: 1}},
 		}
 
 		for _, tc := range testCases {
@@ -284,7 +284,8 @@ type Cfg struct {
 	MetricsGrafanaEnvironmentInfo    map[string]string
 
 	// Dashboards
-	DefaultHomeDashboardPath string
+	DefaultHomeDashboardPath  string
+	DashboardSchemaValidation DashboardSchemaValidationSettings
 
 	// Auth
 	LoginCookieName              string
@@ -378,6 +379,8 @@ type Cfg struct {
 
 	// Data sources
 	DataSourceLimit int
+	// SQLiteAllowedPaths holds the glob patterns of database files the SQLite data source may open
+	SQLiteAllowedPaths []string
 
 	// Snapshots
 	SnapshotPublicMode bool
@@ -925,6 +928,9 @@ func (cfg *Cfg) Load(args CommandLineArgs) error {
 	MinRefreshInterval = valueAsString(dashboards, "min_refresh_interval", "5s")
 
 	cfg.DefaultHomeDashboardPath = dashboards.Key("default_home_dashboard_path").MustString("")
+	if cfg.DashboardSchemaValidation, err = readDashboardSchemaValidationSettings(dashboards); err != nil {
+		return err
+	}
 
 	if err := readUserSettings(iniFile, cfg); err != nil {
 		return err
@@ -1549,6 +1555,7 @@ func (cfg *Cfg) GetContentDeliveryURL(prefix string) string {
 func (cfg *Cfg) readDataSourcesSettings() {
 	datasources := cfg.Raw.Section("datasources")
 	cfg.DataSourceLimit = datasources.Key("datasource_limit").MustInt(5000)
+	cfg.SQLiteAllowedPaths = util.SplitString(datasources.Key("sqlite_allowed_paths").MustString(""))
 }
 
 func GetAllowedOriginGlobs(originPatterns []string) ([]glob.Glob, error) {
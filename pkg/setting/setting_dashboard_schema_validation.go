@@ -0,0 +1,66 @@
+package setting
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/ini.v1"
+
+	"github.com/grafana/grafana/pkg/util"
+)
+
+// DashboardSchemaValidationMode is how the dashboards saved are validated against the dashboard schema.
+type DashboardSchemaValidationMode string
+
+const (
+	// DashboardSchemaValidationOff saves the dashboards without validating them.
+	DashboardSchemaValidationOff DashboardSchemaValidationMode = "off"
+	// DashboardSchemaValidationWarn saves the dashboards that are not valid, and logs their errors.
+	DashboardSchemaValidationWarn DashboardSchemaValidationMode = "warn"
+	// DashboardSchemaValidationEnforce rejects the dashboards that are not valid.
+	DashboardSchemaValidationEnforce DashboardSchemaValidationMode = "enforce"
+)
+
+type DashboardSchemaValidationSettings struct {
+	// Mode is the validation mode of the organizations without a mode of their own.
+	Mode DashboardSchemaValidationMode
+	// OrgModes are the validation modes of single organizations, by organization ID.
+	OrgModes map[int64]DashboardSchemaValidationMode
+}
+
+func readDashboardSchemaValidationSettings(section *ini.Section) (DashboardSchemaValidationSettings, error) {
+	s := DashboardSchemaValidationSettings{
+		OrgModes: make(map[int64]DashboardSchemaValidationMode),
+	}
+
+	var err error
+	if s.Mode, err = parseDashboardSchemaValidationMode(valueAsString(section, "schema_validation", string(DashboardSchemaValidationOff))); err != nil {
+		return s, err
+	}
+
+	// org modes are a list of org_id:mode pairs, e.g. 1:enforce 2:warn
+	for _, orgMode := range util.SplitString(valueAsString(section, "schema_validation_orgs", "")) {
+		parts := strings.SplitN(orgMode, ":", 2)
+		if len(parts) != 2 {
+			return s, fmt.Errorf("invalid dashboard schema validation mode of organization %q, expected org_id:mode", orgMode)
+		}
+		orgID, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			return s, fmt.Errorf("invalid organization ID of dashboard schema validation mode %q: %w", orgMode, err)
+		}
+		if s.OrgModes[orgID], err = parseDashboardSchemaValidationMode(parts[1]); err != nil {
+			return s, err
+		}
+	}
+
+	return s, nil
+}
+
+func parseDashboardSchemaValidationMode(mode string) (DashboardSchemaValidationMode, error) {
+	switch m := DashboardSchemaValidationMode(strings.ToLower(strings.TrimSpace(mode))); m {
+	case DashboardSchemaValidationOff, DashboardSchemaValidationWarn, DashboardSchemaValidationEnforce:
+		return m, nil
+	}
+	return "", fmt.Errorf("invalid dashboard schema validation mode %q, expected off, warn or enforce", mode)
+}
@@ -681,3 +681,42 @@ func TestAlertingEnabled(t *testing.T) {
 		})
 	}
 }
+
+func TestReadDashboardSchemaValidationSettings(t *testing.T) {
+	t.Run("Should read the modes of the organizations", func(t *testing.T) {
+		f := ini.Empty()
+		section, err := f.NewSection("dashboards")
+		require.NoError(t, err)
+		_, err = section.NewKey("schema_validation", "warn")
+		require.NoError(t, err)
+		_, err = section.NewKey("schema_validation_orgs", "2:enforce, 3:off")
+		require.NoError(t, err)
+
+		s, err := readDashboardSchemaValidationSettings(section)
+		require.NoError(t, err)
+		require.Equal(t, DashboardSchemaValidationWarn, s.Mode)
+		require.Equal(t, map[int64]DashboardSchemaValidationMode{
+			2: DashboardSchemaValidationEnforce,
+			3: DashboardSchemaValidationOff,
+		}, s.OrgModes)
+	})
+
+	t.Run("Should fail on invalid modes", func(t *testing.T) {
+		for _, values := range []map[string]string{
+			{"schema_validation": "strict"},
+			{"schema_validation_orgs": "2"},
+			{"schema_validation_orgs": "main:warn"},
+			{"schema_validation_orgs": "2:strict"},
+		} {
+			f := ini.Empty()
+			section, err := f.NewSection("dashboards")
+			require.NoError(t, err)
+			for k, v := range values {
+				_, err = section.NewKey(k, v)
+				require.NoError(t, err)
+			}
+			_, err = readDashboardSchemaValidationSettings(section)
+			require.Error(t, err, values)
+		}
+	})
+}
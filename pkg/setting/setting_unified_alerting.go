@@ -21,6 +21,7 @@ const (
 	alertmanagerDefaultGossipInterval     = cluster.DefaultGossipInterval
 	alertmanagerDefaultPushPullInterval   = cluster.DefaultPushPullInterval
 	alertmanagerDefaultConfigPollInterval = 60 * time.Second
+	alertmanagerDefaultConfigHistoryLimit = 100
 	// To start, the alertmanager needs at least one route defined.
 	// TODO: we should move this to Grafana settings and define this as the default.
 	alertmanagerDefaultConfiguration = `{
@@ -62,6 +63,9 @@ const (
 type UnifiedAlertingSettings struct {
 	AdminConfigPollInterval        time.Duration
 	AlertmanagerConfigPollInterval time.Duration
+	// AlertmanagerConfigHistoryLimit is the number of Alertmanager configuration versions kept per organization.
+	// Zero means that no version is ever deleted.
+	AlertmanagerConfigHistoryLimit int
 	HAListenAddr                   string
 	HAAdvertiseAddr                string
 	HAPeers                        []string
@@ -170,6 +174,10 @@ func (cfg *Cfg) ReadUnifiedAlertingSettings(iniFile *ini.File) error {
 	if err != nil {
 		return err
 	}
+	uaCfg.AlertmanagerConfigHistoryLimit = ua.Key("alertmanager_config_history_limit").MustInt(alertmanagerDefaultConfigHistoryLimit)
+	if uaCfg.AlertmanagerConfigHistoryLimit < 0 {
+		return fmt.Errorf("alertmanager_config_history_limit must not be negative, got %d", uaCfg.AlertmanagerConfigHistoryLimit)
+	}
 	uaCfg.HAPeerTimeout, err = gtime.ParseDuration(valueAsString(ua, "ha_peer_timeout", (alertmanagerDefaultPeerTimeout).String()))
 	if err != nil {
 		return err
@@ -34,6 +34,15 @@ type DatasourceInfo struct {
 	MaxConcurrentShardRequests int64
 	IncludeFrozen              bool
 	XPack                      bool
+	ConfiguredFields           ConfiguredFields
+}
+
+// ConfiguredFields holds the document fields configured on the datasource
+// that are used when building logs data frames
+type ConfiguredFields struct {
+	TimeField       string
+	LogMessageField string
+	LogLevelField   string
 }
 
 const loggerName = "tsdb.elasticsearch.client"
@@ -50,8 +59,10 @@ var newDatasourceHttpClient = func(httpClientProvider httpclient.Provider, ds *D
 type Client interface {
 	GetVersion() *semver.Version
 	GetTimeField() string
+	GetConfiguredFields() ConfiguredFields
 	GetMinInterval(queryInterval string) (time.Duration, error)
 	ExecuteMultisearch(r *MultiSearchRequest) (*MultiSearchResponse, error)
+	GetMapping() (map[string]interface{}, error)
 	MultiSearch() *MultiSearchRequestBuilder
 	EnableDebug()
 }
@@ -100,6 +111,14 @@ func (c *baseClientImpl) GetTimeField() string {
 	return c.timeField
 }
 
+func (c *baseClientImpl) GetConfiguredFields() ConfiguredFields {
+	fields := c.ds.ConfiguredFields
+	if fields.TimeField == "" {
+		fields.TimeField = c.timeField
+	}
+	return fields
+}
+
 func (c *baseClientImpl) GetMinInterval(queryInterval string) (time.Duration, error) {
 	timeInterval := c.ds.TimeInterval
 	return intervalv2.GetIntervalFrom(queryInterval, timeInterval, 0, 5*time.Second)
@@ -267,6 +286,34 @@ func (c *baseClientImpl) ExecuteMultisearch(r *MultiSearchRequest) (*MultiSearch
 	return &msr, nil
 }
 
+func (c *baseClientImpl) GetMapping() (map[string]interface{}, error) {
+	clientLog.Debug("Executing mapping request", "indices", len(c.indices))
+
+	clientRes, err := c.executeRequest(http.MethodGet, path.Join(strings.Join(c.indices, ","), "_mapping"), "ignore_unavailable=true", nil)
+	if err != nil {
+		return nil, err
+	}
+	res := clientRes.httpResponse
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			clientLog.Warn("Failed to close response body", "err", err)
+		}
+	}()
+
+	clientLog.Debug("Received mapping response", "code", res.StatusCode, "status", res.Status, "content-length", res.ContentLength)
+
+	if res.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("elasticsearch mapping request failed, status: %s", res.Status)
+	}
+
+	var mapping map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&mapping); err != nil {
+		return nil, err
+	}
+
+	return mapping, nil
+}
+
 func (c *baseClientImpl) createMultiSearchRequests(searchRequests []*SearchRequest) []*multiRequest {
 	multiRequests := []*multiRequest{}
 
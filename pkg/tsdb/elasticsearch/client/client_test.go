@@ -315,6 +315,32 @@ func TestClient_ExecuteMultisearch(t *testing.T) {
 	})
 }
 
+func TestClient_GetMapping(t *testing.T) {
+	version, err := semver.NewVersion("7.10.0")
+	require.NoError(t, err)
+	httpClientScenario(t, "Given a fake http client and a daily index pattern", &DatasourceInfo{
+		Database:  "[logs-]YYYY.MM.DD",
+		ESVersion: version,
+		TimeField: "@timestamp",
+		Interval:  "Daily",
+	}, func(sc *scenarioContext) {
+		sc.responseBody = `{
+			"logs-2018.05.15": {
+				"mappings": { "properties": { "@timestamp": { "type": "date" } } }
+			}
+		}`
+
+		mapping, err := sc.client.GetMapping()
+		require.NoError(t, err)
+
+		require.NotNil(t, sc.request)
+		assert.Equal(t, http.MethodGet, sc.request.Method)
+		assert.Equal(t, "/logs-2018.05.15/_mapping", sc.request.URL.Path)
+		assert.Equal(t, "ignore_unavailable=true", sc.request.URL.RawQuery)
+		assert.Contains(t, mapping, "logs-2018.05.15")
+	})
+}
+
 func createMultisearchForTest(t *testing.T, c Client) (*MultiSearchRequest, error) {
 	t.Helper()
 
@@ -62,6 +62,23 @@ func (r *SearchRequest) MarshalJSON() ([]byte, error) {
 	return json.Marshal(root)
 }
 
+// SortOrder represents the order of a search request sort
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
+const (
+	// HighlightPreTagsString is the tag inserted before each highlighted term
+	HighlightPreTagsString = "@HIGHLIGHT@"
+	// HighlightPostTagsString is the tag inserted after each highlighted term
+	HighlightPostTagsString = "@/HIGHLIGHT@"
+	// HighlightFragmentSize makes elasticsearch return the whole field value as a single fragment
+	HighlightFragmentSize = 2147483647
+)
+
 // SearchResponseHits represents search response hits
 type SearchResponseHits struct {
 	Hits []map[string]interface{}
@@ -5,7 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Masterminds/semver"
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
@@ -60,6 +65,175 @@ func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest)
 	return query.execute()
 }
 
+// fieldsResourcePath is the resource path used to look up the mapped fields of the datasource indices
+const fieldsResourcePath = "fields"
+
+var numberFieldTypes = []string{"long", "integer", "short", "byte", "double", "float", "half_float", "scaled_float", "unsigned_long"}
+
+type mappedField struct {
+	Text string `json:"text"`
+	Type string `json:"type"`
+}
+
+func (s *Service) CallResource(ctx context.Context, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender) error {
+	dsInfo, err := s.getDSInfo(req.PluginContext)
+	if err != nil {
+		return err
+	}
+
+	if req.Method != http.MethodGet {
+		return fmt.Errorf("invalid resource method: %s", req.Method)
+	}
+
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return err
+	}
+	if strings.Trim(u.Path, "/") != fieldsResourcePath {
+		return fmt.Errorf("invalid resource URL: %s", req.URL)
+	}
+
+	params := u.Query()
+	timeRange, err := parseResourceTimeRange(params)
+	if err != nil {
+		return err
+	}
+
+	client, err := es.NewClient(ctx, s.httpClientProvider, dsInfo, timeRange)
+	if err != nil {
+		return err
+	}
+
+	mapping, err := client.GetMapping()
+	if err != nil {
+		return err
+	}
+
+	var types []string
+	if t := params.Get("type"); t != "" {
+		types = strings.Split(t, ",")
+	}
+
+	body, err := json.Marshal(getMappedFields(mapping, types))
+	if err != nil {
+		return err
+	}
+
+	return sender.Send(&backend.CallResourceResponse{
+		Status: http.StatusOK,
+		Headers: map[string][]string{
+			"content-type": {"application/json"},
+		},
+		Body: body,
+	})
+}
+
+// parseResourceTimeRange reads the epoch millisecond from/to parameters used to resolve
+// time based index patterns, defaulting to the last hour
+func parseResourceTimeRange(params url.Values) (backend.TimeRange, error) {
+	to := time.Now()
+	from := to.Add(-time.Hour)
+
+	if v := params.Get("from"); v != "" {
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return backend.TimeRange{}, fmt.Errorf("invalid from parameter: %w", err)
+		}
+		from = time.Unix(0, ms*int64(time.Millisecond))
+	}
+
+	if v := params.Get("to"); v != "" {
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return backend.TimeRange{}, fmt.Errorf("invalid to parameter: %w", err)
+		}
+		to = time.Unix(0, ms*int64(time.Millisecond))
+	}
+
+	return backend.TimeRange{From: from, To: to}, nil
+}
+
+// getMappedFields flattens the properties of an index mapping response into a sorted, de-duplicated
+// list of field names. When types is not empty only fields of those types are returned, where the
+// "number" type matches all numeric field types.
+func getMappedFields(mapping map[string]interface{}, types []string) []mappedField {
+	allowed := make(map[string]bool)
+	for _, t := range types {
+		if t == "number" {
+			for _, nt := range numberFieldTypes {
+				allowed[nt] = true
+			}
+			continue
+		}
+		allowed[t] = true
+	}
+
+	fields := make(map[string]string)
+	for _, index := range mapping {
+		indexMapping, ok := index.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		mappings, ok := indexMapping["mappings"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if properties, ok := mappings["properties"].(map[string]interface{}); ok {
+			collectMappedFields("", properties, fields)
+			continue
+		}
+		// Elasticsearch < 7 nests the properties under the document type
+		for _, docType := range mappings {
+			if typeMapping, ok := docType.(map[string]interface{}); ok {
+				if properties, ok := typeMapping["properties"].(map[string]interface{}); ok {
+					collectMappedFields("", properties, fields)
+				}
+			}
+		}
+	}
+
+	result := make([]mappedField, 0, len(fields))
+	for name, fieldType := range fields {
+		if len(allowed) > 0 && !allowed[fieldType] {
+			continue
+		}
+		result = append(result, mappedField{Text: name, Type: fieldType})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Text < result[j].Text
+	})
+
+	return result
+}
+
+func collectMappedFields(prefix string, properties map[string]interface{}, fields map[string]string) {
+	for name, p := range properties {
+		property, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		fieldName := name
+		if prefix != "" {
+			fieldName = prefix + "." + name
+		}
+
+		if nested, ok := property["properties"].(map[string]interface{}); ok {
+			collectMappedFields(fieldName, nested, fields)
+			continue
+		}
+
+		fieldType, ok := property["type"].(string)
+		if !ok {
+			continue
+		}
+		fields[fieldName] = fieldType
+
+		if multiFields, ok := property["fields"].(map[string]interface{}); ok {
+			collectMappedFields(fieldName, multiFields, fields)
+		}
+	}
+}
+
 func newInstanceSettings() datasource.InstanceFactoryFunc {
 	return func(settings backend.DataSourceInstanceSettings) (instancemgmt.Instance, error) {
 		jsonData := map[string]interface{}{}
@@ -125,6 +299,16 @@ func newInstanceSettings() datasource.InstanceFactoryFunc {
 			xpack = false
 		}
 
+		logLevelField, ok := jsonData["logLevelField"].(string)
+		if !ok {
+			logLevelField = ""
+		}
+
+		logMessageField, ok := jsonData["logMessageField"].(string)
+		if !ok {
+			logMessageField = ""
+		}
+
 		model := es.DatasourceInfo{
 			ID:                         settings.ID,
 			URL:                        settings.URL,
@@ -137,6 +321,11 @@ func newInstanceSettings() datasource.InstanceFactoryFunc {
 			TimeInterval:               timeInterval,
 			IncludeFrozen:              includeFrozen,
 			XPack:                      xpack,
+			ConfiguredFields: es.ConfiguredFields{
+				TimeField:       timeField,
+				LogLevelField:   logLevelField,
+				LogMessageField: logMessageField,
+			},
 		}
 		return model, nil
 	}
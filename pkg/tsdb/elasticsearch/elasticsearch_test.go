@@ -189,3 +189,58 @@ func TestNewInstanceSettings(t *testing.T) {
 		})
 	})
 }
+
+func TestGetMappedFields(t *testing.T) {
+	var mapping map[string]interface{}
+	err := json.Unmarshal([]byte(`{
+		"logs-2022.05.01": {
+			"mappings": {
+				"properties": {
+					"@timestamp": { "type": "date" },
+					"message": { "type": "text", "fields": { "keyword": { "type": "keyword" } } },
+					"host": { "properties": { "name": { "type": "keyword" }, "cpu": { "type": "float" } } },
+					"bytes": { "type": "long" }
+				}
+			}
+		},
+		"logs-2022.05.02": {
+			"mappings": {
+				"_doc": {
+					"properties": {
+						"@timestamp": { "type": "date" },
+						"latency": { "type": "scaled_float" }
+					}
+				}
+			}
+		}
+	}`), &mapping)
+	require.NoError(t, err)
+
+	t.Run("all fields", func(t *testing.T) {
+		require.Equal(t, []mappedField{
+			{Text: "@timestamp", Type: "date"},
+			{Text: "bytes", Type: "long"},
+			{Text: "host.cpu", Type: "float"},
+			{Text: "host.name", Type: "keyword"},
+			{Text: "latency", Type: "scaled_float"},
+			{Text: "message", Type: "text"},
+			{Text: "message.keyword", Type: "keyword"},
+		}, getMappedFields(mapping, nil))
+	})
+
+	t.Run("number fields", func(t *testing.T) {
+		require.Equal(t, []mappedField{
+			{Text: "bytes", Type: "long"},
+			{Text: "host.cpu", Type: "float"},
+			{Text: "latency", Type: "scaled_float"},
+		}, getMappedFields(mapping, []string{"number"}))
+	})
+
+	t.Run("keyword and date fields", func(t *testing.T) {
+		require.Equal(t, []mappedField{
+			{Text: "@timestamp", Type: "date"},
+			{Text: "host.name", Type: "keyword"},
+			{Text: "message.keyword", Type: "keyword"},
+		}, getMappedFields(mapping, []string{"keyword", "date"}))
+	})
+}
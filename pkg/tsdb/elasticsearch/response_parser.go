@@ -1,6 +1,7 @@
 package elasticsearch
 
 import (
+	"encoding/json"
 	"errors"
 	"regexp"
 	"sort"
@@ -20,6 +21,9 @@ const (
 	percentilesType   = "percentiles"
 	extendedStatsType = "extended_stats"
 	topMetricsType    = "top_metrics"
+	rawDocumentType   = "raw_document"
+	rawDataType       = "raw_data"
+	logsType          = "logs"
 	// Bucket types
 	dateHistType    = "date_histogram"
 	histogramType   = "histogram"
@@ -29,16 +33,19 @@ const (
 )
 
 type responseParser struct {
-	Responses []*es.SearchResponse
-	Targets   []*Query
-	DebugInfo *es.SearchDebugInfo
+	Responses        []*es.SearchResponse
+	Targets          []*Query
+	DebugInfo        *es.SearchDebugInfo
+	ConfiguredFields es.ConfiguredFields
 }
 
-var newResponseParser = func(responses []*es.SearchResponse, targets []*Query, debugInfo *es.SearchDebugInfo) *responseParser {
+var newResponseParser = func(responses []*es.SearchResponse, targets []*Query, debugInfo *es.SearchDebugInfo,
+	configuredFields es.ConfiguredFields) *responseParser {
 	return &responseParser{
-		Responses: responses,
-		Targets:   targets,
-		DebugInfo: debugInfo,
+		Responses:        responses,
+		Targets:          targets,
+		DebugInfo:        debugInfo,
+		ConfiguredFields: configuredFields,
 	}
 }
 
@@ -72,6 +79,11 @@ func (rp *responseParser) getTimeSeries() (*backend.QueryDataResponse, error) {
 			continue
 		}
 
+		if isDocumentQuery(target) {
+			result.Responses[target.RefID] = rp.processDocuments(res, target, debugInfo)
+			continue
+		}
+
 		queryRes := backend.DataResponse{}
 
 		props := make(map[string]string)
@@ -719,3 +731,307 @@ func getErrorFromElasticResponse(response *es.SearchResponse) string {
 
 	return errorString
 }
+
+var highlightRegex = regexp.MustCompile(es.HighlightPreTagsString + `(.*?)` + es.HighlightPostTagsString)
+
+func isDocumentQuery(target *Query) bool {
+	if len(target.BucketAggs) > 0 || len(target.Metrics) == 0 {
+		return false
+	}
+	switch target.Metrics[0].Type {
+	case logsType, rawDataType:
+		return true
+	}
+	return false
+}
+
+// processDocuments converts the hits of a logs or raw data query into a single data frame
+func (rp *responseParser) processDocuments(res *es.SearchResponse, target *Query, debugInfo *simplejson.Json) backend.DataResponse {
+	var hits []map[string]interface{}
+	if res.Hits != nil {
+		hits = res.Hits.Hits
+	}
+
+	custom := map[string]interface{}{}
+	if debugInfo != nil {
+		for k, v := range debugInfo.MustMap() {
+			custom[k] = v
+		}
+	}
+
+	var frame *data.Frame
+	if target.Metrics[0].Type == logsType {
+		var searchWords []string
+		frame, searchWords = rp.processLogsHits(hits)
+		custom["searchWords"] = searchWords
+		frame.Meta = &data.FrameMeta{
+			PreferredVisualization: data.VisTypeLogs,
+		}
+	} else {
+		frame = rp.processRawDataHits(hits)
+		frame.Meta = &data.FrameMeta{
+			PreferredVisualization: data.VisTypeTable,
+		}
+	}
+	frame.RefID = target.RefID
+	frame.Meta.Custom = custom
+
+	return backend.DataResponse{Frames: data.Frames{frame}}
+}
+
+// processLogsHits builds a logs data frame with time, line, level and labels fields, and
+// returns the terms elasticsearch highlighted in the hits
+func (rp *responseParser) processLogsHits(hits []map[string]interface{}) (*data.Frame, []string) {
+	timeField := rp.ConfiguredFields.TimeField
+	messageField := rp.ConfiguredFields.LogMessageField
+	levelField := rp.ConfiguredFields.LogLevelField
+
+	timeVector := make([]time.Time, 0, len(hits))
+	lines := make([]string, 0, len(hits))
+	levels := make([]string, 0, len(hits))
+	labels := make([]json.RawMessage, 0, len(hits))
+	searchWords := make([]string, 0)
+	seenWords := make(map[string]bool)
+
+	for _, hit := range hits {
+		source, _ := hit["_source"].(map[string]interface{})
+		doc := make(map[string]interface{})
+		flattenDocument("", source, doc)
+
+		timeVector = append(timeVector, getHitTime(hit, doc, timeField))
+
+		line := ""
+		if messageField != "" {
+			line = documentValueToString(doc[messageField])
+		}
+		if line == "" {
+			sourceJSON, err := json.Marshal(source)
+			if err != nil {
+				sourceJSON = []byte{}
+			}
+			line = string(sourceJSON)
+		}
+		lines = append(lines, line)
+
+		level := ""
+		if levelField != "" {
+			level = documentValueToString(doc[levelField])
+		}
+		levels = append(levels, level)
+
+		lineLabels := make(map[string]string, len(doc))
+		for k, v := range doc {
+			if k == timeField || k == messageField {
+				continue
+			}
+			lineLabels[k] = documentValueToString(v)
+		}
+		labelsJSON, err := json.Marshal(lineLabels)
+		if err != nil {
+			labelsJSON = []byte("{}")
+		}
+		labels = append(labels, labelsJSON)
+
+		for _, word := range getHighlightedWords(hit) {
+			if !seenWords[word] {
+				seenWords[word] = true
+				searchWords = append(searchWords, word)
+			}
+		}
+	}
+
+	frame := data.NewFrame("",
+		data.NewField("time", nil, timeVector),
+		data.NewField("line", nil, lines),
+		data.NewField("level", nil, levels),
+		data.NewField("labels", nil, labels),
+	)
+
+	return frame, searchWords
+}
+
+// processRawDataHits builds a table data frame with one column per flattened document field
+func (rp *responseParser) processRawDataHits(hits []map[string]interface{}) *data.Frame {
+	timeField := rp.ConfiguredFields.TimeField
+
+	docs := make([]map[string]interface{}, 0, len(hits))
+	timeVector := make([]time.Time, 0, len(hits))
+	columns := make(map[string]bool)
+	for _, hit := range hits {
+		source, _ := hit["_source"].(map[string]interface{})
+		doc := make(map[string]interface{})
+		flattenDocument("", source, doc)
+		timeVector = append(timeVector, getHitTime(hit, doc, timeField))
+
+		doc["_id"] = hit["_id"]
+		doc["_index"] = hit["_index"]
+		for k := range doc {
+			if k != timeField {
+				columns[k] = true
+			}
+		}
+		docs = append(docs, doc)
+	}
+
+	columnNames := make([]string, 0, len(columns))
+	for k := range columns {
+		columnNames = append(columnNames, k)
+	}
+	sort.Strings(columnNames)
+
+	fields := make([]*data.Field, 0, len(columnNames)+1)
+	fields = append(fields, data.NewField(timeField, nil, timeVector))
+	for _, name := range columnNames {
+		fields = append(fields, createDocumentField(name, docs))
+	}
+
+	return data.NewFrame("", fields...)
+}
+
+// createDocumentField creates a nullable field typed after the values found in the documents.
+// Columns with mixed value types fall back to strings.
+func createDocumentField(name string, docs []map[string]interface{}) *data.Field {
+	isNumber, isBool := true, true
+	for _, doc := range docs {
+		switch doc[name].(type) {
+		case nil:
+		case float64:
+			isBool = false
+		case bool:
+			isNumber = false
+		default:
+			isNumber, isBool = false, false
+		}
+	}
+
+	switch {
+	case isNumber:
+		values := make([]*float64, 0, len(docs))
+		for _, doc := range docs {
+			var value *float64
+			if v, ok := doc[name].(float64); ok {
+				value = &v
+			}
+			values = append(values, value)
+		}
+		return data.NewField(name, nil, values)
+	case isBool:
+		values := make([]*bool, 0, len(docs))
+		for _, doc := range docs {
+			var value *bool
+			if v, ok := doc[name].(bool); ok {
+				value = &v
+			}
+			values = append(values, value)
+		}
+		return data.NewField(name, nil, values)
+	default:
+		values := make([]*string, 0, len(docs))
+		for _, doc := range docs {
+			var value *string
+			if v, ok := doc[name]; ok && v != nil {
+				s := documentValueToString(v)
+				value = &s
+			}
+			values = append(values, value)
+		}
+		return data.NewField(name, nil, values)
+	}
+}
+
+// flattenDocument flattens nested objects of a document source into dot separated keys
+func flattenDocument(prefix string, source map[string]interface{}, result map[string]interface{}) {
+	for k, v := range source {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if nested, ok := v.(map[string]interface{}); ok {
+			flattenDocument(key, nested, result)
+			continue
+		}
+		result[key] = v
+	}
+}
+
+// getHitTime reads the timestamp of a hit, preferring the doc value field requested with the search
+func getHitTime(hit map[string]interface{}, doc map[string]interface{}, timeField string) time.Time {
+	if fields, ok := hit["fields"].(map[string]interface{}); ok {
+		if t, ok := parseDocumentTime(fields[timeField]); ok {
+			return t
+		}
+	}
+	if t, ok := parseDocumentTime(doc[timeField]); ok {
+		return t
+	}
+	return time.Time{}
+}
+
+func parseDocumentTime(v interface{}) (time.Time, bool) {
+	switch value := v.(type) {
+	case []interface{}:
+		if len(value) > 0 {
+			return parseDocumentTime(value[0])
+		}
+	case float64:
+		return time.Unix(0, int64(value)*int64(time.Millisecond)).UTC(), true
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			return t.UTC(), true
+		}
+		if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return time.Unix(0, ms*int64(time.Millisecond)).UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+func getHighlightedWords(hit map[string]interface{}) []string {
+	highlight, ok := hit["highlight"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	fieldNames := make([]string, 0, len(highlight))
+	for k := range highlight {
+		fieldNames = append(fieldNames, k)
+	}
+	sort.Strings(fieldNames)
+
+	words := make([]string, 0)
+	for _, fieldName := range fieldNames {
+		fragments, ok := highlight[fieldName].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, fragment := range fragments {
+			text, ok := fragment.(string)
+			if !ok {
+				continue
+			}
+			for _, match := range highlightRegex.FindAllStringSubmatch(text, -1) {
+				words = append(words, match[1])
+			}
+		}
+	}
+	return words
+}
+
+func documentValueToString(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	default:
+		b, err := json.Marshal(value)
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
 	es "github.com/grafana/grafana/pkg/tsdb/elasticsearch/client"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -969,6 +970,123 @@ func TestResponseParser(t *testing.T) {
 		})
 	})
 
+	t.Run("With logs", func(t *testing.T) {
+		targets := map[string]string{
+			"A": `{
+				"timeField": "@timestamp",
+				"query": "hello",
+				"metrics": [{ "type": "logs", "id": "1" }]
+			}`,
+		}
+		response := `{
+			"responses": [{
+				"hits": {
+					"hits": [
+						{
+							"_id": "fdsfs",
+							"_index": "mock-index",
+							"_source": {
+								"@timestamp": "2019-06-24T09:51:19.765Z",
+								"line": "hello, i am a message",
+								"lvl": "debug",
+								"host": { "name": "server-1" }
+							},
+							"fields": { "@timestamp": ["2019-06-24T09:51:19.765Z"] },
+							"highlight": { "line": ["@HIGHLIGHT@hello@/HIGHLIGHT@, i am a message"] }
+						},
+						{
+							"_id": "kdospaidopa",
+							"_index": "mock-index",
+							"_source": {
+								"@timestamp": "2019-06-24T09:52:19.765Z",
+								"lvl": "error",
+								"host": { "name": "server-2" }
+							},
+							"fields": { "@timestamp": ["2019-06-24T09:52:19.765Z"] }
+						}
+					]
+				}
+			}]
+		}`
+		rp, err := newResponseParserForTest(targets, response)
+		require.NoError(t, err)
+		result, err := rp.getTimeSeries()
+		require.NoError(t, err)
+
+		queryRes := result.Responses["A"]
+		require.NoError(t, queryRes.Error)
+		require.Len(t, queryRes.Frames, 1)
+
+		frame := queryRes.Frames[0]
+		require.Equal(t, data.VisTypeLogs, string(frame.Meta.PreferredVisualization))
+		require.Equal(t, []string{"hello"}, frame.Meta.Custom.(map[string]interface{})["searchWords"])
+		require.Len(t, frame.Fields, 4)
+		require.Equal(t, 2, frame.Rows())
+
+		require.Equal(t, "time", frame.Fields[0].Name)
+		require.Equal(t, time.Date(2019, 6, 24, 9, 51, 19, 765000000, time.UTC), frame.Fields[0].At(0))
+
+		require.Equal(t, "line", frame.Fields[1].Name)
+		require.Equal(t, "hello, i am a message", frame.Fields[1].At(0))
+		require.Equal(t, `{"@timestamp":"2019-06-24T09:52:19.765Z","host":{"name":"server-2"},"lvl":"error"}`, frame.Fields[1].At(1))
+
+		require.Equal(t, "level", frame.Fields[2].Name)
+		require.Equal(t, "debug", frame.Fields[2].At(0))
+		require.Equal(t, "error", frame.Fields[2].At(1))
+
+		require.Equal(t, "labels", frame.Fields[3].Name)
+		require.JSONEq(t, `{"host.name":"server-1","lvl":"debug"}`, string(frame.Fields[3].At(0).(json.RawMessage)))
+	})
+
+	t.Run("With raw data", func(t *testing.T) {
+		targets := map[string]string{
+			"A": `{
+				"timeField": "@timestamp",
+				"metrics": [{ "type": "raw_data", "id": "1" }]
+			}`,
+		}
+		response := `{
+			"responses": [{
+				"hits": {
+					"hits": [
+						{
+							"_id": "1",
+							"_index": "mock-index",
+							"_source": { "@timestamp": 1561369879765, "value": 1.5, "host": "server-1" }
+						},
+						{
+							"_id": "2",
+							"_index": "mock-index",
+							"_source": { "@timestamp": 1561369939765, "host": "server-2", "up": true }
+						}
+					]
+				}
+			}]
+		}`
+		rp, err := newResponseParserForTest(targets, response)
+		require.NoError(t, err)
+		result, err := rp.getTimeSeries()
+		require.NoError(t, err)
+
+		frame := result.Responses["A"].Frames[0]
+		require.Equal(t, data.VisTypeTable, string(frame.Meta.PreferredVisualization))
+		require.Equal(t, 2, frame.Rows())
+
+		names := make([]string, 0, len(frame.Fields))
+		for _, f := range frame.Fields {
+			names = append(names, f.Name)
+		}
+		require.Equal(t, []string{"@timestamp", "_id", "_index", "host", "up", "value"}, names)
+
+		require.Equal(t, time.Date(2019, 6, 24, 9, 51, 19, 765000000, time.UTC), frame.Fields[0].At(0))
+		require.Equal(t, data.FieldTypeNullableString, frame.Fields[3].Type())
+		require.Equal(t, data.FieldTypeNullableBool, frame.Fields[4].Type())
+		require.Nil(t, frame.Fields[4].At(0))
+		require.Equal(t, data.FieldTypeNullableFloat64, frame.Fields[5].Type())
+		require.Equal(t, 1.5, *frame.Fields[5].At(0).(*float64))
+		require.Nil(t, frame.Fields[5].At(1))
+	})
+
 	t.Run("With top_metrics", func(t *testing.T) {
 		targets := map[string]string{
 			"A": `{
@@ -1092,5 +1210,11 @@ func newResponseParserForTest(tsdbQueries map[string]string, responseBody string
 		return nil, err
 	}
 
-	return newResponseParser(response.Responses, queries, nil), nil
+	configuredFields := es.ConfiguredFields{
+		TimeField:       "@timestamp",
+		LogMessageField: "line",
+		LogLevelField:   "lvl",
+	}
+
+	return newResponseParser(response.Responses, queries, nil, configuredFields), nil
 }
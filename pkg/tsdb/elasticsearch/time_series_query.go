@@ -58,7 +58,7 @@ func (e *timeSeriesQuery) execute() (*backend.QueryDataResponse, error) {
 		return &backend.QueryDataResponse{}, err
 	}
 
-	rp := newResponseParser(res.Responses, queries, res.DebugInfo)
+	rp := newResponseParser(res.Responses, queries, res.DebugInfo, e.client.GetConfiguredFields())
 	return rp.getTimeSeries()
 }
 
@@ -80,16 +80,36 @@ func (e *timeSeriesQuery) processQuery(q *Query, ms *es.MultiSearchRequestBuilde
 	}
 
 	if len(q.BucketAggs) == 0 {
-		if len(q.Metrics) == 0 || q.Metrics[0].Type != "raw_document" {
+		if len(q.Metrics) == 0 {
 			result.Responses[q.RefID] = backend.DataResponse{
 				Error: fmt.Errorf("invalid query, missing metrics and aggregations"),
 			}
 			return nil
 		}
 		metric := q.Metrics[0]
-		b.Size(metric.Settings.Get("size").MustInt(500))
-		b.SortDesc("@timestamp", "boolean")
-		b.AddDocValueField("@timestamp")
+		switch metric.Type {
+		case rawDocumentType:
+			b.Size(metric.Settings.Get("size").MustInt(500))
+			b.SortDesc("@timestamp", "boolean")
+			b.AddDocValueField("@timestamp")
+		case rawDataType:
+			timeField := e.client.GetTimeField()
+			b.Size(getSizeSetting(metric.Settings, "size", 500))
+			b.Sort(getSortOrderSetting(metric.Settings), timeField, "boolean")
+			b.AddDocValueField(timeField)
+		case logsType:
+			timeField := e.client.GetTimeField()
+			b.Size(getSizeSetting(metric.Settings, "limit", 500))
+			b.Sort(getSortOrderSetting(metric.Settings), timeField, "boolean")
+			b.AddDocValueField(timeField)
+			if q.RawQuery != "" {
+				b.AddHighlight()
+			}
+		default:
+			result.Responses[q.RefID] = backend.DataResponse{
+				Error: fmt.Errorf("invalid query, missing metrics and aggregations"),
+			}
+		}
 		return nil
 	}
 
@@ -182,6 +202,24 @@ func (e *timeSeriesQuery) processQuery(q *Query, ms *es.MultiSearchRequestBuilde
 	return nil
 }
 
+// getSizeSetting reads a document count setting which the query editor may store as a number or a string
+func getSizeSetting(settings *simplejson.Json, key string, defaultValue int) int {
+	if size, err := settings.Get(key).Int(); err == nil && size > 0 {
+		return size
+	}
+	if size, err := strconv.Atoi(settings.Get(key).MustString()); err == nil && size > 0 {
+		return size
+	}
+	return defaultValue
+}
+
+func getSortOrderSetting(settings *simplejson.Json) es.SortOrder {
+	if settings.Get("sortDirection").MustString() == string(es.SortOrderAsc) {
+		return es.SortOrderAsc
+	}
+	return es.SortOrderDesc
+}
+
 func setFloatPath(settings *simplejson.Json, path ...string) {
 	if stringValue, err := settings.GetPath(path...).String(); err == nil {
 		if value, err := strconv.ParseFloat(stringValue, 64); err == nil {
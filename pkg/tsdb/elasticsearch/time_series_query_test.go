@@ -377,6 +377,56 @@ func TestExecuteTimeSeriesQuery(t *testing.T) {
 			require.Equal(t, sr.Size, 1337)
 		})
 
+		t.Run("With raw data metric", func(t *testing.T) {
+			c := newFakeClient("7.10.0")
+			_, err := executeTsdbQuery(c, `{
+				"timeField": "@timestamp",
+				"bucketAggs": [],
+				"metrics": [{ "id": "1", "type": "raw_data", "settings": { "size": "100", "sortDirection": "asc" } }]
+			}`, from, to, 15*time.Second)
+			require.NoError(t, err)
+			sr := c.multisearchRequests[0].Requests[0]
+
+			require.Equal(t, 100, sr.Size)
+			require.Equal(t, map[string]string{"order": "asc", "unmapped_type": "boolean"}, sr.Sort["@timestamp"])
+			require.Equal(t, []string{"@timestamp"}, sr.CustomProps["docvalue_fields"])
+			require.NotContains(t, sr.CustomProps, "highlight")
+		})
+
+		t.Run("With logs metric", func(t *testing.T) {
+			c := newFakeClient("7.10.0")
+			_, err := executeTsdbQuery(c, `{
+				"timeField": "@timestamp",
+				"query": "level:error",
+				"bucketAggs": [],
+				"metrics": [{ "id": "1", "type": "logs", "settings": { "limit": 1000 } }]
+			}`, from, to, 15*time.Second)
+			require.NoError(t, err)
+			sr := c.multisearchRequests[0].Requests[0]
+
+			require.Equal(t, 1000, sr.Size)
+			require.Equal(t, map[string]string{"order": "desc", "unmapped_type": "boolean"}, sr.Sort["@timestamp"])
+			require.Equal(t, []string{"@timestamp"}, sr.CustomProps["docvalue_fields"])
+
+			highlight := sr.CustomProps["highlight"].(map[string]interface{})
+			require.Equal(t, []string{es.HighlightPreTagsString}, highlight["pre_tags"])
+			require.Equal(t, []string{es.HighlightPostTagsString}, highlight["post_tags"])
+		})
+
+		t.Run("With logs metric without limit", func(t *testing.T) {
+			c := newFakeClient("7.10.0")
+			_, err := executeTsdbQuery(c, `{
+				"timeField": "@timestamp",
+				"bucketAggs": [],
+				"metrics": [{ "id": "1", "type": "logs" }]
+			}`, from, to, 15*time.Second)
+			require.NoError(t, err)
+			sr := c.multisearchRequests[0].Requests[0]
+
+			require.Equal(t, 500, sr.Size)
+			require.NotContains(t, sr.CustomProps, "highlight")
+		})
+
 		t.Run("With date histogram agg", func(t *testing.T) {
 			c := newFakeClient("5.0.0")
 			_, err := executeTsdbQuery(c, `{
@@ -1212,6 +1262,14 @@ func (c *fakeClient) GetTimeField() string {
 	return c.timeField
 }
 
+func (c *fakeClient) GetConfiguredFields() es.ConfiguredFields {
+	return es.ConfiguredFields{TimeField: c.timeField}
+}
+
+func (c *fakeClient) GetMapping() (map[string]interface{}, error) {
+	return map[string]interface{}{}, nil
+}
+
 func (c *fakeClient) GetMinInterval(queryInterval string) (time.Duration, error) {
 	return 15 * time.Second, nil
 }
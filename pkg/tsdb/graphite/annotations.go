@@ -0,0 +1,141 @@
+package graphite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+const annotationsQueryType = "annotations"
+
+// GraphiteEventDTO is an event returned by the Graphite events API
+type GraphiteEventDTO struct {
+	When float64 `json:"when"`
+	What string  `json:"what"`
+	Data string  `json:"data"`
+	// Graphite stores tags as a space separated string, newer versions return them as a list
+	Tags interface{} `json:"tags"`
+}
+
+// queryAnnotations fetches the Graphite events matching the tags of the annotation query
+func (s *Service) queryAnnotations(ctx context.Context, dsInfo *datasourceInfo, timeRange backend.TimeRange, model *simplejson.Json) backend.DataResponse {
+	from, until := epochMStoGraphiteTime(timeRange)
+	params := url.Values{
+		"from":  []string{from},
+		"until": []string{until},
+	}
+	if tags := annotationQueryTags(model); len(tags) > 0 {
+		params.Set("tags", strings.Join(tags, " "))
+	}
+
+	u, err := url.Parse(dsInfo.URL)
+	if err != nil {
+		return backend.DataResponse{Error: err}
+	}
+	u.Path = path.Join(u.Path, "events/get_data")
+	u.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return backend.DataResponse{Error: fmt.Errorf("failed to create request: %w", err)}
+	}
+
+	res, err := dsInfo.HTTPClient.Do(req)
+	if err != nil {
+		return backend.DataResponse{Error: err}
+	}
+
+	frame, err := s.toAnnotationsFrame(res)
+	if err != nil {
+		return backend.DataResponse{Error: err}
+	}
+
+	return backend.DataResponse{Frames: data.Frames{frame}}
+}
+
+// annotationQueryTags reads the tags of an annotation query, which may be stored as a list or a space separated string
+func annotationQueryTags(model *simplejson.Json) []string {
+	if tags, err := model.Get("tags").StringArray(); err == nil {
+		return tags
+	}
+	return strings.Fields(model.Get("tags").MustString())
+}
+
+func (s *Service) toAnnotationsFrame(res *http.Response) (*data.Frame, error) {
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			s.logger.Warn("Failed to close response body", "err", err)
+		}
+	}()
+
+	if res.StatusCode/100 != 2 {
+		s.logger.Info("Request failed", "status", res.Status, "body", string(body))
+		return nil, fmt.Errorf("request failed, status: %s", res.Status)
+	}
+
+	var events []GraphiteEventDTO
+	if err := json.Unmarshal(body, &events); err != nil {
+		s.logger.Info("Failed to unmarshal graphite events response", "error", err, "status", res.Status, "body", string(body))
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].When < events[j].When
+	})
+
+	timeVector := make([]time.Time, 0, len(events))
+	titles := make([]string, 0, len(events))
+	texts := make([]string, 0, len(events))
+	tags := make([]json.RawMessage, 0, len(events))
+	for _, event := range events {
+		timeVector = append(timeVector, time.Unix(0, int64(event.When*float64(time.Second))).UTC())
+		titles = append(titles, event.What)
+		texts = append(texts, event.Data)
+
+		eventTags, err := json.Marshal(parseEventTags(event.Tags))
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, eventTags)
+	}
+
+	return data.NewFrame("annotations",
+		data.NewField("time", nil, timeVector),
+		data.NewField("title", nil, titles),
+		data.NewField("text", nil, texts),
+		data.NewField("tags", nil, tags),
+	), nil
+}
+
+func parseEventTags(tags interface{}) []string {
+	switch t := tags.(type) {
+	case string:
+		return strings.Fields(t)
+	case []interface{}:
+		result := make([]string, 0, len(t))
+		for _, tag := range t {
+			if s, ok := tag.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	default:
+		return []string{}
+	}
+}
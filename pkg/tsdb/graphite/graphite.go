@@ -114,12 +114,17 @@ func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest)
 	// Calculate and get the last target of Graphite Request
 	var target string
 	emptyQueries := make([]string, 0)
+	annotationQueries := make(map[string]*simplejson.Json)
 	for _, query := range req.Queries {
 		model, err := simplejson.NewJson(query.JSON)
 		if err != nil {
 			return nil, err
 		}
 		s.logger.Debug("graphite", "query", model)
+		if model.Get("queryType").MustString() == annotationsQueryType {
+			annotationQueries[query.RefID] = model
+			continue
+		}
 		currTarget := ""
 		if fullTarget, err := model.Get(TargetFullModelField).String(); err == nil {
 			currTarget = fullTarget
@@ -136,6 +141,14 @@ func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest)
 
 	var result = backend.QueryDataResponse{}
 
+	if target == "" && len(annotationQueries) > 0 {
+		result.Responses = make(backend.Responses)
+		for refID, model := range annotationQueries {
+			result.Responses[refID] = s.queryAnnotations(ctx, dsInfo, q.TimeRange, model)
+		}
+		return &result, nil
+	}
+
 	if target == "" {
 		s.logger.Error("No targets in query model", "models without targets", strings.Join(emptyQueries, "\n"))
 		return &result, errors.New("no query target found for the alert rule")
@@ -180,6 +193,10 @@ func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest)
 		Frames: frames,
 	}
 
+	for refID, model := range annotationQueries {
+		result.Responses[refID] = s.queryAnnotations(ctx, dsInfo, q.TimeRange, model)
+	}
+
 	return &result, nil
 }
 
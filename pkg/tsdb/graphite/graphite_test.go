@@ -1,15 +1,21 @@
 package graphite
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -112,3 +118,107 @@ func TestFixIntervalFormat(t *testing.T) {
 		}
 	})
 }
+
+func TestAnnotations(t *testing.T) {
+	service := &Service{logger: log.New("tsdb.graphite")}
+
+	t.Run("Reads tags from a list or a space separated string", func(t *testing.T) {
+		model := simplejson.NewFromAny(map[string]interface{}{"tags": []interface{}{"deploy", "prod"}})
+		assert.Equal(t, []string{"deploy", "prod"}, annotationQueryTags(model))
+
+		model = simplejson.NewFromAny(map[string]interface{}{"tags": "deploy  prod"})
+		assert.Equal(t, []string{"deploy", "prod"}, annotationQueryTags(model))
+
+		assert.Empty(t, annotationQueryTags(simplejson.New()))
+	})
+
+	t.Run("Converts events response to an annotations data frame", func(t *testing.T) {
+		body := `
+		[
+			{ "when": 1650000060, "what": "Second", "data": "v2", "tags": "deploy prod" },
+			{ "when": 1650000000.5, "what": "First", "data": "v1", "tags": ["deploy"] }
+		]`
+
+		httpResponse := &http.Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader(body))}
+		frame, err := service.toAnnotationsFrame(httpResponse)
+		require.NoError(t, err)
+
+		expectedFrame := data.NewFrame("annotations",
+			data.NewField("time", nil, []time.Time{time.Unix(1650000000, 500000000).UTC(), time.Unix(1650000060, 0).UTC()}),
+			data.NewField("title", nil, []string{"First", "Second"}),
+			data.NewField("text", nil, []string{"v1", "v2"}),
+			data.NewField("tags", nil, []json.RawMessage{json.RawMessage(`["deploy"]`), json.RawMessage(`["deploy","prod"]`)}),
+		)
+		if diff := cmp.Diff(expectedFrame, frame, data.FrameTestCompareOptions()...); diff != "" {
+			t.Errorf("Result mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Returns an error for failed events requests", func(t *testing.T) {
+		httpResponse := &http.Response{StatusCode: 500, Status: "500 Internal Server Error", Body: ioutil.NopCloser(strings.NewReader(""))}
+		_, err := service.toAnnotationsFrame(httpResponse)
+		require.Error(t, err)
+	})
+}
+
+func TestCallResource(t *testing.T) {
+	service := &Service{logger: log.New("tsdb.graphite")}
+
+	var requestedURL *url.URL
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedURL = r.URL
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`[{"text": "servers", "expandable": 1}]`))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(srv.Close)
+
+	dsInfo := &datasourceInfo{HTTPClient: srv.Client(), URL: srv.URL + "/graphite"}
+
+	t.Run("Proxies metric find requests", func(t *testing.T) {
+		sender := &fakeSender{}
+		err := service.callResource(context.Background(), &backend.CallResourceRequest{
+			Method: http.MethodGet,
+			URL:    "metrics/find?query=servers.*&from=1650000000",
+		}, sender, dsInfo)
+		require.NoError(t, err)
+
+		require.Equal(t, "/graphite/metrics/find", requestedURL.Path)
+		require.Equal(t, "servers.*", requestedURL.Query().Get("query"))
+		require.Equal(t, http.StatusOK, sender.response.Status)
+		require.JSONEq(t, `[{"text": "servers", "expandable": 1}]`, string(sender.response.Body))
+	})
+
+	t.Run("Proxies tag autocomplete requests", func(t *testing.T) {
+		sender := &fakeSender{}
+		err := service.callResource(context.Background(), &backend.CallResourceRequest{
+			Method: http.MethodGet,
+			URL:    "tags/autoComplete/values?tag=dc",
+		}, sender, dsInfo)
+		require.NoError(t, err)
+		require.Equal(t, "/graphite/tags/autoComplete/values", requestedURL.Path)
+	})
+
+	t.Run("Rejects unsupported resources", func(t *testing.T) {
+		err := service.callResource(context.Background(), &backend.CallResourceRequest{
+			Method: http.MethodGet,
+			URL:    "render?target=servers.*",
+		}, &fakeSender{}, dsInfo)
+		require.Error(t, err)
+
+		err = service.callResource(context.Background(), &backend.CallResourceRequest{
+			Method: http.MethodPost,
+			URL:    "metrics/find",
+		}, &fakeSender{}, dsInfo)
+		require.Error(t, err)
+	})
+}
+
+type fakeSender struct {
+	response *backend.CallResourceResponse
+}
+
+func (s *fakeSender) Send(resp *backend.CallResourceResponse) error {
+	s.response = resp
+	return nil
+}
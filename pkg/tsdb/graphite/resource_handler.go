@@ -0,0 +1,86 @@
+package graphite
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+)
+
+// resourcePaths are the read-only Graphite API endpoints that can be reached through CallResource
+var resourcePaths = map[string]bool{
+	"metrics/find":             true,
+	"metrics/expand":           true,
+	"tags/autoComplete/tags":   true,
+	"tags/autoComplete/values": true,
+	"functions":                true,
+	"version":                  true,
+}
+
+func (s *Service) CallResource(ctx context.Context, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender) error {
+	dsInfo, err := s.getDSInfo(req.PluginContext)
+	if err != nil {
+		return err
+	}
+
+	return s.callResource(ctx, req, sender, dsInfo)
+}
+
+func (s *Service) callResource(ctx context.Context, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender, dsInfo *datasourceInfo) error {
+	// a very basic is-this-url-valid check
+	if req.Method != http.MethodGet {
+		return fmt.Errorf("invalid resource method: %s", req.Method)
+	}
+
+	resourceURL, err := url.Parse(req.URL)
+	if err != nil {
+		return err
+	}
+	if !resourcePaths[resourceURL.Path] {
+		return fmt.Errorf("invalid resource URL: %s", req.URL)
+	}
+
+	u, err := url.Parse(dsInfo.URL)
+	if err != nil {
+		return err
+	}
+	u.Path = path.Join(u.Path, resourceURL.Path)
+	u.RawQuery = resourceURL.RawQuery
+
+	graphiteReq, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	res, err := dsInfo.HTTPClient.Do(graphiteReq)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			s.logger.Warn("Failed to close response body", "err", err)
+		}
+	}()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	contentType := res.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/json"
+	}
+
+	return sender.Send(&backend.CallResourceResponse{
+		Status: res.StatusCode,
+		Headers: map[string][]string{
+			"content-type": {contentType},
+		},
+		Body: body,
+	})
+}
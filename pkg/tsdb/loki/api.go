@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/grafana/grafana/pkg/infra/log"
@@ -214,3 +215,28 @@ func (api *LokiAPI) RawQuery(ctx context.Context, resourceURL string) ([]byte, e
 
 	return io.ReadAll(resp.Body)
 }
+
+type seriesResponse struct {
+	Status string              `json:"status"`
+	Data   []map[string]string `json:"data"`
+}
+
+// Series returns the label sets of the streams matching the selector in the time range
+func (api *LokiAPI) Series(ctx context.Context, selector string, start time.Time, end time.Time) ([]map[string]string, error) {
+	qs := url.Values{}
+	qs.Set("match[]", selector)
+	qs.Set("start", strconv.FormatInt(start.UnixNano(), 10))
+	qs.Set("end", strconv.FormatInt(end.UnixNano(), 10))
+
+	bytes, err := api.RawQuery(ctx, "/loki/api/v1/series?"+qs.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var res seriesResponse
+	if err := json.Unmarshal(bytes, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Data, nil
+}
@@ -50,6 +50,10 @@ func adjustMetricFrame(frame *data.Frame, query *lokiQuery) error {
 	isMetricRange := query.QueryType == QueryTypeRange
 
 	name := formatName(labels, query)
+	if name == "" && query.VolumeQuery {
+		// log lines without a level label are grouped into an empty label set
+		name = logVolumeUnknownLevel
+	}
 	frame.Name = name
 
 	if frame.Meta == nil {
@@ -0,0 +1,68 @@
+package loki
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// runLabelStatsQuery computes, for every label of the streams matching the stream selector
+// of the query, the number of distinct values and the number of streams having the label
+func runLabelStatsQuery(ctx context.Context, api *LokiAPI, query *lokiQuery) (data.Frames, error) {
+	selector := strings.TrimSpace(query.Expr)
+	if !strings.HasPrefix(selector, "{") || !strings.HasSuffix(selector, "}") {
+		return data.Frames{}, fmt.Errorf("label statistics require a stream selector, got: %s", query.Expr)
+	}
+
+	series, err := api.Series(ctx, selector, query.Start, query.End)
+	if err != nil {
+		return data.Frames{}, err
+	}
+
+	frame := makeLabelStatsFrame(series)
+	frame.Meta = &data.FrameMeta{
+		ExecutedQueryString:    "Series: " + selector,
+		PreferredVisualization: data.VisTypeTable,
+		Stats: []data.QueryStat{
+			{FieldConfig: data.FieldConfig{DisplayName: "Streams"}, Value: float64(len(series))},
+		},
+	}
+
+	return data.Frames{frame}, nil
+}
+
+func makeLabelStatsFrame(series []map[string]string) *data.Frame {
+	values := make(map[string]map[string]bool)
+	streams := make(map[string]int64)
+	for _, labels := range series {
+		for name, value := range labels {
+			if values[name] == nil {
+				values[name] = make(map[string]bool)
+			}
+			values[name][value] = true
+			streams[name]++
+		}
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	cardinality := make([]int64, 0, len(names))
+	streamCounts := make([]int64, 0, len(names))
+	for _, name := range names {
+		cardinality = append(cardinality, int64(len(values[name])))
+		streamCounts = append(streamCounts, streams[name])
+	}
+
+	return data.NewFrame("labelStats",
+		data.NewField("label", nil, names),
+		data.NewField("distinctValues", nil, cardinality),
+		data.NewField("streams", nil, streamCounts),
+	)
+}
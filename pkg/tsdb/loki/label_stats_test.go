@@ -0,0 +1,60 @@
+package loki
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelStats(t *testing.T) {
+	t.Run("computes label cardinality from the matching series", func(t *testing.T) {
+		response := []byte(`
+		{
+			"status": "success",
+			"data": [
+				{ "app": "grafana", "level": "info", "pod": "grafana-1" },
+				{ "app": "grafana", "level": "error", "pod": "grafana-1" },
+				{ "app": "grafana", "level": "info", "pod": "grafana-2" },
+				{ "app": "grafana", "pod": "grafana-3" }
+			]
+		}`)
+
+		var requestedURL string
+		api := makeMockedAPI(http.StatusOK, "application/json", response, func(req *http.Request) {
+			requestedURL = req.URL.String()
+		})
+
+		query := &lokiQuery{
+			Expr:      `{app="grafana"}`,
+			QueryType: QueryTypeLabelStats,
+			Start:     time.Unix(1650000000, 0),
+			End:       time.Unix(1650003600, 0),
+		}
+		frames, err := runLabelStatsQuery(context.Background(), api, query)
+		require.NoError(t, err)
+		require.Len(t, frames, 1)
+		require.Equal(t, "http://localhost:9999/loki/api/v1/series?end=1650003600000000000&match%5B%5D=%7Bapp%3D%22grafana%22%7D&start=1650000000000000000", requestedURL)
+
+		frame := frames[0]
+		require.Equal(t, 3, frame.Rows())
+		require.Equal(t, []interface{}{"app", int64(1), int64(4)}, frame.RowCopy(0))
+		require.Equal(t, []interface{}{"level", int64(2), int64(3)}, frame.RowCopy(1))
+		require.Equal(t, []interface{}{"pod", int64(3), int64(4)}, frame.RowCopy(2))
+		require.Equal(t, 4.0, frame.Meta.Stats[0].Value)
+	})
+
+	t.Run("requires a stream selector", func(t *testing.T) {
+		api := makeMockedAPI(http.StatusOK, "application/json", []byte(`{}`), nil)
+		_, err := runLabelStatsQuery(context.Background(), api, &lokiQuery{Expr: `{app="grafana"} |= "error"`, QueryType: QueryTypeLabelStats})
+		require.Error(t, err)
+	})
+
+	t.Run("returns loki errors", func(t *testing.T) {
+		api := makeMockedAPI(http.StatusBadRequest, "application/json", []byte(`{"message": "parse error"}`), nil)
+		_, err := runLabelStatsQuery(context.Background(), api, &lokiQuery{Expr: `{app="grafana"}`, QueryType: QueryTypeLabelStats})
+		require.EqualError(t, err, "parse error")
+	})
+}
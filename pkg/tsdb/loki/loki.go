@@ -192,7 +192,12 @@ func queryData(ctx context.Context, req *backend.QueryDataRequest, dsInfo *datas
 		span.SetAttributes("stop_unixnano", query.End, attribute.Key("stop_unixnano").Int64(query.End.UnixNano()))
 		defer span.End()
 
-		frames, err := runQuery(ctx, api, query)
+		var frames data.Frames
+		if query.QueryType == QueryTypeLabelStats {
+			frames, err = runLabelStatsQuery(ctx, api, query)
+		} else {
+			frames, err = runQuery(ctx, api, query)
+		}
 
 		queryRes := backend.DataResponse{}
 
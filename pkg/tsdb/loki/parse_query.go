@@ -56,8 +56,10 @@ func parseQueryType(jsonValue string) (QueryType, error) {
 	switch jsonValue {
 	case "instant":
 		return QueryTypeInstant, nil
-	case "range":
+	case "range", queryTypeLogVolume:
 		return QueryTypeRange, nil
+	case "labelStats":
+		return QueryTypeLabelStats, nil
 	case "":
 		// there are older queries stored in alerting that did not have queryType,
 		// those were range-queries
@@ -115,19 +117,33 @@ func parseQuery(queryContext *backend.QueryDataRequest) ([]*lokiQuery, error) {
 			return nil, err
 		}
 
+		volumeQuery := model.VolumeQuery
+		legendFormat := model.LegendFormat
+		if model.QueryType == queryTypeLogVolume {
+			expr = makeLogVolumeExpr(expr, step)
+			volumeQuery = true
+			legendFormat = "{{" + logVolumeLevelLabel + "}}"
+		}
+
 		qs = append(qs, &lokiQuery{
 			Expr:         expr,
 			QueryType:    queryType,
 			Direction:    direction,
 			Step:         step,
 			MaxLines:     model.MaxLines,
-			LegendFormat: model.LegendFormat,
+			LegendFormat: legendFormat,
 			Start:        start,
 			End:          end,
 			RefID:        query.RefID,
-			VolumeQuery:  model.VolumeQuery,
+			VolumeQuery:  volumeQuery,
 		})
 	}
 
 	return qs, nil
 }
+
+// makeLogVolumeExpr wraps a log query in a metric query counting the log lines per level,
+// using the step of the query as the counting window
+func makeLogVolumeExpr(expr string, step time.Duration) string {
+	return fmt.Sprintf("sum by (%s) (count_over_time(%s [%dms]))", logVolumeLevelLabel, expr, step.Milliseconds())
+}
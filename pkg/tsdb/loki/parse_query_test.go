@@ -34,6 +34,57 @@ func TestParseQuery(t *testing.T) {
 		require.Equal(t, time.Second*15, models[0].Step)
 		require.Equal(t, "go_goroutines 15s 15000 3000s 3000 3000000", models[0].Expr)
 	})
+	t.Run("parsing log volume query model", func(t *testing.T) {
+		queryContext := &backend.QueryDataRequest{
+			Queries: []backend.DataQuery{
+				{
+					JSON: []byte(`
+					{
+						"expr": "{app=\"grafana\"} |= \"error\"",
+						"queryType": "logVolume",
+						"refId": "A"
+					}`,
+					),
+					TimeRange: backend.TimeRange{
+						From: time.Now().Add(-3000 * time.Second),
+						To:   time.Now(),
+					},
+					Interval:      time.Second * 15,
+					MaxDataPoints: 200,
+				},
+			},
+		}
+		models, err := parseQuery(queryContext)
+		require.NoError(t, err)
+		require.Equal(t, QueryTypeRange, models[0].QueryType)
+		require.True(t, models[0].VolumeQuery)
+		require.Equal(t, "{{level}}", models[0].LegendFormat)
+		require.Equal(t, `sum by (level) (count_over_time({app="grafana"} |= "error" [15000ms]))`, models[0].Expr)
+	})
+	t.Run("parsing label stats query model", func(t *testing.T) {
+		queryContext := &backend.QueryDataRequest{
+			Queries: []backend.DataQuery{
+				{
+					JSON: []byte(`
+					{
+						"expr": "{app=\"grafana\"}",
+						"queryType": "labelStats",
+						"refId": "A"
+					}`,
+					),
+					TimeRange: backend.TimeRange{
+						From: time.Now().Add(-3000 * time.Second),
+						To:   time.Now(),
+					},
+					Interval: time.Second * 15,
+				},
+			},
+		}
+		models, err := parseQuery(queryContext)
+		require.NoError(t, err)
+		require.Equal(t, QueryTypeLabelStats, models[0].QueryType)
+		require.Equal(t, `{app="grafana"}`, models[0].Expr)
+	})
 	t.Run("interpolate variables, range between 1s and 0.5s", func(t *testing.T) {
 		expr := "go_goroutines $__interval $__interval_ms $__range $__range_s $__range_ms"
 
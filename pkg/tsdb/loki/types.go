@@ -7,6 +7,18 @@ type QueryType string
 const (
 	QueryTypeRange   QueryType = "range"
 	QueryTypeInstant QueryType = "instant"
+	// QueryTypeLabelStats computes label cardinality statistics for the streams matching a selector
+	QueryTypeLabelStats QueryType = "labelStats"
+)
+
+const (
+	// queryTypeLogVolume is the query model type of log volume histograms. Those queries are
+	// executed as range queries over a count_over_time expression built from the log query.
+	queryTypeLogVolume = "logVolume"
+	// logVolumeLevelLabel is the label log volume histograms are grouped by
+	logVolumeLevelLabel = "level"
+	// logVolumeUnknownLevel is the name of the log volume series of log lines without a level
+	logVolumeUnknownLevel = "unknown"
 )
 
 type Direction string
@@ -41,6 +41,12 @@ type SqlQueryResultTransformer interface {
 	GetConverterList() []sqlutil.StringConverter
 }
 
+// SqlQueryResultConverterProvider can be implemented by a SqlQueryResultTransformer to provide frame
+// converters directly, e.g. dynamic converters for databases whose column types are only known per value.
+type SqlQueryResultConverterProvider interface {
+	GetConverters() []sqlutil.Converter
+}
+
 var sqlIntervalCalculator = intervalv2.NewCalculator()
 
 // NewXormEngine is an xorm.Engine factory, that can be stubbed by tests.
@@ -279,7 +285,22 @@ func (e *DataSourceHandler) executeQuery(query backend.DataQuery, wg *sync.WaitG
 
 	// Convert row.Rows to dataframe
 	stringConverters := e.queryResultTransformer.GetConverterList()
-	frame, err := sqlutil.FrameFromRows(rows.Rows, e.rowLimit, sqlutil.ToConverters(stringConverters...)...)
+	converters := sqlutil.ToConverters(stringConverters...)
+	provider, hasConverterProvider := e.queryResultTransformer.(SqlQueryResultConverterProvider)
+	if hasConverterProvider {
+		converters = append(converters, provider.GetConverters()...)
+	}
+	frame, err := sqlutil.FrameFromRows(rows.Rows, e.rowLimit, converters...)
+	if hasConverterProvider {
+		if err == nil {
+			// dynamic converters don't report errors that occur while iterating the rows
+			err = rows.Err()
+		}
+		if err != nil {
+			// the errors then come from the driver, which the transformer knows how to report
+			err = e.transformQueryError(err)
+		}
+	}
 	if err != nil {
 		errAppendDebug("convert frame from rows error", err, interpolatedQuery)
 		return
@@ -0,0 +1,87 @@
+package sqlite
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMacroEngine(t *testing.T) {
+	engine := newSqliteMacroEngine()
+	query := &backend.DataQuery{}
+
+	from := time.Date(2018, 4, 12, 18, 0, 0, 0, time.UTC)
+	to := from.Add(5 * time.Minute)
+	timeRange := backend.TimeRange{From: from, To: to}
+	epoch := unixEpoch("time_column")
+
+	t.Run("interpolate __time function", func(t *testing.T) {
+		sql, err := engine.Interpolate(query, timeRange, "select $__time(time_column)")
+		require.NoError(t, err)
+
+		require.Equal(t, "select "+epoch+" AS time", sql)
+	})
+
+	t.Run("unix epoch expression handles numeric and ISO timestamps", func(t *testing.T) {
+		require.Equal(t, "(CASE WHEN typeof(time_column) IN ('integer', 'real') THEN time_column ELSE CAST(strftime('%s', time_column) AS INTEGER) END)", epoch)
+	})
+
+	t.Run("interpolate __timeFilter function", func(t *testing.T) {
+		sql, err := engine.Interpolate(query, timeRange, "WHERE $__timeFilter(time_column)")
+		require.NoError(t, err)
+
+		require.Equal(t, fmt.Sprintf("WHERE %s BETWEEN %d AND %d", epoch, from.Unix(), to.Unix()), sql)
+	})
+
+	t.Run("interpolate __timeGroup function", func(t *testing.T) {
+		sql, err := engine.Interpolate(query, timeRange, "GROUP BY $__timeGroup(time_column,'5m')")
+		require.NoError(t, err)
+		sql2, err := engine.Interpolate(query, timeRange, "GROUP BY $__timeGroupAlias(time_column , '5m')")
+		require.NoError(t, err)
+
+		require.Equal(t, "GROUP BY CAST("+epoch+" / 300 AS INTEGER) * 300", sql)
+		require.Equal(t, sql+" AS \"time\"", sql2)
+	})
+
+	t.Run("interpolate __timeGroup function with an interval under 1s should return error", func(t *testing.T) {
+		_, err := engine.Interpolate(query, timeRange, "GROUP BY $__timeGroup(time_column,'500ms')")
+		require.Error(t, err)
+	})
+
+	t.Run("interpolate __timeGroup function with fill", func(t *testing.T) {
+		query := &backend.DataQuery{JSON: []byte("{}")}
+		_, err := engine.Interpolate(query, timeRange, "GROUP BY $__timeGroup(time_column,'5m', NULL)")
+		require.NoError(t, err)
+
+		require.Contains(t, string(query.JSON), `"fill":true`)
+	})
+
+	t.Run("interpolate __timeFrom and __timeTo functions", func(t *testing.T) {
+		sql, err := engine.Interpolate(query, timeRange, "select $__timeFrom(), $__timeTo()")
+		require.NoError(t, err)
+
+		require.Equal(t, "select '2018-04-12 18:00:00', '2018-04-12 18:05:00'", sql)
+	})
+
+	t.Run("interpolate __unixEpochFilter function", func(t *testing.T) {
+		sql, err := engine.Interpolate(query, timeRange, "select $__unixEpochFilter(time)")
+		require.NoError(t, err)
+
+		require.Equal(t, fmt.Sprintf("select time >= %d AND time <= %d", from.Unix(), to.Unix()), sql)
+	})
+
+	t.Run("interpolate __unixEpochGroup function", func(t *testing.T) {
+		sql, err := engine.Interpolate(query, timeRange, "SELECT $__unixEpochGroupAlias(time_column,'5m')")
+		require.NoError(t, err)
+
+		require.Equal(t, "SELECT CAST(time_column / 300 AS INTEGER) * 300 AS \"time\"", sql)
+	})
+
+	t.Run("unknown macro returns an error", func(t *testing.T) {
+		_, err := engine.Interpolate(query, timeRange, "select $__unknown(time_column)")
+		require.Error(t, err)
+	})
+}
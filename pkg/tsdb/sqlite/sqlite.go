@@ -0,0 +1,193 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/datasource"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/instancemgmt"
+	"github.com/grafana/grafana-plugin-sdk-go/data/sqlutil"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/tsdb/sqleng"
+	"github.com/mattn/go-sqlite3"
+)
+
+var logger = log.New("tsdb.sqlite")
+
+var (
+	errMissingPath    = errors.New("database file path is required")
+	errPathNotAllowed = errors.New("database file path is not allowed - please inspect Grafana server log for details")
+	errQueryFailed    = errors.New("query failed - please inspect Grafana server log for details")
+)
+
+type Service struct {
+	im instancemgmt.InstanceManager
+}
+
+func ProvideService(cfg *setting.Cfg) *Service {
+	return &Service{
+		im: datasource.NewInstanceManager(newInstanceSettings(cfg)),
+	}
+}
+
+func newInstanceSettings(cfg *setting.Cfg) datasource.InstanceFactoryFunc {
+	return func(settings backend.DataSourceInstanceSettings) (instancemgmt.Instance, error) {
+		jsonData := sqleng.JsonData{
+			MaxOpenConns:    0,
+			MaxIdleConns:    2,
+			ConnMaxLifetime: 14400,
+		}
+
+		err := json.Unmarshal(settings.JSONData, &jsonData)
+		if err != nil {
+			return nil, fmt.Errorf("error reading settings: %w", err)
+		}
+		dsInfo := sqleng.DataSourceInfo{
+			JsonData:                jsonData,
+			URL:                     settings.URL,
+			User:                    settings.User,
+			Database:                settings.Database,
+			ID:                      settings.ID,
+			Updated:                 settings.Updated,
+			UID:                     settings.UID,
+			DecryptedSecureJSONData: settings.DecryptedSecureJSONData,
+		}
+
+		path, err := resolvePath(dsInfo.Database, cfg.SQLiteAllowedPaths)
+		if err != nil {
+			return nil, err
+		}
+
+		config := sqleng.DataPluginConfiguration{
+			DriverName:        "sqlite3",
+			ConnectionString:  connectionString(path),
+			DSInfo:            dsInfo,
+			TimeColumnNames:   []string{"time", "time_sec"},
+			MetricColumnTypes: []string{"TEXT", "VARCHAR", "CHAR", "CLOB"},
+			RowLimit:          cfg.DataProxyRowLimit,
+		}
+
+		rowTransformer := sqliteQueryResultTransformer{
+			log: logger,
+		}
+
+		return sqleng.NewQueryDataHandler(config, &rowTransformer, newSqliteMacroEngine(), logger)
+	}
+}
+
+// resolvePath returns the absolute path of the database file, with its symlinks resolved, provided it matches one of
+// the allowed glob patterns. Symlinks are resolved so that a link in an allowed directory cannot point to a file
+// outside of it.
+func resolvePath(path string, allowedPaths []string) (string, error) {
+	if path == "" {
+		return "", errMissingPath
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid database file path: %w", err)
+	}
+
+	realPath, err := filepath.EvalSymlinks(absPath)
+	if err != nil {
+		// the error is not returned, so that the data source cannot be used to probe for files
+		logger.Error("Failed to resolve SQLite database file path", "path", absPath, "err", err)
+		return "", errPathNotAllowed
+	}
+
+	for _, pattern := range allowedPaths {
+		matched, err := filepath.Match(resolvePattern(pattern), realPath)
+		if err != nil {
+			logger.Warn("Invalid SQLite allowed path pattern", "pattern", pattern, "err", err)
+			continue
+		}
+		if matched {
+			return realPath, nil
+		}
+	}
+
+	logger.Error("SQLite database file path is not in the allowed paths", "path", absPath, "resolvedPath", realPath, "allowed", allowedPaths)
+	return "", errPathNotAllowed
+}
+
+// resolvePattern resolves the symlinks of the directory of an allowed path pattern when the directory has no
+// wildcards, so that the pattern matches the resolved paths of the files in it.
+func resolvePattern(pattern string) string {
+	dir, file := filepath.Split(pattern)
+	if dir == "" || strings.ContainsAny(dir, `*?[\`) {
+		return pattern
+	}
+	realDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return pattern
+	}
+	return filepath.Join(realDir, file)
+}
+
+// connectionString opens the database file read-only. query_only additionally rejects any statement
+// that would modify the database, e.g. when the file is attached through a shared cache.
+func connectionString(path string) string {
+	u := url.URL{Scheme: "file", Opaque: path}
+	q := url.Values{}
+	q.Set("mode", "ro")
+	q.Set("_query_only", "true")
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+func (s *Service) getDataSourceHandler(pluginCtx backend.PluginContext) (*sqleng.DataSourceHandler, error) {
+	i, err := s.im.Get(pluginCtx)
+	if err != nil {
+		return nil, err
+	}
+	instance := i.(*sqleng.DataSourceHandler)
+	return instance, nil
+}
+
+func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest) (*backend.QueryDataResponse, error) {
+	dsHandler, err := s.getDataSourceHandler(req.PluginContext)
+	if err != nil {
+		return nil, err
+	}
+	return dsHandler.QueryData(ctx, req)
+}
+
+type sqliteQueryResultTransformer struct {
+	log log.Logger
+}
+
+func (t *sqliteQueryResultTransformer) TransformQueryError(err error) error {
+	var driverErr sqlite3.Error
+	if errors.As(err, &driverErr) {
+		// syntax errors, unknown tables and columns are all reported as SQLITE_ERROR,
+		// attempts to modify the database as SQLITE_READONLY, both are safe to show to the user
+		if driverErr.Code != sqlite3.ErrError && driverErr.Code != sqlite3.ErrReadonly {
+			t.log.Error("query error", "err", err)
+			return errQueryFailed
+		}
+	}
+
+	return err
+}
+
+func (t *sqliteQueryResultTransformer) GetConverterList() []sqlutil.StringConverter {
+	return nil
+}
+
+// GetConverters returns a dynamic converter, since SQLite column types are only a hint
+// and every value carries its own storage class.
+func (t *sqliteQueryResultTransformer) GetConverters() []sqlutil.Converter {
+	return []sqlutil.Converter{
+		{
+			Name:    "dynamic",
+			Dynamic: true,
+		},
+	}
+}
@@ -0,0 +1,216 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/tsdb/sqleng"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePath(t *testing.T) {
+	dir := t.TempDir()
+	allowedDir := filepath.Join(dir, "sqlite")
+	require.NoError(t, os.Mkdir(allowedDir, 0750))
+	dbPath := filepath.Join(allowedDir, "metrics.db")
+	require.NoError(t, os.WriteFile(dbPath, nil, 0600))
+	outsidePath := filepath.Join(dir, "grafana.db")
+	require.NoError(t, os.WriteFile(outsidePath, nil, 0600))
+
+	realDBPath, err := filepath.EvalSymlinks(dbPath)
+	require.NoError(t, err)
+	allowed := []string{"/nonexistent/*.db", filepath.Join(allowedDir, "*.db")}
+
+	t.Run("path matching an allowed pattern is accepted", func(t *testing.T) {
+		path, err := resolvePath(dbPath, allowed)
+		require.NoError(t, err)
+		require.Equal(t, realDBPath, path)
+	})
+
+	t.Run("path is cleaned before matching", func(t *testing.T) {
+		_, err := resolvePath(filepath.Join(allowedDir, "..", "grafana.db"), allowed)
+		require.ErrorIs(t, err, errPathNotAllowed)
+	})
+
+	t.Run("symlink in an allowed directory to a file outside of it is rejected", func(t *testing.T) {
+		link := filepath.Join(allowedDir, "link.db")
+		require.NoError(t, os.Symlink(outsidePath, link))
+
+		_, err := resolvePath(link, allowed)
+		require.ErrorIs(t, err, errPathNotAllowed)
+	})
+
+	t.Run("symlink in an allowed directory to a file in it is accepted", func(t *testing.T) {
+		link := filepath.Join(allowedDir, "alias.db")
+		require.NoError(t, os.Symlink(dbPath, link))
+
+		path, err := resolvePath(link, allowed)
+		require.NoError(t, err)
+		require.Equal(t, realDBPath, path)
+	})
+
+	t.Run("allowed directory that is a symlink is resolved", func(t *testing.T) {
+		linkDir := filepath.Join(dir, "linked")
+		require.NoError(t, os.Symlink(allowedDir, linkDir))
+
+		path, err := resolvePath(filepath.Join(linkDir, "metrics.db"), []string{filepath.Join(linkDir, "*.db")})
+		require.NoError(t, err)
+		require.Equal(t, realDBPath, path)
+	})
+
+	t.Run("missing file is rejected", func(t *testing.T) {
+		_, err := resolvePath(filepath.Join(allowedDir, "missing.db"), allowed)
+		require.ErrorIs(t, err, errPathNotAllowed)
+	})
+
+	t.Run("no allowed patterns rejects every path", func(t *testing.T) {
+		_, err := resolvePath(dbPath, nil)
+		require.ErrorIs(t, err, errPathNotAllowed)
+	})
+
+	t.Run("empty path is rejected", func(t *testing.T) {
+		_, err := resolvePath("", []string{"*"})
+		require.ErrorIs(t, err, errMissingPath)
+	})
+}
+
+func TestConnectionString(t *testing.T) {
+	require.Equal(t, "file:/var/lib/grafana/sqlite/metrics.db?_query_only=true&mode=ro", connectionString("/var/lib/grafana/sqlite/metrics.db"))
+}
+
+func TestQueryData(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "metrics.db")
+
+	db, err := sql.Open("sqlite3", dbPath)
+	require.NoError(t, err)
+	_, err = db.Exec(`CREATE TABLE metric (ts, host TEXT, value REAL);
+		INSERT INTO metric VALUES (1523556000, 'a', 1.5);
+		INSERT INTO metric VALUES ('2018-04-12 18:01:00', 'a', 2.5);
+		INSERT INTO metric VALUES (1523556060, 'b', 3);
+		INSERT INTO metric VALUES ('2018-04-13 00:00:00', 'b', 4);`)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	origInterpolate := sqleng.Interpolate
+	t.Cleanup(func() {
+		sqleng.Interpolate = origInterpolate
+	})
+	sqleng.Interpolate = func(query backend.DataQuery, timeRange backend.TimeRange, timeInterval string, sql string) (string, error) {
+		return sql, nil
+	}
+
+	cfg := setting.NewCfg()
+	cfg.DataProxyRowLimit = 1000
+	cfg.SQLiteAllowedPaths = []string{filepath.Join(dir, "*.db")}
+
+	newHandler := func(t *testing.T, path string) (*sqleng.DataSourceHandler, error) {
+		t.Helper()
+		instance, err := newInstanceSettings(cfg)(backend.DataSourceInstanceSettings{
+			JSONData: []byte("{}"),
+			Database: path,
+		})
+		if err != nil {
+			return nil, err
+		}
+		handler := instance.(*sqleng.DataSourceHandler)
+		t.Cleanup(handler.Dispose)
+		return handler, nil
+	}
+
+	from := time.Date(2018, 4, 12, 18, 0, 0, 0, time.UTC)
+	timeRange := backend.TimeRange{From: from, To: from.Add(5 * time.Minute)}
+
+	t.Run("time series query handles unix and ISO timestamps", func(t *testing.T) {
+		handler, err := newHandler(t, dbPath)
+		require.NoError(t, err)
+
+		resp, err := handler.QueryData(context.Background(), &backend.QueryDataRequest{
+			Queries: []backend.DataQuery{
+				{
+					RefID: "A",
+					JSON: []byte(`{
+						"rawSql": "SELECT $__timeGroupAlias(ts, '1m'), sum(value) AS value FROM metric WHERE $__timeFilter(ts) GROUP BY 1 ORDER BY 1",
+						"format": "time_series"
+					}`),
+					TimeRange: timeRange,
+				},
+			},
+		})
+		require.NoError(t, err)
+		queryResult := resp.Responses["A"]
+		require.NoError(t, queryResult.Error)
+
+		frames := queryResult.Frames
+		require.Len(t, frames, 1)
+		require.Equal(t, 2, frames[0].Rows())
+		require.Equal(t, from.Unix(), frames[0].Fields[0].At(0).(*time.Time).Unix())
+		require.Equal(t, from.Add(time.Minute).Unix(), frames[0].Fields[0].At(1).(*time.Time).Unix())
+		require.Equal(t, 1.5, *frames[0].Fields[1].At(0).(*float64))
+		require.Equal(t, 5.5, *frames[0].Fields[1].At(1).(*float64))
+	})
+
+	t.Run("table query returns inferred column types", func(t *testing.T) {
+		handler, err := newHandler(t, dbPath)
+		require.NoError(t, err)
+
+		resp, err := handler.QueryData(context.Background(), &backend.QueryDataRequest{
+			Queries: []backend.DataQuery{
+				{
+					RefID: "A",
+					JSON: []byte(`{
+						"rawSql": "SELECT host, value FROM metric ORDER BY value LIMIT 1",
+						"format": "table"
+					}`),
+					TimeRange: timeRange,
+				},
+			},
+		})
+		require.NoError(t, err)
+		queryResult := resp.Responses["A"]
+		require.NoError(t, queryResult.Error)
+
+		frames := queryResult.Frames
+		require.Len(t, frames, 1)
+		require.Equal(t, "a", *frames[0].Fields[0].At(0).(*string))
+		require.Equal(t, 1.5, *frames[0].Fields[1].At(0).(*float64))
+	})
+
+	t.Run("database is opened read-only", func(t *testing.T) {
+		handler, err := newHandler(t, dbPath)
+		require.NoError(t, err)
+
+		resp, err := handler.QueryData(context.Background(), &backend.QueryDataRequest{
+			Queries: []backend.DataQuery{
+				{
+					RefID: "A",
+					JSON: []byte(`{
+						"rawSql": "DELETE FROM metric",
+						"format": "table"
+					}`),
+					TimeRange: timeRange,
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.ErrorContains(t, resp.Responses["A"].Error, "readonly")
+
+		db, err := sql.Open("sqlite3", dbPath)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, db.Close()) }()
+		var count int
+		require.NoError(t, db.QueryRow("SELECT count(*) FROM metric").Scan(&count))
+		require.Equal(t, 4, count)
+	})
+
+	t.Run("database outside of the allowed paths is rejected", func(t *testing.T) {
+		_, err := newHandler(t, filepath.Join(t.TempDir(), "other.db"))
+		require.ErrorIs(t, err, errPathNotAllowed)
+	})
+}
@@ -0,0 +1,198 @@
+package tempo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+const (
+	queryTypeNativeSearch = "nativeSearch"
+	queryTypeTraceQL      = "traceql"
+
+	defaultSearchLimit = 20
+)
+
+// SearchResponse is the response of the Tempo search API
+type SearchResponse struct {
+	Traces []*TraceSearchMetadata `json:"traces"`
+}
+
+// TraceSearchMetadata describes a single trace matched by a search
+type TraceSearchMetadata struct {
+	TraceID           string `json:"traceID"`
+	RootServiceName   string `json:"rootServiceName"`
+	RootTraceName     string `json:"rootTraceName"`
+	StartTimeUnixNano string `json:"startTimeUnixNano"`
+	DurationMs        uint32 `json:"durationMs"`
+}
+
+func (s *Service) search(ctx context.Context, dsInfo *datasourceInfo, query backend.DataQuery, model *QueryModel) backend.DataResponse {
+	queryRes := backend.DataResponse{}
+
+	request, err := s.createSearchRequest(ctx, dsInfo, query.TimeRange, model)
+	if err != nil {
+		queryRes.Error = err
+		return queryRes
+	}
+
+	resp, err := dsInfo.HTTPClient.Do(request)
+	if err != nil {
+		queryRes.Error = fmt.Errorf("failed get to tempo: %w", err)
+		return queryRes
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			s.tlog.Warn("failed to close response body", "err", err)
+		}
+	}()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		queryRes.Error = err
+		return queryRes
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		queryRes.Error = fmt.Errorf("failed to search traces Status: %s Body: %s", resp.Status, string(body))
+		return queryRes
+	}
+
+	var searchResponse SearchResponse
+	if err := json.Unmarshal(body, &searchResponse); err != nil {
+		queryRes.Error = fmt.Errorf("failed to parse tempo search response: %w", err)
+		return queryRes
+	}
+
+	frame, err := searchResponseToFrame(&searchResponse)
+	if err != nil {
+		queryRes.Error = err
+		return queryRes
+	}
+	frame.RefID = query.RefID
+	queryRes.Frames = data.Frames{frame}
+	return queryRes
+}
+
+func (s *Service) createSearchRequest(ctx context.Context, dsInfo *datasourceInfo, timeRange backend.TimeRange, model *QueryModel) (*http.Request, error) {
+	params := url.Values{}
+
+	switch model.QueryType {
+	case queryTypeTraceQL:
+		if strings.TrimSpace(model.TraceID) == "" {
+			return nil, fmt.Errorf("traceql query is empty")
+		}
+		params.Set("q", model.TraceID)
+	case queryTypeNativeSearch:
+		tags, err := searchTags(model)
+		if err != nil {
+			return nil, err
+		}
+		if tags != "" {
+			params.Set("tags", tags)
+		}
+		if model.MinDuration != "" {
+			if _, err := time.ParseDuration(model.MinDuration); err != nil {
+				return nil, fmt.Errorf("invalid minDuration: %w", err)
+			}
+			params.Set("minDuration", model.MinDuration)
+		}
+		if model.MaxDuration != "" {
+			if _, err := time.ParseDuration(model.MaxDuration); err != nil {
+				return nil, fmt.Errorf("invalid maxDuration: %w", err)
+			}
+			params.Set("maxDuration", model.MaxDuration)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported query type: %s", model.QueryType)
+	}
+
+	limit := model.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	params.Set("limit", strconv.FormatInt(limit, 10))
+
+	if !timeRange.From.IsZero() && !timeRange.To.IsZero() {
+		params.Set("start", strconv.FormatInt(timeRange.From.Unix(), 10))
+		params.Set("end", strconv.FormatInt(timeRange.To.Unix(), 10))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", dsInfo.URL+"/api/search?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	s.tlog.Debug("Tempo search request", "url", req.URL.String())
+	return req, nil
+}
+
+// searchTags builds the logfmt encoded tags parameter of the search API from the query model
+func searchTags(model *QueryModel) (string, error) {
+	tags := make([]string, 0)
+	if model.ServiceName != "" {
+		tags = append(tags, "service.name="+logfmtValue(model.ServiceName))
+	}
+	if model.SpanName != "" {
+		tags = append(tags, "name="+logfmtValue(model.SpanName))
+	}
+
+	search := strings.TrimSpace(model.Search)
+	if search != "" {
+		for _, tag := range strings.Fields(search) {
+			if !strings.Contains(tag, "=") {
+				return "", fmt.Errorf("invalid search tag %q, expected key=value", tag)
+			}
+		}
+		tags = append(tags, search)
+	}
+
+	return strings.Join(tags, " "), nil
+}
+
+func logfmtValue(value string) string {
+	if strings.ContainsAny(value, " =\"") {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+func searchResponseToFrame(res *SearchResponse) (*data.Frame, error) {
+	frame := data.NewFrame("Traces",
+		data.NewField("traceID", nil, []string{}),
+		data.NewField("traceService", nil, []string{}),
+		data.NewField("traceName", nil, []string{}),
+		data.NewField("startTime", nil, []time.Time{}),
+		data.NewField("traceDuration", nil, []float64{}).SetConfig(&data.FieldConfig{Unit: "ms"}),
+	)
+	frame.Meta = &data.FrameMeta{
+		PreferredVisualization: data.VisTypeTable,
+	}
+
+	for _, trace := range res.Traces {
+		startTime := time.Time{}
+		if trace.StartTimeUnixNano != "" {
+			ns, err := strconv.ParseInt(trace.StartTimeUnixNano, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse start time of trace %s: %w", trace.TraceID, err)
+			}
+			startTime = time.Unix(0, ns).UTC()
+		}
+
+		frame.AppendRow(trace.TraceID, trace.RootServiceName, trace.RootTraceName, startTime, float64(trace.DurationMs))
+	}
+
+	return frame, nil
+}
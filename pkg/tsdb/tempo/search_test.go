@@ -0,0 +1,126 @@
+package tempo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateSearchRequest(t *testing.T) {
+	service := &Service{tlog: log.New("tempo-test")}
+	timeRange := backend.TimeRange{
+		From: time.Unix(1650000000, 0),
+		To:   time.Unix(1650003600, 0),
+	}
+
+	t.Run("native search with tags, durations and limit", func(t *testing.T) {
+		req, err := service.createSearchRequest(context.Background(), &datasourceInfo{URL: "http://tempo"}, timeRange, &QueryModel{
+			QueryType:   queryTypeNativeSearch,
+			ServiceName: "app",
+			SpanName:    "HTTP GET",
+			Search:      "http.status_code=500",
+			MinDuration: "100ms",
+			MaxDuration: "5s",
+			Limit:       50,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "/api/search", req.URL.Path)
+
+		params := req.URL.Query()
+		assert.Equal(t, `service.name=app name="HTTP GET" http.status_code=500`, params.Get("tags"))
+		assert.Equal(t, "100ms", params.Get("minDuration"))
+		assert.Equal(t, "5s", params.Get("maxDuration"))
+		assert.Equal(t, "50", params.Get("limit"))
+		assert.Equal(t, "1650000000", params.Get("start"))
+		assert.Equal(t, "1650003600", params.Get("end"))
+	})
+
+	t.Run("native search uses default limit", func(t *testing.T) {
+		req, err := service.createSearchRequest(context.Background(), &datasourceInfo{}, timeRange, &QueryModel{
+			QueryType: queryTypeNativeSearch,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "20", req.URL.Query().Get("limit"))
+		assert.Empty(t, req.URL.Query().Get("tags"))
+	})
+
+	t.Run("native search with invalid duration", func(t *testing.T) {
+		_, err := service.createSearchRequest(context.Background(), &datasourceInfo{}, timeRange, &QueryModel{
+			QueryType:   queryTypeNativeSearch,
+			MinDuration: "fast",
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("native search with invalid tag", func(t *testing.T) {
+		_, err := service.createSearchRequest(context.Background(), &datasourceInfo{}, timeRange, &QueryModel{
+			QueryType: queryTypeNativeSearch,
+			Search:    "error",
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("traceql", func(t *testing.T) {
+		req, err := service.createSearchRequest(context.Background(), &datasourceInfo{}, timeRange, &QueryModel{
+			QueryType: queryTypeTraceQL,
+			TraceID:   `{ .http.status_code = 500 }`,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `{ .http.status_code = 500 }`, req.URL.Query().Get("q"))
+	})
+}
+
+func TestSearchResponseToFrame(t *testing.T) {
+	frame, err := searchResponseToFrame(&SearchResponse{
+		Traces: []*TraceSearchMetadata{
+			{
+				TraceID:           "2f3e0c6b",
+				RootServiceName:   "app",
+				RootTraceName:     "HTTP GET",
+				StartTimeUnixNano: "1650000000000000000",
+				DurationMs:        120,
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, frame.Rows())
+	assert.Equal(t, "2f3e0c6b", frame.Fields[0].At(0))
+	assert.Equal(t, "app", frame.Fields[1].At(0))
+	assert.Equal(t, "HTTP GET", frame.Fields[2].At(0))
+	assert.Equal(t, time.Unix(1650000000, 0).UTC(), frame.Fields[3].At(0))
+	assert.Equal(t, 120.0, frame.Fields[4].At(0))
+}
+
+func TestIsValidResourceURL(t *testing.T) {
+	testCases := []struct {
+		resourceURL string
+		valid       bool
+	}{
+		{resourceURL: "tags", valid: true},
+		{resourceURL: "tag/service.name/values", valid: true},
+		{resourceURL: "tag/service.name/values?limit=10", valid: true},
+		{resourceURL: "tag/http%20method/values", valid: true},
+		{resourceURL: "tag//values", valid: false},
+		{resourceURL: "../traces/1", valid: false},
+		{resourceURL: "tag/a/b/values", valid: false},
+		{resourceURL: "tag/./values", valid: false},
+		{resourceURL: "tag/../values", valid: false},
+		{resourceURL: "tag/%2e%2e/values", valid: false},
+		{resourceURL: "tag/%2E/values", valid: false},
+		{resourceURL: "tag/a%2fb/values", valid: false},
+		{resourceURL: "tag/a%2Fb/values", valid: false},
+		{resourceURL: "tag/a%5cb/values", valid: false},
+		{resourceURL: "tag/%zz/values", valid: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.resourceURL, func(t *testing.T) {
+			assert.Equal(t, tc.valid, isValidResourceURL(tc.resourceURL))
+		})
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/grafana/grafana-plugin-sdk-go/backend/datasource"
@@ -34,7 +36,15 @@ type datasourceInfo struct {
 }
 
 type QueryModel struct {
-	TraceID string `json:"query"`
+	// TraceID holds the trace ID for trace ID queries and the TraceQL expression for TraceQL queries
+	TraceID     string `json:"query"`
+	QueryType   string `json:"queryType"`
+	ServiceName string `json:"serviceName"`
+	SpanName    string `json:"spanName"`
+	Search      string `json:"search"`
+	MinDuration string `json:"minDuration"`
+	MaxDuration string `json:"maxDuration"`
+	Limit       int64  `json:"limit"`
 }
 
 func newInstanceSettings(httpClientProvider httpclient.Provider) datasource.InstanceFactoryFunc {
@@ -59,28 +69,45 @@ func newInstanceSettings(httpClientProvider httpclient.Provider) datasource.Inst
 
 func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest) (*backend.QueryDataResponse, error) {
 	result := backend.NewQueryDataResponse()
-	queryRes := backend.DataResponse{}
-	refID := req.Queries[0].RefID
-
-	model := &QueryModel{}
-	err := json.Unmarshal(req.Queries[0].JSON, model)
-	if err != nil {
-		return result, err
-	}
 
 	dsInfo, err := s.getDSInfo(req.PluginContext)
 	if err != nil {
 		return nil, err
 	}
 
+	for _, query := range req.Queries {
+		model := &QueryModel{}
+		err := json.Unmarshal(query.JSON, model)
+		if err != nil {
+			return result, err
+		}
+
+		switch model.QueryType {
+		case queryTypeNativeSearch, queryTypeTraceQL:
+			result.Responses[query.RefID] = s.search(ctx, dsInfo, query, model)
+		default:
+			queryRes, err := s.getTrace(ctx, dsInfo, query.RefID, model)
+			if err != nil {
+				return &backend.QueryDataResponse{}, err
+			}
+			result.Responses[query.RefID] = queryRes
+		}
+	}
+
+	return result, nil
+}
+
+func (s *Service) getTrace(ctx context.Context, dsInfo *datasourceInfo, refID string, model *QueryModel) (backend.DataResponse, error) {
+	queryRes := backend.DataResponse{}
+
 	request, err := s.createRequest(ctx, dsInfo, model.TraceID)
 	if err != nil {
-		return result, err
+		return queryRes, err
 	}
 
 	resp, err := dsInfo.HTTPClient.Do(request)
 	if err != nil {
-		return result, fmt.Errorf("failed get to tempo: %w", err)
+		return queryRes, fmt.Errorf("failed get to tempo: %w", err)
 	}
 
 	defer func() {
@@ -91,30 +118,28 @@ func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest)
 
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return &backend.QueryDataResponse{}, err
+		return queryRes, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
 		queryRes.Error = fmt.Errorf("failed to get trace with id: %s Status: %s Body: %s", model.TraceID, resp.Status, string(body))
-		result.Responses[refID] = queryRes
-		return result, nil
+		return queryRes, nil
 	}
 
 	otTrace, err := otlp.NewProtobufTracesUnmarshaler().UnmarshalTraces(body)
 
 	if err != nil {
-		return &backend.QueryDataResponse{}, fmt.Errorf("failed to convert tempo response to Otlp: %w", err)
+		return queryRes, fmt.Errorf("failed to convert tempo response to Otlp: %w", err)
 	}
 
 	frame, err := TraceToFrame(otTrace)
 	if err != nil {
-		return &backend.QueryDataResponse{}, fmt.Errorf("failed to transform trace %v to data frame: %w", model.TraceID, err)
+		return queryRes, fmt.Errorf("failed to transform trace %v to data frame: %w", model.TraceID, err)
 	}
 	frame.RefID = refID
 	frames := []*data.Frame{frame}
 	queryRes.Frames = frames
-	result.Responses[refID] = queryRes
-	return result, nil
+	return queryRes, nil
 }
 
 func (s *Service) createRequest(ctx context.Context, dsInfo *datasourceInfo, traceID string) (*http.Request, error) {
@@ -129,6 +154,73 @@ func (s *Service) createRequest(ctx context.Context, dsInfo *datasourceInfo, tra
 	return req, nil
 }
 
+func (s *Service) CallResource(ctx context.Context, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender) error {
+	dsInfo, err := s.getDSInfo(req.PluginContext)
+	if err != nil {
+		return err
+	}
+
+	// a very basic is-this-url-valid check
+	if req.Method != http.MethodGet {
+		return fmt.Errorf("invalid resource method: %s", req.Method)
+	}
+	if !isValidResourceURL(req.URL) {
+		return fmt.Errorf("invalid resource URL: %s", req.URL)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, dsInfo.URL+"/api/search/"+req.URL, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/json")
+
+	resp, err := dsInfo.HTTPClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed get to tempo: %w", err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			s.tlog.Warn("failed to close response body", "err", err)
+		}
+	}()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	return sender.Send(&backend.CallResourceResponse{
+		Status: resp.StatusCode,
+		Headers: map[string][]string{
+			"content-type": {"application/json"},
+		},
+		Body: body,
+	})
+}
+
+// isValidResourceURL accepts the tag autocomplete resources, `tags` and `tag/$tag_name/values`
+func isValidResourceURL(resourceURL string) bool {
+	path := resourceURL
+	if i := strings.Index(path, "?"); i >= 0 {
+		path = path[:i]
+	}
+	if path == "tags" {
+		return true
+	}
+	parts := strings.Split(path, "/")
+	if len(parts) != 3 || parts[0] != "tag" || parts[2] != "values" {
+		return false
+	}
+
+	// the tag name must not change the path of the upstream request once unescaped
+	tag, err := url.PathUnescape(parts[1])
+	if err != nil {
+		return false
+	}
+	return tag != "" && tag != "." && tag != ".." && !strings.ContainsAny(tag, "/\\")
+}
+
 func (s *Service) getDSInfo(pluginCtx backend.PluginContext) (*datasourceInfo, error) {
 	i, err := s.im.Get(pluginCtx)
 	if err != nil {
@@ -76,6 +76,7 @@ export class DashboardMigrator {
     let i, j, k, n;
     const oldVersion = this.dashboard.schemaVersion;
     const panelUpgrades: PanelSchemeUpgradeHandler[] = [];
+    // keep the backend migrations in pkg/components/dashmigrations in sync with the migrations below
     this.dashboard.schemaVersion = 36;
 
     if (oldVersion === this.dashboard.schemaVersion) {
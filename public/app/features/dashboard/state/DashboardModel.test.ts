@@ -96,6 +96,32 @@ describe('DashboardModel', () => {
       expect(savedPanel.title).toBe('updated');
       expect(savedPanel.id).toBe(model.panels[0].id);
     });
+
+    it('should not save the unchanged values that were set from the folder preferences', () => {
+      const model = new DashboardModel(
+        { time: { from: 'now-24h', to: 'now' }, refresh: '1m' },
+        { folderDefaultsApplied: { time: { from: 'now-24h', to: 'now' }, refresh: '1m' } }
+      );
+
+      const saveModel = model.getSaveModelClone();
+
+      expect(saveModel.time).toBeUndefined();
+      expect(saveModel.refresh).toBe('');
+    });
+
+    it('should save the values that were set from the folder preferences once they are changed', () => {
+      const model = new DashboardModel(
+        { time: { from: 'now-24h', to: 'now' }, refresh: '1m' },
+        { folderDefaultsApplied: { time: { from: 'now-24h', to: 'now' }, refresh: '1m' } }
+      );
+      model.time = { from: 'now-1h', to: 'now' };
+      model.refresh = '5m';
+
+      const saveModel = model.getSaveModelClone();
+
+      expect(saveModel.time).toEqual({ from: 'now-1h', to: 'now' });
+      expect(saveModel.refresh).toBe('5m');
+    });
   });
 
   describe('row and panel manipulation', () => {
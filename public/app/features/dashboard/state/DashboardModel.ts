@@ -242,6 +242,8 @@ export class DashboardModel implements TimeModel {
       copy.time = this.originalTime;
     }
 
+    this.removeFolderDefaults(copy);
+
     // get panel save models
     copy.panels = this.getPanelSaveModels();
 
@@ -309,6 +311,30 @@ export class DashboardModel implements TimeModel {
       });
   }
 
+  // The values that were set from the preferences of the folder are not saved unless they were changed, so that the
+  // dashboard keeps following the preferences.
+  private removeFolderDefaults(copy: any) {
+    const applied = this.meta?.folderDefaultsApplied;
+    if (!applied) {
+      return;
+    }
+
+    if (applied.time && isEqual(copy.time, applied.time)) {
+      delete copy.time;
+    }
+    if (applied.refresh && copy.refresh === applied.refresh) {
+      copy.refresh = '';
+    }
+    for (const variable of copy.templating.list) {
+      const value = applied.variables?.[variable.name];
+      if (variable.type === 'constant' && value !== undefined && variable.query === value) {
+        variable.query = '';
+        variable.current = {};
+        variable.options = [];
+      }
+    }
+  }
+
   private updateTemplatingSaveModelClone(
     copy: any,
     defaults: { saveTimerange: boolean; saveVariables: boolean } & CloneOptions
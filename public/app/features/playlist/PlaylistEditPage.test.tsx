@@ -24,7 +24,7 @@ async function getTestContext({ name, interval, items }: Partial<Playlist> = {})
   const playlist = { name, items, interval } as unknown as Playlist;
   const queryParams = {};
   const route: any = {};
-  const match: any = { params: { id: 1 } };
+  const match: any = { params: { uid: 'foo' } };
   const location: any = {};
   const history: any = {};
   const navModel: any = {
@@ -76,7 +76,7 @@ describe('PlaylistEditPage', () => {
       await userEvent.type(screen.getByRole('textbox', { name: /playlist interval/i }), '10s');
       fireEvent.submit(screen.getByRole('button', { name: /save/i }));
       await waitFor(() => expect(putMock).toHaveBeenCalledTimes(1));
-      expect(putMock).toHaveBeenCalledWith('/api/playlists/1', {
+      expect(putMock).toHaveBeenCalledWith('/api/playlists/foo', {
         name: 'A Name',
         interval: '10s',
         items: [{ title: 'First item', type: 'dashboard_by_id', order: 1, value: '1' }],
@@ -21,16 +21,16 @@ interface ConnectedProps {
 }
 
 export interface RouteParams {
-  id: number;
+  uid: string;
 }
 
 interface Props extends ConnectedProps, GrafanaRouteComponentProps<RouteParams> {}
 
 export const PlaylistEditPage: FC<Props> = ({ navModel, match }) => {
   const styles = useStyles2(getPlaylistStyles);
-  const { playlist, loading } = usePlaylist(match.params.id);
+  const { playlist, loading } = usePlaylist(match.params.uid);
   const onSubmit = async (playlist: Playlist) => {
-    await updatePlaylist(match.params.id, playlist);
+    await updatePlaylist(match.params.uid, playlist);
     locationService.push('/playlists');
   };
 
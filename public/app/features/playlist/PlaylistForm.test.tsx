@@ -138,6 +138,24 @@ describe('PlaylistForm', () => {
       expect(onSubmitMock).toHaveBeenCalledWith(playlist);
     });
 
+    it('then the item settings should be submitted', async () => {
+      const { onSubmitMock } = getTestContext(playlist);
+
+      await userEvent.type(within(rows()[0]).getByRole('textbox', { name: /playlist item interval/i }), '30s');
+      await userEvent.type(within(rows()[0]).getByRole('textbox', { name: /playlist item time from/i }), 'now-1h');
+      await userEvent.type(within(rows()[0]).getByRole('textbox', { name: /playlist item time to/i }), 'now');
+      fireEvent.submit(screen.getByRole('button', { name: /save/i }));
+      await waitFor(() => expect(onSubmitMock).toHaveBeenCalledTimes(1));
+      expect(onSubmitMock).toHaveBeenCalledWith({
+        ...playlist,
+        items: [
+          { ...playlist.items![0], interval: '30s', timeFrom: 'now-1h', timeTo: 'now' },
+          playlist.items![1],
+          playlist.items![2],
+        ],
+      });
+    });
+
     describe('and name is missing', () => {
       it('then an alert should appear and nothing should be submitted', async () => {
         const { onSubmitMock } = getTestContext({ ...playlist, name: undefined });
@@ -21,7 +21,7 @@ const searchSrv = new SearchSrv();
 
 export const PlaylistForm: FC<PlaylistFormProps> = ({ onSubmit, playlist }) => {
   const { name, interval, items: propItems } = playlist;
-  const { items, addById, addByTag, deleteItem, moveDown, moveUp } = usePlaylistItems(propItems);
+  const { items, addById, addByTag, deleteItem, moveDown, moveUp, updateItem } = usePlaylistItems(propItems);
   return (
     <>
       <Form onSubmit={(list: Playlist) => onSubmit({ ...list, items })} validateOn={'onBlur'}>
@@ -48,7 +48,13 @@ export const PlaylistForm: FC<PlaylistFormProps> = ({ onSubmit, playlist }) => {
                 />
               </Field>
 
-              <PlaylistTable items={items} onMoveUp={moveUp} onMoveDown={moveDown} onDelete={deleteItem} />
+              <PlaylistTable
+                items={items}
+                onMoveUp={moveUp}
+                onMoveDown={moveDown}
+                onDelete={deleteItem}
+                onChange={updateItem}
+              />
 
               <div className="gf-form-group">
                 <h3 className="page-headering">Add dashboards</h3>
@@ -62,6 +62,7 @@ describe('PlaylistPage', () => {
       fnMock.mockResolvedValue([
         {
           id: 0,
+          uid: 'playlist-0',
           name: 'A test playlist',
           interval: '10m',
           items: [
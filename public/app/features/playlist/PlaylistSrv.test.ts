@@ -88,7 +88,7 @@ describe('PlaylistSrv', () => {
   });
 
   it('runs all dashboards in cycle and reloads page after 3 cycles', async () => {
-    await srv.start(1);
+    await srv.start('1');
 
     for (let i = 0; i < 6; i++) {
       srv.next();
@@ -99,7 +99,7 @@ describe('PlaylistSrv', () => {
   });
 
   it('keeps the refresh counter value after restarting', async () => {
-    await srv.start(1);
+    await srv.start('1');
 
     // 1 complete loop
     for (let i = 0; i < 3; i++) {
@@ -107,7 +107,7 @@ describe('PlaylistSrv', () => {
     }
 
     srv.stop();
-    await srv.start(1);
+    await srv.start('1');
 
     // Another 2 loops
     for (let i = 0; i < 4; i++) {
@@ -119,7 +119,7 @@ describe('PlaylistSrv', () => {
   });
 
   it('Should stop playlist when navigating away', async () => {
-    await srv.start(1);
+    await srv.start('1');
 
     locationService.push('/datasources');
 
@@ -127,7 +127,7 @@ describe('PlaylistSrv', () => {
   });
 
   it('storeUpdated should not stop playlist when navigating to next dashboard', async () => {
-    await srv.start(1);
+    await srv.start('1');
 
     srv.next();
 
@@ -12,9 +12,17 @@ export const queryParamsToPreserve: { [key: string]: boolean } = {
   orgId: true,
 };
 
+interface PlaylistDashboard {
+  url: string;
+  // interval and time range of the playlist item of the dashboard
+  interval?: string;
+  timeFrom?: string;
+  timeTo?: string;
+}
+
 export class PlaylistSrv {
   private nextTimeoutId: any;
-  private declare dashboards: Array<{ url: string }>;
+  private declare dashboards: PlaylistDashboard[];
   private index = 0;
   private declare interval: number;
   private declare startUrl: string;
@@ -47,11 +55,18 @@ export class PlaylistSrv {
     const dash = this.dashboards[this.index];
     const queryParams = locationService.getSearchObject();
     const filteredParams = pickBy(queryParams, (value: any, key: string) => queryParamsToPreserve[key]);
+    if (dash.timeFrom) {
+      filteredParams.from = dash.timeFrom;
+    }
+    if (dash.timeTo) {
+      filteredParams.to = dash.timeTo;
+    }
     const nextDashboardUrl = locationUtil.stripBaseFromUrl(dash.url);
+    const interval = dash.interval ? rangeUtil.intervalToMs(dash.interval) : this.interval;
 
     this.index++;
     this.validPlaylistUrl = nextDashboardUrl;
-    this.nextTimeoutId = setTimeout(() => this.next(), this.interval);
+    this.nextTimeoutId = setTimeout(() => this.next(), interval);
 
     locationService.push(nextDashboardUrl + '?' + urlUtil.toUrlParams(filteredParams));
   }
@@ -68,7 +83,7 @@ export class PlaylistSrv {
     }
   }
 
-  start(playlistId: number) {
+  start(playlistUid: string) {
     this.stop();
 
     this.startUrl = window.location.href;
@@ -79,10 +94,10 @@ export class PlaylistSrv {
     this.locationListenerUnsub = locationService.getHistory().listen(this.locationUpdated);
 
     return getBackendSrv()
-      .get(`/api/playlists/${playlistId}`)
+      .get(`/api/playlists/${playlistUid}`)
       .then((playlist: any) => {
         return getBackendSrv()
-          .get(`/api/playlists/${playlistId}/dashboards`)
+          .get(`/api/playlists/${playlistUid}/dashboards`)
           .then((dashboards: any) => {
             this.dashboards = dashboards;
             this.interval = rangeUtil.intervalToMs(playlist.interval);
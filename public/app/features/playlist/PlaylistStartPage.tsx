@@ -4,10 +4,10 @@ import { GrafanaRouteComponentProps } from '../../core/navigation/types';
 
 import { playlistSrv } from './PlaylistSrv';
 
-interface Props extends GrafanaRouteComponentProps<{ id: string }> {}
+interface Props extends GrafanaRouteComponentProps<{ uid: string }> {}
 
 export const PlaylistStartPage: FC<Props> = ({ match }) => {
-  playlistSrv.start(parseInt(match.params.id, 10));
+  playlistSrv.start(match.params.uid);
   return null;
 };
 
@@ -8,16 +8,23 @@ interface PlaylistTableProps {
   onMoveUp: (item: PlaylistItem) => void;
   onMoveDown: (item: PlaylistItem) => void;
   onDelete: (item: PlaylistItem) => void;
+  onChange: (item: PlaylistItem, changes: Partial<PlaylistItem>) => void;
 }
 
-export const PlaylistTable: FC<PlaylistTableProps> = ({ items, onMoveUp, onMoveDown, onDelete }) => {
+export const PlaylistTable: FC<PlaylistTableProps> = ({ items, onMoveUp, onMoveDown, onDelete, onChange }) => {
   return (
     <div className="gf-form-group">
       <h3 className="page-headering">Dashboards</h3>
 
       <table className="filter-table">
         <tbody>
-          <PlaylistTableRows items={items} onMoveUp={onMoveUp} onMoveDown={onMoveDown} onDelete={onDelete} />
+          <PlaylistTableRows
+            items={items}
+            onMoveUp={onMoveUp}
+            onMoveDown={onMoveDown}
+            onDelete={onDelete}
+            onChange={onChange}
+          />
         </tbody>
       </table>
     </div>
@@ -1,9 +1,9 @@
 import { css, cx } from '@emotion/css';
-import React, { FC, MouseEvent } from 'react';
+import React, { ChangeEvent, FC, MouseEvent } from 'react';
 
 import { GrafanaTheme } from '@grafana/data';
 import { selectors } from '@grafana/e2e-selectors';
-import { Icon, IconButton, useStyles } from '@grafana/ui';
+import { Icon, IconButton, Input, useStyles } from '@grafana/ui';
 
 import { TagBadge } from '../../core/components/TagFilter/TagBadge';
 
@@ -16,9 +16,18 @@ interface PlaylistTableRowProps {
   onMoveUp: (item: PlaylistItem) => void;
   onMoveDown: (item: PlaylistItem) => void;
   onDelete: (item: PlaylistItem) => void;
+  onChange: (item: PlaylistItem, changes: Partial<PlaylistItem>) => void;
 }
 
-export const PlaylistTableRow: FC<PlaylistTableRowProps> = ({ item, onDelete, onMoveDown, onMoveUp, first, last }) => {
+export const PlaylistTableRow: FC<PlaylistTableRowProps> = ({
+  item,
+  onDelete,
+  onMoveDown,
+  onMoveUp,
+  onChange,
+  first,
+  last,
+}) => {
   const styles = useStyles(getStyles);
   const onDeleteClick = (event: MouseEvent) => {
     event.preventDefault();
@@ -32,10 +41,13 @@ export const PlaylistTableRow: FC<PlaylistTableRowProps> = ({ item, onDelete, on
     event.preventDefault();
     onMoveUp(item);
   };
+  const onSettingChange = (key: 'interval' | 'timeFrom' | 'timeTo') => (event: ChangeEvent<HTMLInputElement>) => {
+    onChange(item, { [key]: event.currentTarget.value || undefined });
+  };
 
   return (
     <tr aria-label={selectors.pages.PlaylistForm.itemRow} key={item.title}>
-      {item.type === 'dashboard_by_id' ? (
+      {item.type === 'dashboard_by_id' || item.type === 'dashboard_by_uid' ? (
         <td className={cx(styles.td, styles.item)}>
           <Icon name="apps" aria-label={selectors.pages.PlaylistForm.itemIdType} />
           <span>{item.title}</span>
@@ -47,6 +59,33 @@ export const PlaylistTableRow: FC<PlaylistTableRowProps> = ({ item, onDelete, on
           <TagBadge key={item.id} label={item.title} removeIcon={false} count={0} />
         </td>
       ) : null}
+      <td className={styles.td}>
+        <Input
+          width={12}
+          value={item.interval ?? ''}
+          placeholder="Interval"
+          onChange={onSettingChange('interval')}
+          aria-label="Playlist item interval"
+        />
+      </td>
+      <td className={styles.td}>
+        <Input
+          width={12}
+          value={item.timeFrom ?? ''}
+          placeholder="From"
+          onChange={onSettingChange('timeFrom')}
+          aria-label="Playlist item time from"
+        />
+      </td>
+      <td className={styles.td}>
+        <Input
+          width={12}
+          value={item.timeTo ?? ''}
+          placeholder="To"
+          onChange={onSettingChange('timeTo')}
+          aria-label="Playlist item time to"
+        />
+      </td>
       <td className={cx(styles.td, styles.settings)}>
         {!first ? (
           <IconButton
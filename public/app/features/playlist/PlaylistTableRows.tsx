@@ -8,9 +8,10 @@ interface PlaylistTableRowsProps {
   onMoveUp: (item: PlaylistItem) => void;
   onMoveDown: (item: PlaylistItem) => void;
   onDelete: (item: PlaylistItem) => void;
+  onChange: (item: PlaylistItem, changes: Partial<PlaylistItem>) => void;
 }
 
-export const PlaylistTableRows: FC<PlaylistTableRowsProps> = ({ items, onMoveUp, onMoveDown, onDelete }) => {
+export const PlaylistTableRows: FC<PlaylistTableRowsProps> = ({ items, onMoveUp, onMoveDown, onDelete, onChange }) => {
   if (items.length === 0) {
     return (
       <tr>
@@ -34,6 +35,7 @@ export const PlaylistTableRows: FC<PlaylistTableRowsProps> = ({ items, onMoveUp,
             onDelete={onDelete}
             onMoveDown={onMoveDown}
             onMoveUp={onMoveUp}
+            onChange={onChange}
             key={item.title}
           />
         );
@@ -29,7 +29,7 @@ export const StartModal: FC<StartModalProps> = ({ playlist, onDismiss }) => {
     if (autoFit) {
       params.autofitpanels = true;
     }
-    locationService.push(urlUtil.renderUrl(`/playlists/play/${playlist.id}`, params));
+    locationService.push(urlUtil.renderUrl(`/playlists/play/${playlist.uid}`, params));
   };
 
   return (
@@ -1,5 +1,6 @@
 export interface PlaylistDTO {
   id: number;
+  uid: string;
   name: string;
   startUrl?: string;
 }
@@ -14,6 +15,7 @@ export interface PlayListItemDTO {
 }
 
 export interface Playlist {
+  uid?: string;
   name: string;
   interval: string;
   items?: PlaylistItem[];
@@ -21,9 +23,12 @@ export interface Playlist {
 
 export interface PlaylistItem {
   id?: number;
-  value: string; //tag or id.toString()
-  type: 'dashboard_by_id' | 'dashboard_by_tag';
+  value: string; //tag, uid or id.toString()
+  type: 'dashboard_by_id' | 'dashboard_by_uid' | 'dashboard_by_tag';
   order: number;
   title: string;
   playlistId?: number;
+  interval?: string;
+  timeFrom?: string;
+  timeTo?: string;
 }
@@ -3,22 +3,22 @@ import { useEffect, useState } from 'react';
 import { getPlaylist } from './api';
 import { Playlist } from './types';
 
-export function usePlaylist(playlistId?: number) {
+export function usePlaylist(playlistUid?: string) {
   const [playlist, setPlaylist] = useState<Playlist>({ items: [], interval: '5m', name: '' });
   const [loading, setLoading] = useState<boolean>(true);
 
   useEffect(() => {
     const initPlaylist = async () => {
-      if (!playlistId) {
+      if (!playlistUid) {
         setLoading(false);
         return;
       }
-      const list = await getPlaylist(playlistId);
+      const list = await getPlaylist(playlistUid);
       setPlaylist(list);
       setLoading(false);
     };
     initPlaylist();
-  }, [playlistId]);
+  }, [playlistUid]);
 
   return { playlist, loading };
 }
@@ -79,5 +79,12 @@ export function usePlaylistItems(playlistItems?: PlaylistItem[]) {
     [items]
   );
 
-  return { items, addById, addByTag, deleteItem, moveDown, moveUp };
+  const updateItem = useCallback(
+    (item: PlaylistItem, changes: Partial<PlaylistItem>) => {
+      setItems(items.map((i) => (i === item ? { ...i, ...changes } : i)));
+    },
+    [items]
+  );
+
+  return { items, addById, addByTag, deleteItem, moveDown, moveUp, updateItem };
 }
@@ -0,0 +1,32 @@
+import React, { ChangeEvent } from 'react';
+
+import { DataSourcePluginOptionsEditorProps } from '@grafana/data';
+import { InlineField, Input } from '@grafana/ui';
+
+import { SQLiteOptions } from '../types';
+
+export const ConfigEditor = (props: DataSourcePluginOptionsEditorProps<SQLiteOptions>) => {
+  const { options, onOptionsChange } = props;
+
+  const onPathChange = (event: ChangeEvent<HTMLInputElement>) => {
+    onOptionsChange({ ...options, database: event.currentTarget.value });
+  };
+
+  return (
+    <>
+      <h3 className="page-heading">SQLite database</h3>
+      <InlineField
+        label="Path"
+        labelWidth={14}
+        tooltip="Path of the database file on the Grafana server. It must match one of the sqlite_allowed_paths in the Grafana configuration."
+      >
+        <Input
+          className="width-30"
+          value={options.database || ''}
+          placeholder="/var/lib/grafana/sqlite/data.db"
+          onChange={onPathChange}
+        />
+      </InlineField>
+    </>
+  );
+};
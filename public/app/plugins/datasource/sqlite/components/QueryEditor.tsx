@@ -0,0 +1,43 @@
+import React, { ChangeEvent, useState } from 'react';
+
+import { QueryEditorProps, SelectableValue } from '@grafana/data';
+import { InlineField, Select, TextArea } from '@grafana/ui';
+
+import { SQLiteDatasource } from '../datasource';
+import { ResultFormat, SQLiteOptions, SQLiteQuery } from '../types';
+
+type Props = QueryEditorProps<SQLiteDatasource, SQLiteQuery, SQLiteOptions>;
+
+const formats: Array<SelectableValue<ResultFormat>> = [
+  { label: 'Time series', value: 'time_series' },
+  { label: 'Table', value: 'table' },
+];
+
+export const QueryEditor = ({ query, onChange, onRunQuery }: Props) => {
+  const [rawSql, setRawSql] = useState(query.rawSql ?? '');
+
+  const onSqlBlur = () => {
+    onChange({ ...query, rawSql });
+    onRunQuery();
+  };
+
+  const onFormatChange = (value: SelectableValue<ResultFormat>) => {
+    onChange({ ...query, format: value.value });
+    onRunQuery();
+  };
+
+  return (
+    <>
+      <TextArea
+        rows={5}
+        value={rawSql}
+        placeholder="SELECT time, value FROM metrics WHERE $__timeFilter(time) ORDER BY time"
+        onChange={(event: ChangeEvent<HTMLTextAreaElement>) => setRawSql(event.currentTarget.value)}
+        onBlur={onSqlBlur}
+      />
+      <InlineField label="Format as" labelWidth={14}>
+        <Select width={20} options={formats} value={query.format ?? 'time_series'} onChange={onFormatChange} />
+      </InlineField>
+    </>
+  );
+};
@@ -0,0 +1,77 @@
+import { map } from 'lodash';
+import { lastValueFrom, of } from 'rxjs';
+import { catchError, mapTo } from 'rxjs/operators';
+
+import { DataSourceInstanceSettings, ScopedVars } from '@grafana/data';
+import { DataSourceWithBackend, getBackendSrv } from '@grafana/runtime';
+import { toTestingStatus } from '@grafana/runtime/src/utils/queryResponse';
+import { getTemplateSrv, TemplateSrv } from 'app/features/templating/template_srv';
+
+import { SQLiteOptions, SQLiteQuery } from './types';
+
+export class SQLiteDatasource extends DataSourceWithBackend<SQLiteQuery, SQLiteOptions> {
+  constructor(
+    instanceSettings: DataSourceInstanceSettings<SQLiteOptions>,
+    private readonly templateSrv: TemplateSrv = getTemplateSrv()
+  ) {
+    super(instanceSettings);
+  }
+
+  interpolateVariable(value: any, variable: any) {
+    if (typeof value === 'string') {
+      if (variable.multi || variable.includeAll) {
+        return "'" + value.replace(/'/g, `''`) + "'";
+      }
+      return value;
+    }
+
+    if (typeof value === 'number') {
+      return value;
+    }
+
+    return map(value, (val) => "'" + String(val).replace(/'/g, `''`) + "'").join(',');
+  }
+
+  filterQuery(query: SQLiteQuery): boolean {
+    return !query.hide && !!query.rawSql;
+  }
+
+  applyTemplateVariables(target: SQLiteQuery, scopedVars: ScopedVars): Record<string, any> {
+    return {
+      refId: target.refId,
+      datasource: this.getRef(),
+      rawSql: this.templateSrv.replace(target.rawSql, scopedVars, this.interpolateVariable),
+      format: target.format,
+    };
+  }
+
+  testDatasource(): Promise<any> {
+    return lastValueFrom(
+      getBackendSrv()
+        .fetch({
+          url: '/api/ds/query',
+          method: 'POST',
+          data: {
+            from: '5m',
+            to: 'now',
+            queries: [
+              {
+                refId: 'A',
+                intervalMs: 1,
+                maxDataPoints: 1,
+                datasource: this.getRef(),
+                rawSql: 'SELECT 1',
+                format: 'table',
+              },
+            ],
+          },
+        })
+        .pipe(
+          mapTo({ status: 'success', message: 'Database Connection OK' }),
+          catchError((err) => {
+            return of(toTestingStatus(err));
+          })
+        )
+    );
+  }
+}
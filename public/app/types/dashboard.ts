@@ -39,6 +39,13 @@ export interface DashboardMeta {
   hasUnsavedFolderChange?: boolean;
   annotationsPermissions?: AnnotationsPermissions;
   isPublic?: boolean;
+  folderDefaultsApplied?: FolderDashboardDefaults;
+}
+
+export interface FolderDashboardDefaults {
+  time?: { from: string; to: string };
+  refresh?: string;
+  variables?: Record<string, string>;
 }
 
 export interface AnnotationActions {